@@ -1,19 +1,23 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	_ "time/tzdata" // Embed the IANA database so timezone validation works on minimal images
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/config"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
-	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/handlers"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/metrics"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/middleware"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/models"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/storage"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/worker"
 )
 
 func main() {
@@ -40,6 +44,16 @@ func run() error {
 		return err
 	}
 
+	// Apply server-wide campaign limits
+	service.ConfigureCampaignLimits(cfg.CampaignLimitPerUser, cfg.CampaignLimitExemptUserIDs)
+	service.AllowUnknownSettings(cfg.AllowUnknownCampaignSettings)
+	service.ConfigureDraftLimit(cfg.DraftLimitPerUser)
+	service.ConfigureMaxRollSize(cfg.MaxRollSize)
+	service.ConfigureQueryTimeouts(cfg.DBQueryTimeout, cfg.DBLongQueryTimeout)
+
+	// Report binding failures by JSON field name
+	models.UseJSONFieldNames()
+
 	// Initialize JWT validator for token verification
 	// Supports both JWKS (production) and HS256 secret (local dev)
 	jwtValidator, err := middleware.NewJWTValidator(cfg.SupabaseJWKSURL, cfg.SupabaseJWTSecret)
@@ -49,20 +63,44 @@ func run() error {
 	defer jwtValidator.Close()
 
 	// Initialize database connection
-	db, err := database.Connect(cfg.DatabaseURL)
+	db, err := database.Connect(cfg.DatabaseURL, database.PoolSettings{
+		MaxConns:        cfg.DBMaxConns,
+		MinConns:        cfg.DBMinConns,
+		MaxConnLifetime: cfg.DBMaxConnLifetime,
+		MaxConnIdleTime: cfg.DBMaxConnIdleTime,
+	})
 	if err != nil {
 		return err
 	}
 	defer db.Close()
 
+	poolConfig := db.Pool.Config()
+	log.Printf("Database pool: max_conns=%d min_conns=%d max_conn_lifetime=%s max_conn_idle_time=%s",
+		poolConfig.MaxConns, poolConfig.MinConns, poolConfig.MaxConnLifetime, poolConfig.MaxConnIdleTime)
+
+	if cfg.MetricsEnabled {
+		if metricsErr := metrics.RegisterDBPool(db.Pool); metricsErr != nil {
+			return metricsErr
+		}
+	}
+
 	// Initialize storage client
 	storageClient := storage.NewClient(cfg.SupabaseURL, cfg.SupabaseSecretKey)
 
 	// Initialize queries and services
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 	imageService := service.NewImageService(queries, storageClient)
 	imageHandler := handlers.NewImageHandler(imageService)
 
+	// Start background workers
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go worker.NewNotificationWorker(db).Run(workerCtx)
+	go worker.NewDraftCleanupWorker(db).Run(workerCtx)
+	go worker.NewRemovedPostPurgeWorker(db).Run(workerCtx)
+	go worker.NewTimeGateWorker(db).Run(workerCtx)
+	go worker.NewStorageReconcileWorker(db).Run(workerCtx)
+
 	// Set Gin mode
 	if cfg.Environment == "production" || cfg.Environment == "release" {
 		gin.SetMode(gin.ReleaseMode)
@@ -93,17 +131,38 @@ func setupRouter(
 	// Apply middleware
 	router.Use(gin.Recovery())
 	router.Use(middleware.Logger())
+	if cfg.MetricsEnabled {
+		router.Use(middleware.Metrics())
+	}
 	router.Use(middleware.CORS(cfg.CORSAllowedOrigins))
 
 	// Health check (no auth required)
 	router.GET("/health", handlers.HealthCheck)
+	router.GET("/ready", handlers.ReadinessCheck(db))
+
+	// Prometheus metrics (no auth; only enabled by METRICS_ENABLED)
+	if cfg.MetricsEnabled {
+		router.GET("/metrics", gin.WrapH(metrics.Handler()))
+	}
 
 	// API routes (auth required)
 	api := router.Group("/api/v1")
 	api.Use(middleware.Auth(jwtValidator))
+	api.Use(middleware.MembershipCache())
 
 	registerAPIRoutes(api, db, imageHandler, imageService)
 
+	// Bot routes (campaign bot token required, never a user JWT).
+	// Bots may only post as the narrator and write OOC messages in their campaign.
+	bot := router.Group("/api/v1/bot")
+	bot.Use(middleware.BotAuth(handlers.ResolveBotToken(db)))
+	bot.Use(middleware.MembershipCache())
+	bot.POST("/scenes/:sceneId/posts", handlers.BotCreateNarratorPost(db))
+	bot.POST("/scenes/:sceneId/ooc", handlers.BotCreateOocMessage(db))
+
+	// OpenAPI document (no auth). Registered last so it sees every route above.
+	router.GET("/openapi.json", handlers.OpenAPISpec(router.Routes()))
+
 	return router
 }
 
@@ -115,22 +174,37 @@ func registerAPIRoutes(
 	imageService *service.ImageService,
 ) {
 	// User routes
-	api.GET("/me", handlers.GetCurrentUser())
+	api.GET("/me", handlers.GetCurrentUser(db))
+	api.GET("/me/action-items", handlers.GetMyActionItems(db))
+	api.GET("/me/profile", handlers.GetMyProfile(db))
+	api.PATCH("/me/profile", handlers.UpdateMyProfile(db))
+	api.POST("/me/profile/avatar", imageHandler.UploadUserAvatar)
+	api.DELETE("/me/profile/avatar", imageHandler.DeleteUserAvatar)
 
 	// Campaign routes
 	api.GET("/campaigns", handlers.ListCampaigns(db))
 	api.POST("/campaigns", handlers.CreateCampaign(db))
+	api.POST("/campaigns/import", handlers.ImportCampaign(db, imageService))
 	api.GET("/campaigns/:id", handlers.GetCampaign(db))
 	api.PATCH("/campaigns/:id", handlers.UpdateCampaign(db))
 	api.DELETE("/campaigns/:id", handlers.DeleteCampaign(db))
 	api.POST("/campaigns/:id/pause", handlers.PauseCampaign(db))
 	api.POST("/campaigns/:id/resume", handlers.ResumeCampaign(db))
+	api.POST("/campaigns/:id/duplicate", handlers.DuplicateCampaign(db, imageService))
+	api.GET("/campaigns/:id/export", handlers.ExportCampaign(db))
+	api.GET("/campaigns/:id/audit", handlers.GetCampaignAuditLog(db))
+	api.POST("/campaigns/:id/webhooks", handlers.SetCampaignWebhook(db))
+	api.DELETE("/campaigns/:id/webhooks", handlers.DeleteCampaignWebhook(db))
+	api.GET("/campaigns/:id/bot-tokens", handlers.ListBotTokens(db))
+	api.POST("/campaigns/:id/bot-tokens", handlers.CreateBotToken(db))
+	api.DELETE("/campaigns/:id/bot-tokens/:tokenId", handlers.RevokeBotToken(db))
 
 	// Campaign members routes
 	api.GET("/campaigns/:id/members", handlers.GetCampaignMembers(db))
 	api.POST("/campaigns/:id/leave", handlers.LeaveCampaign(db))
 	api.DELETE("/campaigns/:id/members/:memberId", handlers.RemoveMember(db))
 	api.POST("/campaigns/:id/transfer-gm", handlers.TransferGm(db))
+	api.GET("/campaigns/:id/claim-gm", handlers.GetGmClaimEligibility(db))
 	api.POST("/campaigns/:id/claim-gm", handlers.ClaimGm(db))
 
 	// Invite routes
@@ -150,14 +224,25 @@ func registerAPIRoutes(
 	api.POST("/campaigns/:id/characters/:characterId/unarchive", handlers.UnarchiveCharacter(db))
 	api.POST("/campaigns/:id/characters/:characterId/assign", handlers.AssignCharacter(db))
 	api.DELETE("/campaigns/:id/characters/:characterId/assign", handlers.UnassignCharacter(db))
+	api.POST("/campaigns/:id/characters/:characterId/tags", handlers.AddCharacterTag(db))
+	api.DELETE("/campaigns/:id/characters/:characterId/tags", handlers.RemoveCharacterTag(db))
+	api.GET("/campaigns/:id/characters/:characterId/scene", handlers.GetCharacterScene(db))
 
 	// Scene routes
 	api.GET("/campaigns/:id/scenes", handlers.ListCampaignScenes(db))
 	api.POST("/campaigns/:id/scenes", handlers.CreateScene(db))
+	api.PATCH("/campaigns/:id/scenes/order", handlers.ReorderScenes(db))
+	api.POST("/campaigns/:id/scenes/bulk-archive", handlers.BulkArchiveScenes(db))
 	api.GET("/campaigns/:id/scenes/:sceneId", handlers.GetScene(db))
+	api.GET("/campaigns/:id/scenes/:sceneId/bundle", handlers.GetSceneBundle(db))
 	api.PATCH("/campaigns/:id/scenes/:sceneId", handlers.UpdateScene(db))
 	api.POST("/campaigns/:id/scenes/:sceneId/archive", handlers.ArchiveScene(db))
 	api.POST("/campaigns/:id/scenes/:sceneId/unarchive", handlers.UnarchiveScene(db))
+	api.POST("/campaigns/:id/scenes/:sceneId/freeze", handlers.FreezeScene(db))
+	api.POST("/campaigns/:id/scenes/:sceneId/unfreeze", handlers.UnfreezeScene(db))
+	api.POST("/campaigns/:id/scenes/:sceneId/clone", handlers.CloneScene(db, imageService))
+	api.POST("/campaigns/:id/scenes/:sceneId/favorite", handlers.FavoriteScene(db))
+	api.DELETE("/campaigns/:id/scenes/:sceneId/favorite", handlers.UnfavoriteScene(db))
 	api.DELETE("/campaigns/:id/scenes/:sceneId", handlers.DeleteScene(db, imageService))
 	api.POST("/campaigns/:id/scenes/:sceneId/characters", handlers.AddCharacterToScene(db))
 	api.DELETE(
@@ -165,11 +250,14 @@ func registerAPIRoutes(
 		handlers.RemoveCharacterFromScene(db),
 	)
 	api.GET("/campaigns/:id/scenes/:sceneId/characters", handlers.GetSceneCharacters(db))
+	api.PATCH("/campaigns/:id/scenes/:sceneId/characters/order", handlers.ReorderSceneCharacters(db))
 
 	// Image routes
 	api.GET("/campaigns/:id/storage", imageHandler.GetStorageStatus)
 	api.POST("/campaigns/:id/characters/:characterId/avatar", imageHandler.UploadAvatar)
 	api.DELETE("/campaigns/:id/characters/:characterId/avatar", imageHandler.DeleteAvatar)
+	api.GET("/campaigns/:id/characters/:characterId/avatar/history", imageHandler.GetAvatarHistory)
+	api.POST("/campaigns/:id/characters/:characterId/avatar/restore", imageHandler.RestoreAvatar)
 	api.POST("/campaigns/:id/scenes/:sceneId/header", imageHandler.UploadSceneHeader)
 	api.DELETE("/campaigns/:id/scenes/:sceneId/header", imageHandler.DeleteSceneHeader)
 
@@ -177,20 +265,39 @@ func registerAPIRoutes(
 	api.GET("/campaigns/:id/scenes/:sceneId/posts", handlers.ListScenePosts(db))
 	api.POST("/campaigns/:id/scenes/:sceneId/posts", handlers.CreatePost(db))
 	api.GET("/campaigns/:id/scenes/:sceneId/posts/hidden", handlers.ListHiddenPosts(db))
+	api.POST("/campaigns/:id/scenes/:sceneId/read", handlers.MarkSceneRead(db))
+	api.POST("/campaigns/:id/scenes/:sceneId/grant-witness", handlers.GrantWitness(db))
 	api.GET("/posts/:postId", handlers.GetPost(db))
 	api.PATCH("/posts/:postId", handlers.UpdatePost(db))
 	api.DELETE("/posts/:postId", handlers.DeletePost(db))
 	api.POST("/posts/:postId/submit", handlers.SubmitPost(db))
 	api.POST("/posts/:postId/unhide", handlers.UnhidePost(db))
+	api.POST("/posts/:postId/restore", handlers.RestorePost(db))
+	api.POST("/posts/:postId/pin", handlers.PinPost(db))
+	api.POST("/posts/:postId/unpin", handlers.UnpinPost(db))
+	api.POST("/posts/:postId/move", handlers.MovePost(db))
+	api.POST("/posts/:postId/attribute", handlers.AttributePost(db))
 	api.PATCH("/posts/:postId/witnesses", handlers.UpdatePostWitnesses(db))
+	api.GET("/posts/:postId/reactions", handlers.ListPostReactions(db))
+	api.POST("/posts/:postId/reactions", handlers.AddPostReaction(db))
+	api.DELETE("/posts/:postId/reactions", handlers.RemovePostReaction(db))
+
+	// OOC discussion routes
+	api.GET("/campaigns/:id/scenes/:sceneId/ooc", handlers.ListOocMessages(db))
+	api.POST("/campaigns/:id/scenes/:sceneId/ooc", handlers.CreateOocMessage(db))
+	api.DELETE("/campaigns/:id/scenes/:sceneId/ooc/:messageId", handlers.DeleteOocMessage(db))
 
 	// Compose lock routes
 	api.POST("/compose/acquire", handlers.AcquireComposeLock(db))
 	api.POST("/compose/heartbeat", handlers.HeartbeatComposeLock(db))
+	api.POST("/compose/heartbeat/batch", handlers.HeartbeatComposeLocks(db))
 	api.DELETE("/compose/:lockId", handlers.ReleaseComposeLock(db))
 	api.DELETE("/compose/:lockId/force", handlers.ForceReleaseComposeLock(db))
 	api.PATCH("/compose/:lockId/hidden", handlers.UpdateComposeLockHidden(db))
 	api.GET("/campaigns/:id/scenes/:sceneId/compose-locks", handlers.GetSceneComposeLocks(db))
+	api.DELETE("/campaigns/:id/scenes/:sceneId/compose-locks", handlers.ForceReleaseSceneComposeLocks(db))
+	api.POST("/campaigns/:id/scenes/:sceneId/presence", handlers.ReportTypingPresence(db))
+	api.GET("/campaigns/:id/compose-locks", handlers.GetCampaignComposeLocks(db))
 
 	// Draft routes
 	api.POST("/drafts", handlers.SaveDraft(db))
@@ -201,27 +308,36 @@ func registerAPIRoutes(
 	// Phase management routes
 	api.GET("/campaigns/:id/phase", handlers.GetPhaseStatus(db))
 	api.POST("/campaigns/:id/phase/transition", handlers.TransitionPhase(db))
+	api.POST("/campaigns/:id/phase/check", handlers.CheckTransition(db))
 	api.POST("/campaigns/:id/phase/force-transition", handlers.ForceTransitionPhase(db))
 
 	// Pass management routes
 	api.GET("/campaigns/:id/pass", handlers.GetCampaignPassSummary(db))
+	api.POST("/campaigns/:id/pass-all", handlers.GMPassAll(db))
 	api.GET("/campaigns/:id/scenes/:sceneId/pass", handlers.GetScenePassStates(db))
 	api.POST("/campaigns/:id/scenes/:sceneId/characters/:characterId/pass", handlers.SetPass(db))
 	api.DELETE("/campaigns/:id/scenes/:sceneId/characters/:characterId/pass", handlers.ClearPass(db))
 
 	// Dice system routes
 	api.GET("/dice/presets", handlers.GetAvailablePresets())
+	api.GET("/dice/presets/:name", handlers.GetPresetExpansion())
 	api.GET("/dice/types", handlers.GetValidDiceTypes())
 
 	// Roll routes
 	api.POST("/rolls", handlers.CreateRoll(db))
+	api.POST("/rolls/batch", handlers.CreateRollsBatch(db))
 	api.GET("/rolls/:rollId", handlers.GetRoll(db))
 	api.POST("/rolls/:rollId/override-intention", handlers.OverrideRollIntention(db))
 	api.POST("/rolls/:rollId/resolve", handlers.ManuallyResolveRoll(db))
 	api.POST("/rolls/:rollId/invalidate", handlers.InvalidateRoll(db))
 	api.GET("/posts/:postId/rolls", handlers.GetRollsByPost(db))
+	api.GET("/characters/:characterId/rolls", handlers.GetCharacterRolls(db))
 	api.GET("/characters/:characterId/rolls/pending", handlers.GetPendingRollsForCharacter(db))
 	api.GET("/campaigns/:id/rolls/unresolved", handlers.GetUnresolvedRollsInCampaign(db))
+	api.GET("/campaigns/:id/roll-intentions", handlers.GetRollIntentions(db))
+	api.GET("/campaigns/:id/dice/presets", handlers.GetCampaignDicePresets(db))
+	api.POST("/campaigns/:id/dice/presets", handlers.CreateCampaignDicePreset(db))
+	api.DELETE("/campaigns/:id/dice/presets/:name", handlers.DeleteCampaignDicePreset(db))
 	api.GET("/scenes/:sceneId/rolls", handlers.GetRollsInScene(db))
 
 	// Notification routes
@@ -229,11 +345,24 @@ func registerAPIRoutes(
 	api.GET("/notifications", notificationHandler.GetNotifications())
 	api.GET("/notifications/unread", notificationHandler.GetUnreadNotifications())
 	api.GET("/notifications/unread/count", notificationHandler.GetUnreadCount())
+	api.GET("/notifications/:notificationId", notificationHandler.GetNotification())
 	api.GET("/campaigns/:id/notifications/unread/count", notificationHandler.GetUnreadCountByCampaign())
 	api.POST("/notifications/:notificationId/read", notificationHandler.MarkAsRead())
+	api.POST("/notifications/:notificationId/snooze", notificationHandler.SnoozeNotification())
+	api.POST("/notifications/:notificationId/resend-email", notificationHandler.ResendEmail())
 	api.POST("/notifications/read-all", notificationHandler.MarkAllAsRead())
+	api.POST("/notifications/test", notificationHandler.SendTestNotification())
+	api.DELETE("/notifications/read", notificationHandler.DeleteAllRead())
 	api.DELETE("/notifications/:notificationId", notificationHandler.DeleteNotification())
+	api.DELETE("/campaigns/:id/notifications", notificationHandler.DeleteAllByCampaign())
 	api.GET("/notifications/queued", notificationHandler.GetQueuedNotifications())
+	api.GET("/notifications/digest/preview", notificationHandler.GetDigestPreview())
+	api.GET("/links/resolve", notificationHandler.ResolveLink())
+
+	// Platform admin routes (service role only)
+	admin := api.Group("/admin", middleware.RequireRole(middleware.RoleServiceRole))
+	admin.POST("/notifications/reconcile-unread", notificationHandler.ReconcileUnreadCounts())
+	admin.PUT("/campaigns/:id/storage-limit", imageHandler.SetStorageLimit)
 
 	// Notification preferences routes
 	api.GET("/notification-preferences", notificationHandler.GetNotificationPreferences())
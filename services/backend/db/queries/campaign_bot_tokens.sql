@@ -0,0 +1,29 @@
+-- name: CreateCampaignBotToken :one
+INSERT INTO campaign_bot_tokens (campaign_id, created_by, name, token_hash, token_prefix)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListCampaignBotTokens :many
+SELECT * FROM campaign_bot_tokens
+WHERE campaign_id = $1 AND revoked_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: CountActiveCampaignBotTokens :one
+SELECT COUNT(*) FROM campaign_bot_tokens
+WHERE campaign_id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeCampaignBotToken :execrows
+UPDATE campaign_bot_tokens
+SET revoked_at = NOW()
+WHERE id = $1 AND campaign_id = $2 AND revoked_at IS NULL;
+
+-- name: GetActiveBotTokenByHash :one
+-- Resolves an unrevoked token to its campaign and the campaign's current GM,
+-- whose identity the bot acts under.
+SELECT t.id, t.campaign_id, cm.user_id AS gm_user_id
+FROM campaign_bot_tokens t
+JOIN campaign_members cm ON cm.campaign_id = t.campaign_id AND cm.role = 'gm'
+WHERE t.token_hash = $1 AND t.revoked_at IS NULL;
+
+-- name: TouchBotTokenLastUsed :exec
+UPDATE campaign_bot_tokens SET last_used_at = NOW() WHERE id = $1;
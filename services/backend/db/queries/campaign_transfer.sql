@@ -0,0 +1,114 @@
+-- ============================================
+-- CAMPAIGN EXPORT / IMPORT QUERIES
+-- ============================================
+
+-- name: ListCampaignPostsForExport :many
+-- Returns a campaign's submitted posts oldest first, paged by the (created_at, id) of the last post seen.
+SELECT p.* FROM posts p
+INNER JOIN scenes s ON p.scene_id = s.id
+WHERE s.campaign_id = sqlc.arg('campaign_id') AND p.is_draft = false
+  AND (
+    sqlc.narg('cursor_created_at')::timestamptz IS NULL
+    OR (p.created_at, p.id) > (sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id')::uuid)
+  )
+ORDER BY p.created_at ASC, p.id ASC
+LIMIT sqlc.arg('row_limit');
+
+-- name: ListCampaignRollsForExport :many
+-- Returns a campaign's rolls oldest first, paged by the (created_at, id) of the last roll seen.
+SELECT r.* FROM rolls r
+INNER JOIN scenes s ON r.scene_id = s.id
+WHERE s.campaign_id = sqlc.arg('campaign_id')
+  AND (
+    sqlc.narg('cursor_created_at')::timestamptz IS NULL
+    OR (r.created_at, r.id) > (sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id')::uuid)
+  )
+ORDER BY r.created_at ASC, r.id ASC
+LIMIT sqlc.arg('row_limit');
+
+-- name: ImportCharacter :one
+INSERT INTO characters (
+    campaign_id,
+    display_name,
+    description,
+    avatar_url,
+    character_type,
+    is_archived,
+    created_at,
+    pronouns,
+    gm_notes,
+    tags
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+RETURNING id;
+
+-- name: ImportScene :one
+INSERT INTO scenes (
+    campaign_id,
+    title,
+    description,
+    header_image_url,
+    character_ids,
+    pass_states,
+    is_archived,
+    sort_order,
+    created_at,
+    character_order
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+RETURNING id;
+
+-- name: ImportPost :one
+INSERT INTO posts (
+    scene_id,
+    character_id,
+    user_id,
+    blocks,
+    ooc_text,
+    witnesses,
+    is_hidden,
+    is_locked,
+    locked_at,
+    edited_by_gm,
+    intention,
+    modifier,
+    authored_by_gm,
+    created_at,
+    is_system,
+    is_pinned,
+    deleted_at,
+    submitted_at,
+    witnessed_by_scene
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $14, $18
+)
+RETURNING id;
+
+-- name: ImportRoll :exec
+INSERT INTO rolls (
+    post_id,
+    scene_id,
+    character_id,
+    requested_by,
+    intention,
+    modifier,
+    dice_type,
+    dice_count,
+    result,
+    total,
+    was_overridden,
+    original_intention,
+    status,
+    override_reason,
+    manual_result,
+    manual_resolution_reason,
+    created_at,
+    rolled_at,
+    success_threshold,
+    successes,
+    note
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21
+);
@@ -0,0 +1,15 @@
+-- name: GetCampaignWebhook :one
+SELECT * FROM campaign_webhooks WHERE campaign_id = $1;
+
+-- name: UpsertCampaignWebhook :one
+INSERT INTO campaign_webhooks (campaign_id, url, secret, events, enabled)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (campaign_id) DO UPDATE
+SET url = EXCLUDED.url,
+    secret = EXCLUDED.secret,
+    events = EXCLUDED.events,
+    enabled = EXCLUDED.enabled
+RETURNING *;
+
+-- name: DeleteCampaignWebhook :execrows
+DELETE FROM campaign_webhooks WHERE campaign_id = $1;
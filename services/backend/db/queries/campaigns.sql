@@ -24,6 +24,10 @@ RETURNING *;
 -- name: GetCampaign :one
 SELECT * FROM campaigns WHERE id = $1;
 
+-- name: GetCampaignForUpdate :one
+-- Locks the campaign row for the rest of the transaction
+SELECT * FROM campaigns WHERE id = $1 FOR UPDATE;
+
 -- name: GetCampaignWithMembership :one
 SELECT
     c.*,
@@ -41,6 +45,9 @@ INNER JOIN campaign_members cm ON c.id = cm.campaign_id
 WHERE cm.user_id = $1
 ORDER BY c.updated_at DESC;
 
+-- name: CountUserCampaigns :one
+SELECT COUNT(*) FROM campaign_members WHERE user_id = $1;
+
 -- name: CountUserOwnedCampaigns :one
 SELECT COUNT(*) FROM campaigns WHERE owner_id = $1;
 
@@ -62,6 +69,14 @@ SET
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateCampaignSettings :one
+UPDATE campaigns
+SET
+    settings = $2,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
 -- name: DeleteCampaign :exec
 DELETE FROM campaigns WHERE id = $1;
 
@@ -77,6 +92,13 @@ FROM campaign_members cm
 WHERE cm.campaign_id = $1
 ORDER BY cm.role DESC, cm.joined_at ASC;
 
+-- name: ListCampaignMemberEmails :many
+-- Returns the account email of each campaign member; only GMs may see these
+SELECT cm.user_id, u.email
+FROM campaign_members cm
+INNER JOIN auth.users u ON u.id = cm.user_id
+WHERE cm.campaign_id = $1;
+
 -- name: GetCampaignMember :one
 SELECT * FROM campaign_members
 WHERE campaign_id = $1 AND user_id = $2;
@@ -119,6 +141,10 @@ WHERE id = $1;
 SELECT COUNT(*) FROM campaign_members
 WHERE campaign_id = $1;
 
+-- name: CountCampaignPlayers :one
+SELECT COUNT(*) FROM campaign_members
+WHERE campaign_id = $1 AND role = 'player';
+
 -- name: CheckGmInactivity :one
 SELECT
     id,
@@ -155,6 +181,24 @@ RETURNING storage_used_bytes;
 -- name: GetCampaignStorage :one
 SELECT storage_used_bytes FROM campaigns WHERE id = $1;
 
+-- name: ResetNegativeCampaignStorage :execrows
+-- Clears storage usage driven below zero by double-released files
+UPDATE campaigns
+SET
+    storage_used_bytes = 0,
+    updated_at = NOW()
+WHERE storage_used_bytes < 0;
+
+-- name: GetCampaignStorageLimit :one
+SELECT storage_limit_bytes FROM campaign_storage_limits WHERE campaign_id = $1;
+
+-- name: SetCampaignStorageLimit :one
+INSERT INTO campaign_storage_limits (campaign_id, storage_limit_bytes)
+VALUES ($1, $2)
+ON CONFLICT (campaign_id) DO UPDATE
+SET storage_limit_bytes = EXCLUDED.storage_limit_bytes
+RETURNING *;
+
 -- ============================================
 -- PHASE MANAGEMENT QUERIES
 -- ============================================
@@ -200,6 +244,10 @@ WHERE current_phase = 'pc_phase'
   AND current_phase_expires_at <= NOW()
   AND is_paused = false;
 
+-- name: TryTimeGateWorkerLock :one
+-- Held until the surrounding transaction ends, so only one instance expires time gates at a time
+SELECT pg_try_advisory_xact_lock(hashtext('time_gate_worker')) AS acquired;
+
 -- name: CountActiveLocksInCampaign :one
 SELECT COUNT(*)
 FROM compose_locks cl
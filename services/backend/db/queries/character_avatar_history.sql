@@ -0,0 +1,17 @@
+-- name: AddCharacterAvatarHistory :one
+INSERT INTO character_avatar_history (character_id, avatar_url, size_bytes)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListCharacterAvatarHistory :many
+-- Newest first
+SELECT * FROM character_avatar_history
+WHERE character_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: GetCharacterAvatarHistoryEntry :one
+SELECT * FROM character_avatar_history
+WHERE id = $1 AND character_id = $2;
+
+-- name: DeleteCharacterAvatarHistoryEntry :exec
+DELETE FROM character_avatar_history WHERE id = $1;
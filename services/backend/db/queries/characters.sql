@@ -48,6 +48,8 @@ SET
     description = COALESCE($3, description),
     avatar_url = COALESCE($4, avatar_url),
     character_type = COALESCE($5, character_type),
+    pronouns = $6,
+    gm_notes = $7,
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -84,6 +86,13 @@ RETURNING *;
 -- name: UnassignCharacter :exec
 DELETE FROM character_assignments WHERE character_id = $1;
 
+-- name: UnassignUserCharactersInCampaign :many
+-- Removes every assignment a user holds in a campaign and returns the released characters
+DELETE FROM character_assignments ca
+USING characters c
+WHERE ca.character_id = c.id AND c.campaign_id = $1 AND ca.user_id = $2
+RETURNING c.id, c.display_name, c.character_type;
+
 -- name: GetCharacterAssignment :one
 SELECT * FROM character_assignments WHERE character_id = $1;
 
@@ -129,3 +138,11 @@ WHERE c.id = ANY(
 AND ca.user_id = $2
 AND c.is_archived = false
 ORDER BY c.display_name;
+
+-- name: SetCharacterTags :one
+UPDATE characters
+SET
+    tags = $2,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
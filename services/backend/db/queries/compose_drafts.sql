@@ -60,6 +60,22 @@ DO UPDATE SET
     updated_at = NOW()
 RETURNING *;
 
+-- name: UpsertComposeDraftBlocks :exec
+-- Saves only the draft blocks, keeping any existing OOC text, intention and modifier
+INSERT INTO compose_drafts (
+    scene_id,
+    character_id,
+    user_id,
+    blocks,
+    is_hidden
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (scene_id, character_id)
+DO UPDATE SET
+    blocks = EXCLUDED.blocks,
+    updated_at = NOW();
+
 -- name: DeleteComposeDraft :exec
 DELETE FROM compose_drafts WHERE id = $1;
 
@@ -74,3 +90,41 @@ INNER JOIN scenes s ON cd.scene_id = s.id
 INNER JOIN characters c ON cd.character_id = c.id
 WHERE cd.user_id = $1
 ORDER BY cd.updated_at DESC;
+
+-- name: CountUserDrafts :one
+SELECT COUNT(*) FROM compose_drafts
+WHERE user_id = $1;
+
+-- name: DeleteOldestUserDrafts :execrows
+-- Deletes the user's least recently updated drafts
+DELETE FROM compose_drafts
+WHERE id IN (
+    SELECT id FROM compose_drafts
+    WHERE user_id = $1
+    ORDER BY updated_at ASC
+    LIMIT $2
+);
+
+-- name: PurgeInaccessibleDrafts :execrows
+-- Removes drafts whose author left the campaign, whose character left the scene,
+-- or whose character is no longer assigned to the (non-GM) author
+DELETE FROM compose_drafts cd
+USING scenes s
+WHERE cd.scene_id = s.id
+  AND (
+      NOT (cd.character_id = ANY(s.character_ids))
+      OR NOT EXISTS (
+          SELECT 1 FROM campaign_members cm
+          WHERE cm.campaign_id = s.campaign_id AND cm.user_id = cd.user_id
+      )
+      OR (
+          NOT EXISTS (
+              SELECT 1 FROM campaign_members cm
+              WHERE cm.campaign_id = s.campaign_id AND cm.user_id = cd.user_id AND cm.role = 'gm'
+          )
+          AND NOT EXISTS (
+              SELECT 1 FROM character_assignments ca
+              WHERE ca.character_id = cd.character_id AND ca.user_id = cd.user_id
+          )
+      )
+  );
@@ -34,7 +34,7 @@ WHERE id = $1;
 -- name: DeleteComposeLock :exec
 DELETE FROM compose_locks WHERE id = $1;
 
--- name: DeleteSceneComposeLocks :exec
+-- name: DeleteSceneComposeLocks :execrows
 DELETE FROM compose_locks WHERE scene_id = $1;
 
 -- name: DeleteExpiredComposeLocks :exec
@@ -60,3 +60,26 @@ SELECT
 FROM compose_locks cl
 INNER JOIN characters c ON cl.character_id = c.id
 WHERE cl.scene_id = $1 AND cl.character_id = $2;
+
+-- name: GetComposeLocksByCampaign :many
+SELECT
+    cl.*,
+    s.title AS scene_title,
+    c.display_name AS character_name,
+    c.avatar_url AS character_avatar,
+    cm.alias AS user_alias
+FROM compose_locks cl
+INNER JOIN scenes s ON cl.scene_id = s.id
+INNER JOIN characters c ON cl.character_id = c.id
+LEFT JOIN campaign_members cm ON cm.campaign_id = s.campaign_id AND cm.user_id = cl.user_id
+WHERE s.campaign_id = $1
+ORDER BY s.sort_order ASC, cl.acquired_at ASC;
+
+-- name: UpdateComposeLocksActivity :many
+-- Refreshes the given locks owned by the user, skipping any they do not own
+UPDATE compose_locks
+SET
+    last_activity_at = $3,
+    expires_at = $4
+WHERE user_id = $1 AND id = ANY($2::uuid[])
+RETURNING id, expires_at;
@@ -0,0 +1,13 @@
+-- name: CreateGmAuditEntry :exec
+INSERT INTO gm_audit_log (campaign_id, gm_user_id, action, target_type, target_id, detail)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListGmAuditLog :many
+-- Returns a page of a campaign's audit log, newest first
+SELECT * FROM gm_audit_log
+WHERE campaign_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountGmAuditLog :one
+SELECT COUNT(*) FROM gm_audit_log WHERE campaign_id = $1;
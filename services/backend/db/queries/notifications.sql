@@ -26,29 +26,70 @@ RETURNING *;
 SELECT * FROM notifications
 WHERE id = $1;
 
+-- name: GetUserNotification :one
+SELECT
+    n.*,
+    c.title AS campaign_title,
+    s.title AS scene_title
+FROM notifications n
+LEFT JOIN campaigns c ON n.campaign_id = c.id
+LEFT JOIN scenes s ON n.scene_id = s.id
+WHERE n.id = $1 AND n.user_id = $2;
+
 -- name: GetNotificationsByUser :many
+-- The types and is_read filters match every notification when NULL
 SELECT * FROM notifications
-WHERE user_id = $1
+WHERE user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('types')::text[] IS NULL OR type = ANY(sqlc.narg('types')::text[]))
+  AND (sqlc.narg('is_read')::boolean IS NULL OR is_read = sqlc.narg('is_read')::boolean)
 ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
+LIMIT sqlc.arg('row_limit') OFFSET sqlc.arg('row_offset');
 
 -- name: GetUnreadNotificationsByUser :many
 SELECT * FROM notifications
 WHERE user_id = $1
   AND is_read = false
+  AND (snooze_until IS NULL OR snooze_until <= NOW())
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
+-- name: GetUnreadNotificationsByType :many
+SELECT * FROM notifications
+WHERE user_id = $1
+  AND type = $2
+  AND is_read = false
+  AND (snooze_until IS NULL OR snooze_until <= NOW())
+ORDER BY created_at DESC
+LIMIT $3;
+
 -- name: GetUnreadNotificationCount :one
 SELECT COUNT(*) FROM notifications
 WHERE user_id = $1
-  AND is_read = false;
+  AND is_read = false
+  AND (snooze_until IS NULL OR snooze_until <= NOW());
 
 -- name: GetUnreadNotificationCountByCampaign :one
 SELECT COUNT(*) FROM notifications
 WHERE user_id = $1
   AND campaign_id = $2
-  AND is_read = false;
+  AND is_read = false
+  AND (snooze_until IS NULL OR snooze_until <= NOW());
+
+-- name: GetUnreadNotificationCounter :one
+-- Reads the user's maintained unread counters; GetUnreadNotificationCount is the source of truth
+SELECT
+    COALESCE(SUM(unread_count), 0)::bigint AS unread_count,
+    MAX(updated_at)::timestamptz AS updated_at
+FROM notification_unread_counts
+WHERE user_id = $1;
+
+-- name: GetUnreadNotificationCounterByCampaign :one
+SELECT
+    COALESCE(SUM(unread_count), 0)::bigint AS unread_count,
+    MAX(updated_at)::timestamptz AS updated_at
+FROM notification_unread_counts
+WHERE user_id = $1
+  AND campaign_id = $2;
 
 -- name: MarkNotificationAsRead :one
 UPDATE notifications
@@ -61,10 +102,29 @@ UPDATE notifications
 SET is_read = true, read_at = NOW()
 WHERE user_id = $1 AND is_read = false;
 
+-- name: SnoozeNotification :one
+UPDATE notifications
+SET snooze_until = $3
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: ClearExpiredSnoozes :execrows
+UPDATE notifications
+SET snooze_until = NULL
+WHERE snooze_until <= NOW();
+
 -- name: DeleteNotification :exec
 DELETE FROM notifications
 WHERE id = $1 AND user_id = $2;
 
+-- name: DeleteReadNotifications :execrows
+DELETE FROM notifications
+WHERE user_id = $1 AND is_read = true;
+
+-- name: DeleteCampaignNotifications :execrows
+DELETE FROM notifications
+WHERE user_id = $1 AND campaign_id = $2;
+
 -- name: DeleteExpiredNotifications :execrows
 DELETE FROM notifications
 WHERE expires_at < NOW();
@@ -84,6 +144,42 @@ WHERE user_id = $1
   AND created_at > $2
 ORDER BY created_at DESC;
 
+-- name: ReconcileNotificationUnreadCounts :one
+-- Rewrites unread counters that have drifted from the notifications table and returns how many
+-- were fixed. Counters changed while this runs are left for the next pass.
+WITH actual AS (
+    SELECT user_id, campaign_id, COUNT(*)::int AS unread_count
+    FROM notifications
+    WHERE is_read = false
+      AND snooze_until IS NULL
+    GROUP BY user_id, campaign_id
+),
+fixed AS (
+    INSERT INTO notification_unread_counts (user_id, campaign_id, unread_count)
+    SELECT user_id, campaign_id, unread_count FROM actual
+    ON CONFLICT (user_id, campaign_id) DO UPDATE SET
+        unread_count = EXCLUDED.unread_count,
+        updated_at = clock_timestamp()
+    WHERE notification_unread_counts.unread_count <> EXCLUDED.unread_count
+      AND notification_unread_counts.updated_at < statement_timestamp()
+    RETURNING 1
+),
+stale AS (
+    UPDATE notification_unread_counts nuc
+    SET
+        unread_count = 0,
+        updated_at = clock_timestamp()
+    WHERE nuc.unread_count <> 0
+      AND nuc.updated_at < statement_timestamp()
+      AND NOT EXISTS (
+        SELECT 1 FROM actual a
+        WHERE a.user_id = nuc.user_id
+          AND a.campaign_id IS NOT DISTINCT FROM nuc.campaign_id
+      )
+    RETURNING 1
+)
+SELECT ((SELECT COUNT(*) FROM fixed) + (SELECT COUNT(*) FROM stale))::bigint AS fixed_count;
+
 -- ============================================
 -- NOTIFICATION PREFERENCES QUERIES
 -- ============================================
@@ -97,14 +193,16 @@ INSERT INTO notification_preferences (
     user_id,
     email_enabled,
     email_frequency,
-    in_app_enabled
+    in_app_enabled,
+    type_preferences
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, COALESCE($5::jsonb, '{}'::jsonb)
 )
 ON CONFLICT (user_id) DO UPDATE SET
     email_enabled = EXCLUDED.email_enabled,
     email_frequency = EXCLUDED.email_frequency,
     in_app_enabled = EXCLUDED.in_app_enabled,
+    type_preferences = COALESCE($5::jsonb, notification_preferences.type_preferences),
     updated_at = NOW()
 RETURNING *;
 
@@ -158,11 +256,33 @@ WHERE nq.deliver_after <= NOW()
 ORDER BY nq.deliver_after ASC
 LIMIT 100;
 
+-- name: ClaimDueQueuedNotifications :many
+-- Marks due queue entries as delivered before sending so a crash mid-flush cannot double-send.
+UPDATE notification_queue
+SET delivered_at = NOW()
+WHERE id IN (
+    SELECT nq.id FROM notification_queue nq
+    WHERE nq.deliver_after <= NOW()
+      AND nq.delivered_at IS NULL
+    ORDER BY nq.deliver_after ASC
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
 -- name: MarkQueuedNotificationDelivered :exec
 UPDATE notification_queue
 SET delivered_at = NOW()
 WHERE id = $1;
 
+-- name: RequeueNotification :exec
+-- Releases a claimed queue entry to be delivered again later.
+UPDATE notification_queue
+SET
+    deliver_after = $2,
+    delivered_at = NULL
+WHERE id = $1;
+
 -- name: DeleteQueuedNotification :exec
 DELETE FROM notification_queue
 WHERE id = $1;
@@ -252,3 +372,11 @@ WHERE s.id = $1;
 UPDATE notifications
 SET email_sent_at = NOW()
 WHERE id = $1;
+
+-- name: ClaimNotificationEmailResend :one
+-- Claims a resend of the user's notification email, at most once a minute
+UPDATE notifications
+SET email_sent_at = NOW()
+WHERE id = $1 AND user_id = $2
+  AND (email_sent_at IS NULL OR email_sent_at <= NOW() - INTERVAL '1 minute')
+RETURNING *;
@@ -0,0 +1,16 @@
+-- name: CreateOocMessage :one
+INSERT INTO ooc_messages (scene_id, user_id, parent_id, body)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetOocMessage :one
+SELECT * FROM ooc_messages WHERE id = $1;
+
+-- name: ListSceneOocMessages :many
+-- Returns a scene's OOC messages oldest first; clients group replies by parent_id
+SELECT * FROM ooc_messages
+WHERE scene_id = $1
+ORDER BY created_at ASC;
+
+-- name: DeleteOocMessage :exec
+DELETE FROM ooc_messages WHERE id = $1;
@@ -0,0 +1,19 @@
+-- name: AddPostReaction :exec
+INSERT INTO post_reactions (post_id, user_id, emoji)
+VALUES ($1, $2, $3)
+ON CONFLICT (post_id, user_id, emoji) DO NOTHING;
+
+-- name: RemovePostReaction :exec
+DELETE FROM post_reactions
+WHERE post_id = $1 AND user_id = $2 AND emoji = $3;
+
+-- name: ListPostReactionCounts :many
+-- Returns reaction counts per emoji and whether the given user reacted with it
+SELECT
+    emoji,
+    COUNT(*)::int AS count,
+    BOOL_OR(user_id = $2)::boolean AS reacted
+FROM post_reactions
+WHERE post_id = $1
+GROUP BY emoji
+ORDER BY MIN(created_at) ASC;
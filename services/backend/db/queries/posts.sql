@@ -9,9 +9,14 @@ INSERT INTO posts (
     is_hidden,
     is_draft,
     intention,
-    modifier
+    modifier,
+    authored_by_gm,
+    is_system,
+    witnessed_by_scene,
+    submitted_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13,
+    CASE WHEN $8 THEN NULL ELSE NOW() END
 )
 RETURNING *;
 
@@ -37,7 +42,7 @@ SELECT
 FROM posts p
 LEFT JOIN characters c ON p.character_id = c.id
 WHERE p.scene_id = $1 AND p.is_draft = false
-ORDER BY p.created_at ASC;
+ORDER BY p.is_pinned DESC, p.created_at ASC;
 
 -- name: ListScenePostsForCharacter :many
 SELECT
@@ -58,11 +63,39 @@ WHERE scene_id = $1 AND is_draft = false
 ORDER BY created_at DESC
 LIMIT 1;
 
+-- name: GetLastVisibleScenePost :one
+-- Returns the most recently published post the user can see: any post for GMs,
+-- otherwise one witnessed by a character the user plays in the scene
+SELECT p.* FROM posts p
+INNER JOIN scenes s ON s.id = p.scene_id
+WHERE p.scene_id = sqlc.arg('scene_id')
+  AND p.is_draft = false
+  AND (
+    sqlc.arg('is_gm')::boolean
+    OR EXISTS (
+      SELECT 1 FROM characters c
+      INNER JOIN character_assignments ca ON ca.character_id = c.id
+      WHERE ca.user_id = sqlc.arg('user_id')
+        AND c.id = ANY(s.character_ids)
+        AND c.is_archived = false
+        AND c.id = ANY(p.witnesses)
+    )
+  )
+ORDER BY COALESCE(p.submitted_at, p.created_at) DESC
+LIMIT 1;
+
 -- name: GetUserDraftPost :one
 SELECT * FROM posts
 WHERE scene_id = $1 AND character_id = $2 AND user_id = $3 AND is_draft = true
 LIMIT 1;
 
+-- name: GetLatestCharacterPostSince :one
+-- Returns the character's newest submitted post in the scene created after the given time
+SELECT id FROM posts
+WHERE scene_id = $1 AND character_id = $2 AND is_draft = false AND created_at > $3
+ORDER BY created_at DESC
+LIMIT 1;
+
 -- name: CountScenePosts :one
 SELECT COUNT(*) FROM posts
 WHERE scene_id = $1 AND is_draft = false;
@@ -85,6 +118,8 @@ SET
     is_draft = false,
     witnesses = $2,
     is_hidden = $3,
+    witnessed_by_scene = $4,
+    submitted_at = NOW(),
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -180,6 +215,88 @@ LIMIT $5;
 UPDATE posts
 SET
     witnesses = $2,
+    witnessed_by_scene = $3,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: SetPostMentions :exec
+UPDATE posts
+SET mentions = $2
+WHERE id = $1;
+
+-- name: PinPost :one
+UPDATE posts
+SET is_pinned = true
+WHERE id = $1
+RETURNING *;
+
+-- name: UnpinPost :one
+UPDATE posts
+SET is_pinned = false
+WHERE id = $1
+RETURNING *;
+
+-- name: MovePost :one
+-- GM-only: Move a post to another scene with new witnesses; locks are fixed separately
+UPDATE posts
+SET
+    scene_id = $2,
+    witnesses = $3,
+    witnessed_by_scene = $4,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: SaveRemovedPostContent :exec
+-- Copies a post's content aside before TombstonePost blanks it
+INSERT INTO removed_post_contents (post_id, blocks, ooc_text, intention, modifier)
+SELECT id, blocks, ooc_text, intention, modifier FROM posts WHERE id = $1;
+
+-- name: TombstonePost :one
+-- GM-only: Replace a post with a placeholder, keeping its place and lock state; also unpins it.
+-- The content is blanked, so SaveRemovedPostContent must run first.
+UPDATE posts
+SET
+    deleted_at = NOW(),
+    deleted_by = $2,
+    is_pinned = false,
+    blocks = '[]'::jsonb,
+    ooc_text = NULL,
+    intention = NULL,
+    modifier = NULL,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: RestorePost :one
+-- Puts back the content saved by SaveRemovedPostContent; no row if it was purged
+UPDATE posts
+SET
+    deleted_at = NULL,
+    deleted_by = NULL,
+    blocks = r.blocks,
+    ooc_text = r.ooc_text,
+    intention = r.intention,
+    modifier = r.modifier,
+    updated_at = NOW()
+FROM removed_post_contents r
+WHERE posts.id = $1 AND r.post_id = posts.id
+RETURNING posts.*;
+
+-- name: DeleteRemovedPostContent :exec
+DELETE FROM removed_post_contents WHERE post_id = $1;
+
+-- name: PurgeRemovedPostContents :execrows
+-- Drops removed content older than the restore window
+DELETE FROM removed_post_contents WHERE removed_at < $1;
+
+-- name: AttributePost :one
+-- GM-only: Give a narrator post a character, with witnesses that include it
+UPDATE posts
+SET
+    character_id = $2,
+    witnesses = $3,
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
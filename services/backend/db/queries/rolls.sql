@@ -12,8 +12,10 @@ INSERT INTO rolls (
     modifier,
     dice_type,
     dice_count,
+    success_threshold,
+    note,
     status
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'pending')
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 'pending')
 RETURNING *;
 
 -- name: GetRoll :one
@@ -32,6 +34,7 @@ UPDATE rolls
 SET
     result = $2,
     total = $3,
+    successes = $4,
     rolled_at = NOW(),
     status = 'completed'
 WHERE id = $1
@@ -58,6 +61,20 @@ WHERE r.character_id = $1
   AND r.status = 'pending'
 ORDER BY r.created_at DESC;
 
+-- name: ListCharacterRolls :many
+-- Returns a character's rolls newest first, paged by the (created_at, id) of the last roll seen.
+-- Invalidated rolls are skipped unless include_invalidated is true.
+SELECT r.*
+FROM rolls r
+WHERE r.character_id = sqlc.arg('character_id')
+  AND (sqlc.arg('include_invalidated')::boolean OR r.status != 'invalidated')
+  AND (
+    sqlc.narg('cursor_created_at')::timestamptz IS NULL
+    OR (r.created_at, r.id) < (sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id')::uuid)
+  )
+ORDER BY r.created_at DESC, r.id DESC
+LIMIT sqlc.arg('row_limit');
+
 -- name: GetPendingRollsInScene :many
 SELECT
     r.*,
@@ -80,7 +97,19 @@ JOIN scenes s ON s.id = r.scene_id
 LEFT JOIN posts p ON p.id = r.post_id
 WHERE s.campaign_id = $1
   AND r.status = 'pending'
-ORDER BY r.created_at ASC;
+  AND ($2::timestamptz IS NULL OR r.created_at >= $2)
+ORDER BY r.created_at ASC
+LIMIT $3 OFFSET $4;
+
+-- name: CountUnresolvedRollsInCampaign :one
+-- Counts the rows GetUnresolvedRollsInCampaign would return without pagination
+SELECT COUNT(*)
+FROM rolls r
+JOIN characters c ON c.id = r.character_id
+JOIN scenes s ON s.id = r.scene_id
+WHERE s.campaign_id = $1
+  AND r.status = 'pending'
+  AND ($2::timestamptz IS NULL OR r.created_at >= $2);
 
 -- name: CountPendingRollsForCharacter :one
 SELECT COUNT(*)
@@ -136,12 +165,17 @@ WHERE r.scene_id = $1
 ORDER BY r.created_at DESC;
 
 -- name: ListRollsByScene :many
+-- Status and character filters are optional; pass NULL to include all rolls
 SELECT
     r.*,
-    c.display_name AS character_name
+    c.display_name AS character_name,
+    p.witnesses AS post_witnesses
 FROM rolls r
 LEFT JOIN characters c ON r.character_id = c.id
-WHERE r.scene_id = $1
+LEFT JOIN posts p ON r.post_id = p.id
+WHERE r.scene_id = sqlc.arg('scene_id')
+  AND (sqlc.narg('status')::roll_status IS NULL OR r.status = sqlc.narg('status')::roll_status)
+  AND (sqlc.narg('character_id')::uuid IS NULL OR r.character_id = sqlc.narg('character_id')::uuid)
 ORDER BY r.created_at DESC;
 
 -- name: GetRollCountByStatus :one
@@ -158,3 +192,9 @@ DELETE FROM rolls WHERE id = $1;
 
 -- name: GetSceneIDForRoll :one
 SELECT scene_id FROM rolls WHERE id = $1;
+
+-- name: MovePostRolls :exec
+-- Keeps a moved post's rolls in the post's new scene
+UPDATE rolls
+SET scene_id = $2
+WHERE post_id = $1;
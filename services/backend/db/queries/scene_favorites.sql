@@ -0,0 +1,15 @@
+-- name: AddSceneFavorite :exec
+INSERT INTO scene_favorites (user_id, scene_id)
+VALUES ($1, $2)
+ON CONFLICT (user_id, scene_id) DO NOTHING;
+
+-- name: RemoveSceneFavorite :exec
+DELETE FROM scene_favorites
+WHERE user_id = $1 AND scene_id = $2;
+
+-- name: ListFavoriteSceneIDs :many
+-- Returns the ids of the user's favorite scenes in a campaign
+SELECT sf.scene_id
+FROM scene_favorites sf
+INNER JOIN scenes s ON s.id = sf.scene_id
+WHERE sf.user_id = $1 AND s.campaign_id = $2;
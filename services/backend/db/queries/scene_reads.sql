@@ -0,0 +1,30 @@
+-- name: UpsertSceneRead :exec
+-- Moves the user's read marker forward; older markers are ignored
+INSERT INTO scene_reads (user_id, scene_id, last_read_post_id, last_read_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, scene_id) DO UPDATE
+SET
+    last_read_post_id = EXCLUDED.last_read_post_id,
+    last_read_at = EXCLUDED.last_read_at,
+    updated_at = NOW()
+WHERE scene_reads.last_read_at < EXCLUDED.last_read_at;
+
+-- name: CountUnreadPostsByScene :many
+-- Counts submitted posts by others published after the user's read marker, per scene.
+-- For GMs every post counts; players only count posts witnessed by one of their characters.
+SELECT p.scene_id, COUNT(*)::bigint AS unread_count
+FROM posts p
+INNER JOIN scenes s ON s.id = p.scene_id
+LEFT JOIN scene_reads sr ON sr.scene_id = p.scene_id AND sr.user_id = sqlc.arg('user_id')
+WHERE s.campaign_id = sqlc.arg('campaign_id')
+  AND p.is_draft = false
+  AND p.user_id != sqlc.arg('user_id')
+  AND (sr.last_read_at IS NULL OR COALESCE(p.submitted_at, p.created_at) > sr.last_read_at)
+  AND (
+    sqlc.arg('is_gm')::boolean
+    OR EXISTS (
+      SELECT 1 FROM character_assignments ca
+      WHERE ca.user_id = sqlc.arg('user_id') AND ca.character_id = ANY(p.witnesses)
+    )
+  )
+GROUP BY p.scene_id;
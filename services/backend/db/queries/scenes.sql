@@ -2,9 +2,11 @@
 INSERT INTO scenes (
     campaign_id,
     title,
-    description
+    description,
+    sort_order
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3,
+    (SELECT COALESCE(MAX(sort_order), -1) + 1 FROM scenes WHERE campaign_id = $1)
 )
 RETURNING *;
 
@@ -24,12 +26,33 @@ WHERE s.id = $1;
 -- name: ListCampaignScenes :many
 SELECT * FROM scenes
 WHERE campaign_id = $1
-ORDER BY is_archived ASC, created_at ASC;
+ORDER BY is_archived ASC, sort_order ASC, created_at ASC;
+
+-- name: ListSceneActivity :many
+-- Returns character and submitted post counts plus the latest publish time per scene.
+-- For GMs every post counts; players only count posts witnessed by one of their characters.
+SELECT
+    s.id AS scene_id,
+    COALESCE(cardinality(s.character_ids), 0)::bigint AS character_count,
+    COUNT(p.id)::bigint AS post_count,
+    MAX(COALESCE(p.submitted_at, p.created_at))::timestamptz AS last_post_at
+FROM scenes s
+LEFT JOIN posts p ON p.scene_id = s.id
+    AND p.is_draft = false
+    AND (
+        sqlc.arg('is_gm')::boolean
+        OR EXISTS (
+            SELECT 1 FROM character_assignments ca
+            WHERE ca.user_id = sqlc.arg('user_id') AND ca.character_id = ANY(p.witnesses)
+        )
+    )
+WHERE s.campaign_id = sqlc.arg('campaign_id')
+GROUP BY s.id;
 
 -- name: ListActiveScenes :many
 SELECT * FROM scenes
 WHERE campaign_id = $1 AND is_archived = false
-ORDER BY created_at ASC;
+ORDER BY sort_order ASC, created_at ASC;
 
 -- name: CountCampaignScenes :one
 SELECT COUNT(*) FROM scenes WHERE campaign_id = $1;
@@ -47,6 +70,31 @@ SET
 WHERE id = $1
 RETURNING *;
 
+-- name: SetSceneDefaultWitnessMode :one
+UPDATE scenes
+SET
+    default_witness_mode = $2,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: SetSceneFogOverride :one
+UPDATE scenes
+SET
+    fog_override = $2,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: SetSceneSummary :one
+-- A NULL summary clears it
+UPDATE scenes
+SET
+    summary = $2,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
 -- name: ArchiveScene :one
 UPDATE scenes
 SET
@@ -55,6 +103,22 @@ SET
 WHERE id = $1
 RETURNING *;
 
+-- name: FreezeScene :one
+UPDATE scenes
+SET
+    is_frozen = true,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: UnfreezeScene :one
+UPDATE scenes
+SET
+    is_frozen = false,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
 -- name: UnarchiveScene :one
 UPDATE scenes
 SET
@@ -154,7 +218,9 @@ LEFT JOIN character_assignments ca ON c.id = ca.character_id
 WHERE c.id = ANY(
     SELECT unnest(character_ids) FROM scenes WHERE scenes.id = $1
 )
-ORDER BY c.display_name;
+ORDER BY
+    array_position((SELECT character_order FROM scenes WHERE scenes.id = $1), c.id) NULLS LAST,
+    c.display_name;
 
 -- name: GetVisibleScenesForCharacter :many
 -- Returns scenes where the character has witnessed at least one post
@@ -164,7 +230,7 @@ INNER JOIN posts p ON p.scene_id = s.id
 WHERE s.campaign_id = $1
   AND $2::uuid = ANY(p.witnesses)
   AND s.is_archived = false
-ORDER BY s.created_at DESC;
+ORDER BY s.sort_order ASC, s.created_at ASC;
 
 -- name: GetVisibleScenesForUser :many
 -- Returns scenes where any of the user's assigned characters have witnessed posts
@@ -176,7 +242,7 @@ INNER JOIN character_assignments ca ON ca.character_id = ANY(p.witnesses)
 WHERE s.campaign_id = $1
   AND ca.user_id = $2
   AND s.is_archived = false
-ORDER BY s.created_at ASC;
+ORDER BY s.sort_order ASC, s.created_at ASC;
 
 -- name: GetPresentCharactersInScene :many
 -- Returns all characters currently in a scene (for witness capture)
@@ -327,3 +393,18 @@ FROM (
         AND s.pass_states->c.id::text != '"none"'
     )
 ) sub;
+
+-- name: UpdateSceneSortOrders :exec
+-- Sets each scene's sort_order to its position in the given id list
+UPDATE scenes
+SET sort_order = ordered.position
+FROM unnest($2::uuid[]) WITH ORDINALITY AS ordered(id, position)
+WHERE scenes.id = ordered.id AND scenes.campaign_id = $1;
+
+-- name: SetSceneCharacterOrder :one
+UPDATE scenes
+SET
+    character_order = $2,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
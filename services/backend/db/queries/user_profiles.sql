@@ -0,0 +1,21 @@
+-- name: GetUserProfile :one
+SELECT * FROM user_profiles WHERE user_id = $1;
+
+-- name: ListUserProfiles :many
+SELECT * FROM user_profiles WHERE user_id = ANY(sqlc.arg('user_ids')::uuid[]);
+
+-- name: UpsertUserProfileDisplayName :one
+INSERT INTO user_profiles (user_id, display_name)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET display_name = EXCLUDED.display_name
+RETURNING *;
+
+-- name: SetUserProfileAvatar :one
+-- Replaces the avatar and the storage it uses; a NULL avatar_url clears it
+INSERT INTO user_profiles (user_id, avatar_url, storage_used_bytes)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET avatar_url = EXCLUDED.avatar_url,
+    storage_used_bytes = EXCLUDED.storage_used_bytes
+RETURNING *;
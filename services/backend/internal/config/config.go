@@ -3,7 +3,29 @@ package config
 import (
 	"errors"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+)
+
+// defaultDraftLimitPerUser is the number of compose drafts a user may keep when
+// DRAFT_LIMIT_PER_USER is unset.
+const defaultDraftLimitPerUser = 100
+
+// defaultDBQueryTimeout bounds a single database call when DB_QUERY_TIMEOUT is unset.
+const defaultDBQueryTimeout = 5 * time.Second
+
+// defaultDBLongQueryTimeout bounds phase transition and roll database calls when
+// DB_LONG_QUERY_TIMEOUT is unset.
+const defaultDBLongQueryTimeout = 30 * time.Second
+
+// Connection pool defaults, used when the DB_MAX_CONNS, DB_MIN_CONNS,
+// DB_MAX_CONN_LIFETIME, and DB_MAX_CONN_IDLE_TIME variables are unset.
+const (
+	defaultDBMaxConns        = 10
+	defaultDBMinConns        = 2
+	defaultDBMaxConnLifetime = time.Hour
+	defaultDBMaxConnIdleTime = 30 * time.Minute
 )
 
 // Config holds the application configuration.
@@ -17,6 +39,33 @@ type Config struct {
 	SupabaseJWKSURL        string
 	SupabaseJWTSecret      string // JWT secret for HS256 validation (local dev)
 	CORSAllowedOrigins     []string
+
+	// Campaign creation limits; 0 keeps service.MaxCampaignsPerUser
+	CampaignLimitPerUser       int
+	CampaignLimitExemptUserIDs []string
+
+	// Compose drafts kept per user before the oldest are deleted
+	DraftLimitPerUser int
+
+	// Largest roll allowed, measured as dice count times sides; 0 keeps
+	// dice.DefaultMaxRollSize
+	MaxRollSize int
+
+	// Per-call database timeouts; the long budget covers phase transitions and rolls
+	DBQueryTimeout     time.Duration
+	DBLongQueryTimeout time.Duration
+
+	// Connection pool sizing and connection recycling
+	DBMaxConns        int
+	DBMinConns        int
+	DBMaxConnLifetime time.Duration
+	DBMaxConnIdleTime time.Duration
+
+	// Keep unrecognized campaign settings keys instead of rejecting them
+	AllowUnknownCampaignSettings bool
+
+	// Serve Prometheus metrics on /metrics (unauthenticated; keep it off public listeners)
+	MetricsEnabled bool
 }
 
 // Load reads configuration from environment variables.
@@ -31,12 +80,34 @@ func Load() (*Config, error) {
 		SupabaseJWKSURL:        os.Getenv("SUPABASE_JWKS_URL"),
 		SupabaseJWTSecret:      os.Getenv("SUPABASE_JWT_SECRET"),
 		CORSAllowedOrigins:     strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173"), ","),
+
+		CampaignLimitPerUser:       getEnvInt("CAMPAIGN_LIMIT_PER_USER", 0),
+		CampaignLimitExemptUserIDs: splitNonEmpty(os.Getenv("CAMPAIGN_LIMIT_EXEMPT_USER_IDS")),
+
+		DraftLimitPerUser: getEnvInt("DRAFT_LIMIT_PER_USER", defaultDraftLimitPerUser),
+
+		MaxRollSize: getEnvInt("DICE_MAX_ROLL_SIZE", 0),
+
+		DBQueryTimeout:     getEnvDuration("DB_QUERY_TIMEOUT", defaultDBQueryTimeout),
+		DBLongQueryTimeout: getEnvDuration("DB_LONG_QUERY_TIMEOUT", defaultDBLongQueryTimeout),
+
+		DBMaxConns:        getEnvInt("DB_MAX_CONNS", defaultDBMaxConns),
+		DBMinConns:        getEnvInt("DB_MIN_CONNS", defaultDBMinConns),
+		DBMaxConnLifetime: getEnvDuration("DB_MAX_CONN_LIFETIME", defaultDBMaxConnLifetime),
+		DBMaxConnIdleTime: getEnvDuration("DB_MAX_CONN_IDLE_TIME", defaultDBMaxConnIdleTime),
+
+		AllowUnknownCampaignSettings: os.Getenv("CAMPAIGN_SETTINGS_ALLOW_UNKNOWN") == "true",
+
+		MetricsEnabled: os.Getenv("METRICS_ENABLED") == "true",
 	}
 
 	// Validate required fields
 	if cfg.DatabaseURL == "" {
 		return nil, errors.New("DATABASE_URL is required")
 	}
+	if cfg.DBMinConns > cfg.DBMaxConns {
+		return nil, errors.New("DB_MIN_CONNS must not exceed DB_MAX_CONNS")
+	}
 	// Either JWKS URL or JWT Secret is required for auth
 	if cfg.SupabaseJWKSURL == "" && cfg.SupabaseJWTSecret == "" {
 		return nil, errors.New("either SUPABASE_JWKS_URL or SUPABASE_JWT_SECRET is required")
@@ -59,3 +130,32 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvDuration parses a Go duration such as "5s", falling back to the default
+// when the value is unset, malformed, or not positive.
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// splitNonEmpty splits a comma-separated list, trimming spaces and dropping empty entries.
+func splitNonEmpty(value string) []string {
+	parts := []string{}
+	for _, part := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			parts = append(parts, trimmed)
+		}
+	}
+	return parts
+}
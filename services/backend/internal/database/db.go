@@ -3,6 +3,8 @@ package database
 import (
 	"context"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -12,15 +14,42 @@ type DB struct {
 	Pool *pgxpool.Pool
 }
 
+// PoolSettings sizes the connection pool. Zero fields keep the pgxpool default.
+type PoolSettings struct {
+	MaxConns        int
+	MinConns        int
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+}
+
 // Connect creates a new database connection pool.
-func Connect(databaseURL string) (*DB, error) {
-	pool, err := pgxpool.New(context.Background(), databaseURL)
+func Connect(databaseURL string, settings PoolSettings) (*DB, error) {
+	poolConfig, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse database URL: %w", err)
+	}
+
+	if settings.MaxConns > 0 {
+		poolConfig.MaxConns = int32(min(settings.MaxConns, math.MaxInt32)) //nolint:gosec // Clamped to int32
+	}
+	if settings.MinConns > 0 {
+		poolConfig.MinConns = int32(min(settings.MinConns, math.MaxInt32)) //nolint:gosec // Clamped to int32
+	}
+	if settings.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = settings.MaxConnLifetime
+	}
+	if settings.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = settings.MaxConnIdleTime
+	}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection pool: %w", err)
 	}
 
 	// Test connection
 	if pingErr := pool.Ping(context.Background()); pingErr != nil {
+		pool.Close()
 		return nil, fmt.Errorf("unable to ping database: %w", pingErr)
 	}
 
@@ -99,6 +99,18 @@ func (q *Queries) CountActiveLocksInCampaign(ctx context.Context, campaignID pgt
 	return count, err
 }
 
+const countCampaignPlayers = `-- name: CountCampaignPlayers :one
+SELECT COUNT(*) FROM campaign_members
+WHERE campaign_id = $1 AND role = 'player'
+`
+
+func (q *Queries) CountCampaignPlayers(ctx context.Context, campaignID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countCampaignPlayers, campaignID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countPendingRollsInCampaign = `-- name: CountPendingRollsInCampaign :one
 SELECT COUNT(*)
 FROM rolls r
@@ -114,6 +126,17 @@ func (q *Queries) CountPendingRollsInCampaign(ctx context.Context, campaignID pg
 	return count, err
 }
 
+const countUserCampaigns = `-- name: CountUserCampaigns :one
+SELECT COUNT(*) FROM campaign_members WHERE user_id = $1
+`
+
+func (q *Queries) CountUserCampaigns(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countUserCampaigns, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUserOwnedCampaigns = `-- name: CountUserOwnedCampaigns :one
 SELECT COUNT(*) FROM campaigns WHERE owner_id = $1
 `
@@ -228,6 +251,33 @@ func (q *Queries) GetCampaign(ctx context.Context, id pgtype.UUID) (Campaign, er
 	return i, err
 }
 
+const getCampaignForUpdate = `-- name: GetCampaignForUpdate :one
+SELECT id, title, description, owner_id, settings, current_phase, current_phase_started_at, current_phase_expires_at, is_paused, last_gm_activity_at, storage_used_bytes, scene_count, created_at, updated_at FROM campaigns WHERE id = $1 FOR UPDATE
+`
+
+// Locks the campaign row for the rest of the transaction
+func (q *Queries) GetCampaignForUpdate(ctx context.Context, id pgtype.UUID) (Campaign, error) {
+	row := q.db.QueryRow(ctx, getCampaignForUpdate, id)
+	var i Campaign
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.OwnerID,
+		&i.Settings,
+		&i.CurrentPhase,
+		&i.CurrentPhaseStartedAt,
+		&i.CurrentPhaseExpiresAt,
+		&i.IsPaused,
+		&i.LastGmActivityAt,
+		&i.StorageUsedBytes,
+		&i.SceneCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getCampaignMember = `-- name: GetCampaignMember :one
 SELECT id, campaign_id, user_id, role, joined_at, alias FROM campaign_members
 WHERE campaign_id = $1 AND user_id = $2
@@ -362,6 +412,17 @@ func (q *Queries) GetCampaignStorage(ctx context.Context, id pgtype.UUID) (int64
 	return storage_used_bytes, err
 }
 
+const getCampaignStorageLimit = `-- name: GetCampaignStorageLimit :one
+SELECT storage_limit_bytes FROM campaign_storage_limits WHERE campaign_id = $1
+`
+
+func (q *Queries) GetCampaignStorageLimit(ctx context.Context, campaignID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, getCampaignStorageLimit, campaignID)
+	var storage_limit_bytes int64
+	err := row.Scan(&storage_limit_bytes)
+	return storage_limit_bytes, err
+}
+
 const getCampaignWithMembership = `-- name: GetCampaignWithMembership :one
 SELECT
     c.id, c.title, c.description, c.owner_id, c.settings, c.current_phase, c.current_phase_started_at, c.current_phase_expires_at, c.is_paused, c.last_gm_activity_at, c.storage_used_bytes, c.scene_count, c.created_at, c.updated_at,
@@ -562,6 +623,39 @@ func (q *Queries) IsUserGM(ctx context.Context, arg IsUserGMParams) (bool, error
 	return is_gm, err
 }
 
+const listCampaignMemberEmails = `-- name: ListCampaignMemberEmails :many
+SELECT cm.user_id, u.email
+FROM campaign_members cm
+INNER JOIN auth.users u ON u.id = cm.user_id
+WHERE cm.campaign_id = $1
+`
+
+type ListCampaignMemberEmailsRow struct {
+	UserID pgtype.UUID `json:"user_id"`
+	Email  pgtype.Text `json:"email"`
+}
+
+// Returns the account email of each campaign member; only GMs may see these
+func (q *Queries) ListCampaignMemberEmails(ctx context.Context, campaignID pgtype.UUID) ([]ListCampaignMemberEmailsRow, error) {
+	rows, err := q.db.Query(ctx, listCampaignMemberEmails, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCampaignMemberEmailsRow
+	for rows.Next() {
+		var i ListCampaignMemberEmailsRow
+		if err := rows.Scan(&i.UserID, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUserCampaigns = `-- name: ListUserCampaigns :many
 SELECT
     c.id, c.title, c.description, c.owner_id, c.settings, c.current_phase, c.current_phase_started_at, c.current_phase_expires_at, c.is_paused, c.last_gm_activity_at, c.storage_used_bytes, c.scene_count, c.created_at, c.updated_at,
@@ -641,6 +735,47 @@ func (q *Queries) RemoveCampaignMember(ctx context.Context, arg RemoveCampaignMe
 	return err
 }
 
+const resetNegativeCampaignStorage = `-- name: ResetNegativeCampaignStorage :execrows
+UPDATE campaigns
+SET
+    storage_used_bytes = 0,
+    updated_at = NOW()
+WHERE storage_used_bytes < 0
+`
+
+// Clears storage usage driven below zero by double-released files
+func (q *Queries) ResetNegativeCampaignStorage(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, resetNegativeCampaignStorage)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setCampaignStorageLimit = `-- name: SetCampaignStorageLimit :one
+INSERT INTO campaign_storage_limits (campaign_id, storage_limit_bytes)
+VALUES ($1, $2)
+ON CONFLICT (campaign_id) DO UPDATE
+SET storage_limit_bytes = EXCLUDED.storage_limit_bytes
+RETURNING campaign_id, storage_limit_bytes, updated_at
+`
+
+type SetCampaignStorageLimitParams struct {
+	CampaignID        pgtype.UUID `json:"campaign_id"`
+	StorageLimitBytes int64       `json:"storage_limit_bytes"`
+}
+
+func (q *Queries) SetCampaignStorageLimit(ctx context.Context, arg SetCampaignStorageLimitParams) (CampaignStorageLimit, error) {
+	row := q.db.QueryRow(ctx, setCampaignStorageLimit, arg.CampaignID, arg.StorageLimitBytes)
+	var i CampaignStorageLimit
+	err := row.Scan(
+		&i.CampaignID,
+		&i.StorageLimitBytes,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const transitionCampaignPhase = `-- name: TransitionCampaignPhase :one
 UPDATE campaigns
 SET
@@ -680,6 +815,18 @@ func (q *Queries) TransitionCampaignPhase(ctx context.Context, arg TransitionCam
 	return i, err
 }
 
+const tryTimeGateWorkerLock = `-- name: TryTimeGateWorkerLock :one
+SELECT pg_try_advisory_xact_lock(hashtext('time_gate_worker')) AS acquired
+`
+
+// Held until the surrounding transaction ends, so only one instance expires time gates at a time
+func (q *Queries) TryTimeGateWorkerLock(ctx context.Context) (bool, error) {
+	row := q.db.QueryRow(ctx, tryTimeGateWorkerLock)
+	var acquired bool
+	err := row.Scan(&acquired)
+	return acquired, err
+}
+
 const updateCampaign = `-- name: UpdateCampaign :one
 UPDATE campaigns
 SET
@@ -818,6 +965,42 @@ func (q *Queries) UpdateCampaignPhase(ctx context.Context, arg UpdateCampaignPha
 	return err
 }
 
+const updateCampaignSettings = `-- name: UpdateCampaignSettings :one
+UPDATE campaigns
+SET
+    settings = $2,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, title, description, owner_id, settings, current_phase, current_phase_started_at, current_phase_expires_at, is_paused, last_gm_activity_at, storage_used_bytes, scene_count, created_at, updated_at
+`
+
+type UpdateCampaignSettingsParams struct {
+	ID       pgtype.UUID `json:"id"`
+	Settings []byte      `json:"settings"`
+}
+
+func (q *Queries) UpdateCampaignSettings(ctx context.Context, arg UpdateCampaignSettingsParams) (Campaign, error) {
+	row := q.db.QueryRow(ctx, updateCampaignSettings, arg.ID, arg.Settings)
+	var i Campaign
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.OwnerID,
+		&i.Settings,
+		&i.CurrentPhase,
+		&i.CurrentPhaseStartedAt,
+		&i.CurrentPhaseExpiresAt,
+		&i.IsPaused,
+		&i.LastGmActivityAt,
+		&i.StorageUsedBytes,
+		&i.SceneCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateGmActivity = `-- name: UpdateGmActivity :exec
 UPDATE campaigns
 SET last_gm_activity_at = NOW()
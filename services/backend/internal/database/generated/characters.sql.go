@@ -17,7 +17,7 @@ SET
     is_archived = true,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at
+RETURNING id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at, pronouns, gm_notes, tags
 `
 
 func (q *Queries) ArchiveCharacter(ctx context.Context, id pgtype.UUID) (Character, error) {
@@ -33,6 +33,9 @@ func (q *Queries) ArchiveCharacter(ctx context.Context, id pgtype.UUID) (Charact
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Pronouns,
+		&i.GmNotes,
+		&i.Tags,
 	)
 	return i, err
 }
@@ -74,7 +77,7 @@ SET
     avatar_url = NULL,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at
+RETURNING id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at, pronouns, gm_notes, tags
 `
 
 func (q *Queries) ClearCharacterAvatar(ctx context.Context, id pgtype.UUID) (Character, error) {
@@ -90,6 +93,9 @@ func (q *Queries) ClearCharacterAvatar(ctx context.Context, id pgtype.UUID) (Cha
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Pronouns,
+		&i.GmNotes,
+		&i.Tags,
 	)
 	return i, err
 }
@@ -114,7 +120,7 @@ INSERT INTO characters (
 ) VALUES (
     $1, $2, $3, $4
 )
-RETURNING id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at
+RETURNING id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at, pronouns, gm_notes, tags
 `
 
 type CreateCharacterParams struct {
@@ -142,12 +148,15 @@ func (q *Queries) CreateCharacter(ctx context.Context, arg CreateCharacterParams
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Pronouns,
+		&i.GmNotes,
+		&i.Tags,
 	)
 	return i, err
 }
 
 const getCharacter = `-- name: GetCharacter :one
-SELECT id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at FROM characters WHERE id = $1
+SELECT id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at, pronouns, gm_notes, tags FROM characters WHERE id = $1
 `
 
 func (q *Queries) GetCharacter(ctx context.Context, id pgtype.UUID) (Character, error) {
@@ -163,6 +172,9 @@ func (q *Queries) GetCharacter(ctx context.Context, id pgtype.UUID) (Character,
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Pronouns,
+		&i.GmNotes,
+		&i.Tags,
 	)
 	return i, err
 }
@@ -196,7 +208,7 @@ func (q *Queries) GetCharacterCampaignID(ctx context.Context, id pgtype.UUID) (p
 
 const getCharacterWithAssignment = `-- name: GetCharacterWithAssignment :one
 SELECT
-    c.id, c.campaign_id, c.display_name, c.description, c.avatar_url, c.character_type, c.is_archived, c.created_at, c.updated_at,
+    c.id, c.campaign_id, c.display_name, c.description, c.avatar_url, c.character_type, c.is_archived, c.created_at, c.updated_at, c.pronouns, c.gm_notes, c.tags,
     ca.user_id AS assigned_user_id,
     ca.assigned_at
 FROM characters c
@@ -214,6 +226,9 @@ type GetCharacterWithAssignmentRow struct {
 	IsArchived     bool               `json:"is_archived"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Pronouns       pgtype.Text        `json:"pronouns"`
+	GmNotes        pgtype.Text        `json:"gm_notes"`
+	Tags           []string           `json:"tags"`
 	AssignedUserID pgtype.UUID        `json:"assigned_user_id"`
 	AssignedAt     pgtype.Timestamptz `json:"assigned_at"`
 }
@@ -231,6 +246,9 @@ func (q *Queries) GetCharacterWithAssignment(ctx context.Context, id pgtype.UUID
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Pronouns,
+		&i.GmNotes,
+		&i.Tags,
 		&i.AssignedUserID,
 		&i.AssignedAt,
 	)
@@ -238,7 +256,7 @@ func (q *Queries) GetCharacterWithAssignment(ctx context.Context, id pgtype.UUID
 }
 
 const getOrphanedCharacters = `-- name: GetOrphanedCharacters :many
-SELECT c.id, c.campaign_id, c.display_name, c.description, c.avatar_url, c.character_type, c.is_archived, c.created_at, c.updated_at
+SELECT c.id, c.campaign_id, c.display_name, c.description, c.avatar_url, c.character_type, c.is_archived, c.created_at, c.updated_at, c.pronouns, c.gm_notes, c.tags
 FROM characters c
 LEFT JOIN character_assignments ca ON c.id = ca.character_id
 WHERE c.campaign_id = $1 AND ca.id IS NULL AND c.is_archived = false
@@ -264,6 +282,9 @@ func (q *Queries) GetOrphanedCharacters(ctx context.Context, campaignID pgtype.U
 			&i.IsArchived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Pronouns,
+			&i.GmNotes,
+			&i.Tags,
 		); err != nil {
 			return nil, err
 		}
@@ -277,7 +298,7 @@ func (q *Queries) GetOrphanedCharacters(ctx context.Context, campaignID pgtype.U
 
 const getUserCharactersInScene = `-- name: GetUserCharactersInScene :many
 SELECT
-    c.id, c.campaign_id, c.display_name, c.description, c.avatar_url, c.character_type, c.is_archived, c.created_at, c.updated_at,
+    c.id, c.campaign_id, c.display_name, c.description, c.avatar_url, c.character_type, c.is_archived, c.created_at, c.updated_at, c.pronouns, c.gm_notes, c.tags,
     ca.user_id AS assigned_user_id,
     ca.assigned_at
 FROM characters c
@@ -305,6 +326,9 @@ type GetUserCharactersInSceneRow struct {
 	IsArchived     bool               `json:"is_archived"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Pronouns       pgtype.Text        `json:"pronouns"`
+	GmNotes        pgtype.Text        `json:"gm_notes"`
+	Tags           []string           `json:"tags"`
 	AssignedUserID pgtype.UUID        `json:"assigned_user_id"`
 	AssignedAt     pgtype.Timestamptz `json:"assigned_at"`
 }
@@ -328,6 +352,9 @@ func (q *Queries) GetUserCharactersInScene(ctx context.Context, arg GetUserChara
 			&i.IsArchived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Pronouns,
+			&i.GmNotes,
+			&i.Tags,
 			&i.AssignedUserID,
 			&i.AssignedAt,
 		); err != nil {
@@ -343,7 +370,7 @@ func (q *Queries) GetUserCharactersInScene(ctx context.Context, arg GetUserChara
 
 const listCampaignCharacters = `-- name: ListCampaignCharacters :many
 SELECT
-    c.id, c.campaign_id, c.display_name, c.description, c.avatar_url, c.character_type, c.is_archived, c.created_at, c.updated_at,
+    c.id, c.campaign_id, c.display_name, c.description, c.avatar_url, c.character_type, c.is_archived, c.created_at, c.updated_at, c.pronouns, c.gm_notes, c.tags,
     ca.user_id AS assigned_user_id,
     ca.assigned_at
 FROM characters c
@@ -362,6 +389,9 @@ type ListCampaignCharactersRow struct {
 	IsArchived     bool               `json:"is_archived"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Pronouns       pgtype.Text        `json:"pronouns"`
+	GmNotes        pgtype.Text        `json:"gm_notes"`
+	Tags           []string           `json:"tags"`
 	AssignedUserID pgtype.UUID        `json:"assigned_user_id"`
 	AssignedAt     pgtype.Timestamptz `json:"assigned_at"`
 }
@@ -385,6 +415,9 @@ func (q *Queries) ListCampaignCharacters(ctx context.Context, campaignID pgtype.
 			&i.IsArchived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Pronouns,
+			&i.GmNotes,
+			&i.Tags,
 			&i.AssignedUserID,
 			&i.AssignedAt,
 		); err != nil {
@@ -400,7 +433,7 @@ func (q *Queries) ListCampaignCharacters(ctx context.Context, campaignID pgtype.
 
 const listUserCharactersInCampaign = `-- name: ListUserCharactersInCampaign :many
 SELECT
-    c.id, c.campaign_id, c.display_name, c.description, c.avatar_url, c.character_type, c.is_archived, c.created_at, c.updated_at,
+    c.id, c.campaign_id, c.display_name, c.description, c.avatar_url, c.character_type, c.is_archived, c.created_at, c.updated_at, c.pronouns, c.gm_notes, c.tags,
     ca.user_id AS assigned_user_id,
     ca.assigned_at
 FROM characters c
@@ -424,6 +457,9 @@ type ListUserCharactersInCampaignRow struct {
 	IsArchived     bool               `json:"is_archived"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Pronouns       pgtype.Text        `json:"pronouns"`
+	GmNotes        pgtype.Text        `json:"gm_notes"`
+	Tags           []string           `json:"tags"`
 	AssignedUserID pgtype.UUID        `json:"assigned_user_id"`
 	AssignedAt     pgtype.Timestamptz `json:"assigned_at"`
 }
@@ -447,6 +483,9 @@ func (q *Queries) ListUserCharactersInCampaign(ctx context.Context, arg ListUser
 			&i.IsArchived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Pronouns,
+			&i.GmNotes,
+			&i.Tags,
 			&i.AssignedUserID,
 			&i.AssignedAt,
 		); err != nil {
@@ -460,13 +499,47 @@ func (q *Queries) ListUserCharactersInCampaign(ctx context.Context, arg ListUser
 	return items, nil
 }
 
+const setCharacterTags = `-- name: SetCharacterTags :one
+UPDATE characters
+SET
+    tags = $2,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at, pronouns, gm_notes, tags
+`
+
+type SetCharacterTagsParams struct {
+	ID   pgtype.UUID `json:"id"`
+	Tags []string    `json:"tags"`
+}
+
+func (q *Queries) SetCharacterTags(ctx context.Context, arg SetCharacterTagsParams) (Character, error) {
+	row := q.db.QueryRow(ctx, setCharacterTags, arg.ID, arg.Tags)
+	var i Character
+	err := row.Scan(
+		&i.ID,
+		&i.CampaignID,
+		&i.DisplayName,
+		&i.Description,
+		&i.AvatarUrl,
+		&i.CharacterType,
+		&i.IsArchived,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Pronouns,
+		&i.GmNotes,
+		&i.Tags,
+	)
+	return i, err
+}
+
 const unarchiveCharacter = `-- name: UnarchiveCharacter :one
 UPDATE characters
 SET
     is_archived = false,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at
+RETURNING id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at, pronouns, gm_notes, tags
 `
 
 func (q *Queries) UnarchiveCharacter(ctx context.Context, id pgtype.UUID) (Character, error) {
@@ -482,6 +555,9 @@ func (q *Queries) UnarchiveCharacter(ctx context.Context, id pgtype.UUID) (Chara
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Pronouns,
+		&i.GmNotes,
+		&i.Tags,
 	)
 	return i, err
 }
@@ -495,6 +571,45 @@ func (q *Queries) UnassignCharacter(ctx context.Context, characterID pgtype.UUID
 	return err
 }
 
+const unassignUserCharactersInCampaign = `-- name: UnassignUserCharactersInCampaign :many
+DELETE FROM character_assignments ca
+USING characters c
+WHERE ca.character_id = c.id AND c.campaign_id = $1 AND ca.user_id = $2
+RETURNING c.id, c.display_name, c.character_type
+`
+
+type UnassignUserCharactersInCampaignParams struct {
+	CampaignID pgtype.UUID `json:"campaign_id"`
+	UserID     pgtype.UUID `json:"user_id"`
+}
+
+type UnassignUserCharactersInCampaignRow struct {
+	ID            pgtype.UUID   `json:"id"`
+	DisplayName   string        `json:"display_name"`
+	CharacterType CharacterType `json:"character_type"`
+}
+
+// Removes every assignment a user holds in a campaign and returns the released characters
+func (q *Queries) UnassignUserCharactersInCampaign(ctx context.Context, arg UnassignUserCharactersInCampaignParams) ([]UnassignUserCharactersInCampaignRow, error) {
+	rows, err := q.db.Query(ctx, unassignUserCharactersInCampaign, arg.CampaignID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UnassignUserCharactersInCampaignRow
+	for rows.Next() {
+		var i UnassignUserCharactersInCampaignRow
+		if err := rows.Scan(&i.ID, &i.DisplayName, &i.CharacterType); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateCharacter = `-- name: UpdateCharacter :one
 UPDATE characters
 SET
@@ -502,9 +617,11 @@ SET
     description = COALESCE($3, description),
     avatar_url = COALESCE($4, avatar_url),
     character_type = COALESCE($5, character_type),
+    pronouns = $6,
+    gm_notes = $7,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at
+RETURNING id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at, pronouns, gm_notes, tags
 `
 
 type UpdateCharacterParams struct {
@@ -513,6 +630,8 @@ type UpdateCharacterParams struct {
 	Description   pgtype.Text   `json:"description"`
 	AvatarUrl     pgtype.Text   `json:"avatar_url"`
 	CharacterType CharacterType `json:"character_type"`
+	Pronouns      pgtype.Text   `json:"pronouns"`
+	GmNotes       pgtype.Text   `json:"gm_notes"`
 }
 
 func (q *Queries) UpdateCharacter(ctx context.Context, arg UpdateCharacterParams) (Character, error) {
@@ -522,6 +641,8 @@ func (q *Queries) UpdateCharacter(ctx context.Context, arg UpdateCharacterParams
 		arg.Description,
 		arg.AvatarUrl,
 		arg.CharacterType,
+		arg.Pronouns,
+		arg.GmNotes,
 	)
 	var i Character
 	err := row.Scan(
@@ -534,6 +655,9 @@ func (q *Queries) UpdateCharacter(ctx context.Context, arg UpdateCharacterParams
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Pronouns,
+		&i.GmNotes,
+		&i.Tags,
 	)
 	return i, err
 }
@@ -544,7 +668,7 @@ SET
     avatar_url = $2,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at
+RETURNING id, campaign_id, display_name, description, avatar_url, character_type, is_archived, created_at, updated_at, pronouns, gm_notes, tags
 `
 
 type UpdateCharacterAvatarParams struct {
@@ -565,6 +689,9 @@ func (q *Queries) UpdateCharacterAvatar(ctx context.Context, arg UpdateCharacter
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Pronouns,
+		&i.GmNotes,
+		&i.Tags,
 	)
 	return i, err
 }
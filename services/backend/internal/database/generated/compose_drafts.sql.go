@@ -11,6 +11,18 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countUserDrafts = `-- name: CountUserDrafts :one
+SELECT COUNT(*) FROM compose_drafts
+WHERE user_id = $1
+`
+
+func (q *Queries) CountUserDrafts(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countUserDrafts, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createComposeDraft = `-- name: CreateComposeDraft :one
 INSERT INTO compose_drafts (
     scene_id,
@@ -89,6 +101,30 @@ func (q *Queries) DeleteComposeDraftByCharacter(ctx context.Context, arg DeleteC
 	return err
 }
 
+const deleteOldestUserDrafts = `-- name: DeleteOldestUserDrafts :execrows
+DELETE FROM compose_drafts
+WHERE id IN (
+    SELECT id FROM compose_drafts
+    WHERE user_id = $1
+    ORDER BY updated_at ASC
+    LIMIT $2
+)
+`
+
+type DeleteOldestUserDraftsParams struct {
+	UserID pgtype.UUID `json:"user_id"`
+	Limit  int32       `json:"limit"`
+}
+
+// Deletes the user's least recently updated drafts
+func (q *Queries) DeleteOldestUserDrafts(ctx context.Context, arg DeleteOldestUserDraftsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOldestUserDrafts, arg.UserID, arg.Limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getComposeDraft = `-- name: GetComposeDraft :one
 SELECT id, scene_id, character_id, user_id, blocks, ooc_text, intention, modifier, is_hidden, updated_at FROM compose_drafts
 WHERE scene_id = $1 AND character_id = $2
@@ -226,6 +262,39 @@ func (q *Queries) ListUserDrafts(ctx context.Context, userID pgtype.UUID) ([]Lis
 	return items, nil
 }
 
+const purgeInaccessibleDrafts = `-- name: PurgeInaccessibleDrafts :execrows
+DELETE FROM compose_drafts cd
+USING scenes s
+WHERE cd.scene_id = s.id
+  AND (
+      NOT (cd.character_id = ANY(s.character_ids))
+      OR NOT EXISTS (
+          SELECT 1 FROM campaign_members cm
+          WHERE cm.campaign_id = s.campaign_id AND cm.user_id = cd.user_id
+      )
+      OR (
+          NOT EXISTS (
+              SELECT 1 FROM campaign_members cm
+              WHERE cm.campaign_id = s.campaign_id AND cm.user_id = cd.user_id AND cm.role = 'gm'
+          )
+          AND NOT EXISTS (
+              SELECT 1 FROM character_assignments ca
+              WHERE ca.character_id = cd.character_id AND ca.user_id = cd.user_id
+          )
+      )
+  )
+`
+
+// Removes drafts whose author left the campaign, whose character left the scene,
+// or whose character is no longer assigned to the (non-GM) author
+func (q *Queries) PurgeInaccessibleDrafts(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeInaccessibleDrafts)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateComposeDraft = `-- name: UpdateComposeDraft :one
 UPDATE compose_drafts
 SET
@@ -334,3 +403,39 @@ func (q *Queries) UpsertComposeDraft(ctx context.Context, arg UpsertComposeDraft
 	)
 	return i, err
 }
+
+const upsertComposeDraftBlocks = `-- name: UpsertComposeDraftBlocks :exec
+INSERT INTO compose_drafts (
+    scene_id,
+    character_id,
+    user_id,
+    blocks,
+    is_hidden
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (scene_id, character_id)
+DO UPDATE SET
+    blocks = EXCLUDED.blocks,
+    updated_at = NOW()
+`
+
+type UpsertComposeDraftBlocksParams struct {
+	SceneID     pgtype.UUID `json:"scene_id"`
+	CharacterID pgtype.UUID `json:"character_id"`
+	UserID      pgtype.UUID `json:"user_id"`
+	Blocks      []byte      `json:"blocks"`
+	IsHidden    bool        `json:"is_hidden"`
+}
+
+// Saves only the draft blocks, keeping any existing OOC text, intention and modifier
+func (q *Queries) UpsertComposeDraftBlocks(ctx context.Context, arg UpsertComposeDraftBlocksParams) error {
+	_, err := q.db.Exec(ctx, upsertComposeDraftBlocks,
+		arg.SceneID,
+		arg.CharacterID,
+		arg.UserID,
+		arg.Blocks,
+		arg.IsHidden,
+	)
+	return err
+}
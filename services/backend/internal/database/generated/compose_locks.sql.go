@@ -83,13 +83,16 @@ func (q *Queries) DeleteExpiredComposeLocks(ctx context.Context, expiresAt pgtyp
 	return err
 }
 
-const deleteSceneComposeLocks = `-- name: DeleteSceneComposeLocks :exec
+const deleteSceneComposeLocks = `-- name: DeleteSceneComposeLocks :execrows
 DELETE FROM compose_locks WHERE scene_id = $1
 `
 
-func (q *Queries) DeleteSceneComposeLocks(ctx context.Context, sceneID pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteSceneComposeLocks, sceneID)
-	return err
+func (q *Queries) DeleteSceneComposeLocks(ctx context.Context, sceneID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteSceneComposeLocks, sceneID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getComposeLock = `-- name: GetComposeLock :one
@@ -236,6 +239,69 @@ func (q *Queries) GetComposeLockWithHiddenInfo(ctx context.Context, arg GetCompo
 	return i, err
 }
 
+const getComposeLocksByCampaign = `-- name: GetComposeLocksByCampaign :many
+SELECT
+    cl.id, cl.scene_id, cl.character_id, cl.user_id, cl.acquired_at, cl.last_activity_at, cl.expires_at, cl.is_hidden,
+    s.title AS scene_title,
+    c.display_name AS character_name,
+    c.avatar_url AS character_avatar,
+    cm.alias AS user_alias
+FROM compose_locks cl
+INNER JOIN scenes s ON cl.scene_id = s.id
+INNER JOIN characters c ON cl.character_id = c.id
+LEFT JOIN campaign_members cm ON cm.campaign_id = s.campaign_id AND cm.user_id = cl.user_id
+WHERE s.campaign_id = $1
+ORDER BY s.sort_order ASC, cl.acquired_at ASC
+`
+
+type GetComposeLocksByCampaignRow struct {
+	ID              pgtype.UUID        `json:"id"`
+	SceneID         pgtype.UUID        `json:"scene_id"`
+	CharacterID     pgtype.UUID        `json:"character_id"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	AcquiredAt      pgtype.Timestamptz `json:"acquired_at"`
+	LastActivityAt  pgtype.Timestamptz `json:"last_activity_at"`
+	ExpiresAt       pgtype.Timestamptz `json:"expires_at"`
+	IsHidden        bool               `json:"is_hidden"`
+	SceneTitle      string             `json:"scene_title"`
+	CharacterName   string             `json:"character_name"`
+	CharacterAvatar pgtype.Text        `json:"character_avatar"`
+	UserAlias       pgtype.Text        `json:"user_alias"`
+}
+
+func (q *Queries) GetComposeLocksByCampaign(ctx context.Context, campaignID pgtype.UUID) ([]GetComposeLocksByCampaignRow, error) {
+	rows, err := q.db.Query(ctx, getComposeLocksByCampaign, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetComposeLocksByCampaignRow
+	for rows.Next() {
+		var i GetComposeLocksByCampaignRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SceneID,
+			&i.CharacterID,
+			&i.UserID,
+			&i.AcquiredAt,
+			&i.LastActivityAt,
+			&i.ExpiresAt,
+			&i.IsHidden,
+			&i.SceneTitle,
+			&i.CharacterName,
+			&i.CharacterAvatar,
+			&i.UserAlias,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserComposeLockInScene = `-- name: GetUserComposeLockInScene :one
 SELECT id, scene_id, character_id, user_id, acquired_at, last_activity_at, expires_at, is_hidden FROM compose_locks
 WHERE scene_id = $1 AND user_id = $2
@@ -296,3 +362,50 @@ func (q *Queries) UpdateComposeLockHidden(ctx context.Context, arg UpdateCompose
 	_, err := q.db.Exec(ctx, updateComposeLockHidden, arg.ID, arg.IsHidden)
 	return err
 }
+
+const updateComposeLocksActivity = `-- name: UpdateComposeLocksActivity :many
+UPDATE compose_locks
+SET
+    last_activity_at = $3,
+    expires_at = $4
+WHERE user_id = $1 AND id = ANY($2::uuid[])
+RETURNING id, expires_at
+`
+
+type UpdateComposeLocksActivityParams struct {
+	UserID         pgtype.UUID        `json:"user_id"`
+	Column2        []pgtype.UUID      `json:"column_2"`
+	LastActivityAt pgtype.Timestamptz `json:"last_activity_at"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+}
+
+type UpdateComposeLocksActivityRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+// Refreshes the given locks owned by the user, skipping any they do not own
+func (q *Queries) UpdateComposeLocksActivity(ctx context.Context, arg UpdateComposeLocksActivityParams) ([]UpdateComposeLocksActivityRow, error) {
+	rows, err := q.db.Query(ctx, updateComposeLocksActivity,
+		arg.UserID,
+		arg.Column2,
+		arg.LastActivityAt,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UpdateComposeLocksActivityRow
+	for rows.Next() {
+		var i UpdateComposeLocksActivityRow
+		if err := rows.Scan(&i.ID, &i.ExpiresAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
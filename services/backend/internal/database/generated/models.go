@@ -554,6 +554,20 @@ type Campaign struct {
 	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
 }
 
+type CampaignBotToken struct {
+	ID         pgtype.UUID `json:"id"`
+	CampaignID pgtype.UUID `json:"campaign_id"`
+	CreatedBy  pgtype.UUID `json:"created_by"`
+	Name       string      `json:"name"`
+	// Hex SHA-256 of the token; the plaintext is never stored
+	TokenHash string `json:"token_hash"`
+	// First characters of the token, shown so GMs can tell tokens apart
+	TokenPrefix string             `json:"token_prefix"`
+	LastUsedAt  pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt   pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
 type CampaignMember struct {
 	ID         pgtype.UUID        `json:"id"`
 	CampaignID pgtype.UUID        `json:"campaign_id"`
@@ -564,6 +578,25 @@ type CampaignMember struct {
 	Alias pgtype.Text `json:"alias"`
 }
 
+type CampaignStorageLimit struct {
+	CampaignID pgtype.UUID `json:"campaign_id"`
+	// Image storage quota set by a platform admin; overrides the default
+	StorageLimitBytes int64              `json:"storage_limit_bytes"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+}
+
+type CampaignWebhook struct {
+	CampaignID pgtype.UUID `json:"campaign_id"`
+	Url        string      `json:"url"`
+	// HMAC-SHA256 signing key; deliveries carry the body signature in X-Vanguard-Signature
+	Secret string `json:"secret"`
+	// Event types delivered to the webhook
+	Events    []string           `json:"events"`
+	Enabled   bool               `json:"enabled"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Character struct {
 	ID            pgtype.UUID        `json:"id"`
 	CampaignID    pgtype.UUID        `json:"campaign_id"`
@@ -574,6 +607,12 @@ type Character struct {
 	IsArchived    bool               `json:"is_archived"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	// Optional pronouns shown to all campaign members
+	Pronouns pgtype.Text `json:"pronouns"`
+	// Private GM notes, never exposed to players
+	GmNotes pgtype.Text `json:"gm_notes"`
+	// Condition tags set by the GM, visible to all campaign members
+	Tags []string `json:"tags"`
 }
 
 type CharacterAssignment struct {
@@ -583,6 +622,16 @@ type CharacterAssignment struct {
 	AssignedAt  pgtype.Timestamptz `json:"assigned_at"`
 }
 
+type CharacterAvatarHistory struct {
+	ID          pgtype.UUID `json:"id"`
+	CharacterID pgtype.UUID `json:"character_id"`
+	AvatarUrl   string      `json:"avatar_url"`
+	// Stored file size, released from campaign storage when the entry is evicted; 0 if unknown
+	SizeBytes int64 `json:"size_bytes"`
+	// When the avatar was replaced and moved into history
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type ComposeDraft struct {
 	ID          pgtype.UUID        `json:"id"`
 	SceneID     pgtype.UUID        `json:"scene_id"`
@@ -616,6 +665,20 @@ type EmailDigest struct {
 	CampaignIds       []pgtype.UUID      `json:"campaign_ids"`
 }
 
+type GmAuditLog struct {
+	ID         pgtype.UUID `json:"id"`
+	CampaignID pgtype.UUID `json:"campaign_id"`
+	GmUserID   pgtype.UUID `json:"gm_user_id"`
+	// What the GM did, e.g. force_transition or delete_post
+	Action string `json:"action"`
+	// Kind of thing acted on: campaign, scene, post, roll, compose_lock, or user
+	TargetType string      `json:"target_type"`
+	TargetID   pgtype.UUID `json:"target_id"`
+	// Action-specific context, such as the previous and new values
+	Detail    []byte             `json:"detail"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type InviteLink struct {
 	ID         pgtype.UUID        `json:"id"`
 	CampaignID pgtype.UUID        `json:"campaign_id"`
@@ -670,6 +733,8 @@ type Notification struct {
 	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
 	CharacterID pgtype.UUID        `json:"character_id"`
 	Metadata    []byte             `json:"metadata"`
+	// Hidden from unread lists and counts until this time
+	SnoozeUntil pgtype.Timestamptz `json:"snooze_until"`
 }
 
 type NotificationPreference struct {
@@ -680,6 +745,8 @@ type NotificationPreference struct {
 	InAppEnabled   bool                  `json:"in_app_enabled"`
 	CreatedAt      pgtype.Timestamptz    `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz    `json:"updated_at"`
+	// Per-type channel toggles keyed by notification type; missing entries default to enabled
+	TypePreferences []byte `json:"type_preferences"`
 }
 
 type NotificationQueue struct {
@@ -691,6 +758,26 @@ type NotificationQueue struct {
 	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
 }
 
+type NotificationUnreadCount struct {
+	UserID pgtype.UUID `json:"user_id"`
+	// Campaign the notifications belong to, NULL for notifications outside any campaign
+	CampaignID pgtype.UUID `json:"campaign_id"`
+	// Unread, unsnoozed notifications; reconciled against the notifications table by the notification worker
+	UnreadCount int32 `json:"unread_count"`
+	// Last change to the count, used in unread count ETags
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type OocMessage struct {
+	ID      pgtype.UUID `json:"id"`
+	SceneID pgtype.UUID `json:"scene_id"`
+	UserID  pgtype.UUID `json:"user_id"`
+	// Top-level message this is a reply to, NULL for a new thread
+	ParentID  pgtype.UUID        `json:"parent_id"`
+	Body      string             `json:"body"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type Post struct {
 	ID          pgtype.UUID        `json:"id"`
 	SceneID     pgtype.UUID        `json:"scene_id"`
@@ -708,6 +795,30 @@ type Post struct {
 	Modifier    pgtype.Int4        `json:"modifier"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	// True when the GM posted as a player character owned by someone else
+	AuthoredByGm bool `json:"authored_by_gm"`
+	// Users mentioned in the OOC text who can witness the post
+	Mentions []pgtype.UUID `json:"mentions"`
+	// True for narrator posts generated by the server, e.g. roll resolution summaries
+	IsSystem bool `json:"is_system"`
+	// True when the GM has pinned the post to the top of its scene
+	IsPinned bool `json:"is_pinned"`
+	// When a GM removed the post, leaving a placeholder in the transcript; NULL if not removed
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+	// GM who removed the post
+	DeletedBy pgtype.UUID `json:"deleted_by"`
+	// When the post was published (created directly or submitted from a draft); NULL for drafts
+	SubmittedAt pgtype.Timestamptz `json:"submitted_at"`
+	// Whether the post was submitted to every character in its scene
+	WitnessedByScene bool `json:"witnessed_by_scene"`
+}
+
+type PostReaction struct {
+	PostID pgtype.UUID `json:"post_id"`
+	UserID pgtype.UUID `json:"user_id"`
+	// Emoji used as the reaction, stored as entered
+	Emoji     string             `json:"emoji"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
 type QuietHour struct {
@@ -722,6 +833,16 @@ type QuietHour struct {
 	UrgentBypass bool               `json:"urgent_bypass"`
 }
 
+type RemovedPostContent struct {
+	PostID    pgtype.UUID `json:"post_id"`
+	Blocks    []byte      `json:"blocks"`
+	OocText   pgtype.Text `json:"ooc_text"`
+	Intention pgtype.Text `json:"intention"`
+	Modifier  pgtype.Int4 `json:"modifier"`
+	// When the post was removed; content is purged once the restore window passes
+	RemovedAt pgtype.Timestamptz `json:"removed_at"`
+}
+
 type Roll struct {
 	ID                pgtype.UUID        `json:"id"`
 	PostID            pgtype.UUID        `json:"post_id"`
@@ -752,6 +873,12 @@ type Roll struct {
 	ManualResolutionReason pgtype.Text `json:"manual_resolution_reason"`
 	// When the roll was executed
 	RolledAt pgtype.Timestamptz `json:"rolled_at"`
+	// Success-counting mode: dice at or above this value count as successes
+	SuccessThreshold pgtype.Int4 `json:"success_threshold"`
+	// Computed successes in success-counting mode, including modifier
+	Successes pgtype.Int4 `json:"successes"`
+	// Player annotation shown to the table alongside the roll
+	Note pgtype.Text `json:"note"`
 }
 
 type Scene struct {
@@ -765,4 +892,41 @@ type Scene struct {
 	IsArchived     bool               `json:"is_archived"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	// Manual position of the scene within its campaign
+	SortOrder int32 `json:"sort_order"`
+	// Manual roster order of the scene's characters; unlisted characters sort after by name
+	CharacterOrder []pgtype.UUID `json:"character_order"`
+	// GM freeze: no new posts, edits, or compose locks while set
+	IsFrozen bool `json:"is_frozen"`
+	// Who witnesses non-hidden posts by default: all, author, or gm_only
+	DefaultWitnessMode string `json:"default_witness_mode"`
+	// GM-written recap of the scene so far, NULL when not set
+	Summary pgtype.Text `json:"summary"`
+	// Per-scene fog of war: inherit the campaign setting, always_visible, or fog
+	FogOverride string `json:"fog_override"`
+}
+
+type SceneFavorite struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	SceneID   pgtype.UUID        `json:"scene_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type SceneRead struct {
+	UserID         pgtype.UUID        `json:"user_id"`
+	SceneID        pgtype.UUID        `json:"scene_id"`
+	LastReadPostID pgtype.UUID        `json:"last_read_post_id"`
+	LastReadAt     pgtype.Timestamptz `json:"last_read_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type UserProfile struct {
+	UserID pgtype.UUID `json:"user_id"`
+	// Global display name, used where a campaign alias is not set
+	DisplayName pgtype.Text `json:"display_name"`
+	AvatarUrl   pgtype.Text `json:"avatar_url"`
+	// Bytes used by the user's own uploads (avatar), separate from campaign storage
+	StorageUsedBytes int64              `json:"storage_used_bytes"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 }
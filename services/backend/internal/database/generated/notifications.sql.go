@@ -11,6 +11,102 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const claimDueQueuedNotifications = `-- name: ClaimDueQueuedNotifications :many
+UPDATE notification_queue
+SET delivered_at = NOW()
+WHERE id IN (
+    SELECT nq.id FROM notification_queue nq
+    WHERE nq.deliver_after <= NOW()
+      AND nq.delivered_at IS NULL
+    ORDER BY nq.deliver_after ASC
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, user_id, notification_id, queued_at, deliver_after, delivered_at
+`
+
+// Marks due queue entries as delivered before sending so a crash mid-flush cannot double-send.
+func (q *Queries) ClaimDueQueuedNotifications(ctx context.Context, limit int32) ([]NotificationQueue, error) {
+	rows, err := q.db.Query(ctx, claimDueQueuedNotifications, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationQueue
+	for rows.Next() {
+		var i NotificationQueue
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.NotificationID,
+			&i.QueuedAt,
+			&i.DeliverAfter,
+			&i.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimNotificationEmailResend = `-- name: ClaimNotificationEmailResend :one
+UPDATE notifications
+SET email_sent_at = NOW()
+WHERE id = $1 AND user_id = $2
+  AND (email_sent_at IS NULL OR email_sent_at <= NOW() - INTERVAL '1 minute')
+RETURNING id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata, snooze_until
+`
+
+type ClaimNotificationEmailResendParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+// Claims a resend of the user's notification email, at most once a minute
+func (q *Queries) ClaimNotificationEmailResend(ctx context.Context, arg ClaimNotificationEmailResendParams) (Notification, error) {
+	row := q.db.QueryRow(ctx, claimNotificationEmailResend, arg.ID, arg.UserID)
+	var i Notification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Body,
+		&i.Type,
+		&i.CampaignID,
+		&i.SceneID,
+		&i.PostID,
+		&i.IsRead,
+		&i.ReadAt,
+		&i.EmailSentAt,
+		&i.CreatedAt,
+		&i.IsUrgent,
+		&i.Link,
+		&i.ExpiresAt,
+		&i.CharacterID,
+		&i.Metadata,
+		&i.SnoozeUntil,
+	)
+	return i, err
+}
+
+const clearExpiredSnoozes = `-- name: ClearExpiredSnoozes :execrows
+UPDATE notifications
+SET snooze_until = NULL
+WHERE snooze_until <= NOW()
+`
+
+func (q *Queries) ClearExpiredSnoozes(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, clearExpiredSnoozes)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createNotification = `-- name: CreateNotification :one
 
 INSERT INTO notifications (
@@ -30,7 +126,7 @@ INSERT INTO notifications (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
     COALESCE($12, NOW() + INTERVAL '90 days')
 )
-RETURNING id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata
+RETURNING id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata, snooze_until
 `
 
 type CreateNotificationParams struct {
@@ -85,10 +181,29 @@ func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotification
 		&i.ExpiresAt,
 		&i.CharacterID,
 		&i.Metadata,
+		&i.SnoozeUntil,
 	)
 	return i, err
 }
 
+const deleteCampaignNotifications = `-- name: DeleteCampaignNotifications :execrows
+DELETE FROM notifications
+WHERE user_id = $1 AND campaign_id = $2
+`
+
+type DeleteCampaignNotificationsParams struct {
+	UserID     pgtype.UUID `json:"user_id"`
+	CampaignID pgtype.UUID `json:"campaign_id"`
+}
+
+func (q *Queries) DeleteCampaignNotifications(ctx context.Context, arg DeleteCampaignNotificationsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCampaignNotifications, arg.UserID, arg.CampaignID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteExpiredNotifications = `-- name: DeleteExpiredNotifications :execrows
 DELETE FROM notifications
 WHERE expires_at < NOW()
@@ -127,6 +242,19 @@ func (q *Queries) DeleteQueuedNotification(ctx context.Context, id pgtype.UUID)
 	return err
 }
 
+const deleteReadNotifications = `-- name: DeleteReadNotifications :execrows
+DELETE FROM notifications
+WHERE user_id = $1 AND is_read = true
+`
+
+func (q *Queries) DeleteReadNotifications(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteReadNotifications, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deliverAllQueuedNotifications = `-- name: DeliverAllQueuedNotifications :execrows
 UPDATE notification_queue
 SET delivered_at = NOW()
@@ -143,7 +271,7 @@ func (q *Queries) DeliverAllQueuedNotifications(ctx context.Context, userID pgty
 }
 
 const findSimilarNotification = `-- name: FindSimilarNotification :one
-SELECT id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata FROM notifications
+SELECT id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata, snooze_until FROM notifications
 WHERE user_id = $1
   AND campaign_id = $2
   AND type = $3
@@ -184,6 +312,7 @@ func (q *Queries) FindSimilarNotification(ctx context.Context, arg FindSimilarNo
 		&i.ExpiresAt,
 		&i.CharacterID,
 		&i.Metadata,
+		&i.SnoozeUntil,
 	)
 	return i, err
 }
@@ -242,7 +371,7 @@ func (q *Queries) GetLastDigestSent(ctx context.Context, arg GetLastDigestSentPa
 }
 
 const getNotification = `-- name: GetNotification :one
-SELECT id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata FROM notifications
+SELECT id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata, snooze_until FROM notifications
 WHERE id = $1
 `
 
@@ -267,13 +396,14 @@ func (q *Queries) GetNotification(ctx context.Context, id pgtype.UUID) (Notifica
 		&i.ExpiresAt,
 		&i.CharacterID,
 		&i.Metadata,
+		&i.SnoozeUntil,
 	)
 	return i, err
 }
 
 const getNotificationPreferences = `-- name: GetNotificationPreferences :one
 
-SELECT id, user_id, email_enabled, email_frequency, in_app_enabled, created_at, updated_at FROM notification_preferences
+SELECT id, user_id, email_enabled, email_frequency, in_app_enabled, created_at, updated_at, type_preferences FROM notification_preferences
 WHERE user_id = $1
 `
 
@@ -291,25 +421,37 @@ func (q *Queries) GetNotificationPreferences(ctx context.Context, userID pgtype.
 		&i.InAppEnabled,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.TypePreferences,
 	)
 	return i, err
 }
 
 const getNotificationsByUser = `-- name: GetNotificationsByUser :many
-SELECT id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata FROM notifications
+SELECT id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata, snooze_until FROM notifications
 WHERE user_id = $1
+  AND ($2::text[] IS NULL OR type = ANY($2::text[]))
+  AND ($3::boolean IS NULL OR is_read = $3::boolean)
 ORDER BY created_at DESC
-LIMIT $2 OFFSET $3
+LIMIT $4 OFFSET $5
 `
 
 type GetNotificationsByUserParams struct {
-	UserID pgtype.UUID `json:"user_id"`
-	Limit  int32       `json:"limit"`
-	Offset int32       `json:"offset"`
+	UserID    pgtype.UUID `json:"user_id"`
+	Types     []string    `json:"types"`
+	IsRead    pgtype.Bool `json:"is_read"`
+	RowLimit  int32       `json:"row_limit"`
+	RowOffset int32       `json:"row_offset"`
 }
 
+// The types and is_read filters match every notification when NULL
 func (q *Queries) GetNotificationsByUser(ctx context.Context, arg GetNotificationsByUserParams) ([]Notification, error) {
-	rows, err := q.db.Query(ctx, getNotificationsByUser, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, getNotificationsByUser,
+		arg.UserID,
+		arg.Types,
+		arg.IsRead,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -335,6 +477,7 @@ func (q *Queries) GetNotificationsByUser(ctx context.Context, arg GetNotificatio
 			&i.ExpiresAt,
 			&i.CharacterID,
 			&i.Metadata,
+			&i.SnoozeUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -347,7 +490,7 @@ func (q *Queries) GetNotificationsByUser(ctx context.Context, arg GetNotificatio
 }
 
 const getNotificationsSince = `-- name: GetNotificationsSince :many
-SELECT id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata FROM notifications
+SELECT id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata, snooze_until FROM notifications
 WHERE user_id = $1
   AND is_read = false
   AND created_at > $2
@@ -386,6 +529,7 @@ func (q *Queries) GetNotificationsSince(ctx context.Context, arg GetNotification
 			&i.ExpiresAt,
 			&i.CharacterID,
 			&i.Metadata,
+			&i.SnoozeUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -437,7 +581,7 @@ func (q *Queries) GetPCUsersInCampaign(ctx context.Context, campaignID pgtype.UU
 }
 
 const getQueuedNotificationsReadyForDelivery = `-- name: GetQueuedNotificationsReadyForDelivery :many
-SELECT nq.id, nq.user_id, nq.notification_id, nq.queued_at, nq.deliver_after, nq.delivered_at, n.id, n.user_id, n.title, n.body, n.type, n.campaign_id, n.scene_id, n.post_id, n.is_read, n.read_at, n.email_sent_at, n.created_at, n.is_urgent, n.link, n.expires_at, n.character_id, n.metadata FROM notification_queue nq
+SELECT nq.id, nq.user_id, nq.notification_id, nq.queued_at, nq.deliver_after, nq.delivered_at, n.id, n.user_id, n.title, n.body, n.type, n.campaign_id, n.scene_id, n.post_id, n.is_read, n.read_at, n.email_sent_at, n.created_at, n.is_urgent, n.link, n.expires_at, n.character_id, n.metadata, n.snooze_until FROM notification_queue nq
 JOIN notifications n ON n.id = nq.notification_id
 WHERE nq.deliver_after <= NOW()
   AND nq.delivered_at IS NULL
@@ -469,6 +613,7 @@ type GetQueuedNotificationsReadyForDeliveryRow struct {
 	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
 	CharacterID    pgtype.UUID        `json:"character_id"`
 	Metadata       []byte             `json:"metadata"`
+	SnoozeUntil    pgtype.Timestamptz `json:"snooze_until"`
 }
 
 func (q *Queries) GetQueuedNotificationsReadyForDelivery(ctx context.Context) ([]GetQueuedNotificationsReadyForDeliveryRow, error) {
@@ -504,6 +649,7 @@ func (q *Queries) GetQueuedNotificationsReadyForDelivery(ctx context.Context) ([
 			&i.ExpiresAt,
 			&i.CharacterID,
 			&i.Metadata,
+			&i.SnoozeUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -545,6 +691,7 @@ const getUnreadNotificationCount = `-- name: GetUnreadNotificationCount :one
 SELECT COUNT(*) FROM notifications
 WHERE user_id = $1
   AND is_read = false
+  AND (snooze_until IS NULL OR snooze_until <= NOW())
 `
 
 func (q *Queries) GetUnreadNotificationCount(ctx context.Context, userID pgtype.UUID) (int64, error) {
@@ -559,6 +706,7 @@ SELECT COUNT(*) FROM notifications
 WHERE user_id = $1
   AND campaign_id = $2
   AND is_read = false
+  AND (snooze_until IS NULL OR snooze_until <= NOW())
 `
 
 type GetUnreadNotificationCountByCampaignParams struct {
@@ -573,10 +721,113 @@ func (q *Queries) GetUnreadNotificationCountByCampaign(ctx context.Context, arg
 	return count, err
 }
 
+const getUnreadNotificationCounter = `-- name: GetUnreadNotificationCounter :one
+SELECT
+    COALESCE(SUM(unread_count), 0)::bigint AS unread_count,
+    MAX(updated_at)::timestamptz AS updated_at
+FROM notification_unread_counts
+WHERE user_id = $1
+`
+
+type GetUnreadNotificationCounterRow struct {
+	UnreadCount int64              `json:"unread_count"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Reads the user's maintained unread counters; GetUnreadNotificationCount is the source of truth
+func (q *Queries) GetUnreadNotificationCounter(ctx context.Context, userID pgtype.UUID) (GetUnreadNotificationCounterRow, error) {
+	row := q.db.QueryRow(ctx, getUnreadNotificationCounter, userID)
+	var i GetUnreadNotificationCounterRow
+	err := row.Scan(&i.UnreadCount, &i.UpdatedAt)
+	return i, err
+}
+
+const getUnreadNotificationCounterByCampaign = `-- name: GetUnreadNotificationCounterByCampaign :one
+SELECT
+    COALESCE(SUM(unread_count), 0)::bigint AS unread_count,
+    MAX(updated_at)::timestamptz AS updated_at
+FROM notification_unread_counts
+WHERE user_id = $1
+  AND campaign_id = $2
+`
+
+type GetUnreadNotificationCounterByCampaignParams struct {
+	UserID     pgtype.UUID `json:"user_id"`
+	CampaignID pgtype.UUID `json:"campaign_id"`
+}
+
+type GetUnreadNotificationCounterByCampaignRow struct {
+	UnreadCount int64              `json:"unread_count"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) GetUnreadNotificationCounterByCampaign(ctx context.Context, arg GetUnreadNotificationCounterByCampaignParams) (GetUnreadNotificationCounterByCampaignRow, error) {
+	row := q.db.QueryRow(ctx, getUnreadNotificationCounterByCampaign, arg.UserID, arg.CampaignID)
+	var i GetUnreadNotificationCounterByCampaignRow
+	err := row.Scan(&i.UnreadCount, &i.UpdatedAt)
+	return i, err
+}
+
+const getUnreadNotificationsByType = `-- name: GetUnreadNotificationsByType :many
+SELECT id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata, snooze_until FROM notifications
+WHERE user_id = $1
+  AND type = $2
+  AND is_read = false
+  AND (snooze_until IS NULL OR snooze_until <= NOW())
+ORDER BY created_at DESC
+LIMIT $3
+`
+
+type GetUnreadNotificationsByTypeParams struct {
+	UserID pgtype.UUID `json:"user_id"`
+	Type   string      `json:"type"`
+	Limit  int32       `json:"limit"`
+}
+
+func (q *Queries) GetUnreadNotificationsByType(ctx context.Context, arg GetUnreadNotificationsByTypeParams) ([]Notification, error) {
+	rows, err := q.db.Query(ctx, getUnreadNotificationsByType, arg.UserID, arg.Type, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Notification
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Body,
+			&i.Type,
+			&i.CampaignID,
+			&i.SceneID,
+			&i.PostID,
+			&i.IsRead,
+			&i.ReadAt,
+			&i.EmailSentAt,
+			&i.CreatedAt,
+			&i.IsUrgent,
+			&i.Link,
+			&i.ExpiresAt,
+			&i.CharacterID,
+			&i.Metadata,
+			&i.SnoozeUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUnreadNotificationsByUser = `-- name: GetUnreadNotificationsByUser :many
-SELECT id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata FROM notifications
+SELECT id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata, snooze_until FROM notifications
 WHERE user_id = $1
   AND is_read = false
+  AND (snooze_until IS NULL OR snooze_until <= NOW())
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -614,6 +865,7 @@ func (q *Queries) GetUnreadNotificationsByUser(ctx context.Context, arg GetUnrea
 			&i.ExpiresAt,
 			&i.CharacterID,
 			&i.Metadata,
+			&i.SnoozeUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -625,6 +877,73 @@ func (q *Queries) GetUnreadNotificationsByUser(ctx context.Context, arg GetUnrea
 	return items, nil
 }
 
+const getUserNotification = `-- name: GetUserNotification :one
+SELECT
+    n.id, n.user_id, n.title, n.body, n.type, n.campaign_id, n.scene_id, n.post_id, n.is_read, n.read_at, n.email_sent_at, n.created_at, n.is_urgent, n.link, n.expires_at, n.character_id, n.metadata, n.snooze_until,
+    c.title AS campaign_title,
+    s.title AS scene_title
+FROM notifications n
+LEFT JOIN campaigns c ON n.campaign_id = c.id
+LEFT JOIN scenes s ON n.scene_id = s.id
+WHERE n.id = $1 AND n.user_id = $2
+`
+
+type GetUserNotificationParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type GetUserNotificationRow struct {
+	ID            pgtype.UUID        `json:"id"`
+	UserID        pgtype.UUID        `json:"user_id"`
+	Title         string             `json:"title"`
+	Body          string             `json:"body"`
+	Type          string             `json:"type"`
+	CampaignID    pgtype.UUID        `json:"campaign_id"`
+	SceneID       pgtype.UUID        `json:"scene_id"`
+	PostID        pgtype.UUID        `json:"post_id"`
+	IsRead        bool               `json:"is_read"`
+	ReadAt        pgtype.Timestamptz `json:"read_at"`
+	EmailSentAt   pgtype.Timestamptz `json:"email_sent_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	IsUrgent      bool               `json:"is_urgent"`
+	Link          pgtype.Text        `json:"link"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+	CharacterID   pgtype.UUID        `json:"character_id"`
+	Metadata      []byte             `json:"metadata"`
+	SnoozeUntil   pgtype.Timestamptz `json:"snooze_until"`
+	CampaignTitle pgtype.Text        `json:"campaign_title"`
+	SceneTitle    pgtype.Text        `json:"scene_title"`
+}
+
+func (q *Queries) GetUserNotification(ctx context.Context, arg GetUserNotificationParams) (GetUserNotificationRow, error) {
+	row := q.db.QueryRow(ctx, getUserNotification, arg.ID, arg.UserID)
+	var i GetUserNotificationRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Body,
+		&i.Type,
+		&i.CampaignID,
+		&i.SceneID,
+		&i.PostID,
+		&i.IsRead,
+		&i.ReadAt,
+		&i.EmailSentAt,
+		&i.CreatedAt,
+		&i.IsUrgent,
+		&i.Link,
+		&i.ExpiresAt,
+		&i.CharacterID,
+		&i.Metadata,
+		&i.SnoozeUntil,
+		&i.CampaignTitle,
+		&i.SceneTitle,
+	)
+	return i, err
+}
+
 const getUserQueuedCount = `-- name: GetUserQueuedCount :one
 SELECT COUNT(*) FROM notification_queue
 WHERE user_id = $1
@@ -705,7 +1024,7 @@ func (q *Queries) GetUsersInScene(ctx context.Context, id pgtype.UUID) ([]GetUse
 }
 
 const getUsersWithDigestPreference = `-- name: GetUsersWithDigestPreference :many
-SELECT id, user_id, email_enabled, email_frequency, in_app_enabled, created_at, updated_at FROM notification_preferences
+SELECT id, user_id, email_enabled, email_frequency, in_app_enabled, created_at, updated_at, type_preferences FROM notification_preferences
 WHERE email_frequency = $1
   AND email_enabled = true
 `
@@ -727,6 +1046,7 @@ func (q *Queries) GetUsersWithDigestPreference(ctx context.Context, emailFrequen
 			&i.InAppEnabled,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.TypePreferences,
 		); err != nil {
 			return nil, err
 		}
@@ -781,7 +1101,7 @@ const markNotificationAsRead = `-- name: MarkNotificationAsRead :one
 UPDATE notifications
 SET is_read = true, read_at = NOW()
 WHERE id = $1 AND user_id = $2
-RETURNING id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata
+RETURNING id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata, snooze_until
 `
 
 type MarkNotificationAsReadParams struct {
@@ -810,6 +1130,7 @@ func (q *Queries) MarkNotificationAsRead(ctx context.Context, arg MarkNotificati
 		&i.ExpiresAt,
 		&i.CharacterID,
 		&i.Metadata,
+		&i.SnoozeUntil,
 	)
 	return i, err
 }
@@ -871,6 +1192,50 @@ func (q *Queries) QueueNotification(ctx context.Context, arg QueueNotificationPa
 	return i, err
 }
 
+const reconcileNotificationUnreadCounts = `-- name: ReconcileNotificationUnreadCounts :one
+WITH actual AS (
+    SELECT user_id, campaign_id, COUNT(*)::int AS unread_count
+    FROM notifications
+    WHERE is_read = false
+      AND snooze_until IS NULL
+    GROUP BY user_id, campaign_id
+),
+fixed AS (
+    INSERT INTO notification_unread_counts (user_id, campaign_id, unread_count)
+    SELECT user_id, campaign_id, unread_count FROM actual
+    ON CONFLICT (user_id, campaign_id) DO UPDATE SET
+        unread_count = EXCLUDED.unread_count,
+        updated_at = clock_timestamp()
+    WHERE notification_unread_counts.unread_count <> EXCLUDED.unread_count
+      AND notification_unread_counts.updated_at < statement_timestamp()
+    RETURNING 1
+),
+stale AS (
+    UPDATE notification_unread_counts nuc
+    SET
+        unread_count = 0,
+        updated_at = clock_timestamp()
+    WHERE nuc.unread_count <> 0
+      AND nuc.updated_at < statement_timestamp()
+      AND NOT EXISTS (
+        SELECT 1 FROM actual a
+        WHERE a.user_id = nuc.user_id
+          AND a.campaign_id IS NOT DISTINCT FROM nuc.campaign_id
+      )
+    RETURNING 1
+)
+SELECT ((SELECT COUNT(*) FROM fixed) + (SELECT COUNT(*) FROM stale))::bigint AS fixed_count
+`
+
+// Rewrites unread counters that have drifted from the notifications table and returns how many
+// were fixed. Counters changed while this runs are left for the next pass.
+func (q *Queries) ReconcileNotificationUnreadCounts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, reconcileNotificationUnreadCounts)
+	var fixed_count int64
+	err := row.Scan(&fixed_count)
+	return fixed_count, err
+}
+
 const recordEmailDigest = `-- name: RecordEmailDigest :one
 
 INSERT INTO email_digests (
@@ -913,6 +1278,64 @@ func (q *Queries) RecordEmailDigest(ctx context.Context, arg RecordEmailDigestPa
 	return i, err
 }
 
+const requeueNotification = `-- name: RequeueNotification :exec
+UPDATE notification_queue
+SET
+    deliver_after = $2,
+    delivered_at = NULL
+WHERE id = $1
+`
+
+type RequeueNotificationParams struct {
+	ID           pgtype.UUID        `json:"id"`
+	DeliverAfter pgtype.Timestamptz `json:"deliver_after"`
+}
+
+// Releases a claimed queue entry to be delivered again later.
+func (q *Queries) RequeueNotification(ctx context.Context, arg RequeueNotificationParams) error {
+	_, err := q.db.Exec(ctx, requeueNotification, arg.ID, arg.DeliverAfter)
+	return err
+}
+
+const snoozeNotification = `-- name: SnoozeNotification :one
+UPDATE notifications
+SET snooze_until = $3
+WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, title, body, type, campaign_id, scene_id, post_id, is_read, read_at, email_sent_at, created_at, is_urgent, link, expires_at, character_id, metadata, snooze_until
+`
+
+type SnoozeNotificationParams struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	SnoozeUntil pgtype.Timestamptz `json:"snooze_until"`
+}
+
+func (q *Queries) SnoozeNotification(ctx context.Context, arg SnoozeNotificationParams) (Notification, error) {
+	row := q.db.QueryRow(ctx, snoozeNotification, arg.ID, arg.UserID, arg.SnoozeUntil)
+	var i Notification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Body,
+		&i.Type,
+		&i.CampaignID,
+		&i.SceneID,
+		&i.PostID,
+		&i.IsRead,
+		&i.ReadAt,
+		&i.EmailSentAt,
+		&i.CreatedAt,
+		&i.IsUrgent,
+		&i.Link,
+		&i.ExpiresAt,
+		&i.CharacterID,
+		&i.Metadata,
+		&i.SnoozeUntil,
+	)
+	return i, err
+}
+
 const updateQueuedNotificationDeliveryTime = `-- name: UpdateQueuedNotificationDeliveryTime :exec
 UPDATE notification_queue
 SET deliver_after = $2
@@ -934,16 +1357,18 @@ INSERT INTO notification_preferences (
     user_id,
     email_enabled,
     email_frequency,
-    in_app_enabled
+    in_app_enabled,
+    type_preferences
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, COALESCE($5::jsonb, '{}'::jsonb)
 )
 ON CONFLICT (user_id) DO UPDATE SET
     email_enabled = EXCLUDED.email_enabled,
     email_frequency = EXCLUDED.email_frequency,
     in_app_enabled = EXCLUDED.in_app_enabled,
+    type_preferences = COALESCE($5::jsonb, notification_preferences.type_preferences),
     updated_at = NOW()
-RETURNING id, user_id, email_enabled, email_frequency, in_app_enabled, created_at, updated_at
+RETURNING id, user_id, email_enabled, email_frequency, in_app_enabled, created_at, updated_at, type_preferences
 `
 
 type UpsertNotificationPreferencesParams struct {
@@ -951,6 +1376,7 @@ type UpsertNotificationPreferencesParams struct {
 	EmailEnabled   bool                  `json:"email_enabled"`
 	EmailFrequency NotificationFrequency `json:"email_frequency"`
 	InAppEnabled   bool                  `json:"in_app_enabled"`
+	Column5        []byte                `json:"column_5"`
 }
 
 func (q *Queries) UpsertNotificationPreferences(ctx context.Context, arg UpsertNotificationPreferencesParams) (NotificationPreference, error) {
@@ -959,6 +1385,7 @@ func (q *Queries) UpsertNotificationPreferences(ctx context.Context, arg UpsertN
 		arg.EmailEnabled,
 		arg.EmailFrequency,
 		arg.InAppEnabled,
+		arg.Column5,
 	)
 	var i NotificationPreference
 	err := row.Scan(
@@ -969,6 +1396,7 @@ func (q *Queries) UpsertNotificationPreferences(ctx context.Context, arg UpsertN
 		&i.InAppEnabled,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.TypePreferences,
 	)
 	return i, err
 }
@@ -11,6 +11,55 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const attributePost = `-- name: AttributePost :one
+UPDATE posts
+SET
+    character_id = $2,
+    witnesses = $3,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene
+`
+
+type AttributePostParams struct {
+	ID          pgtype.UUID   `json:"id"`
+	CharacterID pgtype.UUID   `json:"character_id"`
+	Witnesses   []pgtype.UUID `json:"witnesses"`
+}
+
+// GM-only: Give a narrator post a character, with witnesses that include it
+func (q *Queries) AttributePost(ctx context.Context, arg AttributePostParams) (Post, error) {
+	row := q.db.QueryRow(ctx, attributePost, arg.ID, arg.CharacterID, arg.Witnesses)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.SceneID,
+		&i.CharacterID,
+		&i.UserID,
+		&i.Blocks,
+		&i.OocText,
+		&i.Witnesses,
+		&i.IsHidden,
+		&i.IsDraft,
+		&i.IsLocked,
+		&i.LockedAt,
+		&i.EditedByGm,
+		&i.Intention,
+		&i.Modifier,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
+	)
+	return i, err
+}
+
 const countScenePosts = `-- name: CountScenePosts :one
 SELECT COUNT(*) FROM posts
 WHERE scene_id = $1 AND is_draft = false
@@ -34,24 +83,32 @@ INSERT INTO posts (
     is_hidden,
     is_draft,
     intention,
-    modifier
+    modifier,
+    authored_by_gm,
+    is_system,
+    witnessed_by_scene,
+    submitted_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13,
+    CASE WHEN $8 THEN NULL ELSE NOW() END
 )
-RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at
+RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene
 `
 
 type CreatePostParams struct {
-	SceneID     pgtype.UUID   `json:"scene_id"`
-	CharacterID pgtype.UUID   `json:"character_id"`
-	UserID      pgtype.UUID   `json:"user_id"`
-	Blocks      []byte        `json:"blocks"`
-	OocText     pgtype.Text   `json:"ooc_text"`
-	Witnesses   []pgtype.UUID `json:"witnesses"`
-	IsHidden    bool          `json:"is_hidden"`
-	IsDraft     bool          `json:"is_draft"`
-	Intention   pgtype.Text   `json:"intention"`
-	Modifier    pgtype.Int4   `json:"modifier"`
+	SceneID          pgtype.UUID   `json:"scene_id"`
+	CharacterID      pgtype.UUID   `json:"character_id"`
+	UserID           pgtype.UUID   `json:"user_id"`
+	Blocks           []byte        `json:"blocks"`
+	OocText          pgtype.Text   `json:"ooc_text"`
+	Witnesses        []pgtype.UUID `json:"witnesses"`
+	IsHidden         bool          `json:"is_hidden"`
+	IsDraft          bool          `json:"is_draft"`
+	Intention        pgtype.Text   `json:"intention"`
+	Modifier         pgtype.Int4   `json:"modifier"`
+	AuthoredByGm     bool          `json:"authored_by_gm"`
+	IsSystem         bool          `json:"is_system"`
+	WitnessedByScene bool          `json:"witnessed_by_scene"`
 }
 
 func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, error) {
@@ -66,6 +123,9 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		arg.IsDraft,
 		arg.Intention,
 		arg.Modifier,
+		arg.AuthoredByGm,
+		arg.IsSystem,
+		arg.WitnessedByScene,
 	)
 	var i Post
 	err := row.Scan(
@@ -85,6 +145,14 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		&i.Modifier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
 	)
 	return i, err
 }
@@ -98,23 +166,34 @@ func (q *Queries) DeletePost(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const deleteRemovedPostContent = `-- name: DeleteRemovedPostContent :exec
+DELETE FROM removed_post_contents WHERE post_id = $1
+`
+
+func (q *Queries) DeleteRemovedPostContent(ctx context.Context, postID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteRemovedPostContent, postID)
+	return err
+}
+
 const editPostWitnesses = `-- name: EditPostWitnesses :one
 UPDATE posts
 SET
     witnesses = $2,
+    witnessed_by_scene = $3,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at
+RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene
 `
 
 type EditPostWitnessesParams struct {
-	ID        pgtype.UUID   `json:"id"`
-	Witnesses []pgtype.UUID `json:"witnesses"`
+	ID               pgtype.UUID   `json:"id"`
+	Witnesses        []pgtype.UUID `json:"witnesses"`
+	WitnessedByScene bool          `json:"witnessed_by_scene"`
 }
 
 // GM-only: Update witnesses on a post without changing hidden status
 func (q *Queries) EditPostWitnesses(ctx context.Context, arg EditPostWitnessesParams) (Post, error) {
-	row := q.db.QueryRow(ctx, editPostWitnesses, arg.ID, arg.Witnesses)
+	row := q.db.QueryRow(ctx, editPostWitnesses, arg.ID, arg.Witnesses, arg.WitnessedByScene)
 	var i Post
 	err := row.Scan(
 		&i.ID,
@@ -133,6 +212,14 @@ func (q *Queries) EditPostWitnesses(ctx context.Context, arg EditPostWitnessesPa
 		&i.Modifier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
 	)
 	return i, err
 }
@@ -155,7 +242,7 @@ func (q *Queries) GetCharacterPostCountInScene(ctx context.Context, arg GetChara
 }
 
 const getLastScenePost = `-- name: GetLastScenePost :one
-SELECT id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at FROM posts
+SELECT id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene FROM posts
 WHERE scene_id = $1 AND is_draft = false
 ORDER BY created_at DESC
 LIMIT 1
@@ -181,12 +268,101 @@ func (q *Queries) GetLastScenePost(ctx context.Context, sceneID pgtype.UUID) (Po
 		&i.Modifier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
+	)
+	return i, err
+}
+
+const getLastVisibleScenePost = `-- name: GetLastVisibleScenePost :one
+SELECT p.id, p.scene_id, p.character_id, p.user_id, p.blocks, p.ooc_text, p.witnesses, p.is_hidden, p.is_draft, p.is_locked, p.locked_at, p.edited_by_gm, p.intention, p.modifier, p.created_at, p.updated_at, p.authored_by_gm, p.mentions, p.is_system, p.is_pinned, p.deleted_at, p.deleted_by, p.submitted_at, p.witnessed_by_scene FROM posts p
+INNER JOIN scenes s ON s.id = p.scene_id
+WHERE p.scene_id = $1
+  AND p.is_draft = false
+  AND (
+    $2::boolean
+    OR EXISTS (
+      SELECT 1 FROM characters c
+      INNER JOIN character_assignments ca ON ca.character_id = c.id
+      WHERE ca.user_id = $3
+        AND c.id = ANY(s.character_ids)
+        AND c.is_archived = false
+        AND c.id = ANY(p.witnesses)
+    )
+  )
+ORDER BY COALESCE(p.submitted_at, p.created_at) DESC
+LIMIT 1
+`
+
+type GetLastVisibleScenePostParams struct {
+	SceneID pgtype.UUID `json:"scene_id"`
+	IsGm    bool        `json:"is_gm"`
+	UserID  pgtype.UUID `json:"user_id"`
+}
+
+// Returns the most recently published post the user can see: any post for GMs,
+// otherwise one witnessed by a character the user plays in the scene
+func (q *Queries) GetLastVisibleScenePost(ctx context.Context, arg GetLastVisibleScenePostParams) (Post, error) {
+	row := q.db.QueryRow(ctx, getLastVisibleScenePost, arg.SceneID, arg.IsGm, arg.UserID)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.SceneID,
+		&i.CharacterID,
+		&i.UserID,
+		&i.Blocks,
+		&i.OocText,
+		&i.Witnesses,
+		&i.IsHidden,
+		&i.IsDraft,
+		&i.IsLocked,
+		&i.LockedAt,
+		&i.EditedByGm,
+		&i.Intention,
+		&i.Modifier,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
 	)
 	return i, err
 }
 
+const getLatestCharacterPostSince = `-- name: GetLatestCharacterPostSince :one
+SELECT id FROM posts
+WHERE scene_id = $1 AND character_id = $2 AND is_draft = false AND created_at > $3
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+type GetLatestCharacterPostSinceParams struct {
+	SceneID     pgtype.UUID        `json:"scene_id"`
+	CharacterID pgtype.UUID        `json:"character_id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+// Returns the character's newest submitted post in the scene created after the given time
+func (q *Queries) GetLatestCharacterPostSince(ctx context.Context, arg GetLatestCharacterPostSinceParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getLatestCharacterPostSince, arg.SceneID, arg.CharacterID, arg.CreatedAt)
+	var id pgtype.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getPost = `-- name: GetPost :one
-SELECT id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at FROM posts WHERE id = $1
+SELECT id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene FROM posts WHERE id = $1
 `
 
 func (q *Queries) GetPost(ctx context.Context, id pgtype.UUID) (Post, error) {
@@ -209,6 +385,14 @@ func (q *Queries) GetPost(ctx context.Context, id pgtype.UUID) (Post, error) {
 		&i.Modifier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
 	)
 	return i, err
 }
@@ -236,7 +420,7 @@ func (q *Queries) GetPostCountForCharacterInScene(ctx context.Context, arg GetPo
 
 const getPostWithCharacter = `-- name: GetPostWithCharacter :one
 SELECT
-    p.id, p.scene_id, p.character_id, p.user_id, p.blocks, p.ooc_text, p.witnesses, p.is_hidden, p.is_draft, p.is_locked, p.locked_at, p.edited_by_gm, p.intention, p.modifier, p.created_at, p.updated_at,
+    p.id, p.scene_id, p.character_id, p.user_id, p.blocks, p.ooc_text, p.witnesses, p.is_hidden, p.is_draft, p.is_locked, p.locked_at, p.edited_by_gm, p.intention, p.modifier, p.created_at, p.updated_at, p.authored_by_gm, p.mentions, p.is_system, p.is_pinned, p.deleted_at, p.deleted_by, p.submitted_at, p.witnessed_by_scene,
     c.display_name AS character_name,
     c.avatar_url AS character_avatar,
     c.character_type
@@ -246,25 +430,33 @@ WHERE p.id = $1
 `
 
 type GetPostWithCharacterRow struct {
-	ID              pgtype.UUID        `json:"id"`
-	SceneID         pgtype.UUID        `json:"scene_id"`
-	CharacterID     pgtype.UUID        `json:"character_id"`
-	UserID          pgtype.UUID        `json:"user_id"`
-	Blocks          []byte             `json:"blocks"`
-	OocText         pgtype.Text        `json:"ooc_text"`
-	Witnesses       []pgtype.UUID      `json:"witnesses"`
-	IsHidden        bool               `json:"is_hidden"`
-	IsDraft         bool               `json:"is_draft"`
-	IsLocked        bool               `json:"is_locked"`
-	LockedAt        pgtype.Timestamptz `json:"locked_at"`
-	EditedByGm      bool               `json:"edited_by_gm"`
-	Intention       pgtype.Text        `json:"intention"`
-	Modifier        pgtype.Int4        `json:"modifier"`
-	CreatedAt       pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
-	CharacterName   pgtype.Text        `json:"character_name"`
-	CharacterAvatar pgtype.Text        `json:"character_avatar"`
-	CharacterType   NullCharacterType  `json:"character_type"`
+	ID               pgtype.UUID        `json:"id"`
+	SceneID          pgtype.UUID        `json:"scene_id"`
+	CharacterID      pgtype.UUID        `json:"character_id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	Blocks           []byte             `json:"blocks"`
+	OocText          pgtype.Text        `json:"ooc_text"`
+	Witnesses        []pgtype.UUID      `json:"witnesses"`
+	IsHidden         bool               `json:"is_hidden"`
+	IsDraft          bool               `json:"is_draft"`
+	IsLocked         bool               `json:"is_locked"`
+	LockedAt         pgtype.Timestamptz `json:"locked_at"`
+	EditedByGm       bool               `json:"edited_by_gm"`
+	Intention        pgtype.Text        `json:"intention"`
+	Modifier         pgtype.Int4        `json:"modifier"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	AuthoredByGm     bool               `json:"authored_by_gm"`
+	Mentions         []pgtype.UUID      `json:"mentions"`
+	IsSystem         bool               `json:"is_system"`
+	IsPinned         bool               `json:"is_pinned"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	DeletedBy        pgtype.UUID        `json:"deleted_by"`
+	SubmittedAt      pgtype.Timestamptz `json:"submitted_at"`
+	WitnessedByScene bool               `json:"witnessed_by_scene"`
+	CharacterName    pgtype.Text        `json:"character_name"`
+	CharacterAvatar  pgtype.Text        `json:"character_avatar"`
+	CharacterType    NullCharacterType  `json:"character_type"`
 }
 
 func (q *Queries) GetPostWithCharacter(ctx context.Context, id pgtype.UUID) (GetPostWithCharacterRow, error) {
@@ -287,6 +479,14 @@ func (q *Queries) GetPostWithCharacter(ctx context.Context, id pgtype.UUID) (Get
 		&i.Modifier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
 		&i.CharacterName,
 		&i.CharacterAvatar,
 		&i.CharacterType,
@@ -295,7 +495,7 @@ func (q *Queries) GetPostWithCharacter(ctx context.Context, id pgtype.UUID) (Get
 }
 
 const getPreviousPost = `-- name: GetPreviousPost :one
-SELECT id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at FROM posts
+SELECT id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene FROM posts
 WHERE scene_id = $1
     AND is_draft = false
     AND created_at < $2
@@ -328,6 +528,14 @@ func (q *Queries) GetPreviousPost(ctx context.Context, arg GetPreviousPostParams
 		&i.Modifier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
 	)
 	return i, err
 }
@@ -345,7 +553,7 @@ func (q *Queries) GetScenePostCount(ctx context.Context, sceneID pgtype.UUID) (i
 }
 
 const getUserDraftPost = `-- name: GetUserDraftPost :one
-SELECT id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at FROM posts
+SELECT id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene FROM posts
 WHERE scene_id = $1 AND character_id = $2 AND user_id = $3 AND is_draft = true
 LIMIT 1
 `
@@ -376,13 +584,21 @@ func (q *Queries) GetUserDraftPost(ctx context.Context, arg GetUserDraftPostPara
 		&i.Modifier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
 	)
 	return i, err
 }
 
 const listHiddenPostsInScene = `-- name: ListHiddenPostsInScene :many
 SELECT
-    p.id, p.scene_id, p.character_id, p.user_id, p.blocks, p.ooc_text, p.witnesses, p.is_hidden, p.is_draft, p.is_locked, p.locked_at, p.edited_by_gm, p.intention, p.modifier, p.created_at, p.updated_at,
+    p.id, p.scene_id, p.character_id, p.user_id, p.blocks, p.ooc_text, p.witnesses, p.is_hidden, p.is_draft, p.is_locked, p.locked_at, p.edited_by_gm, p.intention, p.modifier, p.created_at, p.updated_at, p.authored_by_gm, p.mentions, p.is_system, p.is_pinned, p.deleted_at, p.deleted_by, p.submitted_at, p.witnessed_by_scene,
     c.display_name AS character_name,
     c.avatar_url AS character_avatar,
     c.character_type
@@ -393,25 +609,33 @@ ORDER BY p.created_at ASC
 `
 
 type ListHiddenPostsInSceneRow struct {
-	ID              pgtype.UUID        `json:"id"`
-	SceneID         pgtype.UUID        `json:"scene_id"`
-	CharacterID     pgtype.UUID        `json:"character_id"`
-	UserID          pgtype.UUID        `json:"user_id"`
-	Blocks          []byte             `json:"blocks"`
-	OocText         pgtype.Text        `json:"ooc_text"`
-	Witnesses       []pgtype.UUID      `json:"witnesses"`
-	IsHidden        bool               `json:"is_hidden"`
-	IsDraft         bool               `json:"is_draft"`
-	IsLocked        bool               `json:"is_locked"`
-	LockedAt        pgtype.Timestamptz `json:"locked_at"`
-	EditedByGm      bool               `json:"edited_by_gm"`
-	Intention       pgtype.Text        `json:"intention"`
-	Modifier        pgtype.Int4        `json:"modifier"`
-	CreatedAt       pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
-	CharacterName   pgtype.Text        `json:"character_name"`
-	CharacterAvatar pgtype.Text        `json:"character_avatar"`
-	CharacterType   NullCharacterType  `json:"character_type"`
+	ID               pgtype.UUID        `json:"id"`
+	SceneID          pgtype.UUID        `json:"scene_id"`
+	CharacterID      pgtype.UUID        `json:"character_id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	Blocks           []byte             `json:"blocks"`
+	OocText          pgtype.Text        `json:"ooc_text"`
+	Witnesses        []pgtype.UUID      `json:"witnesses"`
+	IsHidden         bool               `json:"is_hidden"`
+	IsDraft          bool               `json:"is_draft"`
+	IsLocked         bool               `json:"is_locked"`
+	LockedAt         pgtype.Timestamptz `json:"locked_at"`
+	EditedByGm       bool               `json:"edited_by_gm"`
+	Intention        pgtype.Text        `json:"intention"`
+	Modifier         pgtype.Int4        `json:"modifier"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	AuthoredByGm     bool               `json:"authored_by_gm"`
+	Mentions         []pgtype.UUID      `json:"mentions"`
+	IsSystem         bool               `json:"is_system"`
+	IsPinned         bool               `json:"is_pinned"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	DeletedBy        pgtype.UUID        `json:"deleted_by"`
+	SubmittedAt      pgtype.Timestamptz `json:"submitted_at"`
+	WitnessedByScene bool               `json:"witnessed_by_scene"`
+	CharacterName    pgtype.Text        `json:"character_name"`
+	CharacterAvatar  pgtype.Text        `json:"character_avatar"`
+	CharacterType    NullCharacterType  `json:"character_type"`
 }
 
 func (q *Queries) ListHiddenPostsInScene(ctx context.Context, sceneID pgtype.UUID) ([]ListHiddenPostsInSceneRow, error) {
@@ -440,6 +664,14 @@ func (q *Queries) ListHiddenPostsInScene(ctx context.Context, sceneID pgtype.UUI
 			&i.Modifier,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AuthoredByGm,
+			&i.Mentions,
+			&i.IsSystem,
+			&i.IsPinned,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.SubmittedAt,
+			&i.WitnessedByScene,
 			&i.CharacterName,
 			&i.CharacterAvatar,
 			&i.CharacterType,
@@ -456,36 +688,44 @@ func (q *Queries) ListHiddenPostsInScene(ctx context.Context, sceneID pgtype.UUI
 
 const listScenePosts = `-- name: ListScenePosts :many
 SELECT
-    p.id, p.scene_id, p.character_id, p.user_id, p.blocks, p.ooc_text, p.witnesses, p.is_hidden, p.is_draft, p.is_locked, p.locked_at, p.edited_by_gm, p.intention, p.modifier, p.created_at, p.updated_at,
+    p.id, p.scene_id, p.character_id, p.user_id, p.blocks, p.ooc_text, p.witnesses, p.is_hidden, p.is_draft, p.is_locked, p.locked_at, p.edited_by_gm, p.intention, p.modifier, p.created_at, p.updated_at, p.authored_by_gm, p.mentions, p.is_system, p.is_pinned, p.deleted_at, p.deleted_by, p.submitted_at, p.witnessed_by_scene,
     c.display_name AS character_name,
     c.avatar_url AS character_avatar,
     c.character_type
 FROM posts p
 LEFT JOIN characters c ON p.character_id = c.id
 WHERE p.scene_id = $1 AND p.is_draft = false
-ORDER BY p.created_at ASC
+ORDER BY p.is_pinned DESC, p.created_at ASC
 `
 
 type ListScenePostsRow struct {
-	ID              pgtype.UUID        `json:"id"`
-	SceneID         pgtype.UUID        `json:"scene_id"`
-	CharacterID     pgtype.UUID        `json:"character_id"`
-	UserID          pgtype.UUID        `json:"user_id"`
-	Blocks          []byte             `json:"blocks"`
-	OocText         pgtype.Text        `json:"ooc_text"`
-	Witnesses       []pgtype.UUID      `json:"witnesses"`
-	IsHidden        bool               `json:"is_hidden"`
-	IsDraft         bool               `json:"is_draft"`
-	IsLocked        bool               `json:"is_locked"`
-	LockedAt        pgtype.Timestamptz `json:"locked_at"`
-	EditedByGm      bool               `json:"edited_by_gm"`
-	Intention       pgtype.Text        `json:"intention"`
-	Modifier        pgtype.Int4        `json:"modifier"`
-	CreatedAt       pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
-	CharacterName   pgtype.Text        `json:"character_name"`
-	CharacterAvatar pgtype.Text        `json:"character_avatar"`
-	CharacterType   NullCharacterType  `json:"character_type"`
+	ID               pgtype.UUID        `json:"id"`
+	SceneID          pgtype.UUID        `json:"scene_id"`
+	CharacterID      pgtype.UUID        `json:"character_id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	Blocks           []byte             `json:"blocks"`
+	OocText          pgtype.Text        `json:"ooc_text"`
+	Witnesses        []pgtype.UUID      `json:"witnesses"`
+	IsHidden         bool               `json:"is_hidden"`
+	IsDraft          bool               `json:"is_draft"`
+	IsLocked         bool               `json:"is_locked"`
+	LockedAt         pgtype.Timestamptz `json:"locked_at"`
+	EditedByGm       bool               `json:"edited_by_gm"`
+	Intention        pgtype.Text        `json:"intention"`
+	Modifier         pgtype.Int4        `json:"modifier"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	AuthoredByGm     bool               `json:"authored_by_gm"`
+	Mentions         []pgtype.UUID      `json:"mentions"`
+	IsSystem         bool               `json:"is_system"`
+	IsPinned         bool               `json:"is_pinned"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	DeletedBy        pgtype.UUID        `json:"deleted_by"`
+	SubmittedAt      pgtype.Timestamptz `json:"submitted_at"`
+	WitnessedByScene bool               `json:"witnessed_by_scene"`
+	CharacterName    pgtype.Text        `json:"character_name"`
+	CharacterAvatar  pgtype.Text        `json:"character_avatar"`
+	CharacterType    NullCharacterType  `json:"character_type"`
 }
 
 func (q *Queries) ListScenePosts(ctx context.Context, sceneID pgtype.UUID) ([]ListScenePostsRow, error) {
@@ -514,6 +754,14 @@ func (q *Queries) ListScenePosts(ctx context.Context, sceneID pgtype.UUID) ([]Li
 			&i.Modifier,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AuthoredByGm,
+			&i.Mentions,
+			&i.IsSystem,
+			&i.IsPinned,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.SubmittedAt,
+			&i.WitnessedByScene,
 			&i.CharacterName,
 			&i.CharacterAvatar,
 			&i.CharacterType,
@@ -530,7 +778,7 @@ func (q *Queries) ListScenePosts(ctx context.Context, sceneID pgtype.UUID) ([]Li
 
 const listScenePostsForCharacter = `-- name: ListScenePostsForCharacter :many
 SELECT
-    p.id, p.scene_id, p.character_id, p.user_id, p.blocks, p.ooc_text, p.witnesses, p.is_hidden, p.is_draft, p.is_locked, p.locked_at, p.edited_by_gm, p.intention, p.modifier, p.created_at, p.updated_at,
+    p.id, p.scene_id, p.character_id, p.user_id, p.blocks, p.ooc_text, p.witnesses, p.is_hidden, p.is_draft, p.is_locked, p.locked_at, p.edited_by_gm, p.intention, p.modifier, p.created_at, p.updated_at, p.authored_by_gm, p.mentions, p.is_system, p.is_pinned, p.deleted_at, p.deleted_by, p.submitted_at, p.witnessed_by_scene,
     c.display_name AS character_name,
     c.avatar_url AS character_avatar,
     c.character_type
@@ -549,25 +797,33 @@ type ListScenePostsForCharacterParams struct {
 }
 
 type ListScenePostsForCharacterRow struct {
-	ID              pgtype.UUID        `json:"id"`
-	SceneID         pgtype.UUID        `json:"scene_id"`
-	CharacterID     pgtype.UUID        `json:"character_id"`
-	UserID          pgtype.UUID        `json:"user_id"`
-	Blocks          []byte             `json:"blocks"`
-	OocText         pgtype.Text        `json:"ooc_text"`
-	Witnesses       []pgtype.UUID      `json:"witnesses"`
-	IsHidden        bool               `json:"is_hidden"`
-	IsDraft         bool               `json:"is_draft"`
-	IsLocked        bool               `json:"is_locked"`
-	LockedAt        pgtype.Timestamptz `json:"locked_at"`
-	EditedByGm      bool               `json:"edited_by_gm"`
-	Intention       pgtype.Text        `json:"intention"`
-	Modifier        pgtype.Int4        `json:"modifier"`
-	CreatedAt       pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
-	CharacterName   pgtype.Text        `json:"character_name"`
-	CharacterAvatar pgtype.Text        `json:"character_avatar"`
-	CharacterType   NullCharacterType  `json:"character_type"`
+	ID               pgtype.UUID        `json:"id"`
+	SceneID          pgtype.UUID        `json:"scene_id"`
+	CharacterID      pgtype.UUID        `json:"character_id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	Blocks           []byte             `json:"blocks"`
+	OocText          pgtype.Text        `json:"ooc_text"`
+	Witnesses        []pgtype.UUID      `json:"witnesses"`
+	IsHidden         bool               `json:"is_hidden"`
+	IsDraft          bool               `json:"is_draft"`
+	IsLocked         bool               `json:"is_locked"`
+	LockedAt         pgtype.Timestamptz `json:"locked_at"`
+	EditedByGm       bool               `json:"edited_by_gm"`
+	Intention        pgtype.Text        `json:"intention"`
+	Modifier         pgtype.Int4        `json:"modifier"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	AuthoredByGm     bool               `json:"authored_by_gm"`
+	Mentions         []pgtype.UUID      `json:"mentions"`
+	IsSystem         bool               `json:"is_system"`
+	IsPinned         bool               `json:"is_pinned"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	DeletedBy        pgtype.UUID        `json:"deleted_by"`
+	SubmittedAt      pgtype.Timestamptz `json:"submitted_at"`
+	WitnessedByScene bool               `json:"witnessed_by_scene"`
+	CharacterName    pgtype.Text        `json:"character_name"`
+	CharacterAvatar  pgtype.Text        `json:"character_avatar"`
+	CharacterType    NullCharacterType  `json:"character_type"`
 }
 
 func (q *Queries) ListScenePostsForCharacter(ctx context.Context, arg ListScenePostsForCharacterParams) ([]ListScenePostsForCharacterRow, error) {
@@ -596,6 +852,14 @@ func (q *Queries) ListScenePostsForCharacter(ctx context.Context, arg ListSceneP
 			&i.Modifier,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AuthoredByGm,
+			&i.Mentions,
+			&i.IsSystem,
+			&i.IsPinned,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.SubmittedAt,
+			&i.WitnessedByScene,
 			&i.CharacterName,
 			&i.CharacterAvatar,
 			&i.CharacterType,
@@ -612,7 +876,7 @@ func (q *Queries) ListScenePostsForCharacter(ctx context.Context, arg ListSceneP
 
 const listScenePostsPaginated = `-- name: ListScenePostsPaginated :many
 SELECT
-    p.id, p.scene_id, p.character_id, p.user_id, p.blocks, p.ooc_text, p.witnesses, p.is_hidden, p.is_draft, p.is_locked, p.locked_at, p.edited_by_gm, p.intention, p.modifier, p.created_at, p.updated_at,
+    p.id, p.scene_id, p.character_id, p.user_id, p.blocks, p.ooc_text, p.witnesses, p.is_hidden, p.is_draft, p.is_locked, p.locked_at, p.edited_by_gm, p.intention, p.modifier, p.created_at, p.updated_at, p.authored_by_gm, p.mentions, p.is_system, p.is_pinned, p.deleted_at, p.deleted_by, p.submitted_at, p.witnessed_by_scene,
     c.display_name AS character_name,
     c.avatar_url AS character_avatar,
     c.character_type
@@ -635,25 +899,33 @@ type ListScenePostsPaginatedParams struct {
 }
 
 type ListScenePostsPaginatedRow struct {
-	ID              pgtype.UUID        `json:"id"`
-	SceneID         pgtype.UUID        `json:"scene_id"`
-	CharacterID     pgtype.UUID        `json:"character_id"`
-	UserID          pgtype.UUID        `json:"user_id"`
-	Blocks          []byte             `json:"blocks"`
-	OocText         pgtype.Text        `json:"ooc_text"`
-	Witnesses       []pgtype.UUID      `json:"witnesses"`
-	IsHidden        bool               `json:"is_hidden"`
-	IsDraft         bool               `json:"is_draft"`
-	IsLocked        bool               `json:"is_locked"`
-	LockedAt        pgtype.Timestamptz `json:"locked_at"`
-	EditedByGm      bool               `json:"edited_by_gm"`
-	Intention       pgtype.Text        `json:"intention"`
-	Modifier        pgtype.Int4        `json:"modifier"`
-	CreatedAt       pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
-	CharacterName   pgtype.Text        `json:"character_name"`
-	CharacterAvatar pgtype.Text        `json:"character_avatar"`
-	CharacterType   NullCharacterType  `json:"character_type"`
+	ID               pgtype.UUID        `json:"id"`
+	SceneID          pgtype.UUID        `json:"scene_id"`
+	CharacterID      pgtype.UUID        `json:"character_id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	Blocks           []byte             `json:"blocks"`
+	OocText          pgtype.Text        `json:"ooc_text"`
+	Witnesses        []pgtype.UUID      `json:"witnesses"`
+	IsHidden         bool               `json:"is_hidden"`
+	IsDraft          bool               `json:"is_draft"`
+	IsLocked         bool               `json:"is_locked"`
+	LockedAt         pgtype.Timestamptz `json:"locked_at"`
+	EditedByGm       bool               `json:"edited_by_gm"`
+	Intention        pgtype.Text        `json:"intention"`
+	Modifier         pgtype.Int4        `json:"modifier"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	AuthoredByGm     bool               `json:"authored_by_gm"`
+	Mentions         []pgtype.UUID      `json:"mentions"`
+	IsSystem         bool               `json:"is_system"`
+	IsPinned         bool               `json:"is_pinned"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	DeletedBy        pgtype.UUID        `json:"deleted_by"`
+	SubmittedAt      pgtype.Timestamptz `json:"submitted_at"`
+	WitnessedByScene bool               `json:"witnessed_by_scene"`
+	CharacterName    pgtype.Text        `json:"character_name"`
+	CharacterAvatar  pgtype.Text        `json:"character_avatar"`
+	CharacterType    NullCharacterType  `json:"character_type"`
 }
 
 // Cursor-based pagination for posts
@@ -689,6 +961,14 @@ func (q *Queries) ListScenePostsPaginated(ctx context.Context, arg ListScenePost
 			&i.Modifier,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AuthoredByGm,
+			&i.Mentions,
+			&i.IsSystem,
+			&i.IsPinned,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.SubmittedAt,
+			&i.WitnessedByScene,
 			&i.CharacterName,
 			&i.CharacterAvatar,
 			&i.CharacterType,
@@ -716,25 +996,270 @@ func (q *Queries) LockPost(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const movePost = `-- name: MovePost :one
+UPDATE posts
+SET
+    scene_id = $2,
+    witnesses = $3,
+    witnessed_by_scene = $4,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene
+`
+
+type MovePostParams struct {
+	ID               pgtype.UUID   `json:"id"`
+	SceneID          pgtype.UUID   `json:"scene_id"`
+	Witnesses        []pgtype.UUID `json:"witnesses"`
+	WitnessedByScene bool          `json:"witnessed_by_scene"`
+}
+
+// GM-only: Move a post to another scene with new witnesses; locks are fixed separately
+func (q *Queries) MovePost(ctx context.Context, arg MovePostParams) (Post, error) {
+	row := q.db.QueryRow(ctx, movePost,
+		arg.ID,
+		arg.SceneID,
+		arg.Witnesses,
+		arg.WitnessedByScene,
+	)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.SceneID,
+		&i.CharacterID,
+		&i.UserID,
+		&i.Blocks,
+		&i.OocText,
+		&i.Witnesses,
+		&i.IsHidden,
+		&i.IsDraft,
+		&i.IsLocked,
+		&i.LockedAt,
+		&i.EditedByGm,
+		&i.Intention,
+		&i.Modifier,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
+	)
+	return i, err
+}
+
+const pinPost = `-- name: PinPost :one
+UPDATE posts
+SET is_pinned = true
+WHERE id = $1
+RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene
+`
+
+func (q *Queries) PinPost(ctx context.Context, id pgtype.UUID) (Post, error) {
+	row := q.db.QueryRow(ctx, pinPost, id)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.SceneID,
+		&i.CharacterID,
+		&i.UserID,
+		&i.Blocks,
+		&i.OocText,
+		&i.Witnesses,
+		&i.IsHidden,
+		&i.IsDraft,
+		&i.IsLocked,
+		&i.LockedAt,
+		&i.EditedByGm,
+		&i.Intention,
+		&i.Modifier,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
+	)
+	return i, err
+}
+
+const purgeRemovedPostContents = `-- name: PurgeRemovedPostContents :execrows
+DELETE FROM removed_post_contents WHERE removed_at < $1
+`
+
+// Drops removed content older than the restore window
+func (q *Queries) PurgeRemovedPostContents(ctx context.Context, removedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeRemovedPostContents, removedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restorePost = `-- name: RestorePost :one
+UPDATE posts
+SET
+    deleted_at = NULL,
+    deleted_by = NULL,
+    blocks = r.blocks,
+    ooc_text = r.ooc_text,
+    intention = r.intention,
+    modifier = r.modifier,
+    updated_at = NOW()
+FROM removed_post_contents r
+WHERE posts.id = $1 AND r.post_id = posts.id
+RETURNING posts.id, posts.scene_id, posts.character_id, posts.user_id, posts.blocks, posts.ooc_text, posts.witnesses, posts.is_hidden, posts.is_draft, posts.is_locked, posts.locked_at, posts.edited_by_gm, posts.intention, posts.modifier, posts.created_at, posts.updated_at, posts.authored_by_gm, posts.mentions, posts.is_system, posts.is_pinned, posts.deleted_at, posts.deleted_by, posts.submitted_at, posts.witnessed_by_scene
+`
+
+// Puts back the content saved by SaveRemovedPostContent; no row if it was purged
+func (q *Queries) RestorePost(ctx context.Context, id pgtype.UUID) (Post, error) {
+	row := q.db.QueryRow(ctx, restorePost, id)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.SceneID,
+		&i.CharacterID,
+		&i.UserID,
+		&i.Blocks,
+		&i.OocText,
+		&i.Witnesses,
+		&i.IsHidden,
+		&i.IsDraft,
+		&i.IsLocked,
+		&i.LockedAt,
+		&i.EditedByGm,
+		&i.Intention,
+		&i.Modifier,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
+	)
+	return i, err
+}
+
+const saveRemovedPostContent = `-- name: SaveRemovedPostContent :exec
+INSERT INTO removed_post_contents (post_id, blocks, ooc_text, intention, modifier)
+SELECT id, blocks, ooc_text, intention, modifier FROM posts WHERE id = $1
+`
+
+// Copies a post's content aside before TombstonePost blanks it
+func (q *Queries) SaveRemovedPostContent(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, saveRemovedPostContent, id)
+	return err
+}
+
+const setPostMentions = `-- name: SetPostMentions :exec
+UPDATE posts
+SET mentions = $2
+WHERE id = $1
+`
+
+type SetPostMentionsParams struct {
+	ID       pgtype.UUID   `json:"id"`
+	Mentions []pgtype.UUID `json:"mentions"`
+}
+
+func (q *Queries) SetPostMentions(ctx context.Context, arg SetPostMentionsParams) error {
+	_, err := q.db.Exec(ctx, setPostMentions, arg.ID, arg.Mentions)
+	return err
+}
+
 const submitPost = `-- name: SubmitPost :one
 UPDATE posts
 SET
     is_draft = false,
     witnesses = $2,
     is_hidden = $3,
+    witnessed_by_scene = $4,
+    submitted_at = NOW(),
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at
+RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene
 `
 
 type SubmitPostParams struct {
-	ID        pgtype.UUID   `json:"id"`
-	Witnesses []pgtype.UUID `json:"witnesses"`
-	IsHidden  bool          `json:"is_hidden"`
+	ID               pgtype.UUID   `json:"id"`
+	Witnesses        []pgtype.UUID `json:"witnesses"`
+	IsHidden         bool          `json:"is_hidden"`
+	WitnessedByScene bool          `json:"witnessed_by_scene"`
 }
 
 func (q *Queries) SubmitPost(ctx context.Context, arg SubmitPostParams) (Post, error) {
-	row := q.db.QueryRow(ctx, submitPost, arg.ID, arg.Witnesses, arg.IsHidden)
+	row := q.db.QueryRow(ctx, submitPost,
+		arg.ID,
+		arg.Witnesses,
+		arg.IsHidden,
+		arg.WitnessedByScene,
+	)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.SceneID,
+		&i.CharacterID,
+		&i.UserID,
+		&i.Blocks,
+		&i.OocText,
+		&i.Witnesses,
+		&i.IsHidden,
+		&i.IsDraft,
+		&i.IsLocked,
+		&i.LockedAt,
+		&i.EditedByGm,
+		&i.Intention,
+		&i.Modifier,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
+	)
+	return i, err
+}
+
+const tombstonePost = `-- name: TombstonePost :one
+UPDATE posts
+SET
+    deleted_at = NOW(),
+    deleted_by = $2,
+    is_pinned = false,
+    blocks = '[]'::jsonb,
+    ooc_text = NULL,
+    intention = NULL,
+    modifier = NULL,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene
+`
+
+type TombstonePostParams struct {
+	ID        pgtype.UUID `json:"id"`
+	DeletedBy pgtype.UUID `json:"deleted_by"`
+}
+
+// GM-only: Replace a post with a placeholder, keeping its place and lock state; also unpins it.
+// The content is blanked, so SaveRemovedPostContent must run first.
+func (q *Queries) TombstonePost(ctx context.Context, arg TombstonePostParams) (Post, error) {
+	row := q.db.QueryRow(ctx, tombstonePost, arg.ID, arg.DeletedBy)
 	var i Post
 	err := row.Scan(
 		&i.ID,
@@ -753,6 +1278,14 @@ func (q *Queries) SubmitPost(ctx context.Context, arg SubmitPostParams) (Post, e
 		&i.Modifier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
 	)
 	return i, err
 }
@@ -764,7 +1297,7 @@ SET
     is_hidden = false,
     updated_at = NOW()
 WHERE id = $1 AND is_hidden = true
-RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at
+RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene
 `
 
 type UnhidePostWithCustomWitnessesParams struct {
@@ -793,6 +1326,14 @@ func (q *Queries) UnhidePostWithCustomWitnesses(ctx context.Context, arg UnhideP
 		&i.Modifier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
 	)
 	return i, err
 }
@@ -810,6 +1351,45 @@ func (q *Queries) UnlockPost(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const unpinPost = `-- name: UnpinPost :one
+UPDATE posts
+SET is_pinned = false
+WHERE id = $1
+RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene
+`
+
+func (q *Queries) UnpinPost(ctx context.Context, id pgtype.UUID) (Post, error) {
+	row := q.db.QueryRow(ctx, unpinPost, id)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.SceneID,
+		&i.CharacterID,
+		&i.UserID,
+		&i.Blocks,
+		&i.OocText,
+		&i.Witnesses,
+		&i.IsHidden,
+		&i.IsDraft,
+		&i.IsLocked,
+		&i.LockedAt,
+		&i.EditedByGm,
+		&i.Intention,
+		&i.Modifier,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
+	)
+	return i, err
+}
+
 const updatePost = `-- name: UpdatePost :one
 UPDATE posts
 SET
@@ -820,7 +1400,7 @@ SET
     edited_by_gm = COALESCE($6, edited_by_gm),
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at
+RETURNING id, scene_id, character_id, user_id, blocks, ooc_text, witnesses, is_hidden, is_draft, is_locked, locked_at, edited_by_gm, intention, modifier, created_at, updated_at, authored_by_gm, mentions, is_system, is_pinned, deleted_at, deleted_by, submitted_at, witnessed_by_scene
 `
 
 type UpdatePostParams struct {
@@ -859,6 +1439,14 @@ func (q *Queries) UpdatePost(ctx context.Context, arg UpdatePostParams) (Post, e
 		&i.Modifier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AuthoredByGm,
+		&i.Mentions,
+		&i.IsSystem,
+		&i.IsPinned,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.SubmittedAt,
+		&i.WitnessedByScene,
 	)
 	return i, err
 }
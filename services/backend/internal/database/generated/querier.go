@@ -14,24 +14,37 @@ import (
 type Querier interface {
 	AcquireComposeLock(ctx context.Context, arg AcquireComposeLockParams) (ComposeLock, error)
 	AddCampaignMember(ctx context.Context, arg AddCampaignMemberParams) (CampaignMember, error)
+	AddCharacterAvatarHistory(ctx context.Context, arg AddCharacterAvatarHistoryParams) (CharacterAvatarHistory, error)
 	AddCharacterToScene(ctx context.Context, arg AddCharacterToSceneParams) (Scene, error)
+	AddPostReaction(ctx context.Context, arg AddPostReactionParams) error
+	AddSceneFavorite(ctx context.Context, arg AddSceneFavoriteParams) error
 	ArchiveCharacter(ctx context.Context, id pgtype.UUID) (Character, error)
 	ArchiveScene(ctx context.Context, id pgtype.UUID) (Scene, error)
 	AssignCharacter(ctx context.Context, arg AssignCharacterParams) (CharacterAssignment, error)
+	// GM-only: Give a narrator post a character, with witnesses that include it
+	AttributePost(ctx context.Context, arg AttributePostParams) (Post, error)
 	CharacterHasPendingRolls(ctx context.Context, characterID pgtype.UUID) (bool, error)
 	// Returns true if all PCs in active scenes have passed
 	// Only PCs need to pass, NPCs are excluded from this check
 	CheckAllCharactersPassed(ctx context.Context, campaignID pgtype.UUID) (bool, error)
 	CheckGmInactivity(ctx context.Context, id pgtype.UUID) (CheckGmInactivityRow, error)
+	// Marks due queue entries as delivered before sending so a crash mid-flush cannot double-send.
+	ClaimDueQueuedNotifications(ctx context.Context, limit int32) ([]NotificationQueue, error)
+	// Claims a resend of the user's notification email, at most once a minute
+	ClaimNotificationEmailResend(ctx context.Context, arg ClaimNotificationEmailResendParams) (Notification, error)
 	ClearCampaignTimeGate(ctx context.Context, id pgtype.UUID) error
 	ClearCharacterAvatar(ctx context.Context, id pgtype.UUID) (Character, error)
 	ClearCharacterPassState(ctx context.Context, arg ClearCharacterPassStateParams) (Scene, error)
+	ClearExpiredSnoozes(ctx context.Context) (int64, error)
 	ClearSceneHeaderImage(ctx context.Context, id pgtype.UUID) (Scene, error)
+	CountActiveCampaignBotTokens(ctx context.Context, campaignID pgtype.UUID) (int64, error)
 	CountActiveCampaignInvites(ctx context.Context, campaignID pgtype.UUID) (int64, error)
 	CountActiveLocksInCampaign(ctx context.Context, campaignID pgtype.UUID) (int64, error)
 	CountActiveScenes(ctx context.Context, campaignID pgtype.UUID) (int64, error)
 	CountCampaignCharacters(ctx context.Context, campaignID pgtype.UUID) (int64, error)
+	CountCampaignPlayers(ctx context.Context, campaignID pgtype.UUID) (int64, error)
 	CountCampaignScenes(ctx context.Context, campaignID pgtype.UUID) (int64, error)
+	CountGmAuditLog(ctx context.Context, campaignID pgtype.UUID) (int64, error)
 	// Count PCs that have passed in all their scenes
 	CountPassedCharactersInCampaign(ctx context.Context, campaignID pgtype.UUID) (int64, error)
 	CountPendingRollsForCharacter(ctx context.Context, characterID pgtype.UUID) (int64, error)
@@ -40,15 +53,25 @@ type Querier interface {
 	CountScenePosts(ctx context.Context, sceneID pgtype.UUID) (int64, error)
 	// Count PCs that haven't passed in at least one scene
 	CountUnpassedCharactersInCampaign(ctx context.Context, campaignID pgtype.UUID) (int64, error)
+	// Counts submitted posts by others published after the user's read marker, per scene.
+	// For GMs every post counts; players only count posts witnessed by one of their characters.
+	CountUnreadPostsByScene(ctx context.Context, arg CountUnreadPostsBySceneParams) ([]CountUnreadPostsBySceneRow, error)
+	// Counts the rows GetUnresolvedRollsInCampaign would return without pagination
+	CountUnresolvedRollsInCampaign(ctx context.Context, arg CountUnresolvedRollsInCampaignParams) (int64, error)
+	CountUserDrafts(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountUserCampaigns(ctx context.Context, userID pgtype.UUID) (int64, error)
 	CountUserOwnedCampaigns(ctx context.Context, ownerID pgtype.UUID) (int64, error)
 	CreateCampaign(ctx context.Context, arg CreateCampaignParams) (Campaign, error)
+	CreateCampaignBotToken(ctx context.Context, arg CreateCampaignBotTokenParams) (CampaignBotToken, error)
 	CreateCharacter(ctx context.Context, arg CreateCharacterParams) (Character, error)
 	CreateComposeDraft(ctx context.Context, arg CreateComposeDraftParams) (ComposeDraft, error)
+	CreateGmAuditEntry(ctx context.Context, arg CreateGmAuditEntryParams) error
 	CreateInviteLink(ctx context.Context, arg CreateInviteLinkParams) (InviteLink, error)
 	// ============================================
 	// NOTIFICATION QUERIES
 	// ============================================
 	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreateOocMessage(ctx context.Context, arg CreateOocMessageParams) (OocMessage, error)
 	CreatePost(ctx context.Context, arg CreatePostParams) (Post, error)
 	// ============================================
 	// DICE ROLLS QUERIES
@@ -58,28 +81,42 @@ type Querier interface {
 	DecrementCampaignStorage(ctx context.Context, arg DecrementCampaignStorageParams) (int64, error)
 	DecrementSceneCount(ctx context.Context, id pgtype.UUID) error
 	DeleteCampaign(ctx context.Context, id pgtype.UUID) error
+	DeleteCampaignNotifications(ctx context.Context, arg DeleteCampaignNotificationsParams) (int64, error)
+	DeleteCampaignWebhook(ctx context.Context, campaignID pgtype.UUID) (int64, error)
+	DeleteCharacterAvatarHistoryEntry(ctx context.Context, id pgtype.UUID) error
 	DeleteComposeDraft(ctx context.Context, id pgtype.UUID) error
 	DeleteComposeDraftByCharacter(ctx context.Context, arg DeleteComposeDraftByCharacterParams) error
 	DeleteComposeLock(ctx context.Context, id pgtype.UUID) error
 	DeleteExpiredComposeLocks(ctx context.Context, expiresAt pgtype.Timestamptz) error
 	DeleteExpiredNotifications(ctx context.Context) (int64, error)
 	DeleteNotification(ctx context.Context, arg DeleteNotificationParams) error
+	// Deletes the user's least recently updated drafts
+	DeleteOldestUserDrafts(ctx context.Context, arg DeleteOldestUserDraftsParams) (int64, error)
+	DeleteOocMessage(ctx context.Context, id pgtype.UUID) error
 	DeletePost(ctx context.Context, id pgtype.UUID) error
+	DeleteRemovedPostContent(ctx context.Context, postID pgtype.UUID) error
 	DeleteQueuedNotification(ctx context.Context, id pgtype.UUID) error
+	DeleteReadNotifications(ctx context.Context, userID pgtype.UUID) (int64, error)
 	DeleteRoll(ctx context.Context, id pgtype.UUID) error
 	DeleteScene(ctx context.Context, id pgtype.UUID) error
-	DeleteSceneComposeLocks(ctx context.Context, sceneID pgtype.UUID) error
+	DeleteSceneComposeLocks(ctx context.Context, sceneID pgtype.UUID) (int64, error)
 	DeliverAllQueuedNotifications(ctx context.Context, userID pgtype.UUID) (int64, error)
 	// GM-only: Update witnesses on a post without changing hidden status
 	EditPostWitnesses(ctx context.Context, arg EditPostWitnessesParams) (Post, error)
 	ExecuteRoll(ctx context.Context, arg ExecuteRollParams) (Roll, error)
 	FindSimilarNotification(ctx context.Context, arg FindSimilarNotificationParams) (Notification, error)
+	FreezeScene(ctx context.Context, id pgtype.UUID) (Scene, error)
+	// Resolves an unrevoked token to its campaign and the campaign's current GM,
+	// whose identity the bot acts under.
+	GetActiveBotTokenByHash(ctx context.Context, tokenHash string) (GetActiveBotTokenByHashRow, error)
 	// Returns all non-archived characters in active scenes for a campaign
 	GetActiveCharactersInCampaign(ctx context.Context, campaignID pgtype.UUID) ([]GetActiveCharactersInCampaignRow, error)
 	// Returns all non-archived scenes in a campaign for auto-pass processing
 	GetAllActiveScenesInCampaign(ctx context.Context, campaignID pgtype.UUID) ([]Scene, error)
 	GetAllPassStatesInCampaign(ctx context.Context, campaignID pgtype.UUID) ([]GetAllPassStatesInCampaignRow, error)
 	GetCampaign(ctx context.Context, id pgtype.UUID) (Campaign, error)
+	// Locks the campaign row for the rest of the transaction
+	GetCampaignForUpdate(ctx context.Context, id pgtype.UUID) (Campaign, error)
 	GetCampaignMember(ctx context.Context, arg GetCampaignMemberParams) (CampaignMember, error)
 	GetCampaignMemberCount(ctx context.Context, campaignID pgtype.UUID) (int64, error)
 	GetCampaignMembers(ctx context.Context, campaignID pgtype.UUID) ([]GetCampaignMembersRow, error)
@@ -88,10 +125,13 @@ type Querier interface {
 	// ============================================
 	GetCampaignPhaseStatus(ctx context.Context, id pgtype.UUID) (GetCampaignPhaseStatusRow, error)
 	GetCampaignStorage(ctx context.Context, id pgtype.UUID) (int64, error)
+	GetCampaignStorageLimit(ctx context.Context, campaignID pgtype.UUID) (int64, error)
+	GetCampaignWebhook(ctx context.Context, campaignID pgtype.UUID) (CampaignWebhook, error)
 	GetCampaignWithMembership(ctx context.Context, arg GetCampaignWithMembershipParams) (GetCampaignWithMembershipRow, error)
 	GetCampaignsWithActiveTimeGates(ctx context.Context) ([]Campaign, error)
 	GetCharacter(ctx context.Context, id pgtype.UUID) (Character, error)
 	GetCharacterAssignment(ctx context.Context, characterID pgtype.UUID) (CharacterAssignment, error)
+	GetCharacterAvatarHistoryEntry(ctx context.Context, arg GetCharacterAvatarHistoryEntryParams) (CharacterAvatarHistory, error)
 	GetCharacterCampaignID(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error)
 	GetCharacterOwner(ctx context.Context, characterID pgtype.UUID) (pgtype.UUID, error)
 	// Get pass status for a specific character across all their scenes
@@ -104,19 +144,27 @@ type Querier interface {
 	GetComposeLockByID(ctx context.Context, id pgtype.UUID) (ComposeLock, error)
 	GetComposeLockByScene(ctx context.Context, sceneID pgtype.UUID) ([]GetComposeLockBySceneRow, error)
 	GetComposeLockWithHiddenInfo(ctx context.Context, arg GetComposeLockWithHiddenInfoParams) (GetComposeLockWithHiddenInfoRow, error)
+	GetComposeLocksByCampaign(ctx context.Context, campaignID pgtype.UUID) ([]GetComposeLocksByCampaignRow, error)
 	GetExpiredTimeGateCampaigns(ctx context.Context) ([]Campaign, error)
 	GetGMUserID(ctx context.Context, campaignID pgtype.UUID) (pgtype.UUID, error)
 	GetInviteLinkByCode(ctx context.Context, code string) (GetInviteLinkByCodeRow, error)
 	GetLastDigestSent(ctx context.Context, arg GetLastDigestSentParams) (EmailDigest, error)
 	GetLastScenePost(ctx context.Context, sceneID pgtype.UUID) (Post, error)
+	// Returns the most recently published post the user can see: any post for GMs,
+	// otherwise one witnessed by a character the user plays in the scene
+	GetLastVisibleScenePost(ctx context.Context, arg GetLastVisibleScenePostParams) (Post, error)
+	// Returns the character's newest submitted post in the scene changed after the given time
+	GetLatestCharacterPostSince(ctx context.Context, arg GetLatestCharacterPostSinceParams) (pgtype.UUID, error)
 	GetNotification(ctx context.Context, id pgtype.UUID) (Notification, error)
 	// ============================================
 	// NOTIFICATION PREFERENCES QUERIES
 	// ============================================
 	GetNotificationPreferences(ctx context.Context, userID pgtype.UUID) (NotificationPreference, error)
+	// The types and is_read filters match every notification when NULL
 	GetNotificationsByUser(ctx context.Context, arg GetNotificationsByUserParams) ([]Notification, error)
 	GetNotificationsSince(ctx context.Context, arg GetNotificationsSinceParams) ([]Notification, error)
 	GetOldestArchivedScene(ctx context.Context, campaignID pgtype.UUID) (Scene, error)
+	GetOocMessage(ctx context.Context, id pgtype.UUID) (OocMessage, error)
 	GetOrphanedCharacters(ctx context.Context, campaignID pgtype.UUID) ([]Character, error)
 	// ============================================
 	// CAMPAIGN MEMBER NOTIFICATION HELPERS
@@ -155,12 +203,18 @@ type Querier interface {
 	GetSceneWithCharacter(ctx context.Context, arg GetSceneWithCharacterParams) (Scene, error)
 	GetUnreadNotificationCount(ctx context.Context, userID pgtype.UUID) (int64, error)
 	GetUnreadNotificationCountByCampaign(ctx context.Context, arg GetUnreadNotificationCountByCampaignParams) (int64, error)
+	// Reads the user's maintained unread counters; GetUnreadNotificationCount is the source of truth
+	GetUnreadNotificationCounter(ctx context.Context, userID pgtype.UUID) (GetUnreadNotificationCounterRow, error)
+	GetUnreadNotificationCounterByCampaign(ctx context.Context, arg GetUnreadNotificationCounterByCampaignParams) (GetUnreadNotificationCounterByCampaignRow, error)
+	GetUnreadNotificationsByType(ctx context.Context, arg GetUnreadNotificationsByTypeParams) ([]Notification, error)
 	GetUnreadNotificationsByUser(ctx context.Context, arg GetUnreadNotificationsByUserParams) ([]Notification, error)
-	GetUnresolvedRollsInCampaign(ctx context.Context, campaignID pgtype.UUID) ([]GetUnresolvedRollsInCampaignRow, error)
+	GetUnresolvedRollsInCampaign(ctx context.Context, arg GetUnresolvedRollsInCampaignParams) ([]GetUnresolvedRollsInCampaignRow, error)
 	GetUserCharactersInScene(ctx context.Context, arg GetUserCharactersInSceneParams) ([]GetUserCharactersInSceneRow, error)
 	GetUserComposeLockInScene(ctx context.Context, arg GetUserComposeLockInSceneParams) (ComposeLock, error)
 	GetUserDraftInScene(ctx context.Context, arg GetUserDraftInSceneParams) (ComposeDraft, error)
 	GetUserDraftPost(ctx context.Context, arg GetUserDraftPostParams) (Post, error)
+	GetUserNotification(ctx context.Context, arg GetUserNotificationParams) (GetUserNotificationRow, error)
+	GetUserProfile(ctx context.Context, userID pgtype.UUID) (UserProfile, error)
 	GetUserQueuedCount(ctx context.Context, userID pgtype.UUID) (int64, error)
 	GetUserQueuedNotifications(ctx context.Context, userID pgtype.UUID) ([]NotificationQueue, error)
 	GetUsersInScene(ctx context.Context, id pgtype.UUID) ([]GetUsersInSceneRow, error)
@@ -171,6 +225,10 @@ type Querier interface {
 	// Used for fog of war filtering - aggregates visibility across all user's characters
 	GetVisibleScenesForUser(ctx context.Context, arg GetVisibleScenesForUserParams) ([]Scene, error)
 	GetWitnessUsers(ctx context.Context, dollar_1 []pgtype.UUID) ([]pgtype.UUID, error)
+	ImportCharacter(ctx context.Context, arg ImportCharacterParams) (pgtype.UUID, error)
+	ImportPost(ctx context.Context, arg ImportPostParams) (pgtype.UUID, error)
+	ImportRoll(ctx context.Context, arg ImportRollParams) error
+	ImportScene(ctx context.Context, arg ImportSceneParams) (pgtype.UUID, error)
 	IncrementCampaignStorage(ctx context.Context, arg IncrementCampaignStorageParams) (int64, error)
 	IncrementSceneCount(ctx context.Context, id pgtype.UUID) error
 	InvalidateRoll(ctx context.Context, id pgtype.UUID) (Roll, error)
@@ -178,11 +236,35 @@ type Querier interface {
 	IsCharacterInScene(ctx context.Context, arg IsCharacterInSceneParams) (bool, error)
 	IsUserGM(ctx context.Context, arg IsUserGMParams) (bool, error)
 	ListActiveScenes(ctx context.Context, campaignID pgtype.UUID) ([]Scene, error)
+	ListCampaignBotTokens(ctx context.Context, campaignID pgtype.UUID) ([]CampaignBotToken, error)
 	ListCampaignCharacters(ctx context.Context, campaignID pgtype.UUID) ([]ListCampaignCharactersRow, error)
 	ListCampaignInvites(ctx context.Context, campaignID pgtype.UUID) ([]InviteLink, error)
+	// Returns the account email of each campaign member; only GMs may see these
+	ListCampaignMemberEmails(ctx context.Context, campaignID pgtype.UUID) ([]ListCampaignMemberEmailsRow, error)
+	// Returns a campaign's submitted posts oldest first, paged by the (created_at, id) of the last post seen.
+	ListCampaignPostsForExport(ctx context.Context, arg ListCampaignPostsForExportParams) ([]Post, error)
+	// Returns a campaign's rolls oldest first, paged by the (created_at, id) of the last roll seen.
+	ListCampaignRollsForExport(ctx context.Context, arg ListCampaignRollsForExportParams) ([]Roll, error)
 	ListCampaignScenes(ctx context.Context, campaignID pgtype.UUID) ([]Scene, error)
+	// Newest first
+	ListCharacterAvatarHistory(ctx context.Context, characterID pgtype.UUID) ([]CharacterAvatarHistory, error)
+	// Returns a character's rolls newest first, paged by the (created_at, id) of the last roll seen.
+	// Invalidated rolls are skipped unless include_invalidated is true.
+	ListCharacterRolls(ctx context.Context, arg ListCharacterRollsParams) ([]Roll, error)
+	// Returns the ids of the user's favorite scenes in a campaign
+	ListFavoriteSceneIDs(ctx context.Context, arg ListFavoriteSceneIDsParams) ([]pgtype.UUID, error)
+	// Returns a page of a campaign's audit log, newest first
+	ListGmAuditLog(ctx context.Context, arg ListGmAuditLogParams) ([]GmAuditLog, error)
 	ListHiddenPostsInScene(ctx context.Context, sceneID pgtype.UUID) ([]ListHiddenPostsInSceneRow, error)
-	ListRollsByScene(ctx context.Context, sceneID pgtype.UUID) ([]ListRollsBySceneRow, error)
+	// Returns reaction counts per emoji and whether the given user reacted with it
+	ListPostReactionCounts(ctx context.Context, arg ListPostReactionCountsParams) ([]ListPostReactionCountsRow, error)
+	// Status and character filters are optional; pass NULL to include all rolls
+	ListRollsByScene(ctx context.Context, arg ListRollsBySceneParams) ([]ListRollsBySceneRow, error)
+	// Returns character and submitted post counts plus the latest publish time per scene.
+	// For GMs every post counts; players only count posts witnessed by one of their characters.
+	ListSceneActivity(ctx context.Context, arg ListSceneActivityParams) ([]ListSceneActivityRow, error)
+	// Returns a scene's OOC messages oldest first; clients group replies by parent_id
+	ListSceneOocMessages(ctx context.Context, sceneID pgtype.UUID) ([]OocMessage, error)
 	ListScenePosts(ctx context.Context, sceneID pgtype.UUID) ([]ListScenePostsRow, error)
 	ListScenePostsForCharacter(ctx context.Context, arg ListScenePostsForCharacterParams) ([]ListScenePostsForCharacterRow, error)
 	// Cursor-based pagination for posts
@@ -190,6 +272,7 @@ type Querier interface {
 	ListUserCampaigns(ctx context.Context, userID pgtype.UUID) ([]ListUserCampaignsRow, error)
 	ListUserCharactersInCampaign(ctx context.Context, arg ListUserCharactersInCampaignParams) ([]ListUserCharactersInCampaignRow, error)
 	ListUserDrafts(ctx context.Context, userID pgtype.UUID) ([]ListUserDraftsRow, error)
+	ListUserProfiles(ctx context.Context, userIds []pgtype.UUID) ([]UserProfile, error)
 	LockPost(ctx context.Context, id pgtype.UUID) error
 	ManuallyResolveRoll(ctx context.Context, arg ManuallyResolveRollParams) (Roll, error)
 	MarkAllNotificationsAsRead(ctx context.Context, userID pgtype.UUID) (int64, error)
@@ -197,11 +280,24 @@ type Querier interface {
 	MarkNotificationAsRead(ctx context.Context, arg MarkNotificationAsReadParams) (Notification, error)
 	MarkNotificationEmailSent(ctx context.Context, id pgtype.UUID) error
 	MarkQueuedNotificationDelivered(ctx context.Context, id pgtype.UUID) error
+	// GM-only: Move a post to another scene with new witnesses; locks are fixed separately
+	MovePost(ctx context.Context, arg MovePostParams) (Post, error)
+	// Keeps a moved post's rolls in the post's new scene
+	MovePostRolls(ctx context.Context, arg MovePostRollsParams) error
 	OverrideRollIntention(ctx context.Context, arg OverrideRollIntentionParams) (Roll, error)
+	PinPost(ctx context.Context, id pgtype.UUID) (Post, error)
+	// Removes drafts whose author left the campaign, whose character left the scene,
+	// or whose character is no longer assigned to the (non-GM) author
+	PurgeInaccessibleDrafts(ctx context.Context) (int64, error)
+	// Drops removed content older than the restore window
+	PurgeRemovedPostContents(ctx context.Context, removedAt pgtype.Timestamptz) (int64, error)
 	// ============================================
 	// NOTIFICATION QUEUE QUERIES
 	// ============================================
 	QueueNotification(ctx context.Context, arg QueueNotificationParams) (NotificationQueue, error)
+	// Rewrites unread counters that have drifted from the notifications table and returns how many
+	// were fixed. Counters changed while this runs are left for the next pass.
+	ReconcileNotificationUnreadCounts(ctx context.Context) (int64, error)
 	// ============================================
 	// EMAIL DIGEST QUERIES
 	// ============================================
@@ -209,27 +305,62 @@ type Querier interface {
 	RemoveCampaignMember(ctx context.Context, arg RemoveCampaignMemberParams) error
 	RemoveCharacterFromAllScenes(ctx context.Context, arg RemoveCharacterFromAllScenesParams) error
 	RemoveCharacterFromScene(ctx context.Context, arg RemoveCharacterFromSceneParams) (Scene, error)
+	RemovePostReaction(ctx context.Context, arg RemovePostReactionParams) error
+	RemoveSceneFavorite(ctx context.Context, arg RemoveSceneFavoriteParams) error
+	// Releases a claimed queue entry to be delivered again later.
+	RequeueNotification(ctx context.Context, arg RequeueNotificationParams) error
 	ResetAllPassStatesInCampaign(ctx context.Context, campaignID pgtype.UUID) error
 	ResetAllPassStatesInScene(ctx context.Context, id pgtype.UUID) (Scene, error)
+	// Clears storage usage driven below zero by double-released files
+	ResetNegativeCampaignStorage(ctx context.Context) (int64, error)
+	// Puts back the content saved by SaveRemovedPostContent; no row if it was purged
+	RestorePost(ctx context.Context, id pgtype.UUID) (Post, error)
+	RevokeCampaignBotToken(ctx context.Context, arg RevokeCampaignBotTokenParams) (int64, error)
 	RevokeInvite(ctx context.Context, arg RevokeInviteParams) (InviteLink, error)
+	// Copies a post's content aside before TombstonePost blanks it
+	SaveRemovedPostContent(ctx context.Context, id pgtype.UUID) error
+	SetCampaignStorageLimit(ctx context.Context, arg SetCampaignStorageLimitParams) (CampaignStorageLimit, error)
 	SetCharacterPassState(ctx context.Context, arg SetCharacterPassStateParams) (Scene, error)
+	SetCharacterTags(ctx context.Context, arg SetCharacterTagsParams) (Character, error)
+	SetPostMentions(ctx context.Context, arg SetPostMentionsParams) error
+	SetSceneCharacterOrder(ctx context.Context, arg SetSceneCharacterOrderParams) (Scene, error)
+	SetSceneDefaultWitnessMode(ctx context.Context, arg SetSceneDefaultWitnessModeParams) (Scene, error)
+	SetSceneFogOverride(ctx context.Context, arg SetSceneFogOverrideParams) (Scene, error)
+	// A NULL summary clears it
+	SetSceneSummary(ctx context.Context, arg SetSceneSummaryParams) (Scene, error)
+	// Replaces the avatar and the storage it uses; a NULL avatar_url clears it
+	SetUserProfileAvatar(ctx context.Context, arg SetUserProfileAvatarParams) (UserProfile, error)
+	SnoozeNotification(ctx context.Context, arg SnoozeNotificationParams) (Notification, error)
 	SubmitPost(ctx context.Context, arg SubmitPostParams) (Post, error)
+	// GM-only: Replace a post with a placeholder, keeping its place and lock state; also unpins it.
+	// The content is blanked, so SaveRemovedPostContent must run first.
+	TombstonePost(ctx context.Context, arg TombstonePostParams) (Post, error)
+	TouchBotTokenLastUsed(ctx context.Context, id pgtype.UUID) error
 	TransitionCampaignPhase(ctx context.Context, arg TransitionCampaignPhaseParams) (Campaign, error)
+	// Held until the surrounding transaction ends, so only one instance expires time gates at a time
+	TryTimeGateWorkerLock(ctx context.Context) (bool, error)
 	UnarchiveCharacter(ctx context.Context, id pgtype.UUID) (Character, error)
 	UnarchiveScene(ctx context.Context, id pgtype.UUID) (Scene, error)
 	UnassignCharacter(ctx context.Context, characterID pgtype.UUID) error
+	// Removes every assignment a user holds in a campaign and returns the released characters
+	UnassignUserCharactersInCampaign(ctx context.Context, arg UnassignUserCharactersInCampaignParams) ([]UnassignUserCharactersInCampaignRow, error)
+	UnfreezeScene(ctx context.Context, id pgtype.UUID) (Scene, error)
 	// GM can unhide a post and set specific witnesses
 	UnhidePostWithCustomWitnesses(ctx context.Context, arg UnhidePostWithCustomWitnessesParams) (Post, error)
 	UnlockPost(ctx context.Context, id pgtype.UUID) error
+	UnpinPost(ctx context.Context, id pgtype.UUID) (Post, error)
 	UpdateCampaign(ctx context.Context, arg UpdateCampaignParams) (Campaign, error)
 	UpdateCampaignOwner(ctx context.Context, arg UpdateCampaignOwnerParams) (Campaign, error)
 	UpdateCampaignPausedState(ctx context.Context, arg UpdateCampaignPausedStateParams) (Campaign, error)
 	UpdateCampaignPhase(ctx context.Context, arg UpdateCampaignPhaseParams) error
+	UpdateCampaignSettings(ctx context.Context, arg UpdateCampaignSettingsParams) (Campaign, error)
 	UpdateCharacter(ctx context.Context, arg UpdateCharacterParams) (Character, error)
 	UpdateCharacterAvatar(ctx context.Context, arg UpdateCharacterAvatarParams) (Character, error)
 	UpdateComposeDraft(ctx context.Context, arg UpdateComposeDraftParams) (ComposeDraft, error)
 	UpdateComposeLockActivity(ctx context.Context, arg UpdateComposeLockActivityParams) error
 	UpdateComposeLockHidden(ctx context.Context, arg UpdateComposeLockHiddenParams) error
+	// Refreshes the given locks owned by the user, skipping any they do not own
+	UpdateComposeLocksActivity(ctx context.Context, arg UpdateComposeLocksActivityParams) ([]UpdateComposeLocksActivityRow, error)
 	UpdateGmActivity(ctx context.Context, id pgtype.UUID) error
 	UpdateMemberRole(ctx context.Context, arg UpdateMemberRoleParams) error
 	UpdatePost(ctx context.Context, arg UpdatePostParams) (Post, error)
@@ -238,9 +369,16 @@ type Querier interface {
 	UpdateScene(ctx context.Context, arg UpdateSceneParams) (Scene, error)
 	UpdateSceneHeaderImage(ctx context.Context, arg UpdateSceneHeaderImageParams) (Scene, error)
 	UpdateScenePassStates(ctx context.Context, arg UpdateScenePassStatesParams) (Scene, error)
+	// Sets each scene's sort_order to its position in the given id list
+	UpdateSceneSortOrders(ctx context.Context, arg UpdateSceneSortOrdersParams) error
 	UpsertComposeDraft(ctx context.Context, arg UpsertComposeDraftParams) (ComposeDraft, error)
+	// Saves only the draft blocks, keeping any existing OOC text, intention and modifier
+	UpsertComposeDraftBlocks(ctx context.Context, arg UpsertComposeDraftBlocksParams) error
 	UpsertNotificationPreferences(ctx context.Context, arg UpsertNotificationPreferencesParams) (NotificationPreference, error)
 	UpsertQuietHours(ctx context.Context, arg UpsertQuietHoursParams) (QuietHour, error)
+	// Moves the user's read marker forward; older markers are ignored
+	UpsertSceneRead(ctx context.Context, arg UpsertSceneReadParams) error
+	UpsertUserProfileDisplayName(ctx context.Context, arg UpsertUserProfileDisplayNameParams) (UserProfile, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -40,6 +40,29 @@ func (q *Queries) CountPendingRollsForCharacter(ctx context.Context, characterID
 	return count, err
 }
 
+const countUnresolvedRollsInCampaign = `-- name: CountUnresolvedRollsInCampaign :one
+SELECT COUNT(*)
+FROM rolls r
+JOIN characters c ON c.id = r.character_id
+JOIN scenes s ON s.id = r.scene_id
+WHERE s.campaign_id = $1
+  AND r.status = 'pending'
+  AND ($2::timestamptz IS NULL OR r.created_at >= $2)
+`
+
+type CountUnresolvedRollsInCampaignParams struct {
+	CampaignID pgtype.UUID        `json:"campaign_id"`
+	Column2    pgtype.Timestamptz `json:"column_2"`
+}
+
+// Counts the rows GetUnresolvedRollsInCampaign would return without pagination
+func (q *Queries) CountUnresolvedRollsInCampaign(ctx context.Context, arg CountUnresolvedRollsInCampaignParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countUnresolvedRollsInCampaign, arg.CampaignID, arg.Column2)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createRoll = `-- name: CreateRoll :one
 
 INSERT INTO rolls (
@@ -51,20 +74,24 @@ INSERT INTO rolls (
     modifier,
     dice_type,
     dice_count,
+    success_threshold,
+    note,
     status
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'pending')
-RETURNING id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 'pending')
+RETURNING id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at, success_threshold, successes, note
 `
 
 type CreateRollParams struct {
-	PostID      pgtype.UUID `json:"post_id"`
-	SceneID     pgtype.UUID `json:"scene_id"`
-	CharacterID pgtype.UUID `json:"character_id"`
-	RequestedBy pgtype.UUID `json:"requested_by"`
-	Intention   string      `json:"intention"`
-	Modifier    int32       `json:"modifier"`
-	DiceType    string      `json:"dice_type"`
-	DiceCount   int32       `json:"dice_count"`
+	PostID           pgtype.UUID `json:"post_id"`
+	SceneID          pgtype.UUID `json:"scene_id"`
+	CharacterID      pgtype.UUID `json:"character_id"`
+	RequestedBy      pgtype.UUID `json:"requested_by"`
+	Intention        string      `json:"intention"`
+	Modifier         int32       `json:"modifier"`
+	DiceType         string      `json:"dice_type"`
+	DiceCount        int32       `json:"dice_count"`
+	SuccessThreshold pgtype.Int4 `json:"success_threshold"`
+	Note             pgtype.Text `json:"note"`
 }
 
 // ============================================
@@ -80,6 +107,8 @@ func (q *Queries) CreateRoll(ctx context.Context, arg CreateRollParams) (Roll, e
 		arg.Modifier,
 		arg.DiceType,
 		arg.DiceCount,
+		arg.SuccessThreshold,
+		arg.Note,
 	)
 	var i Roll
 	err := row.Scan(
@@ -105,6 +134,9 @@ func (q *Queries) CreateRoll(ctx context.Context, arg CreateRollParams) (Roll, e
 		&i.ManuallyResolvedBy,
 		&i.ManualResolutionReason,
 		&i.RolledAt,
+		&i.SuccessThreshold,
+		&i.Successes,
+		&i.Note,
 	)
 	return i, err
 }
@@ -123,20 +155,27 @@ UPDATE rolls
 SET
     result = $2,
     total = $3,
+    successes = $4,
     rolled_at = NOW(),
     status = 'completed'
 WHERE id = $1
-RETURNING id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at
+RETURNING id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at, success_threshold, successes, note
 `
 
 type ExecuteRollParams struct {
-	ID     pgtype.UUID `json:"id"`
-	Result []int32     `json:"result"`
-	Total  pgtype.Int4 `json:"total"`
+	ID        pgtype.UUID `json:"id"`
+	Result    []int32     `json:"result"`
+	Total     pgtype.Int4 `json:"total"`
+	Successes pgtype.Int4 `json:"successes"`
 }
 
 func (q *Queries) ExecuteRoll(ctx context.Context, arg ExecuteRollParams) (Roll, error) {
-	row := q.db.QueryRow(ctx, executeRoll, arg.ID, arg.Result, arg.Total)
+	row := q.db.QueryRow(ctx, executeRoll,
+		arg.ID,
+		arg.Result,
+		arg.Total,
+		arg.Successes,
+	)
 	var i Roll
 	err := row.Scan(
 		&i.ID,
@@ -161,12 +200,15 @@ func (q *Queries) ExecuteRoll(ctx context.Context, arg ExecuteRollParams) (Roll,
 		&i.ManuallyResolvedBy,
 		&i.ManualResolutionReason,
 		&i.RolledAt,
+		&i.SuccessThreshold,
+		&i.Successes,
+		&i.Note,
 	)
 	return i, err
 }
 
 const getPendingRollsForCharacter = `-- name: GetPendingRollsForCharacter :many
-SELECT r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at
+SELECT r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at, r.success_threshold, r.successes, r.note
 FROM rolls r
 WHERE r.character_id = $1
   AND r.status = 'pending'
@@ -205,6 +247,9 @@ func (q *Queries) GetPendingRollsForCharacter(ctx context.Context, characterID p
 			&i.ManuallyResolvedBy,
 			&i.ManualResolutionReason,
 			&i.RolledAt,
+			&i.SuccessThreshold,
+			&i.Successes,
+			&i.Note,
 		); err != nil {
 			return nil, err
 		}
@@ -218,7 +263,7 @@ func (q *Queries) GetPendingRollsForCharacter(ctx context.Context, characterID p
 
 const getPendingRollsInScene = `-- name: GetPendingRollsInScene :many
 SELECT
-    r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at,
+    r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at, r.success_threshold, r.successes, r.note,
     c.display_name AS character_name
 FROM rolls r
 JOIN characters c ON c.id = r.character_id
@@ -250,6 +295,9 @@ type GetPendingRollsInSceneRow struct {
 	ManuallyResolvedBy     pgtype.UUID        `json:"manually_resolved_by"`
 	ManualResolutionReason pgtype.Text        `json:"manual_resolution_reason"`
 	RolledAt               pgtype.Timestamptz `json:"rolled_at"`
+	SuccessThreshold       pgtype.Int4        `json:"success_threshold"`
+	Successes              pgtype.Int4        `json:"successes"`
+	Note                   pgtype.Text        `json:"note"`
 	CharacterName          string             `json:"character_name"`
 }
 
@@ -285,6 +333,9 @@ func (q *Queries) GetPendingRollsInScene(ctx context.Context, sceneID pgtype.UUI
 			&i.ManuallyResolvedBy,
 			&i.ManualResolutionReason,
 			&i.RolledAt,
+			&i.SuccessThreshold,
+			&i.Successes,
+			&i.Note,
 			&i.CharacterName,
 		); err != nil {
 			return nil, err
@@ -298,7 +349,7 @@ func (q *Queries) GetPendingRollsInScene(ctx context.Context, sceneID pgtype.UUI
 }
 
 const getRoll = `-- name: GetRoll :one
-SELECT id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at FROM rolls WHERE id = $1
+SELECT id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at, success_threshold, successes, note FROM rolls WHERE id = $1
 `
 
 func (q *Queries) GetRoll(ctx context.Context, id pgtype.UUID) (Roll, error) {
@@ -327,6 +378,9 @@ func (q *Queries) GetRoll(ctx context.Context, id pgtype.UUID) (Roll, error) {
 		&i.ManuallyResolvedBy,
 		&i.ManualResolutionReason,
 		&i.RolledAt,
+		&i.SuccessThreshold,
+		&i.Successes,
+		&i.Note,
 	)
 	return i, err
 }
@@ -356,7 +410,7 @@ func (q *Queries) GetRollCountByStatus(ctx context.Context, campaignID pgtype.UU
 
 const getRollWithCharacter = `-- name: GetRollWithCharacter :one
 SELECT
-    r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at,
+    r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at, r.success_threshold, r.successes, r.note,
     c.display_name AS character_name
 FROM rolls r
 LEFT JOIN characters c ON r.character_id = c.id
@@ -386,6 +440,9 @@ type GetRollWithCharacterRow struct {
 	ManuallyResolvedBy     pgtype.UUID        `json:"manually_resolved_by"`
 	ManualResolutionReason pgtype.Text        `json:"manual_resolution_reason"`
 	RolledAt               pgtype.Timestamptz `json:"rolled_at"`
+	SuccessThreshold       pgtype.Int4        `json:"success_threshold"`
+	Successes              pgtype.Int4        `json:"successes"`
+	Note                   pgtype.Text        `json:"note"`
 	CharacterName          pgtype.Text        `json:"character_name"`
 }
 
@@ -415,13 +472,16 @@ func (q *Queries) GetRollWithCharacter(ctx context.Context, id pgtype.UUID) (Get
 		&i.ManuallyResolvedBy,
 		&i.ManualResolutionReason,
 		&i.RolledAt,
+		&i.SuccessThreshold,
+		&i.Successes,
+		&i.Note,
 		&i.CharacterName,
 	)
 	return i, err
 }
 
 const getRollsByPost = `-- name: GetRollsByPost :many
-SELECT id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at FROM rolls
+SELECT id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at, success_threshold, successes, note FROM rolls
 WHERE post_id = $1
 ORDER BY created_at ASC
 `
@@ -458,6 +518,9 @@ func (q *Queries) GetRollsByPost(ctx context.Context, postID pgtype.UUID) ([]Rol
 			&i.ManuallyResolvedBy,
 			&i.ManualResolutionReason,
 			&i.RolledAt,
+			&i.SuccessThreshold,
+			&i.Successes,
+			&i.Note,
 		); err != nil {
 			return nil, err
 		}
@@ -471,7 +534,7 @@ func (q *Queries) GetRollsByPost(ctx context.Context, postID pgtype.UUID) ([]Rol
 
 const getRollsByPostWithCharacter = `-- name: GetRollsByPostWithCharacter :many
 SELECT
-    r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at,
+    r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at, r.success_threshold, r.successes, r.note,
     c.display_name AS character_name
 FROM rolls r
 LEFT JOIN characters c ON r.character_id = c.id
@@ -502,6 +565,9 @@ type GetRollsByPostWithCharacterRow struct {
 	ManuallyResolvedBy     pgtype.UUID        `json:"manually_resolved_by"`
 	ManualResolutionReason pgtype.Text        `json:"manual_resolution_reason"`
 	RolledAt               pgtype.Timestamptz `json:"rolled_at"`
+	SuccessThreshold       pgtype.Int4        `json:"success_threshold"`
+	Successes              pgtype.Int4        `json:"successes"`
+	Note                   pgtype.Text        `json:"note"`
 	CharacterName          pgtype.Text        `json:"character_name"`
 }
 
@@ -537,6 +603,9 @@ func (q *Queries) GetRollsByPostWithCharacter(ctx context.Context, postID pgtype
 			&i.ManuallyResolvedBy,
 			&i.ManualResolutionReason,
 			&i.RolledAt,
+			&i.SuccessThreshold,
+			&i.Successes,
+			&i.Note,
 			&i.CharacterName,
 		); err != nil {
 			return nil, err
@@ -551,7 +620,7 @@ func (q *Queries) GetRollsByPostWithCharacter(ctx context.Context, postID pgtype
 
 const getRollsInSceneByStatus = `-- name: GetRollsInSceneByStatus :many
 SELECT
-    r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at,
+    r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at, r.success_threshold, r.successes, r.note,
     c.display_name AS character_name
 FROM rolls r
 LEFT JOIN characters c ON r.character_id = c.id
@@ -588,6 +657,9 @@ type GetRollsInSceneByStatusRow struct {
 	ManuallyResolvedBy     pgtype.UUID        `json:"manually_resolved_by"`
 	ManualResolutionReason pgtype.Text        `json:"manual_resolution_reason"`
 	RolledAt               pgtype.Timestamptz `json:"rolled_at"`
+	SuccessThreshold       pgtype.Int4        `json:"success_threshold"`
+	Successes              pgtype.Int4        `json:"successes"`
+	Note                   pgtype.Text        `json:"note"`
 	CharacterName          pgtype.Text        `json:"character_name"`
 }
 
@@ -623,6 +695,9 @@ func (q *Queries) GetRollsInSceneByStatus(ctx context.Context, arg GetRollsInSce
 			&i.ManuallyResolvedBy,
 			&i.ManualResolutionReason,
 			&i.RolledAt,
+			&i.SuccessThreshold,
+			&i.Successes,
+			&i.Note,
 			&i.CharacterName,
 		); err != nil {
 			return nil, err
@@ -648,7 +723,7 @@ func (q *Queries) GetSceneIDForRoll(ctx context.Context, id pgtype.UUID) (pgtype
 
 const getUnresolvedRollsInCampaign = `-- name: GetUnresolvedRollsInCampaign :many
 SELECT
-    r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at,
+    r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at, r.success_threshold, r.successes, r.note,
     c.display_name AS character_name,
     s.title AS scene_title,
     p.blocks AS post_content
@@ -658,9 +733,18 @@ JOIN scenes s ON s.id = r.scene_id
 LEFT JOIN posts p ON p.id = r.post_id
 WHERE s.campaign_id = $1
   AND r.status = 'pending'
+  AND ($2::timestamptz IS NULL OR r.created_at >= $2)
 ORDER BY r.created_at ASC
+LIMIT $3 OFFSET $4
 `
 
+type GetUnresolvedRollsInCampaignParams struct {
+	CampaignID pgtype.UUID        `json:"campaign_id"`
+	Column2    pgtype.Timestamptz `json:"column_2"`
+	Limit      int32              `json:"limit"`
+	Offset     int32              `json:"offset"`
+}
+
 type GetUnresolvedRollsInCampaignRow struct {
 	ID                     pgtype.UUID        `json:"id"`
 	PostID                 pgtype.UUID        `json:"post_id"`
@@ -684,13 +768,21 @@ type GetUnresolvedRollsInCampaignRow struct {
 	ManuallyResolvedBy     pgtype.UUID        `json:"manually_resolved_by"`
 	ManualResolutionReason pgtype.Text        `json:"manual_resolution_reason"`
 	RolledAt               pgtype.Timestamptz `json:"rolled_at"`
+	SuccessThreshold       pgtype.Int4        `json:"success_threshold"`
+	Successes              pgtype.Int4        `json:"successes"`
+	Note                   pgtype.Text        `json:"note"`
 	CharacterName          string             `json:"character_name"`
 	SceneTitle             string             `json:"scene_title"`
 	PostContent            []byte             `json:"post_content"`
 }
 
-func (q *Queries) GetUnresolvedRollsInCampaign(ctx context.Context, campaignID pgtype.UUID) ([]GetUnresolvedRollsInCampaignRow, error) {
-	rows, err := q.db.Query(ctx, getUnresolvedRollsInCampaign, campaignID)
+func (q *Queries) GetUnresolvedRollsInCampaign(ctx context.Context, arg GetUnresolvedRollsInCampaignParams) ([]GetUnresolvedRollsInCampaignRow, error) {
+	rows, err := q.db.Query(ctx, getUnresolvedRollsInCampaign,
+		arg.CampaignID,
+		arg.Column2,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -721,6 +813,9 @@ func (q *Queries) GetUnresolvedRollsInCampaign(ctx context.Context, campaignID p
 			&i.ManuallyResolvedBy,
 			&i.ManualResolutionReason,
 			&i.RolledAt,
+			&i.SuccessThreshold,
+			&i.Successes,
+			&i.Note,
 			&i.CharacterName,
 			&i.SceneTitle,
 			&i.PostContent,
@@ -739,7 +834,7 @@ const invalidateRoll = `-- name: InvalidateRoll :one
 UPDATE rolls
 SET status = 'invalidated'
 WHERE id = $1
-RETURNING id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at
+RETURNING id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at, success_threshold, successes, note
 `
 
 func (q *Queries) InvalidateRoll(ctx context.Context, id pgtype.UUID) (Roll, error) {
@@ -768,20 +863,108 @@ func (q *Queries) InvalidateRoll(ctx context.Context, id pgtype.UUID) (Roll, err
 		&i.ManuallyResolvedBy,
 		&i.ManualResolutionReason,
 		&i.RolledAt,
+		&i.SuccessThreshold,
+		&i.Successes,
+		&i.Note,
 	)
 	return i, err
 }
 
+const listCharacterRolls = `-- name: ListCharacterRolls :many
+SELECT r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at, r.success_threshold, r.successes, r.note
+FROM rolls r
+WHERE r.character_id = $1
+  AND ($2::boolean OR r.status != 'invalidated')
+  AND (
+    $3::timestamptz IS NULL
+    OR (r.created_at, r.id) < ($3::timestamptz, $4::uuid)
+  )
+ORDER BY r.created_at DESC, r.id DESC
+LIMIT $5
+`
+
+type ListCharacterRollsParams struct {
+	CharacterID        pgtype.UUID        `json:"character_id"`
+	IncludeInvalidated bool               `json:"include_invalidated"`
+	CursorCreatedAt    pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID           pgtype.UUID        `json:"cursor_id"`
+	RowLimit           int32              `json:"row_limit"`
+}
+
+// Returns a character's rolls newest first, paged by the (created_at, id) of the last roll seen.
+// Invalidated rolls are skipped unless include_invalidated is true.
+func (q *Queries) ListCharacterRolls(ctx context.Context, arg ListCharacterRollsParams) ([]Roll, error) {
+	rows, err := q.db.Query(ctx, listCharacterRolls,
+		arg.CharacterID,
+		arg.IncludeInvalidated,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Roll
+	for rows.Next() {
+		var i Roll
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.SceneID,
+			&i.CharacterID,
+			&i.RequestedBy,
+			&i.Intention,
+			&i.Modifier,
+			&i.DiceType,
+			&i.DiceCount,
+			&i.Result,
+			&i.Total,
+			&i.WasOverridden,
+			&i.OriginalIntention,
+			&i.Status,
+			&i.CreatedAt,
+			&i.OverriddenBy,
+			&i.OverrideReason,
+			&i.OverrideTimestamp,
+			&i.ManualResult,
+			&i.ManuallyResolvedBy,
+			&i.ManualResolutionReason,
+			&i.RolledAt,
+			&i.SuccessThreshold,
+			&i.Successes,
+			&i.Note,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRollsByScene = `-- name: ListRollsByScene :many
 SELECT
-    r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at,
-    c.display_name AS character_name
+    r.id, r.post_id, r.scene_id, r.character_id, r.requested_by, r.intention, r.modifier, r.dice_type, r.dice_count, r.result, r.total, r.was_overridden, r.original_intention, r.status, r.created_at, r.overridden_by, r.override_reason, r.override_timestamp, r.manual_result, r.manually_resolved_by, r.manual_resolution_reason, r.rolled_at, r.success_threshold, r.successes, r.note,
+    c.display_name AS character_name,
+    p.witnesses AS post_witnesses
 FROM rolls r
 LEFT JOIN characters c ON r.character_id = c.id
+LEFT JOIN posts p ON r.post_id = p.id
 WHERE r.scene_id = $1
+  AND ($2::roll_status IS NULL OR r.status = $2::roll_status)
+  AND ($3::uuid IS NULL OR r.character_id = $3::uuid)
 ORDER BY r.created_at DESC
 `
 
+type ListRollsBySceneParams struct {
+	SceneID     pgtype.UUID    `json:"scene_id"`
+	Status      NullRollStatus `json:"status"`
+	CharacterID pgtype.UUID    `json:"character_id"`
+}
+
 type ListRollsBySceneRow struct {
 	ID                     pgtype.UUID        `json:"id"`
 	PostID                 pgtype.UUID        `json:"post_id"`
@@ -805,11 +988,16 @@ type ListRollsBySceneRow struct {
 	ManuallyResolvedBy     pgtype.UUID        `json:"manually_resolved_by"`
 	ManualResolutionReason pgtype.Text        `json:"manual_resolution_reason"`
 	RolledAt               pgtype.Timestamptz `json:"rolled_at"`
+	SuccessThreshold       pgtype.Int4        `json:"success_threshold"`
+	Successes              pgtype.Int4        `json:"successes"`
+	Note                   pgtype.Text        `json:"note"`
 	CharacterName          pgtype.Text        `json:"character_name"`
+	PostWitnesses          []pgtype.UUID      `json:"post_witnesses"`
 }
 
-func (q *Queries) ListRollsByScene(ctx context.Context, sceneID pgtype.UUID) ([]ListRollsBySceneRow, error) {
-	rows, err := q.db.Query(ctx, listRollsByScene, sceneID)
+// Status and character filters are optional; pass NULL to include all rolls
+func (q *Queries) ListRollsByScene(ctx context.Context, arg ListRollsBySceneParams) ([]ListRollsBySceneRow, error) {
+	rows, err := q.db.Query(ctx, listRollsByScene, arg.SceneID, arg.Status, arg.CharacterID)
 	if err != nil {
 		return nil, err
 	}
@@ -840,7 +1028,11 @@ func (q *Queries) ListRollsByScene(ctx context.Context, sceneID pgtype.UUID) ([]
 			&i.ManuallyResolvedBy,
 			&i.ManualResolutionReason,
 			&i.RolledAt,
+			&i.SuccessThreshold,
+			&i.Successes,
+			&i.Note,
 			&i.CharacterName,
+			&i.PostWitnesses,
 		); err != nil {
 			return nil, err
 		}
@@ -862,7 +1054,7 @@ SET
     status = 'completed',
     rolled_at = NOW()
 WHERE id = $1
-RETURNING id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at
+RETURNING id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at, success_threshold, successes, note
 `
 
 type ManuallyResolveRollParams struct {
@@ -903,10 +1095,30 @@ func (q *Queries) ManuallyResolveRoll(ctx context.Context, arg ManuallyResolveRo
 		&i.ManuallyResolvedBy,
 		&i.ManualResolutionReason,
 		&i.RolledAt,
+		&i.SuccessThreshold,
+		&i.Successes,
+		&i.Note,
 	)
 	return i, err
 }
 
+const movePostRolls = `-- name: MovePostRolls :exec
+UPDATE rolls
+SET scene_id = $2
+WHERE post_id = $1
+`
+
+type MovePostRollsParams struct {
+	PostID  pgtype.UUID `json:"post_id"`
+	SceneID pgtype.UUID `json:"scene_id"`
+}
+
+// Keeps a moved post's rolls in the post's new scene
+func (q *Queries) MovePostRolls(ctx context.Context, arg MovePostRollsParams) error {
+	_, err := q.db.Exec(ctx, movePostRolls, arg.PostID, arg.SceneID)
+	return err
+}
+
 const overrideRollIntention = `-- name: OverrideRollIntention :one
 UPDATE rolls
 SET
@@ -917,7 +1129,7 @@ SET
     override_reason = $4,
     override_timestamp = NOW()
 WHERE id = $1
-RETURNING id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at
+RETURNING id, post_id, scene_id, character_id, requested_by, intention, modifier, dice_type, dice_count, result, total, was_overridden, original_intention, status, created_at, overridden_by, override_reason, override_timestamp, manual_result, manually_resolved_by, manual_resolution_reason, rolled_at, success_threshold, successes, note
 `
 
 type OverrideRollIntentionParams struct {
@@ -958,6 +1170,9 @@ func (q *Queries) OverrideRollIntention(ctx context.Context, arg OverrideRollInt
 		&i.ManuallyResolvedBy,
 		&i.ManualResolutionReason,
 		&i.RolledAt,
+		&i.SuccessThreshold,
+		&i.Successes,
+		&i.Note,
 	)
 	return i, err
 }
@@ -18,7 +18,7 @@ SET
     character_ids = array_append(character_ids, $2::uuid),
     updated_at = NOW()
 WHERE id = $1 AND NOT ($2::uuid = ANY(character_ids))
-RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
 `
 
 type AddCharacterToSceneParams struct {
@@ -40,6 +40,12 @@ func (q *Queries) AddCharacterToScene(ctx context.Context, arg AddCharacterToSce
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
@@ -50,7 +56,7 @@ SET
     is_archived = true,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
 `
 
 func (q *Queries) ArchiveScene(ctx context.Context, id pgtype.UUID) (Scene, error) {
@@ -67,6 +73,12 @@ func (q *Queries) ArchiveScene(ctx context.Context, id pgtype.UUID) (Scene, erro
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
@@ -102,7 +114,7 @@ SET
     pass_states = pass_states - $2::text,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
 `
 
 type ClearCharacterPassStateParams struct {
@@ -124,6 +136,12 @@ func (q *Queries) ClearCharacterPassState(ctx context.Context, arg ClearCharacte
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
@@ -134,7 +152,7 @@ SET
     header_image_url = NULL,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
 `
 
 func (q *Queries) ClearSceneHeaderImage(ctx context.Context, id pgtype.UUID) (Scene, error) {
@@ -151,6 +169,12 @@ func (q *Queries) ClearSceneHeaderImage(ctx context.Context, id pgtype.UUID) (Sc
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
@@ -229,11 +253,13 @@ const createScene = `-- name: CreateScene :one
 INSERT INTO scenes (
     campaign_id,
     title,
-    description
+    description,
+    sort_order
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3,
+    (SELECT COALESCE(MAX(sort_order), -1) + 1 FROM scenes WHERE campaign_id = $1)
 )
-RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
 `
 
 type CreateSceneParams struct {
@@ -256,6 +282,12 @@ func (q *Queries) CreateScene(ctx context.Context, arg CreateSceneParams) (Scene
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
@@ -282,6 +314,39 @@ func (q *Queries) DeleteScene(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const freezeScene = `-- name: FreezeScene :one
+UPDATE scenes
+SET
+    is_frozen = true,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
+`
+
+func (q *Queries) FreezeScene(ctx context.Context, id pgtype.UUID) (Scene, error) {
+	row := q.db.QueryRow(ctx, freezeScene, id)
+	var i Scene
+	err := row.Scan(
+		&i.ID,
+		&i.CampaignID,
+		&i.Title,
+		&i.Description,
+		&i.HeaderImageUrl,
+		&i.CharacterIds,
+		&i.PassStates,
+		&i.IsArchived,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
+	)
+	return i, err
+}
+
 const getActiveCharactersInCampaign = `-- name: GetActiveCharactersInCampaign :many
 SELECT DISTINCT c.id, c.display_name, c.campaign_id, ca.user_id AS assigned_user_id
 FROM characters c
@@ -330,7 +395,7 @@ func (q *Queries) GetActiveCharactersInCampaign(ctx context.Context, campaignID
 }
 
 const getAllActiveScenesInCampaign = `-- name: GetAllActiveScenesInCampaign :many
-SELECT id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at FROM scenes
+SELECT id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override FROM scenes
 WHERE campaign_id = $1 AND is_archived = false
 ORDER BY created_at
 `
@@ -356,6 +421,12 @@ func (q *Queries) GetAllActiveScenesInCampaign(ctx context.Context, campaignID p
 			&i.IsArchived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SortOrder,
+			&i.CharacterOrder,
+			&i.IsFrozen,
+			&i.DefaultWitnessMode,
+			&i.Summary,
+			&i.FogOverride,
 		); err != nil {
 			return nil, err
 		}
@@ -444,7 +515,7 @@ func (q *Queries) GetCharacterPassStatus(ctx context.Context, id pgtype.UUID) (G
 }
 
 const getOldestArchivedScene = `-- name: GetOldestArchivedScene :one
-SELECT id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at FROM scenes
+SELECT id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override FROM scenes
 WHERE campaign_id = $1 AND is_archived = true
 ORDER BY updated_at ASC
 LIMIT 1
@@ -464,6 +535,12 @@ func (q *Queries) GetOldestArchivedScene(ctx context.Context, campaignID pgtype.
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
@@ -499,7 +576,7 @@ func (q *Queries) GetPresentCharactersInScene(ctx context.Context, id pgtype.UUI
 }
 
 const getScene = `-- name: GetScene :one
-SELECT id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at FROM scenes WHERE id = $1
+SELECT id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override FROM scenes WHERE id = $1
 `
 
 func (q *Queries) GetScene(ctx context.Context, id pgtype.UUID) (Scene, error) {
@@ -516,6 +593,12 @@ func (q *Queries) GetScene(ctx context.Context, id pgtype.UUID) (Scene, error) {
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
@@ -532,13 +615,15 @@ func (q *Queries) GetSceneCampaignID(ctx context.Context, id pgtype.UUID) (pgtyp
 }
 
 const getSceneCharacters = `-- name: GetSceneCharacters :many
-SELECT c.id, c.campaign_id, c.display_name, c.description, c.avatar_url, c.character_type, c.is_archived, c.created_at, c.updated_at, ca.user_id AS assigned_user_id, ca.assigned_at
+SELECT c.id, c.campaign_id, c.display_name, c.description, c.avatar_url, c.character_type, c.is_archived, c.created_at, c.updated_at, c.pronouns, c.gm_notes, c.tags, ca.user_id AS assigned_user_id, ca.assigned_at
 FROM characters c
 LEFT JOIN character_assignments ca ON c.id = ca.character_id
 WHERE c.id = ANY(
     SELECT unnest(character_ids) FROM scenes WHERE scenes.id = $1
 )
-ORDER BY c.display_name
+ORDER BY
+    array_position((SELECT character_order FROM scenes WHERE scenes.id = $1), c.id) NULLS LAST,
+    c.display_name
 `
 
 type GetSceneCharactersRow struct {
@@ -551,6 +636,9 @@ type GetSceneCharactersRow struct {
 	IsArchived     bool               `json:"is_archived"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Pronouns       pgtype.Text        `json:"pronouns"`
+	GmNotes        pgtype.Text        `json:"gm_notes"`
+	Tags           []string           `json:"tags"`
 	AssignedUserID pgtype.UUID        `json:"assigned_user_id"`
 	AssignedAt     pgtype.Timestamptz `json:"assigned_at"`
 }
@@ -574,6 +662,9 @@ func (q *Queries) GetSceneCharacters(ctx context.Context, id pgtype.UUID) ([]Get
 			&i.IsArchived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Pronouns,
+			&i.GmNotes,
+			&i.Tags,
 			&i.AssignedUserID,
 			&i.AssignedAt,
 		); err != nil {
@@ -604,7 +695,7 @@ func (q *Queries) GetScenePassStates(ctx context.Context, id pgtype.UUID) (json.
 
 const getSceneWithCampaign = `-- name: GetSceneWithCampaign :one
 SELECT
-    s.id, s.campaign_id, s.title, s.description, s.header_image_url, s.character_ids, s.pass_states, s.is_archived, s.created_at, s.updated_at,
+    s.id, s.campaign_id, s.title, s.description, s.header_image_url, s.character_ids, s.pass_states, s.is_archived, s.created_at, s.updated_at, s.sort_order, s.character_order, s.is_frozen, s.default_witness_mode, s.summary, s.fog_override,
     c.current_phase,
     c.current_phase_expires_at,
     c.owner_id AS campaign_owner_id
@@ -624,6 +715,12 @@ type GetSceneWithCampaignRow struct {
 	IsArchived            bool               `json:"is_archived"`
 	CreatedAt             pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	SortOrder             int32              `json:"sort_order"`
+	CharacterOrder        []pgtype.UUID      `json:"character_order"`
+	IsFrozen              bool               `json:"is_frozen"`
+	DefaultWitnessMode    string             `json:"default_witness_mode"`
+	Summary               pgtype.Text        `json:"summary"`
+	FogOverride           string             `json:"fog_override"`
 	CurrentPhase          CampaignPhase      `json:"current_phase"`
 	CurrentPhaseExpiresAt pgtype.Timestamptz `json:"current_phase_expires_at"`
 	CampaignOwnerID       pgtype.UUID        `json:"campaign_owner_id"`
@@ -643,6 +740,12 @@ func (q *Queries) GetSceneWithCampaign(ctx context.Context, id pgtype.UUID) (Get
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 		&i.CurrentPhase,
 		&i.CurrentPhaseExpiresAt,
 		&i.CampaignOwnerID,
@@ -651,7 +754,7 @@ func (q *Queries) GetSceneWithCampaign(ctx context.Context, id pgtype.UUID) (Get
 }
 
 const getSceneWithCharacter = `-- name: GetSceneWithCharacter :one
-SELECT id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at FROM scenes
+SELECT id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override FROM scenes
 WHERE campaign_id = $1 AND $2::uuid = ANY(character_ids) AND is_archived = false
 LIMIT 1
 `
@@ -675,18 +778,24 @@ func (q *Queries) GetSceneWithCharacter(ctx context.Context, arg GetSceneWithCha
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
 
 const getVisibleScenesForCharacter = `-- name: GetVisibleScenesForCharacter :many
-SELECT DISTINCT s.id, s.campaign_id, s.title, s.description, s.header_image_url, s.character_ids, s.pass_states, s.is_archived, s.created_at, s.updated_at
+SELECT DISTINCT s.id, s.campaign_id, s.title, s.description, s.header_image_url, s.character_ids, s.pass_states, s.is_archived, s.created_at, s.updated_at, s.sort_order, s.character_order, s.is_frozen
 FROM scenes s
 INNER JOIN posts p ON p.scene_id = s.id
 WHERE s.campaign_id = $1
   AND $2::uuid = ANY(p.witnesses)
   AND s.is_archived = false
-ORDER BY s.created_at DESC
+ORDER BY s.sort_order ASC, s.created_at ASC
 `
 
 type GetVisibleScenesForCharacterParams struct {
@@ -715,6 +824,12 @@ func (q *Queries) GetVisibleScenesForCharacter(ctx context.Context, arg GetVisib
 			&i.IsArchived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SortOrder,
+			&i.CharacterOrder,
+			&i.IsFrozen,
+			&i.DefaultWitnessMode,
+			&i.Summary,
+			&i.FogOverride,
 		); err != nil {
 			return nil, err
 		}
@@ -727,14 +842,14 @@ func (q *Queries) GetVisibleScenesForCharacter(ctx context.Context, arg GetVisib
 }
 
 const getVisibleScenesForUser = `-- name: GetVisibleScenesForUser :many
-SELECT DISTINCT s.id, s.campaign_id, s.title, s.description, s.header_image_url, s.character_ids, s.pass_states, s.is_archived, s.created_at, s.updated_at
+SELECT DISTINCT s.id, s.campaign_id, s.title, s.description, s.header_image_url, s.character_ids, s.pass_states, s.is_archived, s.created_at, s.updated_at, s.sort_order, s.character_order, s.is_frozen
 FROM scenes s
 INNER JOIN posts p ON p.scene_id = s.id
 INNER JOIN character_assignments ca ON ca.character_id = ANY(p.witnesses)
 WHERE s.campaign_id = $1
   AND ca.user_id = $2
   AND s.is_archived = false
-ORDER BY s.created_at ASC
+ORDER BY s.sort_order ASC, s.created_at ASC
 `
 
 type GetVisibleScenesForUserParams struct {
@@ -764,6 +879,12 @@ func (q *Queries) GetVisibleScenesForUser(ctx context.Context, arg GetVisibleSce
 			&i.IsArchived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SortOrder,
+			&i.CharacterOrder,
+			&i.IsFrozen,
+			&i.DefaultWitnessMode,
+			&i.Summary,
+			&i.FogOverride,
 		); err != nil {
 			return nil, err
 		}
@@ -808,9 +929,9 @@ func (q *Queries) IsCharacterInScene(ctx context.Context, arg IsCharacterInScene
 }
 
 const listActiveScenes = `-- name: ListActiveScenes :many
-SELECT id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at FROM scenes
+SELECT id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override FROM scenes
 WHERE campaign_id = $1 AND is_archived = false
-ORDER BY created_at ASC
+ORDER BY sort_order ASC, created_at ASC
 `
 
 func (q *Queries) ListActiveScenes(ctx context.Context, campaignID pgtype.UUID) ([]Scene, error) {
@@ -833,6 +954,12 @@ func (q *Queries) ListActiveScenes(ctx context.Context, campaignID pgtype.UUID)
 			&i.IsArchived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SortOrder,
+			&i.CharacterOrder,
+			&i.IsFrozen,
+			&i.DefaultWitnessMode,
+			&i.Summary,
+			&i.FogOverride,
 		); err != nil {
 			return nil, err
 		}
@@ -845,9 +972,9 @@ func (q *Queries) ListActiveScenes(ctx context.Context, campaignID pgtype.UUID)
 }
 
 const listCampaignScenes = `-- name: ListCampaignScenes :many
-SELECT id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at FROM scenes
+SELECT id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override FROM scenes
 WHERE campaign_id = $1
-ORDER BY is_archived ASC, created_at ASC
+ORDER BY is_archived ASC, sort_order ASC, created_at ASC
 `
 
 func (q *Queries) ListCampaignScenes(ctx context.Context, campaignID pgtype.UUID) ([]Scene, error) {
@@ -870,6 +997,72 @@ func (q *Queries) ListCampaignScenes(ctx context.Context, campaignID pgtype.UUID
 			&i.IsArchived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SortOrder,
+			&i.CharacterOrder,
+			&i.IsFrozen,
+			&i.DefaultWitnessMode,
+			&i.Summary,
+			&i.FogOverride,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSceneActivity = `-- name: ListSceneActivity :many
+SELECT
+    s.id AS scene_id,
+    COALESCE(cardinality(s.character_ids), 0)::bigint AS character_count,
+    COUNT(p.id)::bigint AS post_count,
+    MAX(COALESCE(p.submitted_at, p.created_at))::timestamptz AS last_post_at
+FROM scenes s
+LEFT JOIN posts p ON p.scene_id = s.id
+    AND p.is_draft = false
+    AND (
+        $1::boolean
+        OR EXISTS (
+            SELECT 1 FROM character_assignments ca
+            WHERE ca.user_id = $2 AND ca.character_id = ANY(p.witnesses)
+        )
+    )
+WHERE s.campaign_id = $3
+GROUP BY s.id
+`
+
+type ListSceneActivityParams struct {
+	IsGm       bool        `json:"is_gm"`
+	UserID     pgtype.UUID `json:"user_id"`
+	CampaignID pgtype.UUID `json:"campaign_id"`
+}
+
+type ListSceneActivityRow struct {
+	SceneID        pgtype.UUID        `json:"scene_id"`
+	CharacterCount int64              `json:"character_count"`
+	PostCount      int64              `json:"post_count"`
+	LastPostAt     pgtype.Timestamptz `json:"last_post_at"`
+}
+
+// Returns character and submitted post counts plus the latest publish time per scene.
+// For GMs every post counts; players only count posts witnessed by one of their characters.
+func (q *Queries) ListSceneActivity(ctx context.Context, arg ListSceneActivityParams) ([]ListSceneActivityRow, error) {
+	rows, err := q.db.Query(ctx, listSceneActivity, arg.IsGm, arg.UserID, arg.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSceneActivityRow
+	for rows.Next() {
+		var i ListSceneActivityRow
+		if err := rows.Scan(
+			&i.SceneID,
+			&i.CharacterCount,
+			&i.PostCount,
+			&i.LastPostAt,
 		); err != nil {
 			return nil, err
 		}
@@ -905,7 +1098,7 @@ SET
     character_ids = array_remove(character_ids, $2::uuid),
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
 `
 
 type RemoveCharacterFromSceneParams struct {
@@ -927,6 +1120,12 @@ func (q *Queries) RemoveCharacterFromScene(ctx context.Context, arg RemoveCharac
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
@@ -950,7 +1149,7 @@ SET
     pass_states = '{}'::jsonb,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
 `
 
 func (q *Queries) ResetAllPassStatesInScene(ctx context.Context, id pgtype.UUID) (Scene, error) {
@@ -967,6 +1166,12 @@ func (q *Queries) ResetAllPassStatesInScene(ctx context.Context, id pgtype.UUID)
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
@@ -982,7 +1187,7 @@ SET
     ),
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
 `
 
 type SetCharacterPassStateParams struct {
@@ -1005,6 +1210,165 @@ func (q *Queries) SetCharacterPassState(ctx context.Context, arg SetCharacterPas
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
+	)
+	return i, err
+}
+
+const setSceneCharacterOrder = `-- name: SetSceneCharacterOrder :one
+UPDATE scenes
+SET
+    character_order = $2,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
+`
+
+type SetSceneCharacterOrderParams struct {
+	ID             pgtype.UUID   `json:"id"`
+	CharacterOrder []pgtype.UUID `json:"character_order"`
+}
+
+func (q *Queries) SetSceneCharacterOrder(ctx context.Context, arg SetSceneCharacterOrderParams) (Scene, error) {
+	row := q.db.QueryRow(ctx, setSceneCharacterOrder, arg.ID, arg.CharacterOrder)
+	var i Scene
+	err := row.Scan(
+		&i.ID,
+		&i.CampaignID,
+		&i.Title,
+		&i.Description,
+		&i.HeaderImageUrl,
+		&i.CharacterIds,
+		&i.PassStates,
+		&i.IsArchived,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
+	)
+	return i, err
+}
+
+const setSceneDefaultWitnessMode = `-- name: SetSceneDefaultWitnessMode :one
+UPDATE scenes
+SET
+    default_witness_mode = $2,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
+`
+
+type SetSceneDefaultWitnessModeParams struct {
+	ID                 pgtype.UUID `json:"id"`
+	DefaultWitnessMode string      `json:"default_witness_mode"`
+}
+
+func (q *Queries) SetSceneDefaultWitnessMode(ctx context.Context, arg SetSceneDefaultWitnessModeParams) (Scene, error) {
+	row := q.db.QueryRow(ctx, setSceneDefaultWitnessMode, arg.ID, arg.DefaultWitnessMode)
+	var i Scene
+	err := row.Scan(
+		&i.ID,
+		&i.CampaignID,
+		&i.Title,
+		&i.Description,
+		&i.HeaderImageUrl,
+		&i.CharacterIds,
+		&i.PassStates,
+		&i.IsArchived,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
+	)
+	return i, err
+}
+
+const setSceneFogOverride = `-- name: SetSceneFogOverride :one
+UPDATE scenes
+SET
+    fog_override = $2,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
+`
+
+type SetSceneFogOverrideParams struct {
+	ID          pgtype.UUID `json:"id"`
+	FogOverride string      `json:"fog_override"`
+}
+
+func (q *Queries) SetSceneFogOverride(ctx context.Context, arg SetSceneFogOverrideParams) (Scene, error) {
+	row := q.db.QueryRow(ctx, setSceneFogOverride, arg.ID, arg.FogOverride)
+	var i Scene
+	err := row.Scan(
+		&i.ID,
+		&i.CampaignID,
+		&i.Title,
+		&i.Description,
+		&i.HeaderImageUrl,
+		&i.CharacterIds,
+		&i.PassStates,
+		&i.IsArchived,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
+	)
+	return i, err
+}
+
+const setSceneSummary = `-- name: SetSceneSummary :one
+UPDATE scenes
+SET
+    summary = $2,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
+`
+
+type SetSceneSummaryParams struct {
+	ID      pgtype.UUID `json:"id"`
+	Summary pgtype.Text `json:"summary"`
+}
+
+// A NULL summary clears it
+func (q *Queries) SetSceneSummary(ctx context.Context, arg SetSceneSummaryParams) (Scene, error) {
+	row := q.db.QueryRow(ctx, setSceneSummary, arg.ID, arg.Summary)
+	var i Scene
+	err := row.Scan(
+		&i.ID,
+		&i.CampaignID,
+		&i.Title,
+		&i.Description,
+		&i.HeaderImageUrl,
+		&i.CharacterIds,
+		&i.PassStates,
+		&i.IsArchived,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
@@ -1015,7 +1379,7 @@ SET
     is_archived = false,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
 `
 
 func (q *Queries) UnarchiveScene(ctx context.Context, id pgtype.UUID) (Scene, error) {
@@ -1032,6 +1396,45 @@ func (q *Queries) UnarchiveScene(ctx context.Context, id pgtype.UUID) (Scene, er
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
+	)
+	return i, err
+}
+
+const unfreezeScene = `-- name: UnfreezeScene :one
+UPDATE scenes
+SET
+    is_frozen = false,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
+`
+
+func (q *Queries) UnfreezeScene(ctx context.Context, id pgtype.UUID) (Scene, error) {
+	row := q.db.QueryRow(ctx, unfreezeScene, id)
+	var i Scene
+	err := row.Scan(
+		&i.ID,
+		&i.CampaignID,
+		&i.Title,
+		&i.Description,
+		&i.HeaderImageUrl,
+		&i.CharacterIds,
+		&i.PassStates,
+		&i.IsArchived,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
@@ -1044,7 +1447,7 @@ SET
     header_image_url = COALESCE($4, header_image_url),
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
 `
 
 type UpdateSceneParams struct {
@@ -1073,6 +1476,12 @@ func (q *Queries) UpdateScene(ctx context.Context, arg UpdateSceneParams) (Scene
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
@@ -1083,7 +1492,7 @@ SET
     header_image_url = $2,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
 `
 
 type UpdateSceneHeaderImageParams struct {
@@ -1105,6 +1514,12 @@ func (q *Queries) UpdateSceneHeaderImage(ctx context.Context, arg UpdateSceneHea
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
@@ -1115,7 +1530,7 @@ SET
     pass_states = $2,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at
+RETURNING id, campaign_id, title, description, header_image_url, character_ids, pass_states, is_archived, created_at, updated_at, sort_order, character_order, is_frozen, default_witness_mode, summary, fog_override
 `
 
 type UpdateScenePassStatesParams struct {
@@ -1137,6 +1552,30 @@ func (q *Queries) UpdateScenePassStates(ctx context.Context, arg UpdateScenePass
 		&i.IsArchived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SortOrder,
+		&i.CharacterOrder,
+		&i.IsFrozen,
+		&i.DefaultWitnessMode,
+		&i.Summary,
+		&i.FogOverride,
 	)
 	return i, err
 }
+
+const updateSceneSortOrders = `-- name: UpdateSceneSortOrders :exec
+UPDATE scenes
+SET sort_order = ordered.position
+FROM unnest($2::uuid[]) WITH ORDINALITY AS ordered(id, position)
+WHERE scenes.id = ordered.id AND scenes.campaign_id = $1
+`
+
+type UpdateSceneSortOrdersParams struct {
+	CampaignID pgtype.UUID   `json:"campaign_id"`
+	Column2    []pgtype.UUID `json:"column_2"`
+}
+
+// Sets each scene's sort_order to its position in the given id list
+func (q *Queries) UpdateSceneSortOrders(ctx context.Context, arg UpdateSceneSortOrdersParams) error {
+	_, err := q.db.Exec(ctx, updateSceneSortOrders, arg.CampaignID, arg.Column2)
+	return err
+}
@@ -1,8 +1,6 @@
 // Package dice provides dice rolling functionality with system presets.
 package dice
 
-import "slices"
-
 // dnd5eIntentions provides the default intentions for D&D 5th Edition.
 //
 //nolint:gochecknoglobals // Package-level slice for preset configuration
@@ -37,11 +35,24 @@ var pf2eIntentions = []string{
 // PF2eDiceType is the default dice type for Pathfinder 2e.
 const PF2eDiceType = "d20"
 
+// DefaultDiceCount is the number of dice rolled by a preset unless overridden.
+const DefaultDiceCount = 1
+
 // SystemPreset represents a dice system configuration.
 type SystemPreset struct {
 	Name       string   `json:"name"`
 	Intentions []string `json:"intentions"`
 	DiceType   string   `json:"diceType"`
+	DiceCount  int      `json:"diceCount"`
+	Modifier   int      `json:"modifier"`
+}
+
+// PresetExpansion is the concrete roll a preset expands to.
+type PresetExpansion struct {
+	Name      string `json:"name"`
+	DiceType  string `json:"diceType"`
+	DiceCount int    `json:"diceCount"`
+	Modifier  int    `json:"modifier"`
 }
 
 // GetAvailablePresets returns all available system presets.
@@ -51,16 +62,22 @@ func GetAvailablePresets() []SystemPreset {
 			Name:       "dnd5e",
 			Intentions: dnd5eIntentions,
 			DiceType:   DND5eDiceType,
+			DiceCount:  DefaultDiceCount,
+			Modifier:   0,
 		},
 		{
 			Name:       "pf2e",
 			Intentions: pf2eIntentions,
 			DiceType:   PF2eDiceType,
+			DiceCount:  DefaultDiceCount,
+			Modifier:   0,
 		},
 		{
 			Name:       "custom",
 			Intentions: []string{}, // User-defined
 			DiceType:   "d20",      // User-configurable
+			DiceCount:  DefaultDiceCount,
+			Modifier:   0,
 		},
 	}
 }
@@ -76,12 +93,44 @@ func GetPresetByName(name string) *SystemPreset {
 	return nil
 }
 
-// ValidDiceTypes returns all supported dice types.
+// ExpandPreset returns the concrete dice a preset rolls, or nil if not found.
+func ExpandPreset(name string) *PresetExpansion {
+	preset := GetPresetByName(name)
+	if preset == nil {
+		return nil
+	}
+	expansion := preset.expansion()
+	return &expansion
+}
+
+// PresetExpansions returns the concrete dice of every system preset.
+func PresetExpansions() []PresetExpansion {
+	presets := GetAvailablePresets()
+	expansions := make([]PresetExpansion, 0, len(presets))
+	for _, p := range presets {
+		expansions = append(expansions, p.expansion())
+	}
+	return expansions
+}
+
+func (p *SystemPreset) expansion() PresetExpansion {
+	return PresetExpansion{
+		Name:      p.Name,
+		DiceType:  p.DiceType,
+		DiceCount: p.DiceCount,
+		Modifier:  p.Modifier,
+	}
+}
+
+// ValidDiceTypes returns the standard dice types offered to players.
+// Custom dN types accepted by IsValidDiceType are not listed.
 func ValidDiceTypes() []string {
 	return []string{"d4", "d6", "d8", "d10", "d12", "d20", "d100"}
 }
 
-// IsValidDiceType checks if a dice type is valid.
+// IsValidDiceType checks if a dice type is valid: a standard die, "d%", or a
+// custom dN within the supported range of sides.
 func IsValidDiceType(diceType string) bool {
-	return slices.Contains(ValidDiceTypes(), diceType)
+	_, err := ParseDiceType(diceType)
+	return err == nil
 }
@@ -4,6 +4,8 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // Dice side constants for standard RPG dice.
@@ -17,6 +19,15 @@ const (
 	D100Sides = 100
 )
 
+// PercentileDiceType is an alias for d100.
+const PercentileDiceType = "d%"
+
+// Custom dice limits for generic dN types.
+const (
+	MinDiceSides = 2
+	MaxDiceSides = 1000
+)
+
 // Validation constants.
 const (
 	MaxDiceCount = 100
@@ -24,6 +35,10 @@ const (
 	MinModifier  = -100
 )
 
+// DefaultMaxRollSize bounds dice count times sides for a single roll, so 20d100 is
+// allowed but 100d100 is not. Normal rolls stay far below it.
+const DefaultMaxRollSize = 2000
+
 // Roller handles cryptographically secure dice rolling.
 type Roller struct{}
 
@@ -69,13 +84,16 @@ func rollSingleDie(sides int) (int, error) {
 	randomValue := binary.BigEndian.Uint64(buf[:])
 
 	// Map to 1..sides range (inclusive)
-	//nolint:gosec // sides is always positive and small (max 100)
+	//nolint:gosec // sides is always positive and small (max MaxDiceSides)
 	result := int(randomValue%uint64(sides)) + 1
 
 	return result, nil
 }
 
 // ParseDiceType converts a dice type string to number of sides.
+// Besides the standard dice it accepts "d%" for percentile rolls and any
+// "dN" with N between MinDiceSides and MaxDiceSides, written without sign or
+// leading zeros.
 func ParseDiceType(diceType string) (int, error) {
 	switch diceType {
 	case "d4":
@@ -90,11 +108,19 @@ func ParseDiceType(diceType string) (int, error) {
 		return D12Sides, nil
 	case "d20":
 		return D20Sides, nil
-	case "d100":
+	case "d100", PercentileDiceType:
 		return D100Sides, nil
-	default:
+	}
+
+	digits, ok := strings.CutPrefix(diceType, "d")
+	if !ok {
 		return 0, fmt.Errorf("invalid dice type: %s", diceType)
 	}
+	sides, err := strconv.Atoi(digits)
+	if err != nil || strconv.Itoa(sides) != digits || sides < MinDiceSides || sides > MaxDiceSides {
+		return 0, fmt.Errorf("invalid dice type: %s", diceType)
+	}
+	return sides, nil
 }
 
 // CalculateTotal sums dice results and adds modifier.
@@ -106,6 +132,30 @@ func (r *Roller) CalculateTotal(diceResults []int32, modifier int) int {
 	return total
 }
 
+// CountSuccesses counts the dice at or above threshold and adds modifier as
+// automatic successes. The count never drops below zero.
+func (r *Roller) CountSuccesses(diceResults []int32, threshold, modifier int) int {
+	successes := modifier
+	for _, result := range diceResults {
+		if int(result) >= threshold {
+			successes++
+		}
+	}
+	return max(successes, 0)
+}
+
+// ValidateSuccessThreshold checks that threshold is a face of the given dice type.
+func ValidateSuccessThreshold(diceType string, threshold int) error {
+	sides, err := ParseDiceType(diceType)
+	if err != nil {
+		return err
+	}
+	if threshold < 1 || threshold > sides {
+		return fmt.Errorf("success threshold must be between 1 and %d, got %d", sides, threshold)
+	}
+	return nil
+}
+
 // ValidateModifier checks if a modifier is within valid range.
 func ValidateModifier(modifier int) error {
 	if modifier < MinModifier || modifier > MaxModifier {
@@ -114,6 +164,19 @@ func ValidateModifier(modifier int) error {
 	return nil
 }
 
+// ValidateRollSize checks that count dice of the given type stay within maxSize,
+// measured as count times sides.
+func ValidateRollSize(diceType string, count, maxSize int) error {
+	sides, err := ParseDiceType(diceType)
+	if err != nil {
+		return err
+	}
+	if count*sides > maxSize {
+		return fmt.Errorf("%d%s exceeds the maximum roll size of %d", count, diceType, maxSize)
+	}
+	return nil
+}
+
 // ValidateDiceCount checks if dice count is within valid range.
 func ValidateDiceCount(count int) error {
 	if count < 1 || count > MaxDiceCount {
@@ -0,0 +1,77 @@
+package dice
+
+import "testing"
+
+func TestParseDiceType(t *testing.T) {
+	tests := []struct {
+		diceType  string
+		wantSides int
+		wantErr   bool
+	}{
+		{"d20", D20Sides, false},
+		{"d100", D100Sides, false},
+		{"d%", D100Sides, false},
+		{"d2", MinDiceSides, false},
+		{"d1000", MaxDiceSides, false},
+		{"d7", 7, false},
+		{"d0", 0, true},
+		{"d1", 0, true},
+		{"d1001", 0, true},
+		{"d-5", 0, true},
+		{"d+5", 0, true},
+		{"d07", 0, true},
+		{"dabc", 0, true},
+		{"d", 0, true},
+		{"20", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.diceType, func(t *testing.T) {
+			sides, err := ParseDiceType(tt.diceType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDiceType(%q) error = %v, wantErr %v", tt.diceType, err, tt.wantErr)
+			}
+			if sides != tt.wantSides {
+				t.Errorf("ParseDiceType(%q) = %d, want %d", tt.diceType, sides, tt.wantSides)
+			}
+			if IsValidDiceType(tt.diceType) == tt.wantErr {
+				t.Errorf("IsValidDiceType(%q) = %v, want %v", tt.diceType, !tt.wantErr, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRollRejectsInvalidDiceTypes(t *testing.T) {
+	for _, diceType := range []string{"d0", "d-5", "dabc"} {
+		if results, err := NewRoller().Roll(diceType, 1); err == nil {
+			t.Errorf("Roll(%q) = %v, want an error", diceType, results)
+		}
+	}
+}
+
+func TestRollD100StaysWithinBounds(t *testing.T) {
+	const rolls = 50
+	roller := NewRoller()
+
+	for _, diceType := range []string{"d100", PercentileDiceType} {
+		seen := make(map[int32]bool, D100Sides)
+		for range rolls {
+			results, err := roller.Roll(diceType, MaxDiceCount)
+			if err != nil {
+				t.Fatalf("Roll(%q) error = %v", diceType, err)
+			}
+			for _, result := range results {
+				if result < 1 || result > D100Sides {
+					t.Fatalf("Roll(%q) produced %d, want 1-%d", diceType, result, D100Sides)
+				}
+				seen[result] = true
+			}
+		}
+
+		// 5000 rolls miss any given face with negligible probability
+		if len(seen) != D100Sides {
+			t.Errorf("Roll(%q) produced %d distinct faces, want all %d", diceType, len(seen), D100Sides)
+		}
+	}
+}
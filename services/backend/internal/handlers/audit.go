@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/middleware"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/models"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+)
+
+// GetCampaignAuditLog returns a page of the campaign's GM audit log (GM only).
+func GetCampaignAuditLog(db *database.DB) gin.HandlerFunc {
+	svc := service.NewAuditService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		var query service.AuditLogQuery
+		if l := c.Query("limit"); l != "" {
+			if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= service.MaxAuditLogLimit {
+				query.Limit = safeInt32(parsed)
+			}
+		}
+		if o := c.Query("offset"); o != "" {
+			if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+				query.Offset = safeInt32(parsed)
+			}
+		}
+
+		page, err := svc.GetAuditLog(c.Request.Context(), campaignID, parseUUID(userIDStr), query)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, page)
+	}
+}
@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/middleware"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/models"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+)
+
+// ResolveBotToken returns the resolver used by middleware.BotAuth.
+func ResolveBotToken(db *database.DB) middleware.BotTokenResolver {
+	svc := service.NewBotTokenService(db.Pool)
+
+	return func(ctx context.Context, token string) (*middleware.BotIdentity, error) {
+		row, err := svc.ResolveToken(ctx, token)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidBotToken) {
+				return nil, middleware.ErrInvalidBotToken
+			}
+			return nil, err
+		}
+		return &middleware.BotIdentity{
+			TokenID:    uuidToString(row.ID),
+			CampaignID: uuidToString(row.CampaignID),
+			GMUserID:   uuidToString(row.GmUserID),
+		}, nil
+	}
+}
+
+// CreateBotToken issues a new bot token for the campaign (GM only).
+// The token is only ever returned in this response.
+func CreateBotToken(db *database.DB) gin.HandlerFunc {
+	svc := service.NewBotTokenService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		var req service.CreateBotTokenRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request body")
+			return
+		}
+
+		token, err := svc.CreateToken(c.Request.Context(), campaignID, parseUUID(userIDStr), req)
+		if err != nil {
+			handleBotTokenError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, token)
+	}
+}
+
+// ListBotTokens returns the campaign's active bot tokens (GM only).
+func ListBotTokens(db *database.DB) gin.HandlerFunc {
+	svc := service.NewBotTokenService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		tokens, err := svc.ListTokens(c.Request.Context(), campaignID, parseUUID(userIDStr))
+		if err != nil {
+			handleBotTokenError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"tokens": tokens})
+	}
+}
+
+// RevokeBotToken revokes one of the campaign's bot tokens (GM only).
+func RevokeBotToken(db *database.DB) gin.HandlerFunc {
+	svc := service.NewBotTokenService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		tokenID := parseUUID(c.Param("tokenId"))
+		if !tokenID.Valid {
+			models.ValidationError(c, "Invalid token ID format")
+			return
+		}
+
+		if err := svc.RevokeToken(c.Request.Context(), campaignID, tokenID, parseUUID(userIDStr)); err != nil {
+			handleBotTokenError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// BotPostRequest represents a narrator post submitted by a bot.
+type BotPostRequest struct {
+	Blocks  []service.PostBlock `json:"blocks"`
+	OOCText *string             `json:"oocText"`
+}
+
+// BotCreateNarratorPost submits a narrator post to a scene in the bot's campaign.
+func BotCreateNarratorPost(db *database.DB) gin.HandlerFunc {
+	svc := service.NewPostService(db.Pool)
+	queries := service.NewQueries(db.Pool)
+
+	return func(c *gin.Context) {
+		identity, ok := middleware.GetBotIdentity(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		scene, ok := botScene(c, queries, identity)
+		if !ok {
+			return
+		}
+
+		var req BotPostRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request body")
+			return
+		}
+		if len(req.Blocks) == 0 {
+			models.ValidationError(c, "A narrator post needs at least one block")
+			return
+		}
+
+		//nolint:exhaustruct // Bots may only post as the narrator, so character and roll fields stay empty
+		postReq := service.CreatePostRequest{
+			SceneID: uuidToString(scene.ID),
+			Blocks:  req.Blocks,
+			OOCText: req.OOCText,
+		}
+		resp, err := svc.CreatePost(c.Request.Context(), parseUUID(identity.GMUserID), postReq, true)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		// Not delivered to the campaign webhook: the bot already has the
+		// content, and relaying it back would echo it into the source chat.
+		witnessUUIDs := make([]pgtype.UUID, 0, len(resp.Witnesses))
+		for _, w := range resp.Witnesses {
+			witnessUUIDs = append(witnessUUIDs, parseUUID(w))
+		}
+		BroadcastPostCreated(c, parseUUID(resp.ID), scene.ID, scene.CampaignID, emptyUUID(), resp.IsHidden, witnessUUIDs)
+		notifyMentions(c, db, resp)
+
+		c.JSON(http.StatusCreated, resp)
+	}
+}
+
+// BotCreateOocMessage posts an OOC message to a scene in the bot's campaign.
+func BotCreateOocMessage(db *database.DB) gin.HandlerFunc {
+	svc := service.NewOocService(db.Pool)
+	queries := service.NewQueries(db.Pool)
+
+	return func(c *gin.Context) {
+		identity, ok := middleware.GetBotIdentity(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		scene, ok := botScene(c, queries, identity)
+		if !ok {
+			return
+		}
+
+		var req service.CreateOocMessageRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request body")
+			return
+		}
+
+		msg, err := svc.CreateOocMessage(
+			c.Request.Context(),
+			scene.CampaignID,
+			scene.ID,
+			parseUUID(identity.GMUserID),
+			req,
+		)
+		if err != nil {
+			handleOocError(c, err)
+			return
+		}
+
+		BroadcastOocMessageCreated(c, scene.CampaignID, msg)
+
+		c.JSON(http.StatusCreated, msg)
+	}
+}
+
+// botScene loads the scene from the URL and checks it belongs to the bot's
+// campaign. Scenes in other campaigns are reported as not found.
+func botScene(
+	c *gin.Context,
+	queries *generated.Queries,
+	identity *middleware.BotIdentity,
+) (*generated.Scene, bool) {
+	sceneID := parseUUID(c.Param("sceneId"))
+	if !sceneID.Valid {
+		models.ValidationError(c, "Invalid scene ID format")
+		return nil, false
+	}
+
+	scene, err := queries.GetScene(c.Request.Context(), sceneID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			models.NotFoundError(c, "Scene")
+			return nil, false
+		}
+		handleServiceError(c, err)
+		return nil, false
+	}
+	if scene.CampaignID != parseUUID(identity.CampaignID) {
+		models.NotFoundError(c, "Scene")
+		return nil, false
+	}
+
+	return &scene, true
+}
+
+func handleBotTokenError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidBotTokenName):
+		models.ValidationError(c, err.Error())
+	case errors.Is(err, service.ErrBotTokenLimitReached):
+		models.RespondError(
+			c,
+			http.StatusForbidden,
+			models.NewAPIError(
+				"BOT_TOKEN_LIMIT",
+				fmt.Sprintf("A campaign can have at most %d active bot tokens.", service.MaxBotTokensPerCampaign),
+			),
+		)
+	case errors.Is(err, service.ErrBotTokenNotFound):
+		models.NotFoundError(c, "Bot token")
+	default:
+		handleServiceError(c, err)
+	}
+}
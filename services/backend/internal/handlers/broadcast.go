@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"os"
 	"sync"
 
 	"github.com/gin-gonic/gin"
@@ -18,15 +17,7 @@ var (
 // getBroadcastService returns a singleton broadcast service.
 func getBroadcastService() *service.BroadcastService {
 	broadcastOnce.Do(func() {
-		supabaseURL := os.Getenv("SUPABASE_URL")
-		supabaseKey := os.Getenv("SUPABASE_SECRET_KEY")
-		if supabaseKey == "" {
-			supabaseKey = os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
-		}
-
-		if supabaseURL != "" && supabaseKey != "" {
-			broadcastService = service.NewBroadcastService(supabaseURL, supabaseKey)
-		}
+		broadcastService = service.NewBroadcastServiceFromEnv()
 	})
 	return broadcastService
 }
@@ -45,6 +36,16 @@ func BroadcastPhaseTransition(
 	go svc.BroadcastPhaseTransition(c.Request.Context(), campaignID, fromPhase, toPhase, reason)
 }
 
+// BroadcastPhaseStatus broadcasts a campaign's phase status, e.g. after an expired
+// time gate auto-passed characters.
+func BroadcastPhaseStatus(c *gin.Context, campaignID pgtype.UUID, status *service.PhaseStatus) {
+	svc := getBroadcastService()
+	if svc == nil {
+		return
+	}
+	go svc.BroadcastPhaseStatus(c.Request.Context(), campaignID, status)
+}
+
 // BroadcastPostCreated broadcasts a post creation event.
 func BroadcastPostCreated(
 	c *gin.Context,
@@ -83,6 +84,20 @@ func BroadcastPostDeleted(
 	go svc.BroadcastPostDeleted(c.Request.Context(), postID, sceneID, campaignID)
 }
 
+// BroadcastPostReaction broadcasts a post reaction count change.
+func BroadcastPostReaction(
+	c *gin.Context,
+	postID, sceneID, campaignID pgtype.UUID,
+	emoji string,
+	count int32,
+) {
+	svc := getBroadcastService()
+	if svc == nil {
+		return
+	}
+	go svc.BroadcastPostReaction(c.Request.Context(), postID, sceneID, campaignID, emoji, count)
+}
+
 // BroadcastComposeLockAcquired broadcasts a compose lock acquisition event (identity protected).
 func BroadcastComposeLockAcquired(
 	c *gin.Context,
@@ -107,6 +122,15 @@ func BroadcastComposeLockReleased(
 	go svc.BroadcastComposeLockReleased(c.Request.Context(), sceneID, campaignID)
 }
 
+// BroadcastPresence broadcasts a typing indicator (identity protected for hidden posts).
+func BroadcastPresence(c *gin.Context, presence *service.TypingPresence) {
+	svc := getBroadcastService()
+	if svc == nil {
+		return
+	}
+	go svc.BroadcastPresence(c.Request.Context(), presence)
+}
+
 // BroadcastPassStateChanged broadcasts a pass state change event.
 func BroadcastPassStateChanged(
 	c *gin.Context,
@@ -144,17 +168,30 @@ func BroadcastCharacterLeftScene(
 	go svc.BroadcastCharacterLeftScene(c.Request.Context(), sceneID, campaignID, characterID)
 }
 
+// BroadcastCharacterUpdated broadcasts a character's updated tags.
+func BroadcastCharacterUpdated(
+	c *gin.Context,
+	campaignID, sceneID, characterID pgtype.UUID,
+	tags []string,
+) {
+	svc := getBroadcastService()
+	if svc == nil {
+		return
+	}
+	go svc.BroadcastCharacterUpdated(c.Request.Context(), campaignID, sceneID, characterID, tags)
+}
+
 // BroadcastRollCreated broadcasts a roll creation event.
 func BroadcastRollCreated(
 	c *gin.Context,
 	rollID, postID, sceneID, campaignID, characterID pgtype.UUID,
-	intention string,
+	intention, note string,
 ) {
 	svc := getBroadcastService()
 	if svc == nil {
 		return
 	}
-	go svc.BroadcastRollCreated(c.Request.Context(), rollID, postID, sceneID, campaignID, characterID, intention)
+	go svc.BroadcastRollCreated(c.Request.Context(), rollID, postID, sceneID, campaignID, characterID, intention, note)
 }
 
 // BroadcastRollResolved broadcasts a roll resolution event.
@@ -169,3 +206,16 @@ func BroadcastRollResolved(
 	}
 	go svc.BroadcastRollResolved(c.Request.Context(), rollID, sceneID, campaignID, status)
 }
+
+// BroadcastOocMessageCreated broadcasts a new OOC message.
+func BroadcastOocMessageCreated(
+	c *gin.Context,
+	campaignID pgtype.UUID,
+	msg *service.OocMessageResponse,
+) {
+	svc := getBroadcastService()
+	if svc == nil {
+		return
+	}
+	go svc.BroadcastOocMessageCreated(c.Request.Context(), campaignID, msg)
+}
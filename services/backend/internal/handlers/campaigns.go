@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -21,6 +24,11 @@ type CreateCampaignRequest struct {
 	Settings    map[string]any `binding:"-"                      json:"settings,omitempty"`
 }
 
+// DuplicateCampaignRequest represents the request body for duplicating a campaign.
+type DuplicateCampaignRequest struct {
+	Title string `binding:"max=255" json:"title"`
+}
+
 // UpdateCampaignRequest represents the request body for updating a campaign.
 type UpdateCampaignRequest struct {
 	Title       *string         `binding:"omitempty,min=1,max=255" json:"title,omitempty"`
@@ -28,12 +36,22 @@ type UpdateCampaignRequest struct {
 	Settings    *map[string]any `binding:"-"                       json:"settings,omitempty"`
 }
 
+// UpdateCampaignResponse is the updated campaign, plus a warning when the campaign
+// is over a newly lowered player limit.
+type UpdateCampaignResponse struct {
+	*generated.Campaign
+
+	Warning string `json:"warning,omitempty"`
+}
+
 // DeleteCampaignRequest represents the request body for deleting a campaign.
 type DeleteCampaignRequest struct {
 	ConfirmTitle string `binding:"required" json:"confirmTitle"`
 }
 
 // CampaignMemberResponse represents a campaign member with alias and email.
+// Alias falls back to the member's profile display name when no campaign alias is set.
+// Email is present for the current user, and for every member when the viewer is a GM.
 type CampaignMemberResponse struct {
 	ID         string `json:"id"`
 	CampaignID string `json:"campaign_id"`
@@ -58,7 +76,7 @@ func ListCampaigns(db *database.DB) gin.HandlerFunc {
 
 		campaigns, err := svc.ListUserCampaigns(c.Request.Context(), userID)
 		if err != nil {
-			models.InternalError(c)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -84,7 +102,7 @@ func CreateCampaign(db *database.DB) gin.HandlerFunc {
 
 		var req CreateCampaignRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request. Title is required (max 255 characters).")
+			models.BindingError(c, err, "Invalid request. Title is required (max 255 characters).")
 			return
 		}
 
@@ -109,6 +127,134 @@ func CreateCampaign(db *database.DB) gin.HandlerFunc {
 	}
 }
 
+// DuplicateCampaign clones a campaign's settings, NPCs, and scenes into a new campaign (GM only).
+func DuplicateCampaign(db *database.DB, imageService *service.ImageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		var req DuplicateCampaignRequest
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				models.BindingError(c, err, "Invalid request. Title must be at most 255 characters.")
+				return
+			}
+		}
+
+		userID := parseUUID(userIDStr)
+		svc := service.NewCampaignService(db.Pool)
+
+		result, err := svc.DuplicateCampaign(c.Request.Context(), userID, campaignID, req.Title)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		// Copy scene headers into the new campaign's storage
+		if imageService != nil {
+			for _, header := range result.SceneHeaders {
+				if _, copyErr := imageService.CopySceneHeader(
+					c.Request.Context(),
+					uuid.UUID(campaignID.Bytes),
+					uuid.UUID(result.Campaign.ID.Bytes),
+					uuid.UUID(header.SceneID.Bytes),
+					header.SourceURL,
+				); copyErr != nil {
+					//nolint:sloglint // Error logging doesn't need structured logger injection
+					slog.Error("Failed to copy scene header", "error", copyErr)
+				}
+			}
+		}
+
+		c.JSON(http.StatusCreated, result.Campaign)
+	}
+}
+
+// ExportCampaign streams a JSON export of a campaign as a download (GM only).
+func ExportCampaign(db *database.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		svc := service.NewCampaignService(db.Pool)
+
+		export, err := svc.ExportCampaign(c.Request.Context(), parseUUID(userIDStr), campaignID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.Header("Content-Type", "application/json")
+		c.Header(
+			"Content-Disposition",
+			fmt.Sprintf(`attachment; filename="campaign-%s.json"`, c.Param("id")),
+		)
+		c.Status(http.StatusOK)
+		if encodeErr := export.Encode(c.Request.Context(), c.Writer); encodeErr != nil {
+			//nolint:sloglint // Error logging doesn't need structured logger injection
+			slog.Error("Failed to stream campaign export", "error", encodeErr)
+		}
+	}
+}
+
+// ImportCampaign recreates a campaign from a JSON export as a new campaign.
+func ImportCampaign(db *database.DB, imageService *service.ImageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, service.MaxCampaignBundleSize)
+
+		var bundle service.CampaignBundle
+		if err := c.ShouldBindJSON(&bundle); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				handleServiceError(c, service.ErrCampaignBundleTooLarge)
+				return
+			}
+			models.BindingError(c, err, "Invalid campaign export file")
+			return
+		}
+
+		// Image URLs are only kept if they point at the source campaign's storage
+		var assetPrefix string
+		if imageService != nil && bundle.Campaign.ID.Valid {
+			assetPrefix = imageService.CampaignAssetPrefix(uuid.UUID(bundle.Campaign.ID.Bytes))
+		}
+
+		svc := service.NewCampaignService(db.Pool)
+
+		campaign, err := svc.ImportCampaign(c.Request.Context(), parseUUID(userIDStr), &bundle, assetPrefix)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, campaign)
+	}
+}
+
 // GetCampaign returns a single campaign by ID.
 func GetCampaign(db *database.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -160,14 +306,14 @@ func UpdateCampaign(db *database.DB) gin.HandlerFunc {
 
 		var req UpdateCampaignRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request format")
+			models.BindingError(c, err, "Invalid request format")
 			return
 		}
 
 		userID := parseUUID(userIDStr)
 		svc := service.NewCampaignService(db.Pool)
 
-		campaign, err := svc.UpdateCampaign(
+		campaign, warning, err := svc.UpdateCampaign(
 			c.Request.Context(),
 			campaignID,
 			userID,
@@ -182,7 +328,7 @@ func UpdateCampaign(db *database.DB) gin.HandlerFunc {
 			return
 		}
 
-		c.JSON(http.StatusOK, campaign)
+		c.JSON(http.StatusOK, UpdateCampaignResponse{Campaign: campaign, Warning: warning})
 	}
 }
 
@@ -204,7 +350,7 @@ func DeleteCampaign(db *database.DB) gin.HandlerFunc {
 
 		var req DeleteCampaignRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Confirmation title is required")
+			models.BindingError(c, err, "Confirmation title is required")
 			return
 		}
 
@@ -307,29 +453,53 @@ func GetCampaignMembers(db *database.DB) gin.HandlerFunc {
 		// Check if current user is GM
 		isGM, err := svc.IsUserGM(c.Request.Context(), campaignID, userID)
 		if err != nil {
-			models.InternalError(c)
+			handleServiceError(c, err)
+			return
+		}
+
+		// Members without a campaign alias fall back to their profile display name
+		var unaliased []pgtype.UUID
+		for _, member := range members {
+			if member.Alias.String == "" {
+				unaliased = append(unaliased, member.UserID)
+			}
+		}
+		displayNames, err := service.NewProfileService(db.Pool).DisplayNames(c.Request.Context(), unaliased)
+		if err != nil {
+			handleServiceError(c, err)
 			return
 		}
 
-		// Build response with alias, and email only for GMs
+		// Email visibility: users always see their own email, GMs see every member's,
+		// and players see no one else's
+		emails := map[[16]byte]string{}
+		if isGM {
+			emails, err = svc.GetCampaignMemberEmails(c.Request.Context(), campaignID, userID)
+			if err != nil {
+				handleServiceError(c, err)
+				return
+			}
+		}
+		if currentUserEmail, ok := middleware.GetUserEmail(c); ok && currentUserEmail != "" {
+			emails[userID.Bytes] = currentUserEmail
+		}
+
 		response := make([]CampaignMemberResponse, len(members))
-		currentUserEmail, _ := middleware.GetUserEmail(c)
 
 		for i, member := range members {
+			alias := member.Alias.String
+			if alias == "" {
+				alias = displayNames[member.UserID.Bytes]
+			}
 			response[i] = CampaignMemberResponse{
 				ID:         member.ID.String(),
 				CampaignID: member.CampaignID.String(),
 				UserID:     member.UserID.String(),
 				Role:       string(member.Role),
-				Alias:      member.Alias.String,
-				Email:      "",
+				Alias:      alias,
+				Email:      emails[member.UserID.Bytes],
 				JoinedAt:   member.JoinedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
 			}
-
-			// For GMs, include email if it's the current user
-			if isGM && member.UserID == userID && currentUserEmail != "" {
-				response[i].Email = currentUserEmail
-			}
 		}
 
 		c.JSON(http.StatusOK, gin.H{"members": response})
@@ -347,100 +517,31 @@ func parseUUID(s string) pgtype.UUID {
 	return pgtype.UUID{Bytes: u, Valid: true}
 }
 
+// handleServiceError responds with the code, status, and message carried by a
+// service.CodedError. Anything else is unexpected, so it is logged with the
+// request context and reported as an internal error.
 func handleServiceError(c *gin.Context, err error) {
-	switch {
-	case errors.Is(err, service.ErrCampaignLimitReached):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError("CAMPAIGN_LIMIT", "You can only create up to 5 campaigns."),
-		)
-	case errors.Is(err, service.ErrNotGM):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError("NOT_GM", "Only the GM can perform this action."),
-		)
-	case errors.Is(err, service.ErrCampaignNotFound):
-		models.NotFoundError(c, "Campaign")
-	case errors.Is(err, service.ErrNotMember):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError("NOT_MEMBER", "You are not a member of this campaign."),
-		)
-	case errors.Is(err, service.ErrInvalidSettings):
-		models.ValidationError(c, "Invalid campaign settings")
-	case errors.Is(err, service.ErrInviteExpired):
-		models.RespondError(
-			c,
-			http.StatusGone,
-			models.NewAPIError(
-				"INVITE_EXPIRED",
-				"This invite link has expired. Ask the GM for a new one.",
-			),
-		)
-	case errors.Is(err, service.ErrInviteUsed):
-		models.RespondError(
-			c,
-			http.StatusGone,
-			models.NewAPIError("INVITE_USED", "This invite link has already been used."),
-		)
-	case errors.Is(err, service.ErrInviteRevoked):
-		models.RespondError(
-			c,
-			http.StatusGone,
-			models.NewAPIError("INVITE_REVOKED", "This invite link has been revoked."),
-		)
-	case errors.Is(err, service.ErrInviteNotFound):
-		models.NotFoundError(c, "Invite")
-	case errors.Is(err, service.ErrCampaignFull):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError(
-				"CAMPAIGN_FULL",
-				"This campaign has reached the maximum number of players (50).",
-			),
-		)
-	case errors.Is(err, service.ErrAlreadyMember):
-		models.RespondError(
-			c,
-			http.StatusConflict,
-			models.NewAPIError("ALREADY_MEMBER", "You are already a member of this campaign."),
-		)
-	case errors.Is(err, service.ErrCannotLeaveAsGM):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError(
-				"CANNOT_LEAVE_AS_GM",
-				"You must transfer the GM role before leaving the campaign.",
-			),
-		)
-	case errors.Is(err, service.ErrGmNotAbandoned):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError(
-				"GM_NOT_ABANDONED",
-				"The GM is still active. You can only claim the role after 30 days of inactivity.",
-			),
-		)
-	case errors.Is(err, service.ErrInviteLimitReached):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError(
-				"INVITE_LIMIT",
-				"Too many active invites. Please revoke some before creating new ones.",
-			),
-		)
-	default:
-		if err.Error() == "confirmation title does not match campaign title" {
-			models.ValidationError(c, "Confirmation title does not match the campaign title")
-			return
-		}
-		models.InternalError(c)
+	// Transactions carry their deadline on the context, so their timeouts surface
+	// as a bare context error rather than service.ErrDBTimeout
+	if errors.Is(err, context.DeadlineExceeded) && c.Request.Context().Err() == nil {
+		err = fmt.Errorf("%w: %w", service.ErrDBTimeout, err)
 	}
+
+	var coded *service.CodedError
+	if errors.As(err, &coded) {
+		models.RespondError(c, coded.Status, models.NewAPIError(coded.Code, coded.Message))
+		return
+	}
+
+	//nolint:sloglint // Error logging doesn't need structured logger injection
+	slog.ErrorContext(
+		c.Request.Context(),
+		"Unhandled service error",
+		"error", err,
+		"method", c.Request.Method,
+		"route", c.FullPath(),
+		"userId", c.GetString(middleware.UserIDKey),
+	)
+	_ = c.Error(err)
+	models.InternalError(c)
 }
@@ -0,0 +1,117 @@
+//go:build integration
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/middleware"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/testdb"
+)
+
+func TestCampaignMemberEmailVisibility(t *testing.T) {
+	ctx := context.Background()
+	pool := testdb.New(t)
+	queries := generated.New(pool)
+
+	emails := map[string]string{}
+	createUser := func(email string) pgtype.UUID {
+		id := testdb.CreateUser(t, pool, email)
+		emails[uuidToString(id)] = email
+		return id
+	}
+	gm := createUser("gm@example.com")
+	alice := createUser("alice@example.com")
+	bob := createUser("bob@example.com")
+
+	campaign, err := service.NewCampaignService(pool).CreateCampaign(ctx, gm, service.CreateCampaignRequest{
+		Title:       "Test Campaign",
+		Description: "",
+		Settings:    nil,
+	})
+	if err != nil {
+		t.Fatalf("create campaign: %v", err)
+	}
+	for _, player := range []pgtype.UUID{alice, bob} {
+		if _, err = queries.AddCampaignMember(ctx, generated.AddCampaignMemberParams{
+			CampaignID: campaign.ID,
+			UserID:     player,
+			Role:       generated.MemberRolePlayer,
+			Alias:      pgtype.Text{},
+		}); err != nil {
+			t.Fatalf("add member: %v", err)
+		}
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	// memberEmails lists the members as the user, keyed by member user ID
+	memberEmails := func(t *testing.T, userID pgtype.UUID) map[string]string {
+		t.Helper()
+
+		router := gin.New()
+		router.GET("/campaigns/:id/members", func(c *gin.Context) {
+			c.Set(middleware.UserIDKey, uuidToString(userID))
+			c.Set(middleware.UserEmailKey, emails[uuidToString(userID)])
+		}, GetCampaignMembers(&database.DB{Pool: pool}))
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(
+			http.MethodGet, "/campaigns/"+uuidToString(campaign.ID)+"/members", nil,
+		))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("list members status = %d: %s", rec.Code, rec.Body.String())
+		}
+
+		var resp struct {
+			Members []CampaignMemberResponse `json:"members"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode members: %v", err)
+		}
+		seen := make(map[string]string, len(resp.Members))
+		for _, member := range resp.Members {
+			seen[member.UserID] = member.Email
+		}
+		return seen
+	}
+
+	tests := []struct {
+		name    string
+		viewer  pgtype.UUID
+		visible []pgtype.UUID
+	}{
+		{"GM sees every email", gm, []pgtype.UUID{gm, alice, bob}},
+		{"player sees only their own email", alice, []pgtype.UUID{alice}},
+		{"other player sees only their own email", bob, []pgtype.UUID{bob}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := memberEmails(t, tt.viewer)
+			if len(seen) != len(emails) {
+				t.Fatalf("listed %d members, want %d", len(seen), len(emails))
+			}
+
+			want := make(map[string]string, len(tt.visible))
+			for _, id := range tt.visible {
+				want[uuidToString(id)] = emails[uuidToString(id)]
+			}
+			for id, email := range seen {
+				if email != want[id] {
+					t.Errorf("email of %s = %q, want %q", id, email, want[id])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+)
+
+func TestHandleServiceErrorStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"query timeout", fmt.Errorf("%w: %w", service.ErrDBTimeout, context.DeadlineExceeded), http.StatusServiceUnavailable},
+		{"transaction timeout", fmt.Errorf("commit: %w", context.DeadlineExceeded), http.StatusServiceUnavailable},
+		{"coded error", service.ErrNotGM, http.StatusForbidden},
+		{"reworded coded error", service.ErrPassTimeGateExpired, http.StatusForbidden},
+		{"rate limited", service.ErrEmailResendTooSoon, http.StatusTooManyRequests},
+		{"unknown error", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+			handleServiceError(c, tt.err)
+			if w.Code != tt.want {
+				t.Errorf("handleServiceError(%v) status = %d, want %d", tt.err, w.Code, tt.want)
+			}
+		})
+	}
+}
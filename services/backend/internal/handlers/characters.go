@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/middleware"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/models"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
@@ -24,6 +27,8 @@ type UpdateCharacterRequest struct {
 	DisplayName   *string `binding:"omitempty,min=1,max=100" json:"displayName,omitempty"`
 	Description   *string `binding:"omitempty,max=1000"      json:"description,omitempty"`
 	CharacterType *string `binding:"omitempty,oneof=pc npc"  json:"characterType,omitempty"`
+	Pronouns      *string `binding:"omitempty,max=50"        json:"pronouns,omitempty"`
+	GmNotes       *string `binding:"omitempty,max=5000"      json:"gmNotes,omitempty"`
 }
 
 // AssignCharacterRequest represents the request body for assigning a character.
@@ -78,8 +83,9 @@ func CreateCharacter(db *database.DB) gin.HandlerFunc {
 
 		var req CreateCharacterRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(
+			models.BindingError(
 				c,
+				err,
 				"Invalid request. Display name is required (max 100 characters).",
 			)
 			return
@@ -157,7 +163,7 @@ func UpdateCharacter(db *database.DB) gin.HandlerFunc {
 
 		var req UpdateCharacterRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request format")
+			models.BindingError(c, err, "Invalid request format")
 			return
 		}
 
@@ -172,6 +178,8 @@ func UpdateCharacter(db *database.DB) gin.HandlerFunc {
 				DisplayName:   req.DisplayName,
 				Description:   req.Description,
 				CharacterType: req.CharacterType,
+				Pronouns:      req.Pronouns,
+				GmNotes:       req.GmNotes,
 			},
 		)
 		if err != nil {
@@ -261,7 +269,7 @@ func AssignCharacter(db *database.DB) gin.HandlerFunc {
 
 		var req AssignCharacterRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "User ID is required")
+			models.BindingError(c, err, "User ID is required")
 			return
 		}
 
@@ -342,6 +350,78 @@ func GetOrphanedCharacters(db *database.DB) gin.HandlerFunc {
 	}
 }
 
+// CharacterTagRequest represents the request body for adding or removing a character tag.
+type CharacterTagRequest struct {
+	Tag string `json:"tag"`
+}
+
+// AddCharacterTag adds a condition tag to a character (GM only).
+func AddCharacterTag(db *database.DB) gin.HandlerFunc {
+	return characterTagHandler(db, true)
+}
+
+// RemoveCharacterTag removes a condition tag from a character (GM only).
+func RemoveCharacterTag(db *database.DB) gin.HandlerFunc {
+	return characterTagHandler(db, false)
+}
+
+// characterTagHandler adds or removes a character tag and broadcasts the new tags.
+// The tag is read from the JSON body, falling back to the tag query parameter.
+func characterTagHandler(db *database.DB, add bool) gin.HandlerFunc {
+	svc := service.NewCharacterService(db.Pool)
+	queries := service.NewQueries(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		characterID := parseUUID(c.Param("characterId"))
+		if !characterID.Valid {
+			models.ValidationError(c, "Invalid character ID format")
+			return
+		}
+
+		var req CharacterTagRequest
+		_ = c.ShouldBindJSON(&req) // Ignore error if no body
+		if req.Tag == "" {
+			req.Tag = c.Query("tag")
+		}
+
+		userID := parseUUID(userIDStr)
+		var character *generated.Character
+		var err error
+		if add {
+			character, err = svc.AddCharacterTag(c.Request.Context(), campaignID, characterID, userID, req.Tag)
+		} else {
+			character, err = svc.RemoveCharacterTag(c.Request.Context(), campaignID, characterID, userID, req.Tag)
+		}
+		if err != nil {
+			handleCharacterServiceError(c, err)
+			return
+		}
+
+		var sceneID pgtype.UUID
+		if scene, sErr := queries.GetSceneWithCharacter(c.Request.Context(), generated.GetSceneWithCharacterParams{
+			CampaignID: campaignID,
+			Column2:    characterID,
+		}); sErr == nil {
+			sceneID = scene.ID
+		}
+		BroadcastCharacterUpdated(c, campaignID, sceneID, characterID, character.Tags)
+
+		c.JSON(http.StatusOK, character)
+	}
+}
+
 func handleCharacterServiceError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, service.ErrNotGM):
@@ -358,6 +438,19 @@ func handleCharacterServiceError(c *gin.Context, err error) {
 			http.StatusForbidden,
 			models.NewAPIError("NOT_MEMBER", "You are not a member of this campaign."),
 		)
+	case errors.Is(err, service.ErrInvalidCharacterTag):
+		models.ValidationError(c, fmt.Sprintf(
+			"Tags must be between 1 and %d characters.", service.MaxCharacterTagLen,
+		))
+	case errors.Is(err, service.ErrTooManyCharacterTags):
+		models.RespondError(
+			c,
+			http.StatusBadRequest,
+			models.NewAPIError(
+				"TOO_MANY_TAGS",
+				fmt.Sprintf("A character can have at most %d tags.", service.MaxCharacterTags),
+			),
+		)
 	case errors.Is(err, service.ErrCharacterArchived):
 		models.RespondError(
 			c,
@@ -365,6 +458,6 @@ func handleCharacterServiceError(c *gin.Context, err error) {
 			models.NewAPIError("CHARACTER_ARCHIVED", "This character is archived."),
 		)
 	default:
-		models.InternalError(c)
+		handleServiceError(c, err)
 	}
 }
@@ -2,11 +2,11 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
-	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/middleware"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/models"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
@@ -15,7 +15,7 @@ import (
 // AcquireComposeLock acquires a compose lock for a character in a scene.
 func AcquireComposeLock(db *database.DB) gin.HandlerFunc {
 	svc := service.NewComposeService(db.Pool)
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -26,7 +26,7 @@ func AcquireComposeLock(db *database.DB) gin.HandlerFunc {
 
 		var req service.AcquireLockRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request body")
+			models.BindingError(c, err, "Invalid request body")
 			return
 		}
 
@@ -60,7 +60,7 @@ func HeartbeatComposeLock(db *database.DB) gin.HandlerFunc {
 
 		var req service.HeartbeatRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request body")
+			models.BindingError(c, err, "Invalid request body")
 			return
 		}
 
@@ -75,13 +75,46 @@ func HeartbeatComposeLock(db *database.DB) gin.HandlerFunc {
 	}
 }
 
+// HeartbeatManyRequest represents the request body for a batch heartbeat.
+type HeartbeatManyRequest struct {
+	LockIDs []string `binding:"required,min=1" json:"lockIds"`
+}
+
+// HeartbeatComposeLocks refreshes several compose locks owned by the user in one request.
+func HeartbeatComposeLocks(db *database.DB) gin.HandlerFunc {
+	svc := service.NewComposeService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		var req HeartbeatManyRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request body")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		resp, err := svc.HeartbeatMany(c.Request.Context(), userID, req.LockIDs)
+		if err != nil {
+			handleComposeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
 // releaseComposeLockHandler is a shared implementation for release and force-release.
 func releaseComposeLockHandler(
 	db *database.DB,
 	releaseFunc func(svc *service.ComposeService, c *gin.Context, userID, lockID string) error,
 ) gin.HandlerFunc {
 	svc := service.NewComposeService(db.Pool)
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -105,10 +138,12 @@ func releaseComposeLockHandler(
 			return
 		}
 
-		// Broadcast compose lock released (identity protected)
+		// Broadcast compose lock released (identity protected); the lock may have
+		// been all that held the campaign in PC phase
 		if lockErr == nil {
 			if scene, sErr := queries.GetScene(c.Request.Context(), lock.SceneID); sErr == nil {
 				BroadcastComposeLockReleased(c, lock.SceneID, scene.CampaignID)
+				autoTransitionPhase(c, db, scene.CampaignID)
 			}
 		}
 
@@ -138,6 +173,45 @@ func ForceReleaseComposeLock(db *database.DB) gin.HandlerFunc {
 	)
 }
 
+// ForceReleaseSceneComposeLocks releases every compose lock in a scene (GM only).
+func ForceReleaseSceneComposeLocks(db *database.DB) gin.HandlerFunc {
+	svc := service.NewComposeService(db.Pool)
+	queries := service.NewQueries(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		sceneID := parseUUID(c.Param("sceneId"))
+		if !sceneID.Valid {
+			models.ValidationError(c, "Invalid scene ID format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		released, err := svc.ForceReleaseSceneLocks(c.Request.Context(), userID, c.Param("sceneId"))
+		if err != nil {
+			handleComposeError(c, err)
+			return
+		}
+
+		// Broadcast a release for each lock (identity protected)
+		if scene, sErr := queries.GetScene(c.Request.Context(), sceneID); sErr == nil {
+			for range released {
+				BroadcastComposeLockReleased(c, sceneID, scene.CampaignID)
+			}
+			if released > 0 {
+				autoTransitionPhase(c, db, scene.CampaignID)
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"released": released})
+	}
+}
+
 // GetSceneComposeLocks returns all active locks in a scene.
 func GetSceneComposeLocks(db *database.DB) gin.HandlerFunc {
 	svc := service.NewComposeService(db.Pool)
@@ -172,6 +246,71 @@ func GetSceneComposeLocks(db *database.DB) gin.HandlerFunc {
 	}
 }
 
+// ReportTypingPresence broadcasts that the user is typing in a scene. Nothing is
+// stored or locked; clients refresh it while typing and it lapses on its own.
+func ReportTypingPresence(db *database.DB) gin.HandlerFunc {
+	svc := service.NewComposeService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		sceneID := c.Param("sceneId")
+		if sceneID == "" {
+			models.ValidationError(c, "Scene ID is required")
+			return
+		}
+
+		var req service.TypingPresenceRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request body")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		presence, err := svc.ResolveTypingPresence(c.Request.Context(), userID, sceneID, req)
+		if err != nil {
+			handleComposeError(c, err)
+			return
+		}
+
+		BroadcastPresence(c, presence)
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// GetCampaignComposeLocks returns all active locks across a campaign (GM only).
+func GetCampaignComposeLocks(db *database.DB) gin.HandlerFunc {
+	svc := service.NewComposeService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		locks, err := svc.GetCampaignLocks(c.Request.Context(), userID, campaignID)
+		if err != nil {
+			handleComposeError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"locks": locks})
+	}
+}
+
 // UpdateComposeLockHidden updates whether a compose lock is for a hidden post.
 func UpdateComposeLockHidden(db *database.DB) gin.HandlerFunc {
 	svc := service.NewComposeService(db.Pool)
@@ -193,7 +332,7 @@ func UpdateComposeLockHidden(db *database.DB) gin.HandlerFunc {
 			IsHidden bool `json:"isHidden"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request body")
+			models.BindingError(c, err, "Invalid request body")
 			return
 		}
 
@@ -231,6 +370,8 @@ func handleComposeError(c *gin.Context, err error) {
 		)
 	case errors.Is(err, service.ErrCharacterNotInScene):
 		models.ValidationError(c, "Character is not in this scene")
+	case errors.Is(err, service.ErrHeartbeatBatchTooLarge):
+		models.ValidationError(c, fmt.Sprintf("At most %d locks can be refreshed at once", service.MaxHeartbeatBatch))
 	case errors.Is(err, service.ErrNotInPCPhase):
 		models.ValidationError(c, "Posts can only be created during PC Phase")
 	case errors.Is(err, service.ErrTimeGateExpired):
@@ -254,6 +395,6 @@ func handleComposeError(c *gin.Context, err error) {
 			models.NewAPIError("NOT_MEMBER", "You are not a member of this campaign"),
 		)
 	default:
-		models.InternalError(c)
+		handleServiceError(c, err)
 	}
 }
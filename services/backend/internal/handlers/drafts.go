@@ -24,7 +24,7 @@ func SaveDraft(db *database.DB) gin.HandlerFunc {
 
 		var req service.SaveDraftRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request body")
+			models.BindingError(c, err, "Invalid request body")
 			return
 		}
 
@@ -145,6 +145,6 @@ func handleDraftError(c *gin.Context, err error) {
 			models.NewAPIError("NOT_MEMBER", "You are not a member of this campaign"),
 		)
 	default:
-		models.InternalError(c)
+		handleServiceError(c, err)
 	}
 }
@@ -1,11 +1,18 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
 )
 
+// readinessPingTimeout bounds the database ping made by the readiness check.
+const readinessPingTimeout = 2 * time.Second
+
 type HealthResponse struct {
 	Status  string `json:"status"`
 	Version string `json:"version"`
@@ -17,3 +24,53 @@ func HealthCheck(c *gin.Context) {
 		Version: "1.0.0",
 	})
 }
+
+// PoolStats is a snapshot of the database connection pool.
+type PoolStats struct {
+	MaxConns             int32 `json:"maxConns"`
+	TotalConns           int32 `json:"totalConns"`
+	AcquiredConns        int32 `json:"acquiredConns"`
+	IdleConns            int32 `json:"idleConns"`
+	ConstructingConns    int32 `json:"constructingConns"`
+	AcquireCount         int64 `json:"acquireCount"`
+	EmptyAcquireCount    int64 `json:"emptyAcquireCount"`
+	CanceledAcquireCount int64 `json:"canceledAcquireCount"`
+	AcquireDurationMs    int64 `json:"acquireDurationMs"`
+}
+
+type ReadinessResponse struct {
+	Status string    `json:"status"`
+	Pool   PoolStats `json:"pool"`
+}
+
+// ReadinessCheck reports whether the database is reachable, along with connection
+// pool statistics. It responds 503 when the database cannot be pinged.
+func ReadinessCheck(db *database.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stat := db.Pool.Stat()
+		resp := ReadinessResponse{
+			Status: "ready",
+			Pool: PoolStats{
+				MaxConns:             stat.MaxConns(),
+				TotalConns:           stat.TotalConns(),
+				AcquiredConns:        stat.AcquiredConns(),
+				IdleConns:            stat.IdleConns(),
+				ConstructingConns:    stat.ConstructingConns(),
+				AcquireCount:         stat.AcquireCount(),
+				EmptyAcquireCount:    stat.EmptyAcquireCount(),
+				CanceledAcquireCount: stat.CanceledAcquireCount(),
+				AcquireDurationMs:    stat.AcquireDuration().Milliseconds(),
+			},
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readinessPingTimeout)
+		defer cancel()
+		if err := db.Pool.Ping(ctx); err != nil {
+			resp.Status = "unavailable"
+			c.JSON(http.StatusServiceUnavailable, resp)
+			return
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
@@ -32,7 +32,35 @@ func (h *ImageHandler) GetStorageStatus(c *gin.Context) {
 
 	status, err := h.imageService.GetStorageStatus(c.Request.Context(), campaignID)
 	if err != nil {
-		models.InternalError(c)
+		handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// SetStorageLimitRequest is the body of the admin storage limit endpoint.
+type SetStorageLimitRequest struct {
+	StorageLimitMB int `json:"storageLimitMb" binding:"required"`
+}
+
+// SetStorageLimit sets a campaign's storage limit (service role only).
+func (h *ImageHandler) SetStorageLimit(c *gin.Context) {
+	campaignID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		models.ValidationError(c, "Invalid campaign ID")
+		return
+	}
+
+	var req SetStorageLimitRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		models.BindingError(c, bindErr, "Invalid request body")
+		return
+	}
+
+	status, err := h.imageService.SetStorageLimit(c.Request.Context(), campaignID, req.StorageLimitMB)
+	if err != nil {
+		handleImageError(c, err)
 		return
 	}
 
@@ -116,6 +144,78 @@ func (h *ImageHandler) DeleteAvatar(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Avatar deleted"})
 }
 
+// GetAvatarHistory lists a character's previous avatars, newest first.
+func (h *ImageHandler) GetAvatarHistory(c *gin.Context) {
+	campaignID, characterID, gmUserID, ok := avatarHistoryParams(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.imageService.GetAvatarHistory(c.Request.Context(), campaignID, characterID, gmUserID)
+	if err != nil {
+		handleImageError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"history": history})
+}
+
+// RestoreAvatarRequest represents the request body for reselecting a previous avatar.
+type RestoreAvatarRequest struct {
+	HistoryID string `binding:"required,uuid" json:"historyId"`
+}
+
+// RestoreAvatar makes one of a character's previous avatars current again.
+func (h *ImageHandler) RestoreAvatar(c *gin.Context) {
+	campaignID, characterID, gmUserID, ok := avatarHistoryParams(c)
+	if !ok {
+		return
+	}
+
+	var req RestoreAvatarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.BindingError(c, err, "Invalid request body")
+		return
+	}
+
+	url, err := h.imageService.RestoreAvatar(c.Request.Context(), campaignID, characterID, gmUserID, req.HistoryID)
+	if err != nil {
+		handleImageError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"url": url})
+}
+
+// avatarHistoryParams parses the campaign, character, and user IDs for the avatar
+// history endpoints, responding with an error if any is invalid.
+func avatarHistoryParams(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	campaignID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		models.ValidationError(c, "Invalid campaign ID")
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	characterID, err := uuid.Parse(c.Param("characterId"))
+	if err != nil {
+		models.ValidationError(c, "Invalid character ID")
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	userIDStr, ok := middleware.GetUserID(c)
+	if !ok {
+		models.UnauthorizedError(c)
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+	gmUserID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		models.UnauthorizedError(c)
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	return campaignID, characterID, gmUserID, true
+}
+
 // UploadSceneHeader uploads a header image for a scene.
 //
 //nolint:dupl // Handler patterns are intentionally similar across resources
@@ -193,6 +293,56 @@ func (h *ImageHandler) DeleteSceneHeader(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Scene header deleted"})
 }
 
+// UploadUserAvatar uploads the current user's profile avatar.
+func (h *ImageHandler) UploadUserAvatar(c *gin.Context) {
+	userIDStr, ok := middleware.GetUserID(c)
+	if !ok {
+		models.UnauthorizedError(c)
+		return
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		models.UnauthorizedError(c)
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		models.ValidationError(c, "No file provided")
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	url, uploadErr := h.imageService.UploadUserAvatar(c.Request.Context(), userID, file, header)
+	if uploadErr != nil {
+		handleImageError(c, uploadErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"url": url})
+}
+
+// DeleteUserAvatar deletes the current user's profile avatar.
+func (h *ImageHandler) DeleteUserAvatar(c *gin.Context) {
+	userIDStr, ok := middleware.GetUserID(c)
+	if !ok {
+		models.UnauthorizedError(c)
+		return
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		models.UnauthorizedError(c)
+		return
+	}
+
+	if err := h.imageService.DeleteUserAvatar(c.Request.Context(), userID); err != nil {
+		handleImageError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Avatar deleted"})
+}
+
 func handleImageError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, service.ErrNotGM):
@@ -205,7 +355,11 @@ func handleImageError(c *gin.Context, err error) {
 		models.RespondError(c, http.StatusBadRequest, models.NewAPIError("INVALID_FORMAT", err.Error()))
 	case errors.Is(err, service.ErrStorageLimitReached):
 		models.RespondError(c, http.StatusBadRequest, models.NewAPIError("STORAGE_LIMIT_REACHED", err.Error()))
+	case errors.Is(err, service.ErrUserStorageLimitReached):
+		models.RespondError(c, http.StatusBadRequest, models.NewAPIError("STORAGE_LIMIT_REACHED", err.Error()))
+	case errors.Is(err, service.ErrInvalidStorageLimit):
+		models.ValidationError(c, err.Error())
 	default:
-		models.InternalError(c)
+		handleServiceError(c, err)
 	}
 }
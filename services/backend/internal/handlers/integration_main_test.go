@@ -0,0 +1,14 @@
+//go:build integration
+
+package handlers
+
+import (
+	"os"
+	"testing"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/testdb"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testdb.Main(m))
+}
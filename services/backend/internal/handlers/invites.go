@@ -153,7 +153,7 @@ func JoinCampaign(db *database.DB) gin.HandlerFunc {
 
 		var req JoinCampaignRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invite code is required")
+			models.BindingError(c, err, "Invite code is required")
 			return
 		}
 
@@ -166,6 +166,8 @@ func JoinCampaign(db *database.DB) gin.HandlerFunc {
 			return
 		}
 
+		notifyPlayerLimit(c, db, campaign)
+
 		c.JSON(http.StatusOK, campaign)
 	}
 }
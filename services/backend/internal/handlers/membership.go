@@ -34,12 +34,14 @@ func LeaveCampaign(db *database.DB) gin.HandlerFunc {
 		userID := parseUUID(userIDStr)
 		svc := service.NewMembershipService(db.Pool)
 
-		err := svc.LeaveCampaign(c.Request.Context(), campaignID, userID)
+		released, err := svc.LeaveCampaign(c.Request.Context(), campaignID, userID)
 		if err != nil {
 			handleServiceError(c, err)
 			return
 		}
 
+		notifyCharactersOrphaned(c, db, campaignID, released)
+
 		c.JSON(http.StatusOK, gin.H{"message": "Left campaign successfully"})
 	}
 }
@@ -72,13 +74,16 @@ func RemoveMember(db *database.DB) gin.HandlerFunc {
 		userID := parseUUID(userIDStr)
 		svc := service.NewMembershipService(db.Pool)
 
-		err := svc.RemoveMember(c.Request.Context(), campaignID, userID, memberID)
+		released, err := svc.RemoveMember(c.Request.Context(), campaignID, userID, memberID)
 		if err != nil {
 			handleServiceError(c, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+		c.JSON(http.StatusOK, gin.H{
+			"message":            "Member removed successfully",
+			"releasedCharacters": released,
+		})
 	}
 }
 
@@ -102,7 +107,7 @@ func TransferGm(db *database.DB) gin.HandlerFunc {
 
 		var req TransferGmRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "New GM user ID is required")
+			models.BindingError(c, err, "New GM user ID is required")
 			return
 		}
 
@@ -125,7 +130,36 @@ func TransferGm(db *database.DB) gin.HandlerFunc {
 	}
 }
 
-// ClaimGm allows a player to claim GM role after 30 days of GM inactivity.
+// GetGmClaimEligibility previews whether the current user can claim the GM role.
+func GetGmClaimEligibility(db *database.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignIDStr := c.Param("id")
+		campaignID := parseUUID(campaignIDStr)
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		svc := service.NewMembershipService(db.Pool)
+
+		eligibility, err := svc.GetGmClaimEligibility(c.Request.Context(), campaignID, userID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, eligibility)
+	}
+}
+
+// ClaimGm allows a player to claim GM role after the campaign's GM inactivity window.
 func ClaimGm(db *database.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
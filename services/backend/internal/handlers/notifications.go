@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -22,6 +27,10 @@ const (
 	secondsPerHour           = 3600
 	microsecondsPerSecond    = 1000000
 	minTimeStringLength      = 5
+	timeWithSecondsLength    = 8
+	maxHour                  = 23
+	maxMinute                = 59
+	defaultTimezone          = "UTC"
 )
 
 // NotificationHandler handles notification-related requests.
@@ -32,14 +41,15 @@ type NotificationHandler struct {
 
 // NewNotificationHandler creates a new notification handler.
 func NewNotificationHandler(db *database.DB) *NotificationHandler {
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 	return &NotificationHandler{
 		notificationService: service.NewNotificationService(db, queries),
 		queries:             queries,
 	}
 }
 
-// GetNotifications returns a paginated list of notifications for the current user.
+// GetNotifications returns a paginated list of notifications for the current user,
+// optionally filtered by type and read state.
 func (h *NotificationHandler) GetNotifications() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -63,9 +73,20 @@ func (h *NotificationHandler) GetNotifications() gin.HandlerFunc {
 			}
 		}
 
-		notifications, err := h.notificationService.GetNotifications(c.Request.Context(), userID, limit, offset)
+		filter, ok := parseNotificationFilter(c)
+		if !ok {
+			return
+		}
+
+		notifications, err := h.notificationService.GetNotifications(
+			c.Request.Context(), userID, filter, limit, offset,
+		)
 		if err != nil {
-			models.InternalError(c)
+			if errors.Is(err, service.ErrInvalidNotificationType) {
+				models.ValidationError(c, "Invalid notification type")
+				return
+			}
+			handleServiceError(c, err)
 			return
 		}
 
@@ -77,6 +98,32 @@ func (h *NotificationHandler) GetNotifications() gin.HandlerFunc {
 	}
 }
 
+// parseNotificationFilter reads the type and read query parameters. Types can be
+// repeated or comma-separated; read is true, false or all. It writes a validation
+// error and returns false when read is invalid.
+func parseNotificationFilter(c *gin.Context) (service.NotificationFilter, bool) {
+	var filter service.NotificationFilter
+	for _, param := range c.QueryArray("type") {
+		for _, notifType := range strings.Split(param, ",") {
+			if notifType = strings.TrimSpace(notifType); notifType != "" {
+				filter.Types = append(filter.Types, notifType)
+			}
+		}
+	}
+
+	switch read := c.Query("read"); read {
+	case "", "all":
+	case "true", "false":
+		isRead := read == "true"
+		filter.IsRead = &isRead
+	default:
+		models.ValidationError(c, "read must be true, false or all")
+		return filter, false
+	}
+
+	return filter, true
+}
+
 // GetUnreadNotifications returns only unread notifications for the current user.
 func (h *NotificationHandler) GetUnreadNotifications() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -110,7 +157,7 @@ func (h *NotificationHandler) GetUnreadNotifications() gin.HandlerFunc {
 			},
 		)
 		if err != nil {
-			models.InternalError(c)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -132,13 +179,13 @@ func (h *NotificationHandler) GetUnreadCount() gin.HandlerFunc {
 		}
 		userID := parseUUID(userIDStr)
 
-		count, err := h.notificationService.GetUnreadCount(c.Request.Context(), userID)
+		counter, err := h.queries.GetUnreadNotificationCounter(c.Request.Context(), userID)
 		if err != nil {
-			models.InternalError(c)
+			handleServiceError(c, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"count": count})
+		respondUnreadCount(c, counter.UnreadCount, counter.UpdatedAt)
 	}
 }
 
@@ -158,19 +205,101 @@ func (h *NotificationHandler) GetUnreadCountByCampaign() gin.HandlerFunc {
 			return
 		}
 
-		count, err := h.queries.GetUnreadNotificationCountByCampaign(
+		counter, err := h.queries.GetUnreadNotificationCounterByCampaign(
 			c.Request.Context(),
-			generated.GetUnreadNotificationCountByCampaignParams{
+			generated.GetUnreadNotificationCounterByCampaignParams{
 				UserID:     userID,
 				CampaignID: campaignID,
 			},
 		)
 		if err != nil {
-			models.InternalError(c)
+			handleServiceError(c, err)
+			return
+		}
+
+		respondUnreadCount(c, counter.UnreadCount, counter.UpdatedAt)
+	}
+}
+
+// ReconcileUnreadCounts recounts unread notifications for every user and fixes
+// counters that have drifted (admin only).
+func (h *NotificationHandler) ReconcileUnreadCounts() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fixed, err := h.notificationService.ReconcileUnreadCounts(c.Request.Context())
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"fixed": fixed})
+	}
+}
+
+// respondUnreadCount writes an unread count with a weak ETag, or 304 when the client's copy is current.
+// The ETag combines the count with the time its counter last changed, so it changes both when
+// a notification arrives and when one is marked read.
+func respondUnreadCount(c *gin.Context, count int64, changed pgtype.Timestamptz) {
+	var changedNanos int64
+	if changed.Valid {
+		changedNanos = changed.Time.UnixNano()
+	}
+	etag := fmt.Sprintf(`W/"%d-%d"`, count, changedNanos)
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": count})
+}
+
+// etagMatches reports whether an If-None-Match header matches the ETag using weak comparison.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// GetNotification returns a single notification belonging to the current user.
+func (h *NotificationHandler) GetNotification() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+		userID := parseUUID(userIDStr)
+
+		notificationID := parseUUID(c.Param("notificationId"))
+		if !notificationID.Valid {
+			models.ValidationError(c, "Invalid notification ID")
+			return
+		}
+
+		notification, err := h.notificationService.GetNotification(c.Request.Context(), userID, notificationID)
+		if err != nil {
+			if errors.Is(err, service.ErrNotificationNotFound) {
+				models.NotFoundError(c, "Notification")
+				return
+			}
+			handleServiceError(c, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"count": count})
+		c.JSON(http.StatusOK, notification)
 	}
 }
 
@@ -192,7 +321,7 @@ func (h *NotificationHandler) MarkAsRead() gin.HandlerFunc {
 
 		notification, err := h.notificationService.MarkAsRead(c.Request.Context(), notificationID, userID)
 		if err != nil {
-			models.InternalError(c)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -200,6 +329,117 @@ func (h *NotificationHandler) MarkAsRead() gin.HandlerFunc {
 	}
 }
 
+// SnoozeNotificationRequest represents the request body for snoozing a notification.
+type SnoozeNotificationRequest struct {
+	Until time.Time `json:"until" binding:"required"`
+}
+
+// SnoozeNotification hides a notification until the requested time.
+func (h *NotificationHandler) SnoozeNotification() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+		userID := parseUUID(userIDStr)
+
+		notificationID := parseUUID(c.Param("notificationId"))
+		if !notificationID.Valid {
+			models.ValidationError(c, "Invalid notification ID")
+			return
+		}
+
+		var req SnoozeNotificationRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request. until must be an RFC 3339 timestamp.")
+			return
+		}
+
+		notification, err := h.notificationService.Snooze(c.Request.Context(), userID, notificationID, req.Until)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrSnoozeNotInFuture):
+				models.ValidationError(c, "Snooze time must be in the future")
+			case errors.Is(err, service.ErrNotificationNotFound):
+				models.NotFoundError(c, "Notification")
+			default:
+				handleServiceError(c, err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, notification)
+	}
+}
+
+// ResendEmail sends the email for one of the current user's notifications again.
+func (h *NotificationHandler) ResendEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+		userID := parseUUID(userIDStr)
+
+		notificationID := parseUUID(c.Param("notificationId"))
+		if !notificationID.Valid {
+			models.ValidationError(c, "Invalid notification ID")
+			return
+		}
+
+		result, err := h.notificationService.ResendEmail(c.Request.Context(), userID, notificationID)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrNotificationNotFound):
+				models.NotFoundError(c, "Notification")
+			default:
+				handleServiceError(c, err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// TestNotificationRequest represents the optional request body for a test notification.
+type TestNotificationRequest struct {
+	BypassQuietHours bool `json:"bypassQuietHours"`
+}
+
+// SendTestNotification sends the current user a test notification and reports
+// whether its email was sent, queued or suppressed.
+func (h *NotificationHandler) SendTestNotification() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+		userID := parseUUID(userIDStr)
+
+		var req TestNotificationRequest
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				models.BindingError(c, err, "Invalid request. bypassQuietHours must be a boolean.")
+				return
+			}
+		}
+
+		delivery, err := h.notificationService.SendTestNotification(
+			c.Request.Context(), userID, req.BypassQuietHours,
+		)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, delivery)
+	}
+}
+
 // MarkAllAsRead marks all notifications for the current user as read.
 func (h *NotificationHandler) MarkAllAsRead() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -212,7 +452,7 @@ func (h *NotificationHandler) MarkAllAsRead() gin.HandlerFunc {
 
 		count, err := h.notificationService.MarkAllAsRead(c.Request.Context(), userID)
 		if err != nil {
-			models.InternalError(c)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -241,7 +481,7 @@ func (h *NotificationHandler) DeleteNotification() gin.HandlerFunc {
 			UserID: userID,
 		})
 		if err != nil {
-			models.InternalError(c)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -249,8 +489,8 @@ func (h *NotificationHandler) DeleteNotification() gin.HandlerFunc {
 	}
 }
 
-// GetNotificationPreferences returns the user's notification preferences.
-func (h *NotificationHandler) GetNotificationPreferences() gin.HandlerFunc {
+// DeleteAllRead deletes all of the user's read notifications.
+func (h *NotificationHandler) DeleteAllRead() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
 		if !ok {
@@ -259,26 +499,100 @@ func (h *NotificationHandler) GetNotificationPreferences() gin.HandlerFunc {
 		}
 		userID := parseUUID(userIDStr)
 
-		prefs, err := h.queries.GetNotificationPreferences(c.Request.Context(), userID)
+		count, err := h.notificationService.DeleteAllRead(c.Request.Context(), userID)
 		if err != nil {
-			// Return defaults if no preferences set
-			c.JSON(http.StatusOK, gin.H{
-				"email_enabled":   true,
-				"email_frequency": "realtime",
-				"in_app_enabled":  true,
-			})
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"deleted_count": count})
+	}
+}
+
+// DeleteAllByCampaign deletes all of the user's notifications for a campaign.
+func (h *NotificationHandler) DeleteAllByCampaign() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+		userID := parseUUID(userIDStr)
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID")
+			return
+		}
+
+		count, err := h.notificationService.DeleteAllByCampaign(c.Request.Context(), userID, campaignID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"deleted_count": count})
+	}
+}
+
+// NotificationPreferencesResponse represents the user's notification preferences.
+type NotificationPreferencesResponse struct {
+	EmailEnabled    bool                                `json:"email_enabled"`
+	EmailFrequency  string                              `json:"email_frequency"`
+	InAppEnabled    bool                                `json:"in_app_enabled"`
+	TypePreferences service.NotificationTypePreferences `json:"type_preferences"`
+}
+
+// toNotificationPreferencesResponse converts stored preferences to a response.
+func toNotificationPreferencesResponse(prefs generated.NotificationPreference) NotificationPreferencesResponse {
+	return NotificationPreferencesResponse{
+		EmailEnabled:    prefs.EmailEnabled,
+		EmailFrequency:  string(prefs.EmailFrequency),
+		InAppEnabled:    prefs.InAppEnabled,
+		TypePreferences: service.ParseNotificationTypePreferences(prefs.TypePreferences).WithDefaults(),
+	}
+}
+
+// GetNotificationPreferences returns the user's notification preferences.
+func (h *NotificationHandler) GetNotificationPreferences() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
 			return
 		}
+		userID := parseUUID(userIDStr)
 
-		c.JSON(http.StatusOK, prefs)
+		c.JSON(http.StatusOK, loadNotificationPreferences(c.Request.Context(), h.queries, userID))
 	}
 }
 
+// loadNotificationPreferences returns the user's notification preferences, or the
+// defaults when none are set.
+func loadNotificationPreferences(
+	ctx context.Context,
+	queries *generated.Queries,
+	userID pgtype.UUID,
+) NotificationPreferencesResponse {
+	prefs, err := queries.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		return NotificationPreferencesResponse{
+			EmailEnabled:    true,
+			EmailFrequency:  string(generated.NotificationFrequencyRealtime),
+			InAppEnabled:    true,
+			TypePreferences: service.NotificationTypePreferences{}.WithDefaults(),
+		}
+	}
+	return toNotificationPreferencesResponse(prefs)
+}
+
 // UpdateNotificationPreferencesRequest represents the request body for updating preferences.
+// TypePreferences is optional; when omitted, existing per-type preferences are kept.
 type UpdateNotificationPreferencesRequest struct {
-	EmailEnabled   bool   `json:"email_enabled"`
-	EmailFrequency string `json:"email_frequency"`
-	InAppEnabled   bool   `json:"in_app_enabled"`
+	EmailEnabled    bool                                `json:"email_enabled"`
+	EmailFrequency  string                              `json:"email_frequency"`
+	InAppEnabled    bool                                `json:"in_app_enabled"`
+	TypePreferences service.NotificationTypePreferences `json:"type_preferences"`
 }
 
 // UpdateNotificationPreferences updates the user's notification preferences.
@@ -293,7 +607,7 @@ func (h *NotificationHandler) UpdateNotificationPreferences() gin.HandlerFunc {
 
 		var req UpdateNotificationPreferencesRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request body")
+			models.BindingError(c, err, "Invalid request body")
 			return
 		}
 
@@ -316,6 +630,21 @@ func (h *NotificationHandler) UpdateNotificationPreferences() gin.HandlerFunc {
 			return
 		}
 
+		// Validate per-type preferences
+		var typePrefsJSON []byte
+		if req.TypePreferences != nil {
+			if err := req.TypePreferences.Validate(); err != nil {
+				models.ValidationError(c, err.Error())
+				return
+			}
+			encoded, err := json.Marshal(req.TypePreferences)
+			if err != nil {
+				models.InternalError(c)
+				return
+			}
+			typePrefsJSON = encoded
+		}
+
 		prefs, err := h.queries.UpsertNotificationPreferences(
 			c.Request.Context(),
 			generated.UpsertNotificationPreferencesParams{
@@ -323,14 +652,15 @@ func (h *NotificationHandler) UpdateNotificationPreferences() gin.HandlerFunc {
 				EmailEnabled:   req.EmailEnabled,
 				EmailFrequency: emailFreq,
 				InAppEnabled:   req.InAppEnabled,
+				Column5:        typePrefsJSON,
 			},
 		)
 		if err != nil {
-			models.InternalError(c)
+			handleServiceError(c, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, prefs)
+		c.JSON(http.StatusOK, toNotificationPreferencesResponse(prefs))
 	}
 }
 
@@ -344,30 +674,32 @@ func (h *NotificationHandler) GetQuietHours() gin.HandlerFunc {
 		}
 		userID := parseUUID(userIDStr)
 
-		quietHours, err := h.queries.GetQuietHours(c.Request.Context(), userID)
-		if err != nil {
-			// Return defaults if no quiet hours set
-			c.JSON(http.StatusOK, gin.H{
-				"enabled":       false,
-				"start_time":    "22:00",
-				"end_time":      "08:00",
-				"timezone":      "UTC",
-				"urgent_bypass": false,
-			})
-			return
-		}
+		c.JSON(http.StatusOK, loadQuietHours(c.Request.Context(), h.queries, userID))
+	}
+}
 
-		c.JSON(http.StatusOK, quietHours)
+// loadQuietHours returns the user's quiet hours settings, or the defaults when none are set.
+func loadQuietHours(ctx context.Context, queries *generated.Queries, userID pgtype.UUID) any {
+	quietHours, err := queries.GetQuietHours(ctx, userID)
+	if err != nil {
+		return gin.H{
+			"enabled":       false,
+			"start_time":    "22:00",
+			"end_time":      "08:00",
+			"timezone":      "UTC",
+			"urgent_bypass": false,
+		}
 	}
+	return quietHours
 }
 
 // UpdateQuietHoursRequest represents the request body for updating quiet hours.
 type UpdateQuietHoursRequest struct {
-	Enabled      bool   `json:"enabled"`
-	StartTime    string `json:"start_time"`
-	EndTime      string `json:"end_time"`
-	Timezone     string `json:"timezone"`
-	UrgentBypass bool   `json:"urgent_bypass"`
+	Enabled      bool    `json:"enabled"`
+	StartTime    string  `json:"start_time"`
+	EndTime      string  `json:"end_time"`
+	Timezone     *string `json:"timezone"`
+	UrgentBypass bool    `json:"urgent_bypass"`
 }
 
 // UpdateQuietHours updates the user's quiet hours settings.
@@ -382,26 +714,32 @@ func (h *NotificationHandler) UpdateQuietHours() gin.HandlerFunc {
 
 		var req UpdateQuietHoursRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request body")
+			models.BindingError(c, err, "Invalid request body")
 			return
 		}
 
 		// Parse time strings to pgtype.Time
 		startTime, err := parseTimeString(req.StartTime)
 		if err != nil {
-			models.ValidationError(c, "Invalid start_time format. Use HH:MM")
+			models.ValidationError(c, "Invalid start_time: "+err.Error()+". Use HH:MM (00:00-23:59)")
 			return
 		}
 
 		endTime, err := parseTimeString(req.EndTime)
 		if err != nil {
-			models.ValidationError(c, "Invalid end_time format. Use HH:MM")
+			models.ValidationError(c, "Invalid end_time: "+err.Error()+". Use HH:MM (00:00-23:59)")
 			return
 		}
 
-		// Validate timezone
-		if req.Timezone == "" {
-			req.Timezone = "UTC"
+		// Validate timezone (default to UTC only when omitted)
+		timezone := defaultTimezone
+		if req.Timezone != nil {
+			normalized, tzErr := normalizeTimezone(*req.Timezone)
+			if tzErr != nil {
+				models.ValidationError(c, "Invalid timezone. Use an IANA name such as Europe/Stockholm")
+				return
+			}
+			timezone = normalized
 		}
 
 		quietHours, err := h.queries.UpsertQuietHours(c.Request.Context(), generated.UpsertQuietHoursParams{
@@ -409,11 +747,11 @@ func (h *NotificationHandler) UpdateQuietHours() gin.HandlerFunc {
 			Enabled:      req.Enabled,
 			StartTime:    startTime,
 			EndTime:      endTime,
-			Timezone:     req.Timezone,
+			Timezone:     timezone,
 			UrgentBypass: req.UrgentBypass,
 		})
 		if err != nil {
-			models.InternalError(c)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -433,7 +771,7 @@ func (h *NotificationHandler) GetQueuedNotifications() gin.HandlerFunc {
 
 		queued, err := h.queries.GetUserQueuedNotifications(c.Request.Context(), userID)
 		if err != nil {
-			models.InternalError(c)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -444,22 +782,97 @@ func (h *NotificationHandler) GetQueuedNotifications() gin.HandlerFunc {
 	}
 }
 
-// Helper function to parse time string (HH:MM) to pgtype.Time.
+// GetDigestPreview builds a sample digest for the current user without sending it.
+func (h *NotificationHandler) GetDigestPreview() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+		userID := parseUUID(userIDStr)
+
+		frequency := generated.NotificationFrequency(
+			c.DefaultQuery("frequency", string(generated.NotificationFrequencyDigestWeekly)),
+		)
+
+		digest, err := h.notificationService.BuildDigest(c.Request.Context(), userID, frequency, time.Now())
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidDigestFrequency) {
+				models.ValidationError(c, "Invalid frequency. Must be one of: digest_daily, digest_weekly")
+				return
+			}
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, digest)
+	}
+}
+
+// ResolveLink checks whether a notification link still leads to something the
+// current user can open.
+func (h *NotificationHandler) ResolveLink() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+		userID := parseUUID(userIDStr)
+
+		link := c.Query("link")
+		if link == "" {
+			models.ValidationError(c, "link is required")
+			return
+		}
+
+		resolved, err := h.notificationService.ResolveLink(c.Request.Context(), userID, link)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, resolved)
+	}
+}
+
+// Helper function to parse time string (HH:MM) to pgtype.Time. A trailing :SS, as
+// Postgres formats times, is accepted and ignored.
 func parseTimeString(s string) (pgtype.Time, error) {
 	var t pgtype.Time
 	if len(s) < minTimeStringLength {
 		return t, errors.New("time string too short")
 	}
 
-	hours, err := strconv.Atoi(s[:2])
-	if err != nil {
+	if s[2] != ':' || (len(s) != minTimeStringLength && len(s) != timeWithSecondsLength) {
+		return t, errors.New("time string must use HH:MM format")
+	}
+	if len(s) == timeWithSecondsLength {
+		if s[5] != ':' {
+			return t, errors.New("time string must use HH:MM format")
+		}
+		if seconds, ok := twoDigits(s[6:]); !ok || seconds > maxMinute {
+			return t, errors.New("invalid seconds format")
+		}
+	}
+
+	hours, ok := twoDigits(s[:2])
+	if !ok {
 		return t, errors.New("invalid hours format")
 	}
-	minutes, err := strconv.Atoi(s[3:5])
-	if err != nil {
+	minutes, ok := twoDigits(s[3:5])
+	if !ok {
 		return t, errors.New("invalid minutes format")
 	}
 
+	if hours > maxHour {
+		return t, errors.New("hours must be between 00 and 23")
+	}
+	if minutes > maxMinute {
+		return t, errors.New("minutes must be between 00 and 59")
+	}
+
 	// Convert to microseconds since midnight
 	microseconds := int64(hours*secondsPerHour+minutes*secondsPerMinute) * microsecondsPerSecond
 	t.Microseconds = microseconds
@@ -468,6 +881,62 @@ func parseTimeString(s string) (pgtype.Time, error) {
 	return t, nil
 }
 
+// twoDigits parses exactly two ASCII digits, rejecting signs and spaces that
+// strconv.Atoi would let through.
+func twoDigits(s string) (int, bool) {
+	if len(s) != 2 || s[0] < '0' || s[0] > '9' || s[1] < '0' || s[1] > '9' {
+		return 0, false
+	}
+	return int(s[0]-'0')*10 + int(s[1]-'0'), true
+}
+
+// normalizeTimezone resolves common aliases and validates the zone against the IANA database.
+func normalizeTimezone(tz string) (string, error) {
+	name := strings.TrimSpace(tz)
+	if alias, ok := timezoneAliases()[strings.ToLower(name)]; ok {
+		name = alias
+	}
+
+	if name == "" || strings.EqualFold(name, "local") {
+		return "", errors.New("timezone must be an IANA zone name")
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return "", err
+	}
+
+	return loc.String(), nil
+}
+
+// timezoneAliases maps common shorthand names to IANA zone names.
+func timezoneAliases() map[string]string {
+	return map[string]string{
+		"utc":       defaultTimezone,
+		"gmt":       defaultTimezone,
+		"z":         defaultTimezone,
+		"zulu":      defaultTimezone,
+		"universal": defaultTimezone,
+		"est":       "America/New_York",
+		"edt":       "America/New_York",
+		"eastern":   "America/New_York",
+		"cst":       "America/Chicago",
+		"cdt":       "America/Chicago",
+		"central":   "America/Chicago",
+		"mst":       "America/Denver",
+		"mdt":       "America/Denver",
+		"mountain":  "America/Denver",
+		"pst":       "America/Los_Angeles",
+		"pdt":       "America/Los_Angeles",
+		"pacific":   "America/Los_Angeles",
+		"bst":       "Europe/London",
+		"cet":       "Europe/Paris",
+		"cest":      "Europe/Paris",
+		"jst":       "Asia/Tokyo",
+		"aest":      "Australia/Sydney",
+	}
+}
+
 // safeInt32 safely converts an int to int32 with bounds checking.
 func safeInt32(n int) int32 {
 	if n > int(^int32(0)) {
@@ -0,0 +1,52 @@
+package handlers
+
+import "testing"
+
+func TestParseTimeString(t *testing.T) {
+	const microsPerMinute = secondsPerMinute * microsecondsPerSecond
+
+	tests := []struct {
+		name    string
+		input   string
+		want    int64 // minutes since midnight
+		wantErr bool
+	}{
+		{name: "midnight", input: "00:00", want: 0},
+		{name: "morning", input: "08:30", want: 8*60 + 30},
+		{name: "last minute of the day", input: "23:59", want: 23*60 + 59},
+		{name: "postgres seconds are ignored", input: "22:15:00", want: 22*60 + 15},
+		{name: "hour 24", input: "24:00", wantErr: true},
+		{name: "hour 99", input: "99:00", wantErr: true},
+		{name: "minute 60", input: "12:60", wantErr: true},
+		{name: "seconds 60", input: "12:00:60", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+		{name: "single digit hour", input: "8:30", wantErr: true},
+		{name: "missing colon", input: "0830", wantErr: true},
+		{name: "dot separator", input: "08.30", wantErr: true},
+		{name: "signed hour", input: "+1:30", wantErr: true},
+		{name: "negative minutes", input: "10:-1", wantErr: true},
+		{name: "letters", input: "ab:cd", wantErr: true},
+		{name: "trailing garbage", input: "12:30xyz", wantErr: true},
+		{name: "three digit minutes", input: "12:345", wantErr: true},
+		{name: "bad seconds separator", input: "12:30-00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTimeString(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseTimeString(%q) = %v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTimeString(%q) error: %v", tt.input, err)
+			}
+			if !got.Valid || got.Microseconds != tt.want*microsPerMinute {
+				t.Errorf("parseTimeString(%q) = %d us (valid %v), want %d minutes",
+					tt.input, got.Microseconds, got.Valid, tt.want)
+			}
+		})
+	}
+}
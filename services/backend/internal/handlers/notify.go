@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+)
+
+// notifyPlayerLimit warns the GM when a join brings the campaign near or to its player limit.
+func notifyPlayerLimit(c *gin.Context, db *database.DB, campaign *generated.Campaign) {
+	ctx := context.WithoutCancel(c.Request.Context())
+
+	go func() {
+		queries := service.NewQueries(db.Pool)
+		count, err := queries.CountCampaignPlayers(ctx, campaign.ID)
+		if err != nil {
+			return
+		}
+
+		// Small tables only hear about being full; a near-limit warning would fire too early
+		limit := int64(service.MaxPlayers(campaign.Settings))
+		nearLimit := limit > 2*service.PlayerLimitWarningMargin && count == limit-service.PlayerLimitWarningMargin
+		if !nearLimit && count < limit {
+			return
+		}
+
+		svc := service.NewNotificationService(db, queries)
+		if notifyErr := svc.NotifyCampaignPlayerLimit(
+			ctx,
+			campaign.ID,
+			campaign.Title,
+			count,
+			limit,
+		); notifyErr != nil {
+			//nolint:sloglint // Error logging doesn't need structured logger injection
+			slog.Error("Failed to send player limit notification", "error", notifyErr)
+		}
+	}()
+}
+
+// notifySceneLimit warns the GM when a new scene brings the campaign near its scene limit.
+func notifySceneLimit(c *gin.Context, db *database.DB, campaignID pgtype.UUID, response *service.CreateSceneResponse) {
+	if !response.NearLimit {
+		return
+	}
+
+	ctx := context.WithoutCancel(c.Request.Context())
+
+	go func() {
+		queries := service.NewQueries(db.Pool)
+		campaign, err := queries.GetCampaign(ctx, campaignID)
+		if err != nil {
+			return
+		}
+
+		svc := service.NewNotificationService(db, queries)
+		if notifyErr := svc.NotifySceneLimitWarning(
+			ctx,
+			campaignID,
+			campaign.Title,
+			response.SceneCount,
+			response.SceneLimit,
+		); notifyErr != nil {
+			//nolint:sloglint // Error logging doesn't need structured logger injection
+			slog.Error("Failed to send scene limit notification", "error", notifyErr)
+		}
+	}()
+}
+
+// notifyMentions notifies users who were @mentioned in a submitted post.
+func notifyMentions(c *gin.Context, db *database.DB, resp *service.PostResponse) {
+	if len(resp.Mentions) == 0 {
+		return
+	}
+
+	ctx := context.WithoutCancel(c.Request.Context())
+
+	go func() {
+		queries := service.NewQueries(db.Pool)
+		scene, err := queries.GetScene(ctx, parseUUID(resp.SceneID))
+		if err != nil {
+			return
+		}
+
+		userIDs := make([]pgtype.UUID, 0, len(resp.Mentions))
+		for _, m := range resp.Mentions {
+			userIDs = append(userIDs, parseUUID(m))
+		}
+
+		svc := service.NewNotificationService(db, queries)
+		if notifyErr := svc.NotifyMentioned(
+			ctx,
+			scene.CampaignID,
+			scene.ID,
+			parseUUID(resp.ID),
+			userIDs,
+			scene.Title,
+		); notifyErr != nil {
+			//nolint:sloglint // Error logging doesn't need structured logger injection
+			slog.Error("Failed to send mention notifications", "error", notifyErr)
+		}
+	}()
+}
+
+// notifyPassCleared tells the GM that a player's post cleared their character's pass.
+// GM posts are skipped since the GM already knows.
+func notifyPassCleared(c *gin.Context, db *database.DB, scene *generated.Scene, resp *service.PostResponse) {
+	ctx := context.WithoutCancel(c.Request.Context())
+	characterName := "A character"
+	if resp.CharacterName != nil {
+		characterName = *resp.CharacterName
+	}
+
+	go func() {
+		queries := service.NewQueries(db.Pool)
+		gmUserID, err := queries.GetGMUserID(ctx, scene.CampaignID)
+		if err != nil || gmUserID == parseUUID(resp.UserID) {
+			return
+		}
+
+		svc := service.NewNotificationService(db, queries)
+		if notifyErr := svc.NotifyPassStateCleared(
+			ctx,
+			scene.CampaignID,
+			scene.ID,
+			parseUUID(*resp.CharacterID),
+			characterName,
+			scene.Title,
+		); notifyErr != nil {
+			//nolint:sloglint // Error logging doesn't need structured logger injection
+			slog.Error("Failed to send pass cleared notification", "error", notifyErr)
+		}
+	}()
+}
+
+// notifyPhaseAutoTransitioned tells the GM the campaign moved to GM phase automatically.
+func notifyPhaseAutoTransitioned(c *gin.Context, db *database.DB, campaign *generated.Campaign) {
+	ctx := context.WithoutCancel(c.Request.Context())
+
+	go func() {
+		queries := service.NewQueries(db.Pool)
+		svc := service.NewNotificationService(db, queries)
+		if notifyErr := svc.NotifyPhaseAutoTransitioned(ctx, campaign.ID, campaign.Title); notifyErr != nil {
+			//nolint:sloglint // Error logging doesn't need structured logger injection
+			slog.Error("Failed to send auto transition notification", "error", notifyErr)
+		}
+	}()
+}
+
+// notifyCharactersOrphaned tells the GM which characters a departing player left behind.
+func notifyCharactersOrphaned(
+	c *gin.Context,
+	db *database.DB,
+	campaignID pgtype.UUID,
+	released []service.ReleasedCharacter,
+) {
+	if len(released) == 0 {
+		return
+	}
+
+	ctx := context.WithoutCancel(c.Request.Context())
+	names := make([]string, 0, len(released))
+	archived := false
+	for _, char := range released {
+		names = append(names, char.Name)
+		archived = archived || char.Archived
+	}
+
+	go func() {
+		svc := service.NewNotificationService(db, service.NewQueries(db.Pool))
+		if notifyErr := svc.NotifyCharactersOrphaned(ctx, campaignID, names, archived); notifyErr != nil {
+			//nolint:sloglint // Error logging doesn't need structured logger injection
+			slog.Error("Failed to send orphaned characters notification", "error", notifyErr)
+		}
+	}()
+}
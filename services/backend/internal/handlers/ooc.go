@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/middleware"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/models"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+)
+
+// ListOocMessages returns the OOC discussion for a scene.
+func ListOocMessages(db *database.DB) gin.HandlerFunc {
+	svc := service.NewOocService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		sceneID := parseUUID(c.Param("sceneId"))
+		if !sceneID.Valid {
+			models.ValidationError(c, "Invalid scene ID format")
+			return
+		}
+
+		messages, err := svc.ListOocMessages(c.Request.Context(), campaignID, sceneID, parseUUID(userIDStr))
+		if err != nil {
+			handleOocError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"messages": messages})
+	}
+}
+
+// CreateOocMessage posts an OOC message to a scene and broadcasts it.
+func CreateOocMessage(db *database.DB) gin.HandlerFunc {
+	svc := service.NewOocService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		sceneID := parseUUID(c.Param("sceneId"))
+		if !sceneID.Valid {
+			models.ValidationError(c, "Invalid scene ID format")
+			return
+		}
+
+		var req service.CreateOocMessageRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request body")
+			return
+		}
+
+		msg, err := svc.CreateOocMessage(c.Request.Context(), campaignID, sceneID, parseUUID(userIDStr), req)
+		if err != nil {
+			handleOocError(c, err)
+			return
+		}
+
+		BroadcastOocMessageCreated(c, campaignID, msg)
+
+		c.JSON(http.StatusCreated, msg)
+	}
+}
+
+// DeleteOocMessage deletes an OOC message (author or GM only).
+func DeleteOocMessage(db *database.DB) gin.HandlerFunc {
+	svc := service.NewOocService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		sceneID := parseUUID(c.Param("sceneId"))
+		if !sceneID.Valid {
+			models.ValidationError(c, "Invalid scene ID format")
+			return
+		}
+
+		messageID := parseUUID(c.Param("messageId"))
+		if !messageID.Valid {
+			models.ValidationError(c, "Invalid message ID format")
+			return
+		}
+
+		if err := svc.DeleteOocMessage(
+			c.Request.Context(),
+			campaignID,
+			sceneID,
+			messageID,
+			parseUUID(userIDStr),
+		); err != nil {
+			handleOocError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func handleOocError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrSceneNotFound):
+		models.NotFoundError(c, "Scene")
+	case errors.Is(err, service.ErrOocMessageNotFound):
+		models.NotFoundError(c, "OOC message")
+	case errors.Is(err, service.ErrNotMember):
+		models.RespondError(
+			c,
+			http.StatusForbidden,
+			models.NewAPIError("NOT_MEMBER", "You are not a member of this campaign"),
+		)
+	case errors.Is(err, service.ErrNotOocAuthor):
+		models.RespondError(
+			c,
+			http.StatusForbidden,
+			models.NewAPIError("NOT_OOC_AUTHOR", "Only the author or GM can delete this message"),
+		)
+	case errors.Is(err, service.ErrInvalidOocMessage), errors.Is(err, service.ErrInvalidOocParent):
+		models.ValidationError(c, err.Error())
+	default:
+		handleServiceError(c, err)
+	}
+}
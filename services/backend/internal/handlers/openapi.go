@@ -0,0 +1,424 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/models"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/openapi"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+)
+
+// apiVersion is the version reported in the OpenAPI document.
+const apiVersion = "1.0.0"
+
+// OpenAPISpec serves the OpenAPI document for the registered routes. Documented
+// routes that are not registered are dropped and logged, so the document never
+// advertises an endpoint the server does not serve.
+func OpenAPISpec(registered gin.RoutesInfo) gin.HandlerFunc {
+	served := make(map[string]bool, len(registered))
+	for _, route := range registered {
+		served[route.Method+" "+route.Path] = true
+	}
+
+	builder := openapi.New("Vanguard PBP API", apiVersion, models.ErrorResponse{Error: nil})
+	for _, route := range apiRoutes() {
+		if !served[route.Method+" "+route.Path] {
+			//nolint:sloglint // Using global logger during initialization is acceptable
+			slog.Warn("OpenAPI route is not registered", "method", route.Method, "path", route.Path)
+			continue
+		}
+		builder.Add(route)
+	}
+
+	body, err := json.Marshal(builder.Document())
+
+	return func(c *gin.Context) {
+		if err != nil {
+			models.InternalError(c)
+			return
+		}
+		c.Data(http.StatusOK, "application/json", body)
+	}
+}
+
+// apiRoutes documents the main resources: campaigns, scenes, posts, rolls, and notifications.
+//
+//nolint:funlen,exhaustruct // Route tables are long, and most routes leave some fields empty
+func apiRoutes() []openapi.Route {
+	const (
+		tagCampaigns     = "campaigns"
+		tagScenes        = "scenes"
+		tagPosts         = "posts"
+		tagRolls         = "rolls"
+		tagNotifications = "notifications"
+	)
+
+	notificationPage := openapi.Fields{
+		"notifications": []generated.Notification{},
+		"limit":         int32(0),
+		"offset":        int32(0),
+	}
+
+	return []openapi.Route{
+		// Campaigns
+		{
+			Method: http.MethodGet, Path: "/api/v1/campaigns", Tag: tagCampaigns,
+			Summary:  "List the current user's campaigns",
+			Response: openapi.Fields{"campaigns": []CampaignListResponse{}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns", Tag: tagCampaigns,
+			Summary: "Create a campaign",
+			Request: CreateCampaignRequest{}, Response: generated.Campaign{}, Status: http.StatusCreated,
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/campaigns/:id", Tag: tagCampaigns,
+			Summary:  "Get a campaign with the current user's role",
+			Response: CampaignResponse{},
+		},
+		{
+			Method: http.MethodPatch, Path: "/api/v1/campaigns/:id", Tag: tagCampaigns,
+			Summary: "Update a campaign (GM only)",
+			Request: UpdateCampaignRequest{}, Response: UpdateCampaignResponse{},
+		},
+		{
+			Method: http.MethodDelete, Path: "/api/v1/campaigns/:id", Tag: tagCampaigns,
+			Summary: "Delete a campaign (GM only)",
+			Request: DeleteCampaignRequest{}, Response: openapi.Fields{"message": ""},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns/:id/pause", Tag: tagCampaigns,
+			Summary:  "Pause a campaign (GM only)",
+			Response: generated.Campaign{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns/:id/resume", Tag: tagCampaigns,
+			Summary:  "Resume a campaign (GM only)",
+			Response: generated.Campaign{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/campaigns/:id/members", Tag: tagCampaigns,
+			Summary:  "List campaign members",
+			Response: openapi.Fields{"members": []CampaignMemberResponse{}},
+		},
+
+		// Scenes
+		{
+			Method: http.MethodGet, Path: "/api/v1/campaigns/:id/scenes", Tag: tagScenes,
+			Summary: "List a campaign's scenes",
+			Query:   []string{"characterId"},
+			Response: openapi.Fields{
+				"scenes":  []service.SceneListItem{},
+				"count":   int64(0),
+				"warning": "",
+			},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns/:id/scenes", Tag: tagScenes,
+			Summary: "Create a scene (GM only)",
+			Request: CreateSceneRequest{}, Response: service.CreateSceneResponse{}, Status: http.StatusCreated,
+		},
+		{
+			Method: http.MethodPatch, Path: "/api/v1/campaigns/:id/scenes/order", Tag: tagScenes,
+			Summary: "Reorder scenes (GM only)",
+			Request: ReorderScenesRequest{}, Response: openapi.Fields{"scenes": []generated.Scene{}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns/:id/scenes/bulk-archive", Tag: tagScenes,
+			Summary: "Archive or unarchive several scenes (GM only)",
+			Request: BulkArchiveScenesRequest{}, Response: openapi.Fields{"scenes": []generated.Scene{}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/campaigns/:id/scenes/:sceneId", Tag: tagScenes,
+			Summary:  "Get a scene",
+			Response: generated.Scene{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/campaigns/:id/scenes/:sceneId/bundle", Tag: tagScenes,
+			Summary:  "Get a scene with its characters, posts, pass states, locks, rolls, and phase",
+			Response: service.SceneBundle{},
+		},
+		{
+			Method: http.MethodPatch, Path: "/api/v1/campaigns/:id/scenes/:sceneId", Tag: tagScenes,
+			Summary: "Update a scene (GM only)",
+			Request: UpdateSceneRequest{}, Response: generated.Scene{},
+		},
+		{
+			Method: http.MethodDelete, Path: "/api/v1/campaigns/:id/scenes/:sceneId", Tag: tagScenes,
+			Summary: "Delete a scene (GM only)",
+			Status:  http.StatusNoContent,
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns/:id/scenes/:sceneId/archive", Tag: tagScenes,
+			Summary:  "Archive a scene (GM only)",
+			Response: generated.Scene{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns/:id/scenes/:sceneId/unarchive", Tag: tagScenes,
+			Summary:  "Unarchive a scene (GM only)",
+			Response: generated.Scene{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns/:id/scenes/:sceneId/freeze", Tag: tagScenes,
+			Summary:  "Freeze a scene against new posts and edits (GM only)",
+			Response: generated.Scene{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns/:id/scenes/:sceneId/unfreeze", Tag: tagScenes,
+			Summary:  "Unfreeze a scene (GM only)",
+			Response: generated.Scene{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns/:id/scenes/:sceneId/clone", Tag: tagScenes,
+			Summary: "Clone a scene (GM only)",
+			Request: CloneSceneRequest{}, Response: CloneSceneResponse{}, Status: http.StatusCreated,
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns/:id/scenes/:sceneId/characters", Tag: tagScenes,
+			Summary: "Add a character to a scene (GM only)",
+			Request: SceneCharacterRequest{}, Response: service.SceneCharacterMove{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/campaigns/:id/scenes/:sceneId/characters", Tag: tagScenes,
+			Summary:  "List the characters in a scene",
+			Response: openapi.Fields{"characters": []generated.GetSceneCharactersRow{}},
+		},
+
+		// Posts
+		{
+			Method: http.MethodGet, Path: "/api/v1/campaigns/:id/scenes/:sceneId/posts", Tag: tagPosts,
+			Summary:  "List the posts in a scene visible to the current user",
+			Query:    []string{"characterId"},
+			Response: openapi.Fields{"posts": []service.PostResponse{}},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns/:id/scenes/:sceneId/posts", Tag: tagPosts,
+			Summary: "Create a post; pass submit=false to save it as a draft",
+			Query:   []string{"submit"},
+			Request: service.CreatePostRequest{}, Response: service.PostResponse{}, Status: http.StatusCreated,
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns/:id/scenes/:sceneId/read", Tag: tagPosts,
+			Summary: "Mark a scene read up to a post",
+			Request: MarkSceneReadRequest{}, Response: service.SceneReadResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/campaigns/:id/scenes/:sceneId/grant-witness", Tag: tagPosts,
+			Summary: "Add a scene character as a witness to the scene's posts (GM only)",
+			Request: GrantWitnessRequest{}, Response: service.GrantWitnessResult{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/posts/:postId", Tag: tagPosts,
+			Summary:  "Get a post",
+			Response: service.PostResponse{},
+		},
+		{
+			Method: http.MethodPatch, Path: "/api/v1/posts/:postId", Tag: tagPosts,
+			Summary: "Edit a post",
+			Request: service.UpdatePostRequest{}, Response: service.PostResponse{},
+		},
+		{
+			Method: http.MethodDelete, Path: "/api/v1/posts/:postId", Tag: tagPosts,
+			Summary:  "Delete a post; the GM can pass mode=tombstone to replace it with a placeholder instead",
+			Query:    []string{"mode"},
+			Response: openapi.Fields{"success": true},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/posts/:postId/submit", Tag: tagPosts,
+			Summary: "Submit a draft post",
+			Request: SubmitPostRequest{}, Response: service.PostResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/posts/:postId/unhide", Tag: tagPosts,
+			Summary: "Reveal a hidden post (GM only)",
+			Request: service.UnhidePostRequest{}, Response: service.PostResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/posts/:postId/pin", Tag: tagPosts,
+			Summary:  "Pin a post to the top of its scene (GM only)",
+			Response: service.PostResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/posts/:postId/unpin", Tag: tagPosts,
+			Summary:  "Unpin a post (GM only)",
+			Response: service.PostResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/posts/:postId/restore", Tag: tagPosts,
+			Summary:  "Restore a post removed by the GM (GM only)",
+			Response: service.PostResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/posts/:postId/move", Tag: tagPosts,
+			Summary: "Move a post to another scene in the campaign (GM only)",
+			Request: MovePostRequest{}, Response: service.PostResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/posts/:postId/attribute", Tag: tagPosts,
+			Summary: "Attribute a narrator post to an NPC in its scene (GM only)",
+			Request: AttributePostRequest{}, Response: service.PostResponse{},
+		},
+		{
+			Method: http.MethodPatch, Path: "/api/v1/posts/:postId/witnesses", Tag: tagPosts,
+			Summary: "Change who witnessed a post (GM only)",
+			Request: service.UpdatePostWitnessesRequest{}, Response: service.PostResponse{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/posts/:postId/reactions", Tag: tagPosts,
+			Summary:  "List reactions on a post",
+			Response: service.PostReactionsResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/posts/:postId/reactions", Tag: tagPosts,
+			Summary: "React to a post",
+			Request: PostReactionRequest{}, Response: service.PostReactionsResponse{},
+		},
+		{
+			Method: http.MethodDelete, Path: "/api/v1/posts/:postId/reactions", Tag: tagPosts,
+			Summary: "Remove a reaction from a post",
+			Query:   []string{"emoji"},
+			Request: PostReactionRequest{}, Response: service.PostReactionsResponse{},
+		},
+
+		// Rolls
+		{
+			Method: http.MethodPost, Path: "/api/v1/rolls", Tag: tagRolls,
+			Summary: "Create a roll",
+			Request: service.CreateRollRequest{}, Response: service.RollResponse{}, Status: http.StatusCreated,
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/rolls/batch", Tag: tagRolls,
+			Summary: "Create several rolls at once",
+			Request: service.CreateRollsRequest{}, Response: openapi.Fields{"rolls": []service.RollResponse{}},
+			Status: http.StatusCreated,
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/rolls/:rollId", Tag: tagRolls,
+			Summary:  "Get a roll",
+			Response: service.RollResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/rolls/:rollId/override-intention", Tag: tagRolls,
+			Summary: "Override a roll's intention (GM only)",
+			Request: service.OverrideIntentionRequest{}, Response: service.RollResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/rolls/:rollId/resolve", Tag: tagRolls,
+			Summary: "Resolve a roll manually (GM only)",
+			Request: service.ManualResolveRequest{}, Response: service.RollResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/rolls/:rollId/invalidate", Tag: tagRolls,
+			Summary:  "Invalidate a roll (GM only)",
+			Response: service.RollResponse{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/posts/:postId/rolls", Tag: tagRolls,
+			Summary:  "List the rolls attached to a post",
+			Response: openapi.Fields{"rolls": []service.RollResponse{}},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/characters/:characterId/rolls", Tag: tagRolls,
+			Summary:  "List a character's rolls, newest first (owner or GM)",
+			Query:    []string{"limit", "cursor", "includeInvalidated"},
+			Response: service.CharacterRollsPage{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/campaigns/:id/rolls/unresolved", Tag: tagRolls,
+			Summary:  "List unresolved rolls in a campaign (GM only)",
+			Query:    []string{"limit", "offset", "since"},
+			Response: service.UnresolvedRollsPage{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/scenes/:sceneId/rolls", Tag: tagRolls,
+			Summary:  "List the rolls in a scene",
+			Query:    []string{"status", "characterId"},
+			Response: openapi.Fields{"rolls": []service.RollResponse{}},
+		},
+
+		// Notifications
+		{
+			Method: http.MethodGet, Path: "/api/v1/notifications", Tag: tagNotifications,
+			Summary:  "List the current user's notifications",
+			Query:    []string{"limit", "offset", "type", "read"},
+			Response: notificationPage,
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/notifications/unread", Tag: tagNotifications,
+			Summary:  "List unread notifications",
+			Query:    []string{"limit", "offset"},
+			Response: notificationPage,
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/notifications/unread/count", Tag: tagNotifications,
+			Summary:  "Count unread notifications",
+			Response: openapi.Fields{"count": int64(0)},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/notifications/:notificationId", Tag: tagNotifications,
+			Summary:  "Get a notification",
+			Response: generated.GetUserNotificationRow{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/notifications/:notificationId/read", Tag: tagNotifications,
+			Summary:  "Mark a notification read",
+			Response: generated.Notification{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/notifications/:notificationId/snooze", Tag: tagNotifications,
+			Summary: "Snooze a notification",
+			Request: SnoozeNotificationRequest{}, Response: generated.Notification{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/notifications/test", Tag: tagNotifications,
+			Summary: "Send yourself a test notification to check email delivery",
+			Request: TestNotificationRequest{}, Response: service.EmailDelivery{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/notifications/:notificationId/resend-email", Tag: tagNotifications,
+			Summary:  "Send a notification's email again (at most once a minute)",
+			Response: service.ResendEmailResult{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/notifications/read-all", Tag: tagNotifications,
+			Summary:  "Mark all notifications read",
+			Response: openapi.Fields{"marked_count": int64(0)},
+		},
+		{
+			Method: http.MethodDelete, Path: "/api/v1/notifications/:notificationId", Tag: tagNotifications,
+			Summary:  "Delete a notification",
+			Response: openapi.Fields{"success": true},
+		},
+		{
+			Method: http.MethodDelete, Path: "/api/v1/notifications/read", Tag: tagNotifications,
+			Summary:  "Delete all read notifications",
+			Response: openapi.Fields{"deleted_count": int64(0)},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/notifications/queued", Tag: tagNotifications,
+			Summary:  "List notifications queued for later delivery",
+			Response: openapi.Fields{"queued": []generated.NotificationQueue{}, "count": 0},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/links/resolve", Tag: tagNotifications,
+			Summary:  "Check whether a notification link still leads somewhere",
+			Query:    []string{"link"},
+			Response: service.ResolvedLink{},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/admin/notifications/reconcile-unread", Tag: tagNotifications,
+			Summary:  "Fix unread notification counters that drifted from the notifications table (service role only)",
+			Response: openapi.Fields{"fixed": int64(0)},
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/admin/campaigns/:id/storage-limit", Tag: tagCampaigns,
+			Summary: "Set a campaign's image storage limit (service role only)",
+			Request: SetStorageLimitRequest{}, Response: service.StorageStatus{},
+		},
+	}
+}
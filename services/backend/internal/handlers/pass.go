@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"errors"
+	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -38,7 +38,7 @@ func GetCampaignPassSummary(db *database.DB) gin.HandlerFunc {
 		svc := service.NewPassService(db.Pool)
 		summary, err := svc.GetCampaignPassSummary(c.Request.Context(), campaignID, userID)
 		if err != nil {
-			handlePassError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -55,19 +55,19 @@ func GetScenePassStates(db *database.DB) gin.HandlerFunc {
 			return
 		}
 
-		sceneIDStr := c.Param("sceneId")
-		if sceneIDStr == "" {
-			models.ValidationError(c, "Scene ID is required")
+		campaignID := parseUUID(c.Param("id"))
+		sceneID := parseUUID(c.Param("sceneId"))
+		if !campaignID.Valid || !sceneID.Valid {
+			models.ValidationError(c, "Invalid campaign or scene ID format")
 			return
 		}
 
 		userID := parseUUID(userIDStr)
-		sceneID := parseUUID(sceneIDStr)
 
 		svc := service.NewPassService(db.Pool)
-		passStates, err := svc.GetScenePassStates(c.Request.Context(), sceneID, userID)
+		passStates, err := svc.GetScenePassStates(c.Request.Context(), campaignID, sceneID, userID)
 		if err != nil {
-			handlePassError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -77,8 +77,6 @@ func GetScenePassStates(db *database.DB) gin.HandlerFunc {
 
 // SetPass sets the pass state for a character in a scene.
 func SetPass(db *database.DB) gin.HandlerFunc {
-	queries := generated.New(db.Pool)
-
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
 		if !ok {
@@ -86,34 +84,34 @@ func SetPass(db *database.DB) gin.HandlerFunc {
 			return
 		}
 
-		sceneIDStr := c.Param("sceneId")
-		characterIDStr := c.Param("characterId")
-		if sceneIDStr == "" || characterIDStr == "" {
-			models.ValidationError(c, "Scene ID and Character ID are required")
+		campaignID := parseUUID(c.Param("id"))
+		sceneID := parseUUID(c.Param("sceneId"))
+		characterID := parseUUID(c.Param("characterId"))
+		if !campaignID.Valid || !sceneID.Valid || !characterID.Valid {
+			models.ValidationError(c, "Invalid campaign, scene, or character ID format")
 			return
 		}
 
 		var req SetPassRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request. passState must be 'none', 'passed', or 'hard_passed'.")
+			models.BindingError(c, err, "Invalid request. passState must be 'none', 'passed', or 'hard_passed'.")
 			return
 		}
 
 		userID := parseUUID(userIDStr)
-		sceneID := parseUUID(sceneIDStr)
-		characterID := parseUUID(characterIDStr)
 
 		svc := service.NewPassService(db.Pool)
-		err := svc.SetPass(c.Request.Context(), userID, sceneID, characterID, req.PassState)
+		err := svc.SetPass(c.Request.Context(), userID, campaignID, sceneID, characterID, req.PassState)
 		if err != nil {
-			handlePassError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
 		// Broadcast pass state changed
 		hasPassed := req.PassState == "passed" || req.PassState == "hard_passed"
-		if scene, sErr := queries.GetScene(c.Request.Context(), sceneID); sErr == nil {
-			BroadcastPassStateChanged(c, scene.CampaignID, sceneID, characterID, hasPassed)
+		BroadcastPassStateChanged(c, campaignID, sceneID, characterID, hasPassed)
+		if hasPassed {
+			autoTransitionPhase(c, db, campaignID)
 		}
 
 		c.JSON(http.StatusOK, gin.H{"message": "Pass state updated successfully"})
@@ -121,9 +119,8 @@ func SetPass(db *database.DB) gin.HandlerFunc {
 }
 
 // ClearPass clears (sets to 'none') the pass state for a character.
+// GMs can pass ?force=true to also clear a hard pass.
 func ClearPass(db *database.DB) gin.HandlerFunc {
-	queries := generated.New(db.Pool)
-
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
 		if !ok {
@@ -131,62 +128,84 @@ func ClearPass(db *database.DB) gin.HandlerFunc {
 			return
 		}
 
-		sceneIDStr := c.Param("sceneId")
-		characterIDStr := c.Param("characterId")
-		if sceneIDStr == "" || characterIDStr == "" {
-			models.ValidationError(c, "Scene ID and Character ID are required")
+		campaignID := parseUUID(c.Param("id"))
+		sceneID := parseUUID(c.Param("sceneId"))
+		characterID := parseUUID(c.Param("characterId"))
+		if !campaignID.Valid || !sceneID.Valid || !characterID.Valid {
+			models.ValidationError(c, "Invalid campaign, scene, or character ID format")
 			return
 		}
 
 		userID := parseUUID(userIDStr)
-		sceneID := parseUUID(sceneIDStr)
-		characterID := parseUUID(characterIDStr)
+
+		force := c.Query("force") == "true"
 
 		svc := service.NewPassService(db.Pool)
-		err := svc.ClearPass(c.Request.Context(), userID, sceneID, characterID)
+		err := svc.ClearPass(c.Request.Context(), userID, campaignID, sceneID, characterID, force)
 		if err != nil {
-			handlePassError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
 		// Broadcast pass state cleared (hasPassed = false)
-		if scene, sErr := queries.GetScene(c.Request.Context(), sceneID); sErr == nil {
-			BroadcastPassStateChanged(c, scene.CampaignID, sceneID, characterID, false)
-		}
+		BroadcastPassStateChanged(c, campaignID, sceneID, characterID, false)
 
 		c.JSON(http.StatusOK, gin.H{"message": "Pass state cleared successfully"})
 	}
 }
 
-// handlePassError handles pass-related errors and sends appropriate HTTP responses.
-func handlePassError(c *gin.Context, err error) {
-	switch {
-	case errors.Is(err, service.ErrNotMember):
-		models.ForbiddenError(c)
-	case errors.Is(err, service.ErrNotGM):
-		models.ForbiddenError(c)
-	case errors.Is(err, service.ErrCharacterNotOwned):
-		models.ForbiddenError(c)
-	case errors.Is(err, service.ErrSceneNotFound):
-		models.NotFoundError(c, "Scene")
-	case errors.Is(err, service.ErrCharacterNotFound):
-		models.NotFoundError(c, "Character")
-	case errors.Is(err, service.ErrCharacterNotInScene):
-		models.ValidationError(c, "Character is not in this scene")
-	case errors.Is(err, service.ErrNotInPCPhase):
-		models.ValidationError(c, "Can only pass during PC phase")
-	case errors.Is(err, service.ErrTimeGateExpired):
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": gin.H{
-				"code":    "TIME_GATE_EXPIRED",
-				"message": "Phase has expired. Waiting for GM to transition.",
-			},
-		})
-	case errors.Is(err, service.ErrCannotPassPendingRolls):
-		models.ValidationError(c, "Cannot pass with pending rolls")
-	case errors.Is(err, service.ErrInvalidPassState):
-		models.ValidationError(c, "Invalid pass state")
-	default:
-		models.InternalError(c)
+// GMPassAll passes every remaining PC in the campaign (GM only).
+func GMPassAll(db *database.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignIDStr := c.Param("id")
+		if campaignIDStr == "" {
+			models.ValidationError(c, "Campaign ID is required")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		campaignID := parseUUID(campaignIDStr)
+
+		svc := service.NewPassService(db.Pool)
+		passed, err := svc.GMPassAll(c.Request.Context(), campaignID, userID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		for _, p := range passed {
+			BroadcastPassStateChanged(c, campaignID, parseUUID(p.SceneID), parseUUID(p.CharacterID), true)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"passed": passed, "count": len(passed)})
+	}
+}
+
+// autoClearPass clears a regular pass for the character that just posted, then
+// broadcasts the new pass state and notifies the GM. Hard passes are left alone.
+func autoClearPass(c *gin.Context, db *database.DB, scene *generated.Scene, resp *service.PostResponse) {
+	if resp.CharacterID == nil {
+		return
+	}
+	characterID := parseUUID(*resp.CharacterID)
+
+	svc := service.NewPassService(db.Pool)
+	cleared, err := svc.AutoClearPass(c.Request.Context(), scene.ID, characterID)
+	if err != nil {
+		//nolint:sloglint // Error logging doesn't need structured logger injection
+		slog.ErrorContext(c.Request.Context(), "Failed to auto-clear pass", "error", err)
+		return
 	}
+	if !cleared {
+		return
+	}
+
+	BroadcastPassStateChanged(c, scene.CampaignID, scene.ID, characterID, false)
+	notifyPassCleared(c, db, scene, resp)
 }
@@ -0,0 +1,171 @@
+//go:build integration
+
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/middleware"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/testdb"
+)
+
+// captureBroadcasts points the handlers' broadcast service at a fake realtime API
+// and returns the names of the events it receives.
+func captureBroadcasts(t *testing.T) <-chan string {
+	t.Helper()
+
+	events := make(chan string, 32)
+	realtime := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Event string `json:"event"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err == nil {
+			events <- body.Event
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(realtime.Close)
+
+	broadcastOnce.Do(func() {})
+	broadcastService = service.NewBroadcastService(realtime.URL, "test-key")
+	t.Cleanup(func() { broadcastService = nil })
+	return events
+}
+
+func TestPostByPassedCharacterClearsPass(t *testing.T) {
+	ctx := context.Background()
+	events := captureBroadcasts(t)
+
+	pool := testdb.New(t)
+	queries := generated.New(pool)
+	gm := testdb.CreateUser(t, pool, "gm@example.com")
+	player := testdb.CreateUser(t, pool, "player@example.com")
+
+	campaign, err := service.NewCampaignService(pool).CreateCampaign(ctx, gm, service.CreateCampaignRequest{
+		Title:       "Test Campaign",
+		Description: "",
+		Settings:    nil,
+	})
+	if err != nil {
+		t.Fatalf("create campaign: %v", err)
+	}
+	scenes := service.NewSceneService(pool)
+	created, err := scenes.CreateScene(ctx, campaign.ID, gm, service.CreateSceneRequest{
+		Title:       "Opening Scene",
+		Description: "",
+	})
+	if err != nil {
+		t.Fatalf("create scene: %v", err)
+	}
+	scene := created.Scene
+
+	if _, err = queries.AddCampaignMember(ctx, generated.AddCampaignMemberParams{
+		CampaignID: campaign.ID,
+		UserID:     player,
+		Role:       generated.MemberRolePlayer,
+		Alias:      pgtype.Text{},
+	}); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+	assignTo := uuidToString(player)
+	character, err := service.NewCharacterService(pool).CreateCharacter(ctx, campaign.ID, gm,
+		service.CreateCharacterRequest{
+			DisplayName:   "Hero",
+			Description:   "",
+			CharacterType: string(generated.CharacterTypePc),
+			AssignToUser:  &assignTo,
+		})
+	if err != nil {
+		t.Fatalf("create character: %v", err)
+	}
+	if _, err = scenes.AddCharacterToScene(ctx, scene.ID, character.ID, gm); err != nil {
+		t.Fatalf("add character to scene: %v", err)
+	}
+	if _, err = service.NewPhaseService(pool).TransitionPhase(ctx, campaign.ID, gm, service.TransitionPhaseRequest{
+		ToPhase: service.PhasePCPhase,
+	}); err != nil {
+		t.Fatalf("transition to PC phase: %v", err)
+	}
+	if err = service.NewPassService(pool).SetPass(
+		ctx, player, campaign.ID, scene.ID, character.ID, service.PassStatePassed,
+	); err != nil {
+		t.Fatalf("pass: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/posts", func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uuidToString(player))
+	}, CreatePost(&database.DB{Pool: pool}))
+
+	characterID := uuidToString(character.ID)
+	body, err := json.Marshal(service.CreatePostRequest{
+		SceneID:     uuidToString(scene.ID),
+		CharacterID: &characterID,
+		Blocks:      []service.PostBlock{{Type: "action", Content: "I step forward.", Order: 0}},
+	})
+	if err != nil {
+		t.Fatalf("marshal post: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/posts", bytes.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		respBody, _ := io.ReadAll(rec.Body)
+		t.Fatalf("create post status = %d: %s", rec.Code, strings.TrimSpace(string(respBody)))
+	}
+
+	updated, err := queries.GetScene(ctx, scene.ID)
+	if err != nil {
+		t.Fatalf("get scene: %v", err)
+	}
+	var passStates map[string]string
+	if err = json.Unmarshal(updated.PassStates, &passStates); err != nil {
+		t.Fatalf("decode pass states: %v", err)
+	}
+	if state := passStates[characterID]; state == service.PassStatePassed {
+		t.Errorf("pass state = %q after posting, want it cleared", state)
+	}
+
+	deadline := time.After(5 * time.Second)
+	for broadcast := false; !broadcast; {
+		select {
+		case event := <-events:
+			broadcast = event == service.EventPassStateChanged
+		case <-deadline:
+			t.Fatal("no pass_state_changed broadcast after posting")
+		}
+	}
+
+	for {
+		notifications, listErr := queries.GetUnreadNotificationsByType(ctx, generated.GetUnreadNotificationsByTypeParams{
+			UserID: gm,
+			Type:   service.NotifPassStateCleared,
+			Limit:  1,
+		})
+		if listErr != nil {
+			t.Fatalf("list GM notifications: %v", listErr)
+		}
+		if len(notifications) == 1 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("GM was not notified that the pass was cleared")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
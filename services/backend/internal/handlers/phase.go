@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"errors"
+	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/middleware"
@@ -42,6 +44,12 @@ func GetPhaseStatus(db *database.DB) gin.HandlerFunc {
 			return
 		}
 
+		// Let other clients know when this check is what expired the time gate
+		if status.AutoPassedCount > 0 {
+			BroadcastPhaseStatus(c, campaignID, status)
+			autoTransitionPhase(c, db, campaignID)
+		}
+
 		c.JSON(http.StatusOK, status)
 	}
 }
@@ -56,6 +64,41 @@ func ForceTransitionPhase(db *database.DB) gin.HandlerFunc {
 	return handleTransitionPhase(db, true)
 }
 
+// CheckTransition reports every blocker for a phase transition without performing it (GM only).
+func CheckTransition(db *database.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignIDStr := c.Param("id")
+		if campaignIDStr == "" {
+			models.ValidationError(c, "Campaign ID is required")
+			return
+		}
+
+		var req TransitionPhaseRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request. toPhase must be 'pc_phase' or 'gm_phase'.")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		campaignID := parseUUID(campaignIDStr)
+
+		svc := service.NewPhaseService(db.Pool)
+		check, err := svc.CheckTransition(c.Request.Context(), campaignID, userID, req.ToPhase)
+		if err != nil {
+			handlePhaseError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, check)
+	}
+}
+
 // handleTransitionPhase is the common implementation for phase transitions.
 func handleTransitionPhase(db *database.DB, force bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -73,7 +116,7 @@ func handleTransitionPhase(db *database.DB, force bool) gin.HandlerFunc {
 
 		var req TransitionPhaseRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request. toPhase must be 'pc_phase' or 'gm_phase'.")
+			models.BindingError(c, err, "Invalid request. toPhase must be 'pc_phase' or 'gm_phase'.")
 			return
 		}
 
@@ -81,7 +124,7 @@ func handleTransitionPhase(db *database.DB, force bool) gin.HandlerFunc {
 		campaignID := parseUUID(campaignIDStr)
 
 		// Get current phase before transition for broadcast
-		queries := generated.New(db.Pool)
+		queries := service.NewQueries(db.Pool)
 		currentCampaign, _ := queries.GetCampaign(c.Request.Context(), campaignID)
 		fromPhase := string(currentCampaign.CurrentPhase)
 
@@ -106,6 +149,7 @@ func handleTransitionPhase(db *database.DB, force bool) gin.HandlerFunc {
 			reason = "gm_force"
 		}
 		BroadcastPhaseTransition(c, campaignID, fromPhase, req.ToPhase, reason)
+		webhookPhaseTransition(c, db, campaignID, fromPhase, req.ToPhase, reason)
 
 		// Fetch campaign with user_role for proper response
 		campaignWithRole, err := queries.GetCampaignWithMembership(c.Request.Context(),
@@ -155,6 +199,26 @@ func handlePhaseError(c *gin.Context, err error) {
 	case errors.Is(err, service.ErrNotAllPassed):
 		models.ValidationError(c, "Cannot transition to GM phase: not all characters have passed")
 	default:
-		models.InternalError(c)
+		handleServiceError(c, err)
 	}
 }
+
+// autoTransitionPhase moves the campaign to GM phase if every PC has passed and
+// the campaign has autoTransitionOnAllPassed enabled. Call it after anything that
+// can complete the last pass: a pass, time gate expiry, or a compose lock release.
+func autoTransitionPhase(c *gin.Context, db *database.DB, campaignID pgtype.UUID) {
+	svc := service.NewPhaseService(db.Pool)
+	campaign, err := svc.AutoTransitionIfAllPassed(c.Request.Context(), campaignID)
+	if err != nil {
+		//nolint:sloglint // Error logging doesn't need structured logger injection
+		slog.ErrorContext(c.Request.Context(), "Failed to auto-transition phase", "error", err)
+		return
+	}
+	if campaign == nil {
+		return
+	}
+
+	BroadcastPhaseTransition(c, campaignID, service.PhasePCPhase, service.PhaseGMPhase, "all_passed")
+	webhookPhaseTransition(c, db, campaignID, service.PhasePCPhase, service.PhaseGMPhase, "all_passed")
+	notifyPhaseAutoTransitioned(c, db, campaign)
+}
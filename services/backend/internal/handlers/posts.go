@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"errors"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -25,7 +24,7 @@ func emptyUUID() pgtype.UUID {
 //nolint:gocognit // Complex handler with broadcasting logic
 func CreatePost(db *database.DB) gin.HandlerFunc {
 	svc := service.NewPostService(db.Pool)
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -36,7 +35,7 @@ func CreatePost(db *database.DB) gin.HandlerFunc {
 
 		var req service.CreatePostRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request body")
+			models.BindingError(c, err, "Invalid request body")
 			return
 		}
 
@@ -58,7 +57,7 @@ func CreatePost(db *database.DB) gin.HandlerFunc {
 		if err != nil {
 			//nolint:sloglint // Error logging doesn't need structured logger injection
 			slog.ErrorContext(ctx, "CreatePost failed", "error", err)
-			handlePostError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -78,17 +77,25 @@ func CreatePost(db *database.DB) gin.HandlerFunc {
 					witnessUUIDs = append(witnessUUIDs, parseUUID(w))
 				}
 				BroadcastPostCreated(c, postID, sceneID, scene.CampaignID, characterID, resp.IsHidden, witnessUUIDs)
+				webhookPostCreated(c, db, &scene, resp)
+				autoClearPass(c, db, &scene, resp)
 			}
+			notifyMentions(c, db, resp)
 		}
 
 		c.JSON(http.StatusCreated, resp)
 	}
 }
 
+// SubmitPostRequest represents the request to submit a draft post.
+type SubmitPostRequest struct {
+	IsHidden bool `json:"isHidden"`
+}
+
 // SubmitPost submits a draft post.
 func SubmitPost(db *database.DB) gin.HandlerFunc {
 	svc := service.NewPostService(db.Pool)
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -103,9 +110,7 @@ func SubmitPost(db *database.DB) gin.HandlerFunc {
 			return
 		}
 
-		var req struct {
-			IsHidden bool `json:"isHidden"`
-		}
+		var req SubmitPostRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
 			req.IsHidden = false
 		}
@@ -113,7 +118,7 @@ func SubmitPost(db *database.DB) gin.HandlerFunc {
 		userID := parseUUID(userIDStr)
 		resp, err := svc.SubmitPost(c.Request.Context(), userID, postIDParam, req.IsHidden)
 		if err != nil {
-			handlePostError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -130,7 +135,10 @@ func SubmitPost(db *database.DB) gin.HandlerFunc {
 				witnessUUIDs = append(witnessUUIDs, parseUUID(w))
 			}
 			BroadcastPostCreated(c, postID, sceneID, scene.CampaignID, characterID, resp.IsHidden, witnessUUIDs)
+			webhookPostCreated(c, db, &scene, resp)
+			autoClearPass(c, db, &scene, resp)
 		}
+		notifyMentions(c, db, resp)
 
 		c.JSON(http.StatusOK, resp)
 	}
@@ -141,7 +149,7 @@ func SubmitPost(db *database.DB) gin.HandlerFunc {
 //nolint:dupl // Handler structure is similar but services different endpoint
 func UpdatePost(db *database.DB) gin.HandlerFunc {
 	svc := service.NewPostService(db.Pool)
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -158,14 +166,14 @@ func UpdatePost(db *database.DB) gin.HandlerFunc {
 
 		var req service.UpdatePostRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request body")
+			models.BindingError(c, err, "Invalid request body")
 			return
 		}
 
 		userID := parseUUID(userIDStr)
 		resp, err := svc.UpdatePost(c.Request.Context(), userID, postIDParam, req)
 		if err != nil {
-			handlePostError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -183,7 +191,7 @@ func UpdatePost(db *database.DB) gin.HandlerFunc {
 // DeletePost deletes a post (GM only).
 func DeletePost(db *database.DB) gin.HandlerFunc {
 	svc := service.NewPostService(db.Pool)
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -198,13 +206,23 @@ func DeletePost(db *database.DB) gin.HandlerFunc {
 			return
 		}
 
+		userID := parseUUID(userIDStr)
+		switch c.Query("mode") {
+		case "", "hard":
+		case "tombstone":
+			removePost(c, svc, queries, userID, postIDParam)
+			return
+		default:
+			models.ValidationError(c, "mode must be hard or tombstone")
+			return
+		}
+
 		// Get post info for broadcast before deleting
 		postUUID := parseUUID(postIDParam)
 		post, postErr := queries.GetPost(c.Request.Context(), postUUID)
 
-		userID := parseUUID(userIDStr)
 		if err := svc.DeletePost(c.Request.Context(), userID, postIDParam); err != nil {
-			handlePostError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -219,6 +237,66 @@ func DeletePost(db *database.DB) gin.HandlerFunc {
 	}
 }
 
+// removePost replaces a post with a GM placeholder. The post stays in the scene,
+// so clients are sent an update rather than a delete.
+func removePost(
+	c *gin.Context,
+	svc *service.PostService,
+	queries *generated.Queries,
+	userID pgtype.UUID,
+	postID string,
+) {
+	resp, err := svc.RemovePost(c.Request.Context(), userID, postID)
+	if err != nil {
+		handleServiceError(c, err)
+		return
+	}
+
+	broadcastPostResponseUpdated(c, queries, resp)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RestorePost brings back a post removed by the GM (GM only).
+func RestorePost(db *database.DB) gin.HandlerFunc {
+	svc := service.NewPostService(db.Pool)
+	queries := service.NewQueries(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		postIDParam := c.Param("postId")
+		if postIDParam == "" {
+			models.ValidationError(c, "Post ID is required")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		resp, err := svc.RestorePost(c.Request.Context(), userID, postIDParam)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		broadcastPostResponseUpdated(c, queries, resp)
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// broadcastPostResponseUpdated sends a post-updated event for a post response.
+func broadcastPostResponseUpdated(c *gin.Context, queries *generated.Queries, resp *service.PostResponse) {
+	sceneID := parseUUID(resp.SceneID)
+	postID := parseUUID(resp.ID)
+	if scene, sErr := queries.GetScene(c.Request.Context(), sceneID); sErr == nil {
+		BroadcastPostUpdated(c, postID, sceneID, scene.CampaignID)
+	}
+}
+
 // GetPost returns a single post.
 func GetPost(db *database.DB) gin.HandlerFunc {
 	svc := service.NewPostService(db.Pool)
@@ -239,7 +317,7 @@ func GetPost(db *database.DB) gin.HandlerFunc {
 		userID := parseUUID(userIDStr)
 		resp, err := svc.GetPost(c.Request.Context(), userID, postID)
 		if err != nil {
-			handlePostError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -273,7 +351,7 @@ func ListScenePosts(db *database.DB) gin.HandlerFunc {
 		userID := parseUUID(userIDStr)
 		posts, err := svc.ListScenePosts(c.Request.Context(), userID, sceneID, viewAsCharacterID)
 		if err != nil {
-			handlePostError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -285,11 +363,149 @@ func ListScenePosts(db *database.DB) gin.HandlerFunc {
 	}
 }
 
+// MarkSceneReadRequest represents the request body for marking a scene read.
+type MarkSceneReadRequest struct {
+	LastPostID *string `json:"lastPostId"`
+}
+
+// MarkSceneRead records the current user's read position in a scene.
+func MarkSceneRead(db *database.DB) gin.HandlerFunc {
+	svc := service.NewPostService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		sceneID := c.Param("sceneId")
+		if sceneID == "" {
+			models.ValidationError(c, "Scene ID is required")
+			return
+		}
+
+		// Parse optional request body; without lastPostId the latest post is marked read
+		var req MarkSceneReadRequest
+		_ = c.ShouldBindJSON(&req) // Ignore error if no body
+
+		userID := parseUUID(userIDStr)
+		resp, err := svc.MarkSceneRead(c.Request.Context(), userID, sceneID, req.LastPostID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// PostReactionRequest represents the request body for adding or removing a reaction.
+type PostReactionRequest struct {
+	Emoji string `json:"emoji"`
+}
+
+// ListPostReactions returns the reaction counts on a post the current user can see.
+func ListPostReactions(db *database.DB) gin.HandlerFunc {
+	svc := service.NewPostService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		postIDParam := c.Param("postId")
+		if postIDParam == "" {
+			models.ValidationError(c, "Post ID is required")
+			return
+		}
+
+		resp, err := svc.ListReactions(c.Request.Context(), parseUUID(userIDStr), postIDParam)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// AddPostReaction adds the current user's emoji reaction to a post.
+func AddPostReaction(db *database.DB) gin.HandlerFunc {
+	return postReactionHandler(db, true)
+}
+
+// RemovePostReaction removes the current user's emoji reaction from a post.
+func RemovePostReaction(db *database.DB) gin.HandlerFunc {
+	return postReactionHandler(db, false)
+}
+
+// postReactionHandler adds or removes a reaction and broadcasts the new count.
+// The emoji is read from the JSON body, falling back to the emoji query parameter.
+func postReactionHandler(db *database.DB, add bool) gin.HandlerFunc {
+	svc := service.NewPostService(db.Pool)
+	queries := service.NewQueries(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		postIDParam := c.Param("postId")
+		if postIDParam == "" {
+			models.ValidationError(c, "Post ID is required")
+			return
+		}
+
+		var req PostReactionRequest
+		_ = c.ShouldBindJSON(&req) // Ignore error if no body
+		if req.Emoji == "" {
+			req.Emoji = c.Query("emoji")
+		}
+
+		userID := parseUUID(userIDStr)
+		var resp *service.PostReactionsResponse
+		var err error
+		if add {
+			resp, err = svc.AddReaction(c.Request.Context(), userID, postIDParam, req.Emoji)
+		} else {
+			resp, err = svc.RemoveReaction(c.Request.Context(), userID, postIDParam, req.Emoji)
+		}
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		emoji := strings.TrimSpace(req.Emoji)
+		var count int32
+		for _, r := range resp.Reactions {
+			if r.Emoji == emoji {
+				count = r.Count
+			}
+		}
+
+		// The scene channel reaches every member, so restricted posts stay quiet
+		postID := parseUUID(resp.PostID)
+		sceneID := parseUUID(resp.SceneID)
+		post, pErr := queries.GetPost(c.Request.Context(), postID)
+		scene, sErr := queries.GetScene(c.Request.Context(), sceneID)
+		if pErr == nil && sErr == nil && service.IsScenePublic(post.IsHidden, post.Witnesses, scene.CharacterIds) {
+			BroadcastPostReaction(c, postID, sceneID, scene.CampaignID, emoji, count)
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
 // UnhidePost reveals a hidden post (GM only).
 // Accepts optional witnesses array for custom witness selection.
 func UnhidePost(db *database.DB) gin.HandlerFunc {
 	svc := service.NewPostService(db.Pool)
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -311,7 +527,7 @@ func UnhidePost(db *database.DB) gin.HandlerFunc {
 		userID := parseUUID(userIDStr)
 		resp, err := svc.UnhidePost(c.Request.Context(), userID, postIDParam, &req)
 		if err != nil {
-			handlePostError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -326,12 +542,225 @@ func UnhidePost(db *database.DB) gin.HandlerFunc {
 	}
 }
 
+// PinPost pins a post to the top of its scene (GM only).
+func PinPost(db *database.DB) gin.HandlerFunc {
+	return postPinHandler(db, true)
+}
+
+// UnpinPost unpins a post (GM only).
+func UnpinPost(db *database.DB) gin.HandlerFunc {
+	return postPinHandler(db, false)
+}
+
+// postPinHandler sets or clears the pinned flag on a post.
+func postPinHandler(db *database.DB, pinned bool) gin.HandlerFunc {
+	svc := service.NewPostService(db.Pool)
+	queries := service.NewQueries(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		postIDParam := c.Param("postId")
+		if postIDParam == "" {
+			models.ValidationError(c, "Post ID is required")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		var resp *service.PostResponse
+		var err error
+		if pinned {
+			resp, err = svc.PinPost(c.Request.Context(), userID, postIDParam)
+		} else {
+			resp, err = svc.UnpinPost(c.Request.Context(), userID, postIDParam)
+		}
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		// Broadcast post updated (pin changed)
+		sceneID := parseUUID(resp.SceneID)
+		postID := parseUUID(resp.ID)
+		if scene, sErr := queries.GetScene(c.Request.Context(), sceneID); sErr == nil {
+			BroadcastPostUpdated(c, postID, sceneID, scene.CampaignID)
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// MovePostRequest represents the request body for moving a post to another scene.
+type MovePostRequest struct {
+	TargetSceneID string `binding:"required" json:"targetSceneId"`
+}
+
+// MovePost moves a post to another scene in the same campaign (GM only).
+func MovePost(db *database.DB) gin.HandlerFunc {
+	svc := service.NewPostService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		postIDParam := c.Param("postId")
+		if postIDParam == "" {
+			models.ValidationError(c, "Post ID is required")
+			return
+		}
+
+		var req MovePostRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request body")
+			return
+		}
+		if !parseUUID(req.TargetSceneID).Valid {
+			models.ValidationError(c, "Invalid scene ID format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		result, err := svc.MovePost(c.Request.Context(), userID, postIDParam, req.TargetSceneID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		// The post leaves one scene and appears in the other
+		resp := result.Post
+		postID := parseUUID(resp.ID)
+		BroadcastPostDeleted(c, postID, result.FromSceneID, result.CampaignID)
+
+		var characterID = emptyUUID()
+		if resp.CharacterID != nil {
+			characterID = parseUUID(*resp.CharacterID)
+		}
+		witnessUUIDs := make([]pgtype.UUID, 0, len(resp.Witnesses))
+		for _, w := range resp.Witnesses {
+			witnessUUIDs = append(witnessUUIDs, parseUUID(w))
+		}
+		BroadcastPostCreated(c, postID, parseUUID(resp.SceneID), result.CampaignID, characterID,
+			resp.IsHidden, witnessUUIDs)
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// AttributePostRequest represents the request body for attributing a narrator
+// post to an NPC.
+type AttributePostRequest struct {
+	CharacterID string `binding:"required" json:"characterId"`
+}
+
+// AttributePost gives a narrator post to an NPC in its scene (GM only).
+func AttributePost(db *database.DB) gin.HandlerFunc {
+	svc := service.NewPostService(db.Pool)
+	queries := service.NewQueries(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		postIDParam := c.Param("postId")
+		if postIDParam == "" {
+			models.ValidationError(c, "Post ID is required")
+			return
+		}
+
+		var req AttributePostRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request body")
+			return
+		}
+		if !parseUUID(req.CharacterID).Valid {
+			models.ValidationError(c, "Invalid character ID format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		resp, err := svc.AttributePost(c.Request.Context(), userID, postIDParam, req.CharacterID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		broadcastPostResponseUpdated(c, queries, resp)
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// GrantWitnessRequest represents the request body for granting a character
+// witness access to a scene's earlier posts.
+type GrantWitnessRequest struct {
+	CharacterID string  `binding:"required" json:"characterId"`
+	FromPostID  *string `json:"fromPostId,omitempty"`
+}
+
+// GrantWitness adds a character as a witness to a scene's posts (GM only).
+func GrantWitness(db *database.DB) gin.HandlerFunc {
+	svc := service.NewPostService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		sceneID := parseUUID(c.Param("sceneId"))
+		if !sceneID.Valid {
+			models.ValidationError(c, "Invalid scene ID format")
+			return
+		}
+
+		var req GrantWitnessRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request body")
+			return
+		}
+		characterID := parseUUID(req.CharacterID)
+		if !characterID.Valid {
+			models.ValidationError(c, "Invalid character ID format")
+			return
+		}
+		if req.FromPostID != nil && !parseUUID(*req.FromPostID).Valid {
+			models.ValidationError(c, "Invalid post ID format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		result, err := svc.GrantWitnessToCharacter(c.Request.Context(), userID, sceneID, characterID, req.FromPostID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		// Broadcast each post whose witnesses changed
+		for _, postID := range result.PostIDs {
+			BroadcastPostUpdated(c, parseUUID(postID), sceneID, result.CampaignID)
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
 // UpdatePostWitnesses updates the witnesses on a post (GM only).
 //
 //nolint:dupl // Handler structure is similar but services different endpoint
 func UpdatePostWitnesses(db *database.DB) gin.HandlerFunc {
 	svc := service.NewPostService(db.Pool)
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -348,14 +777,14 @@ func UpdatePostWitnesses(db *database.DB) gin.HandlerFunc {
 
 		var req service.UpdatePostWitnessesRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request body")
+			models.BindingError(c, err, "Invalid request body")
 			return
 		}
 
 		userID := parseUUID(userIDStr)
 		resp, err := svc.UpdatePostWitnesses(c.Request.Context(), userID, postIDParam, req)
 		if err != nil {
-			handlePostError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -390,7 +819,7 @@ func ListHiddenPosts(db *database.DB) gin.HandlerFunc {
 		userID := parseUUID(userIDStr)
 		posts, err := svc.ListHiddenPosts(c.Request.Context(), userID, sceneID)
 		if err != nil {
-			handlePostError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -401,66 +830,3 @@ func ListHiddenPosts(db *database.DB) gin.HandlerFunc {
 		c.JSON(http.StatusOK, gin.H{"posts": posts})
 	}
 }
-
-func handlePostError(c *gin.Context, err error) {
-	switch {
-	case errors.Is(err, service.ErrPostNotFound):
-		models.NotFoundError(c, "Post")
-	case errors.Is(err, service.ErrPostLocked):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError("POST_LOCKED", "Post is locked and cannot be edited"),
-		)
-	case errors.Is(err, service.ErrNotPostOwner):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError("NOT_POST_OWNER", "You do not own this post"),
-		)
-	case errors.Is(err, service.ErrNotMostRecentPost):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError("NOT_MOST_RECENT", "Can only edit the most recent post"),
-		)
-	case errors.Is(err, service.ErrSceneNotFound):
-		models.NotFoundError(c, "Scene")
-	case errors.Is(err, service.ErrCharacterNotOwned):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError("NOT_CHARACTER_OWNER", "You do not own this character"),
-		)
-	case errors.Is(err, service.ErrCharacterNotInScene):
-		models.ValidationError(c, "Character is not in this scene")
-	case errors.Is(err, service.ErrNotInPCPhase):
-		models.ValidationError(c, "Posts can only be created during PC Phase")
-	case errors.Is(err, service.ErrTimeGateExpired):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError("TIME_GATE_EXPIRED", "Time gate has expired. Waiting for GM to transition phase."),
-		)
-	case errors.Is(err, service.ErrNotGM):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError("NOT_GM", "Only the GM can perform this action"),
-		)
-	case errors.Is(err, service.ErrNotMember):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError("NOT_MEMBER", "You are not a member of this campaign"),
-		)
-	case strings.HasPrefix(err.Error(), "witness not in scene"):
-		models.ValidationError(c, err.Error())
-	default:
-		// Log the actual error for debugging
-		//nolint:sloglint // Error logging doesn't need structured logger injection
-		slog.ErrorContext(c.Request.Context(), "handlePostError unhandled error", "error", err)
-		_ = c.Error(err)
-		models.InternalError(c)
-	}
-}
@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -17,7 +20,7 @@ import (
 // CreateRoll creates a new dice roll.
 func CreateRoll(db *database.DB) gin.HandlerFunc {
 	svc := service.NewRollService(db.Pool)
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -28,7 +31,7 @@ func CreateRoll(db *database.DB) gin.HandlerFunc {
 
 		var req service.CreateRollRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request body")
+			models.BindingError(c, err, "Invalid request body")
 			return
 		}
 
@@ -39,22 +42,83 @@ func CreateRoll(db *database.DB) gin.HandlerFunc {
 			return
 		}
 
-		// Broadcast roll created
-		rollID := parseUUID(resp.ID)
-		sceneID := parseUUID(resp.SceneID)
-		characterID := parseUUID(resp.CharacterID)
-		var postID pgtype.UUID
-		if resp.PostID != nil {
-			postID = parseUUID(*resp.PostID)
+		broadcastRollCreated(c, queries, resp)
+
+		c.JSON(http.StatusCreated, resp)
+	}
+}
+
+// rollBatchIndexHeader names the failing entry when a roll batch is rejected.
+const rollBatchIndexHeader = "X-Roll-Batch-Index"
+
+// CreateRollsBatch creates several linked rolls at once, such as an attack and its damage.
+// A rejected batch reports the zero-based index of the invalid entry in the X-Roll-Batch-Index header.
+func CreateRollsBatch(db *database.DB) gin.HandlerFunc {
+	svc := service.NewRollService(db.Pool)
+	queries := service.NewQueries(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
 		}
-		if scene, sErr := queries.GetScene(c.Request.Context(), sceneID); sErr == nil {
-			BroadcastRollCreated(c, rollID, postID, sceneID, scene.CampaignID, characterID, resp.Intention)
+
+		var req service.CreateRollsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request body")
+			return
 		}
 
-		c.JSON(http.StatusCreated, resp)
+		userID := parseUUID(userIDStr)
+		rolls, err := svc.CreateRolls(c.Request.Context(), userID, req.Rolls, req.Sync)
+		if err != nil {
+			var batchErr *service.RollBatchError
+			if errors.As(err, &batchErr) {
+				c.Header(rollBatchIndexHeader, strconv.Itoa(batchErr.Index))
+			}
+			handleRollError(c, err)
+			return
+		}
+
+		for i := range rolls {
+			broadcastRollCreated(c, queries, &rolls[i])
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"rolls": rolls})
 	}
 }
 
+// broadcastRollCreated announces a new roll on its scene channel. The intention
+// and note follow the post's visibility: rolls on hidden or restricted posts are
+// announced without them.
+func broadcastRollCreated(c *gin.Context, queries *generated.Queries, resp *service.RollResponse) {
+	rollID := parseUUID(resp.ID)
+	sceneID := parseUUID(resp.SceneID)
+	characterID := parseUUID(resp.CharacterID)
+	scene, sErr := queries.GetScene(c.Request.Context(), sceneID)
+	if sErr != nil {
+		return
+	}
+
+	var postID pgtype.UUID
+	public := true
+	if resp.PostID != nil {
+		postID = parseUUID(*resp.PostID)
+		post, pErr := queries.GetPost(c.Request.Context(), postID)
+		public = pErr == nil && service.IsScenePublic(post.IsHidden, post.Witnesses, scene.CharacterIds)
+	}
+
+	var intention, note string
+	if public {
+		intention = resp.Intention
+		if resp.Note != nil {
+			note = *resp.Note
+		}
+	}
+	BroadcastRollCreated(c, rollID, postID, sceneID, scene.CampaignID, characterID, intention, note)
+}
+
 // GetRoll retrieves a single roll.
 func GetRoll(db *database.DB) gin.HandlerFunc {
 	svc := service.NewRollService(db.Pool)
@@ -140,7 +204,52 @@ func GetPendingRollsForCharacter(db *database.DB) gin.HandlerFunc {
 	}
 }
 
-// GetUnresolvedRollsInCampaign retrieves all unresolved rolls (GM dashboard).
+// GetCharacterRolls returns a character's roll history across scenes, newest first.
+// Supports `limit`, `cursor` (the previous page's nextCursor), and
+// `includeInvalidated=true` query params.
+func GetCharacterRolls(db *database.DB) gin.HandlerFunc {
+	svc := service.NewRollService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		characterID := c.Param("characterId")
+		if characterID == "" {
+			models.ValidationError(c, "Character ID is required")
+			return
+		}
+
+		var limit int32
+		if l := c.Query("limit"); l != "" {
+			if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= service.MaxCharacterRollLimit {
+				limit = safeInt32(parsed)
+			}
+		}
+
+		userID := parseUUID(userIDStr)
+		page, err := svc.GetCharacterRolls(
+			c.Request.Context(),
+			userID,
+			characterID,
+			limit,
+			c.Query("cursor"),
+			c.Query("includeInvalidated") == "true",
+		)
+		if err != nil {
+			handleRollError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, page)
+	}
+}
+
+// GetUnresolvedRollsInCampaign retrieves a page of unresolved rolls (GM dashboard).
+// Supports `limit`, `offset`, and an RFC 3339 `since` query param.
 func GetUnresolvedRollsInCampaign(db *database.DB) gin.HandlerFunc {
 	svc := service.NewRollService(db.Pool)
 
@@ -157,18 +266,67 @@ func GetUnresolvedRollsInCampaign(db *database.DB) gin.HandlerFunc {
 			return
 		}
 
+		var query service.UnresolvedRollsQuery
+		if l := c.Query("limit"); l != "" {
+			if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= service.MaxUnresolvedRollLimit {
+				query.Limit = safeInt32(parsed)
+			}
+		}
+		if o := c.Query("offset"); o != "" {
+			if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+				query.Offset = safeInt32(parsed)
+			}
+		}
+		if since := c.Query("since"); since != "" {
+			parsed, err := time.Parse(time.RFC3339, since)
+			if err != nil {
+				models.ValidationError(c, "since must be an RFC 3339 timestamp")
+				return
+			}
+			query.Since = &parsed
+		}
+
 		userID := parseUUID(userIDStr)
-		rolls, err := svc.GetUnresolvedRollsInCampaign(c.Request.Context(), userID, campaignID)
+		page, err := svc.GetUnresolvedRollsInCampaign(c.Request.Context(), userID, campaignID, query)
 		if err != nil {
 			handleRollError(c, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"rolls": rolls})
+		c.JSON(http.StatusOK, page)
 	}
 }
 
-// GetRollsInScene retrieves all rolls in a scene.
+// GetRollIntentions returns the campaign's suggested roll intentions.
+func GetRollIntentions(db *database.DB) gin.HandlerFunc {
+	svc := service.NewRollService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := c.Param("id")
+		if campaignID == "" {
+			models.ValidationError(c, "Campaign ID is required")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		resp, err := svc.GetRollIntentions(c.Request.Context(), userID, campaignID)
+		if err != nil {
+			handleRollError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// GetRollsInScene retrieves the rolls in a scene.
+// Optional query params `status` and `characterId` narrow the results.
 func GetRollsInScene(db *database.DB) gin.HandlerFunc {
 	svc := service.NewRollService(db.Pool)
 
@@ -185,8 +343,13 @@ func GetRollsInScene(db *database.DB) gin.HandlerFunc {
 			return
 		}
 
+		filter := service.SceneRollFilter{
+			Status:      c.Query("status"),
+			CharacterID: c.Query("characterId"),
+		}
+
 		userID := parseUUID(userIDStr)
-		rolls, err := svc.GetRollsInScene(c.Request.Context(), userID, sceneID)
+		rolls, err := svc.GetRollsInScene(c.Request.Context(), userID, sceneID, filter)
 		if err != nil {
 			handleRollError(c, err)
 			return
@@ -199,6 +362,7 @@ func GetRollsInScene(db *database.DB) gin.HandlerFunc {
 // OverrideRollIntention overrides a roll's intention (GM only).
 func OverrideRollIntention(db *database.DB) gin.HandlerFunc {
 	svc := service.NewRollService(db.Pool)
+	queries := service.NewQueries(db.Pool)
 
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -215,7 +379,7 @@ func OverrideRollIntention(db *database.DB) gin.HandlerFunc {
 
 		var req service.OverrideIntentionRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request body")
+			models.BindingError(c, err, "Invalid request body")
 			return
 		}
 
@@ -226,6 +390,12 @@ func OverrideRollIntention(db *database.DB) gin.HandlerFunc {
 			return
 		}
 
+		if resp.SystemPost != nil {
+			if scene, sErr := queries.GetScene(c.Request.Context(), parseUUID(resp.SceneID)); sErr == nil {
+				broadcastSystemPost(c, resp.SystemPost, scene.CampaignID)
+			}
+		}
+
 		c.JSON(http.StatusOK, resp)
 	}
 }
@@ -233,7 +403,7 @@ func OverrideRollIntention(db *database.DB) gin.HandlerFunc {
 // ManuallyResolveRoll manually resolves a roll (GM only).
 func ManuallyResolveRoll(db *database.DB) gin.HandlerFunc {
 	svc := service.NewRollService(db.Pool)
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -250,7 +420,7 @@ func ManuallyResolveRoll(db *database.DB) gin.HandlerFunc {
 
 		var req service.ManualResolveRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request body")
+			models.BindingError(c, err, "Invalid request body")
 			return
 		}
 
@@ -266,6 +436,10 @@ func ManuallyResolveRoll(db *database.DB) gin.HandlerFunc {
 		sceneID := parseUUID(resp.SceneID)
 		if scene, sErr := queries.GetScene(c.Request.Context(), sceneID); sErr == nil {
 			BroadcastRollResolved(c, rollID, sceneID, scene.CampaignID, resp.Status)
+			webhookRollResolved(c, db, &scene, resp)
+			if resp.SystemPost != nil {
+				broadcastSystemPost(c, resp.SystemPost, scene.CampaignID)
+			}
 		}
 
 		c.JSON(http.StatusOK, resp)
@@ -275,7 +449,7 @@ func ManuallyResolveRoll(db *database.DB) gin.HandlerFunc {
 // InvalidateRoll invalidates a roll (GM only).
 func InvalidateRoll(db *database.DB) gin.HandlerFunc {
 	svc := service.NewRollService(db.Pool)
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -302,6 +476,7 @@ func InvalidateRoll(db *database.DB) gin.HandlerFunc {
 		sceneID := parseUUID(resp.SceneID)
 		if scene, sErr := queries.GetScene(c.Request.Context(), sceneID); sErr == nil {
 			BroadcastRollResolved(c, rollID, sceneID, scene.CampaignID, resp.Status)
+			webhookRollResolved(c, db, &scene, resp)
 		}
 
 		c.JSON(http.StatusOK, resp)
@@ -316,6 +491,107 @@ func GetAvailablePresets() gin.HandlerFunc {
 	}
 }
 
+// GetPresetExpansion returns the concrete dice a preset rolls.
+func GetPresetExpansion() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		expansion := dice.ExpandPreset(c.Param("name"))
+		if expansion == nil {
+			models.NotFoundError(c, "Preset")
+			return
+		}
+		c.JSON(http.StatusOK, expansion)
+	}
+}
+
+// GetCampaignDicePresets returns the campaign's dice presets and the global fallbacks.
+func GetCampaignDicePresets(db *database.DB) gin.HandlerFunc {
+	svc := service.NewRollService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := c.Param("id")
+		if campaignID == "" {
+			models.ValidationError(c, "Campaign ID is required")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		resp, err := svc.ListCampaignDicePresets(c.Request.Context(), userID, campaignID)
+		if err != nil {
+			handleRollError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// CreateCampaignDicePreset adds or replaces a campaign dice preset (GM only).
+func CreateCampaignDicePreset(db *database.DB) gin.HandlerFunc {
+	svc := service.NewRollService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := c.Param("id")
+		if campaignID == "" {
+			models.ValidationError(c, "Campaign ID is required")
+			return
+		}
+
+		var req service.CreateDicePresetRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request body")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		preset, err := svc.CreateCampaignDicePreset(c.Request.Context(), userID, campaignID, req)
+		if err != nil {
+			handleRollError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, preset)
+	}
+}
+
+// DeleteCampaignDicePreset removes a campaign dice preset (GM only).
+func DeleteCampaignDicePreset(db *database.DB) gin.HandlerFunc {
+	svc := service.NewRollService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := c.Param("id")
+		if campaignID == "" {
+			models.ValidationError(c, "Campaign ID is required")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		if err := svc.DeleteCampaignDicePreset(c.Request.Context(), userID, campaignID, c.Param("name")); err != nil {
+			handleRollError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
 // GetValidDiceTypes returns all valid dice types.
 func GetValidDiceTypes() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -324,6 +600,23 @@ func GetValidDiceTypes() gin.HandlerFunc {
 	}
 }
 
+// broadcastSystemPost announces a narrator summary post created alongside a roll action.
+func broadcastSystemPost(c *gin.Context, post *service.PostResponse, campaignID pgtype.UUID) {
+	witnessUUIDs := make([]pgtype.UUID, 0, len(post.Witnesses))
+	for _, w := range post.Witnesses {
+		witnessUUIDs = append(witnessUUIDs, parseUUID(w))
+	}
+	BroadcastPostCreated(
+		c,
+		parseUUID(post.ID),
+		parseUUID(post.SceneID),
+		campaignID,
+		emptyUUID(),
+		post.IsHidden,
+		witnessUUIDs,
+	)
+}
+
 // handleRollError maps service errors to HTTP responses.
 func handleRollError(c *gin.Context, err error) {
 	switch {
@@ -337,13 +630,51 @@ func handleRollError(c *gin.Context, err error) {
 		models.ValidationError(c, "Dice count must be between 1 and 100")
 	case errors.Is(err, service.ErrInvalidIntention):
 		models.ValidationError(c, "Intention is required")
+	case errors.Is(err, service.ErrUnknownPreset):
+		models.ValidationError(c, "Unknown dice preset")
+	case errors.Is(err, service.ErrInvalidDicePreset):
+		models.ValidationError(c, "Preset requires a name (max 50 characters) and a valid dice type")
+	case errors.Is(err, service.ErrTooManyDicePresets):
+		models.ValidationError(c, "Campaign has reached the maximum of 50 dice presets")
+	case errors.Is(err, service.ErrDicePresetNotFound):
+		models.NotFoundError(c, "Preset")
+	case errors.Is(err, service.ErrInvalidRollStatus):
+		models.ValidationError(c, "Status must be one of pending, completed, invalidated")
+	case errors.Is(err, service.ErrInvalidRollFilter):
+		models.ValidationError(c, "Invalid character ID")
+	case errors.Is(err, service.ErrCharacterNotInScene):
+		models.ValidationError(c, "Character is not in this scene")
+	case errors.Is(err, service.ErrRollPostMismatch):
+		models.ValidationError(c, "Post does not belong to this scene")
+	case errors.Is(err, service.ErrRollTooLarge):
+		models.ValidationError(c, fmt.Sprintf(
+			"Roll is too large: dice count times sides must be at most %d",
+			service.MaxRollSize(),
+		))
+	case errors.Is(err, service.ErrInvalidThreshold):
+		models.ValidationError(c, "Success threshold must be within the die's face range")
+	case errors.Is(err, service.ErrRollNoteTooLong):
+		models.ValidationError(c, fmt.Sprintf("Roll note must be at most %d characters", service.MaxRollNoteLen))
+	case errors.Is(err, service.ErrPostNotFound):
+		models.NotFoundError(c, "Post")
+	case errors.Is(err, service.ErrEmptyRollBatch):
+		models.ValidationError(c, "At least one roll is required")
+	case errors.Is(err, service.ErrRollBatchTooLarge):
+		models.ValidationError(c, fmt.Sprintf("At most %d rolls can be created at once", service.MaxRollBatch))
+	case errors.Is(err, service.ErrIntentionNotAllowed):
+		models.RespondError(c, http.StatusBadRequest, models.NewAPIError(
+			"INTENTION_NOT_ALLOWED",
+			"This campaign only allows intentions from its intention list",
+		))
 	case errors.Is(err, service.ErrNotGM):
 		models.ForbiddenError(c)
 	case errors.Is(err, service.ErrNotMember):
 		models.ForbiddenError(c)
 	case errors.Is(err, service.ErrSceneNotFound):
 		models.NotFoundError(c, "Scene")
+	case errors.Is(err, service.ErrCampaignNotFound):
+		models.NotFoundError(c, "Campaign")
 	default:
-		models.InternalError(c)
+		handleServiceError(c, err)
 	}
 }
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"errors"
+	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -21,9 +22,45 @@ type CreateSceneRequest struct {
 }
 
 // UpdateSceneRequest represents the request body for updating a scene.
+// DefaultWitnessMode is one of "all", "author", or "gm_only". FogOverride is one
+// of "inherit", "always_visible", or "fog". An empty Summary clears the scene's
+// recap.
 type UpdateSceneRequest struct {
-	Title       *string `binding:"omitempty,min=1,max=200" json:"title,omitempty"`
-	Description *string `binding:"omitempty,max=2000"      json:"description,omitempty"`
+	Title              *string `binding:"omitempty,min=1,max=200" json:"title,omitempty"`
+	Description        *string `binding:"omitempty,max=2000"      json:"description,omitempty"`
+	DefaultWitnessMode *string `json:"defaultWitnessMode,omitempty"`
+	FogOverride        *string `json:"fogOverride,omitempty"`
+	Summary            *string `binding:"omitempty,max=5000"      json:"summary,omitempty"`
+}
+
+// ReorderScenesRequest represents the request body for reordering scenes.
+type ReorderScenesRequest struct {
+	SceneIDs []string `binding:"required" json:"sceneIds"`
+}
+
+// CloneSceneRequest represents the request body for cloning a scene.
+// An empty title defaults to the source title with " (Copy)" appended.
+type CloneSceneRequest struct {
+	Title string `binding:"max=200" json:"title"`
+}
+
+// CloneSceneResponse is the cloned scene, plus a warning when its header image
+// could not be copied.
+type CloneSceneResponse struct {
+	Scene   *generated.Scene `json:"scene"`
+	Warning string           `json:"warning,omitempty"`
+}
+
+// BulkArchiveScenesRequest represents the request body for archiving or unarchiving
+// several scenes at once.
+type BulkArchiveScenesRequest struct {
+	SceneIDs []string `binding:"required" json:"sceneIds"`
+	Archive  *bool    `binding:"required" json:"archive"`
+}
+
+// ReorderSceneCharactersRequest represents the request body for reordering a scene's characters.
+type ReorderSceneCharactersRequest struct {
+	CharacterIDs []string `binding:"required" json:"characterIds"`
 }
 
 // SceneCharacterRequest represents the request body for adding/removing a character.
@@ -65,7 +102,7 @@ func ListCampaignScenes(db *database.DB) gin.HandlerFunc {
 
 		scenes, err := svc.ListCampaignScenes(c.Request.Context(), campaignID, userID, characterIDPtr)
 		if err != nil {
-			handleSceneServiceError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -100,7 +137,7 @@ func CreateScene(db *database.DB) gin.HandlerFunc {
 
 		var req CreateSceneRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request. Title is required (max 200 characters).")
+			models.BindingError(c, err, "Invalid request. Title is required (max 200 characters).")
 			return
 		}
 
@@ -117,10 +154,12 @@ func CreateScene(db *database.DB) gin.HandlerFunc {
 			},
 		)
 		if err != nil {
-			handleSceneServiceError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
+		notifySceneLimit(c, db, campaignID, response)
+
 		c.JSON(http.StatusCreated, response)
 	}
 }
@@ -146,7 +185,7 @@ func GetScene(db *database.DB) gin.HandlerFunc {
 
 		scene, err := svc.GetScene(c.Request.Context(), sceneID, userID)
 		if err != nil {
-			handleSceneServiceError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -154,6 +193,41 @@ func GetScene(db *database.DB) gin.HandlerFunc {
 	}
 }
 
+// GetSceneBundle returns a scene with its characters, posts, pass states, compose
+// locks, rolls, and the campaign's phase status, for opening the scene in one call.
+func GetSceneBundle(db *database.DB) gin.HandlerFunc {
+	svc := service.NewSceneService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		sceneID := parseUUID(c.Param("sceneId"))
+		if !sceneID.Valid {
+			models.ValidationError(c, "Invalid scene ID format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		bundle, err := svc.GetSceneBundle(c.Request.Context(), userID, sceneID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		// Same as GetPhaseStatus: tell other clients when this check expired the time gate
+		if bundle.Phase.AutoPassedCount > 0 {
+			BroadcastPhaseStatus(c, bundle.Scene.CampaignID, bundle.Phase)
+			autoTransitionPhase(c, db, bundle.Scene.CampaignID)
+		}
+
+		c.JSON(http.StatusOK, bundle)
+	}
+}
+
 // UpdateScene updates a scene.
 //
 //nolint:dupl // Handler patterns are intentionally similar across resources
@@ -174,7 +248,7 @@ func UpdateScene(db *database.DB) gin.HandlerFunc {
 
 		var req UpdateSceneRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Invalid request format")
+			models.BindingError(c, err, "Invalid request format")
 			return
 		}
 
@@ -186,12 +260,15 @@ func UpdateScene(db *database.DB) gin.HandlerFunc {
 			sceneID,
 			userID,
 			service.UpdateSceneRequest{
-				Title:       req.Title,
-				Description: req.Description,
+				Title:              req.Title,
+				Description:        req.Description,
+				DefaultWitnessMode: req.DefaultWitnessMode,
+				FogOverride:        req.FogOverride,
+				Summary:            req.Summary,
 			},
 		)
 		if err != nil {
-			handleSceneServiceError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -199,6 +276,125 @@ func UpdateScene(db *database.DB) gin.HandlerFunc {
 	}
 }
 
+// ReorderScenes sets the manual order of a campaign's scenes.
+func ReorderScenes(db *database.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignIDStr := c.Param("id")
+		campaignID := parseUUID(campaignIDStr)
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		var req ReorderScenesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		svc := service.NewSceneService(db.Pool)
+
+		scenes, err := svc.ReorderScenes(c.Request.Context(), campaignID, userID, req.SceneIDs)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"scenes": scenes})
+	}
+}
+
+// BulkArchiveScenes archives or unarchives several of a campaign's scenes at once.
+func BulkArchiveScenes(db *database.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignIDStr := c.Param("id")
+		campaignID := parseUUID(campaignIDStr)
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		var req BulkArchiveScenesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		svc := service.NewSceneService(db.Pool)
+
+		scenes, err := svc.BulkArchive(c.Request.Context(), userID, campaignID, req.SceneIDs, *req.Archive)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"scenes": scenes})
+	}
+}
+
+// FavoriteScene pins a scene for the current user.
+func FavoriteScene(db *database.DB) gin.HandlerFunc {
+	return sceneFavoriteHandler(db, true)
+}
+
+// UnfavoriteScene unpins a scene for the current user.
+func UnfavoriteScene(db *database.DB) gin.HandlerFunc {
+	return sceneFavoriteHandler(db, false)
+}
+
+// sceneFavoriteHandler sets or clears the current user's favorite flag on a scene.
+func sceneFavoriteHandler(db *database.DB, favorite bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		sceneID := parseUUID(c.Param("sceneId"))
+		if !sceneID.Valid {
+			models.ValidationError(c, "Invalid scene ID format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		svc := service.NewSceneService(db.Pool)
+
+		var err error
+		if favorite {
+			err = svc.FavoriteScene(c.Request.Context(), campaignID, sceneID, userID)
+		} else {
+			err = svc.UnfavoriteScene(c.Request.Context(), campaignID, sceneID, userID)
+		}
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"sceneId": c.Param("sceneId"), "isFavorite": favorite})
+	}
+}
+
 // ArchiveScene archives a scene.
 func ArchiveScene(db *database.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -220,7 +416,7 @@ func ArchiveScene(db *database.DB) gin.HandlerFunc {
 
 		scene, err := svc.ArchiveScene(c.Request.Context(), sceneID, userID)
 		if err != nil {
-			handleSceneServiceError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -249,7 +445,65 @@ func UnarchiveScene(db *database.DB) gin.HandlerFunc {
 
 		scene, err := svc.UnarchiveScene(c.Request.Context(), sceneID, userID)
 		if err != nil {
-			handleSceneServiceError(c, err)
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, scene)
+	}
+}
+
+// FreezeScene freezes a scene so it accepts no new posts or edits.
+func FreezeScene(db *database.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		sceneIDStr := c.Param("sceneId")
+		sceneID := parseUUID(sceneIDStr)
+		if !sceneID.Valid {
+			models.ValidationError(c, "Invalid scene ID format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		svc := service.NewSceneService(db.Pool)
+
+		scene, err := svc.FreezeScene(c.Request.Context(), sceneID, userID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, scene)
+	}
+}
+
+// UnfreezeScene lifts a scene freeze.
+func UnfreezeScene(db *database.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		sceneIDStr := c.Param("sceneId")
+		sceneID := parseUUID(sceneIDStr)
+		if !sceneID.Valid {
+			models.ValidationError(c, "Invalid scene ID format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		svc := service.NewSceneService(db.Pool)
+
+		scene, err := svc.UnfreezeScene(c.Request.Context(), sceneID, userID)
+		if err != nil {
+			handleServiceError(c, err)
 			return
 		}
 
@@ -259,8 +513,6 @@ func UnarchiveScene(db *database.DB) gin.HandlerFunc {
 
 // AddCharacterToScene adds a character to a scene.
 func AddCharacterToScene(db *database.DB) gin.HandlerFunc {
-	queries := generated.New(db.Pool)
-
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
 		if !ok {
@@ -277,7 +529,7 @@ func AddCharacterToScene(db *database.DB) gin.HandlerFunc {
 
 		var req SceneCharacterRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			models.ValidationError(c, "Character ID is required")
+			models.BindingError(c, err, "Character ID is required")
 			return
 		}
 
@@ -290,24 +542,25 @@ func AddCharacterToScene(db *database.DB) gin.HandlerFunc {
 		userID := parseUUID(userIDStr)
 		svc := service.NewSceneService(db.Pool)
 
-		scene, err := svc.AddCharacterToScene(c.Request.Context(), sceneID, characterID, userID)
+		move, err := svc.AddCharacterToScene(c.Request.Context(), sceneID, characterID, userID)
 		if err != nil {
-			handleSceneServiceError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
-		// Broadcast character joined scene
-		if sceneData, sErr := queries.GetScene(c.Request.Context(), sceneID); sErr == nil {
-			BroadcastCharacterJoinedScene(c, sceneID, sceneData.CampaignID, characterID)
+		// Broadcast character left its previous scene and joined this one
+		if move.MovedFromSceneID != nil {
+			BroadcastCharacterLeftScene(c, parseUUID(*move.MovedFromSceneID), move.CampaignID, characterID)
 		}
+		BroadcastCharacterJoinedScene(c, sceneID, move.CampaignID, characterID)
 
-		c.JSON(http.StatusOK, scene)
+		c.JSON(http.StatusOK, move)
 	}
 }
 
 // RemoveCharacterFromScene removes a character from a scene.
 func RemoveCharacterFromScene(db *database.DB) gin.HandlerFunc {
-	queries := generated.New(db.Pool)
+	queries := service.NewQueries(db.Pool)
 
 	return func(c *gin.Context) {
 		userIDStr, ok := middleware.GetUserID(c)
@@ -343,7 +596,7 @@ func RemoveCharacterFromScene(db *database.DB) gin.HandlerFunc {
 			userID,
 		)
 		if err != nil {
-			handleSceneServiceError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -377,7 +630,7 @@ func DeleteScene(db *database.DB, imageService *service.ImageService) gin.Handle
 
 		headerImageURL, campaignID, err := svc.DeleteScene(c.Request.Context(), sceneID, userID)
 		if err != nil {
-			handleSceneServiceError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -394,6 +647,71 @@ func DeleteScene(db *database.DB, imageService *service.ImageService) gin.Handle
 	}
 }
 
+// CloneScene copies a scene's setup into a new scene in the same campaign.
+func CloneScene(db *database.DB, imageService *service.ImageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		sceneID := parseUUID(c.Param("sceneId"))
+		if !sceneID.Valid {
+			models.ValidationError(c, "Invalid scene ID format")
+			return
+		}
+
+		var req CloneSceneRequest
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				models.BindingError(c, err, "Invalid request. Title must be at most 200 characters.")
+				return
+			}
+		}
+
+		userID := parseUUID(userIDStr)
+		svc := service.NewSceneService(db.Pool)
+
+		result, err := svc.CloneScene(c.Request.Context(), userID, campaignID, sceneID, req.Title)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		response := CloneSceneResponse{Scene: result.Scene, Warning: ""}
+
+		// Copy the header image under the new scene, charged to the campaign's storage
+		if result.HeaderSourceURL != "" && imageService != nil {
+			url, copyErr := imageService.CopySceneHeader(
+				c.Request.Context(),
+				uuid.UUID(campaignID.Bytes),
+				uuid.UUID(campaignID.Bytes),
+				uuid.UUID(result.Scene.ID.Bytes),
+				result.HeaderSourceURL,
+			)
+			switch {
+			case errors.Is(copyErr, service.ErrStorageLimitReached):
+				response.Warning = "Scene cloned without its header image: campaign storage is full."
+			case copyErr != nil:
+				//nolint:sloglint // Error logging doesn't need structured logger injection
+				slog.Error("Failed to copy scene header", "error", copyErr)
+				response.Warning = "Scene cloned without its header image."
+			case url != "":
+				response.Scene.HeaderImageUrl = pgtype.Text{String: url, Valid: true}
+			}
+		}
+
+		c.JSON(http.StatusCreated, response)
+	}
+}
+
 // GetSceneCharacters returns all characters in a scene.
 func GetSceneCharacters(db *database.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -415,7 +733,7 @@ func GetSceneCharacters(db *database.DB) gin.HandlerFunc {
 
 		characters, err := svc.GetSceneCharacters(c.Request.Context(), sceneID, userID)
 		if err != nil {
-			handleSceneServiceError(c, err)
+			handleServiceError(c, err)
 			return
 		}
 
@@ -423,40 +741,82 @@ func GetSceneCharacters(db *database.DB) gin.HandlerFunc {
 	}
 }
 
-func handleSceneServiceError(c *gin.Context, err error) {
-	switch {
-	case errors.Is(err, service.ErrNotGM):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError("NOT_GM", "Only the GM can perform this action."),
-		)
-	case errors.Is(err, service.ErrSceneNotFound):
-		models.NotFoundError(c, "Scene")
-	case errors.Is(err, service.ErrNotMember):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError("NOT_MEMBER", "You are not a member of this campaign."),
-		)
-	case errors.Is(err, service.ErrNoArchivedScenes):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError(
-				"SCENE_LIMIT_NO_ARCHIVED",
-				"Scene limit reached (25 max). No archived scenes available to delete.",
-			),
-		)
-	case errors.Is(err, service.ErrNotGMPhase):
-		models.RespondError(
-			c,
-			http.StatusForbidden,
-			models.NewAPIError("NOT_GM_PHASE", "Characters can only be moved during GM Phase."),
+// GetCharacterScene returns the active scene a character is currently in, or null.
+func GetCharacterScene(db *database.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		characterID := parseUUID(c.Param("characterId"))
+		if !characterID.Valid {
+			models.ValidationError(c, "Invalid character ID format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		svc := service.NewSceneService(db.Pool)
+
+		scene, err := svc.GetCharacterScene(c.Request.Context(), campaignID, characterID, userID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"scene": scene})
+	}
+}
+
+// ReorderSceneCharacters sets the roster order of a scene's characters.
+func ReorderSceneCharacters(db *database.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		sceneID := parseUUID(c.Param("sceneId"))
+		if !sceneID.Valid {
+			models.ValidationError(c, "Invalid scene ID format")
+			return
+		}
+
+		var req ReorderSceneCharactersRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request format")
+			return
+		}
+
+		userID := parseUUID(userIDStr)
+		svc := service.NewSceneService(db.Pool)
+
+		characters, err := svc.ReorderSceneCharacters(
+			c.Request.Context(),
+			campaignID,
+			sceneID,
+			userID,
+			req.CharacterIDs,
 		)
-	case errors.Is(err, service.ErrCharacterNotFound):
-		models.NotFoundError(c, "Character")
-	default:
-		models.InternalError(c)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"characters": characters})
 	}
 }
@@ -1,32 +1,148 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/middleware"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/models"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
 )
 
-// CurrentUserResponse represents the response for the /me endpoint.
+// CurrentUserResponse represents the response for the /me endpoint. Alongside the
+// identity it summarizes the account so the app can load with a single call.
 type CurrentUserResponse struct {
-	ID    string `json:"id"`
-	Email string `json:"email"`
+	ID                      string                          `json:"id"`
+	Email                   string                          `json:"email"`
+	CampaignCount           int64                           `json:"campaignCount"`
+	ActionItemCount         int                             `json:"actionItemCount"`
+	UnreadNotificationCount int64                           `json:"unreadNotificationCount"`
+	NotificationPreferences NotificationPreferencesResponse `json:"notificationPreferences"`
+	QuietHours              any                             `json:"quietHours"`
 }
 
 // GetCurrentUser returns the currently authenticated user's info.
-// This endpoint extracts user data from the JWT token.
-func GetCurrentUser() gin.HandlerFunc {
+// Identity comes from the JWT token; the summary counts and preferences from the database.
+func GetCurrentUser(db *database.DB) gin.HandlerFunc {
+	queries := service.NewQueries(db.Pool)
+	campaignSvc := service.NewCampaignService(db.Pool)
+
 	return func(c *gin.Context) {
-		userID, ok := middleware.RequireAuth(c)
+		userIDStr, ok := middleware.RequireAuth(c)
 		if !ok {
 			return // RequireAuth already sent error response
 		}
 
 		email, _ := middleware.GetUserEmail(c)
+		ctx := c.Request.Context()
+		userID := parseUUID(userIDStr)
+
+		campaignCount, err := queries.CountUserCampaigns(ctx, userID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		items, err := campaignSvc.GetMyActionItems(ctx, userID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		unread, err := queries.GetUnreadNotificationCounter(ctx, userID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
 
 		c.JSON(http.StatusOK, CurrentUserResponse{
-			ID:    userID,
-			Email: email,
+			ID:                      userIDStr,
+			Email:                   email,
+			CampaignCount:           campaignCount,
+			ActionItemCount:         items.Count(),
+			UnreadNotificationCount: unread.UnreadCount,
+			NotificationPreferences: loadNotificationPreferences(ctx, queries, userID),
+			QuietHours:              loadQuietHours(ctx, queries, userID),
 		})
 	}
 }
+
+// GetMyProfile returns the current user's global profile.
+func GetMyProfile(db *database.DB) gin.HandlerFunc {
+	svc := service.NewProfileService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		profile, err := svc.GetProfile(c.Request.Context(), parseUUID(userIDStr))
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, profile)
+	}
+}
+
+// UpdateMyProfile updates the current user's global profile.
+func UpdateMyProfile(db *database.DB) gin.HandlerFunc {
+	svc := service.NewProfileService(db.Pool)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		var req service.UpdateProfileRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request body")
+			return
+		}
+
+		profile, err := svc.UpdateProfile(c.Request.Context(), parseUUID(userIDStr), req)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidDisplayName) {
+				models.ValidationError(c, fmt.Sprintf(
+					"Display name must be at most %d characters of letters, numbers, spaces, and - _ . '",
+					service.MaxDisplayNameLen,
+				))
+				return
+			}
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, profile)
+	}
+}
+
+// GetMyActionItems returns where the current user needs to act across all campaigns:
+// unpassed scenes in PC phase, pending roll requests, and unread mentions.
+func GetMyActionItems(db *database.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		svc := service.NewCampaignService(db.Pool)
+
+		items, err := svc.GetMyActionItems(c.Request.Context(), parseUUID(userIDStr))
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, items)
+	}
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/middleware"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/models"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+)
+
+//nolint:gochecknoglobals // Singleton pattern for webhook service
+var (
+	webhookService *service.WebhookService
+	webhookOnce    sync.Once
+)
+
+// getWebhookService returns a singleton webhook service.
+func getWebhookService(db *database.DB) *service.WebhookService {
+	webhookOnce.Do(func() {
+		webhookService = service.NewWebhookService(db.Pool)
+	})
+	return webhookService
+}
+
+// dispatchWebhook delivers an event to the campaign's webhook in the background.
+// Delivery outlives the request so retries are not cut short.
+func dispatchWebhook(
+	c *gin.Context,
+	db *database.DB,
+	campaignID pgtype.UUID,
+	event string,
+	data map[string]any,
+) {
+	svc := getWebhookService(db)
+	go svc.Deliver(context.WithoutCancel(c.Request.Context()), campaignID, event, data)
+}
+
+// webhookPostCreated delivers a published post. Only posts witnessed by every
+// character in the scene are sent outside the campaign; hidden posts and posts
+// restricted to some of the scene never are.
+func webhookPostCreated(c *gin.Context, db *database.DB, scene *generated.Scene, post *service.PostResponse) {
+	if post.IsDraft {
+		return
+	}
+	witnesses := make([]pgtype.UUID, 0, len(post.Witnesses))
+	for _, w := range post.Witnesses {
+		witnesses = append(witnesses, parseUUID(w))
+	}
+	if !service.IsScenePublic(post.IsHidden, witnesses, scene.CharacterIds) {
+		return
+	}
+	dispatchWebhook(c, db, scene.CampaignID, service.EventPostCreated, map[string]any{
+		"postId":      post.ID,
+		"sceneId":     post.SceneID,
+		"characterId": post.CharacterID,
+		"blocks":      post.Blocks,
+	})
+}
+
+// webhookPhaseTransition delivers a phase transition.
+func webhookPhaseTransition(
+	c *gin.Context,
+	db *database.DB,
+	campaignID pgtype.UUID,
+	fromPhase, toPhase, reason string,
+) {
+	dispatchWebhook(c, db, campaignID, service.EventPhaseTransition, map[string]any{
+		"fromPhase": fromPhase,
+		"toPhase":   toPhase,
+		"reason":    reason,
+	})
+}
+
+// webhookRollResolved delivers a manually resolved or invalidated roll. Rolls
+// attached to a post follow the post's visibility: rolls on hidden or restricted
+// posts are never sent outside the campaign.
+func webhookRollResolved(c *gin.Context, db *database.DB, scene *generated.Scene, roll *service.RollResponse) {
+	if roll.PostID != nil {
+		post, err := service.NewQueries(db.Pool).GetPost(c.Request.Context(), parseUUID(*roll.PostID))
+		if err != nil || !service.IsScenePublic(post.IsHidden, post.Witnesses, scene.CharacterIds) {
+			return
+		}
+	}
+	dispatchWebhook(c, db, scene.CampaignID, service.EventRollResolved, map[string]any{
+		"rollId":      roll.ID,
+		"sceneId":     roll.SceneID,
+		"characterId": roll.CharacterID,
+		"intention":   roll.Intention,
+		"total":       roll.Total,
+		"status":      roll.Status,
+	})
+}
+
+// SetCampaignWebhook creates or replaces the campaign's webhook (GM only).
+func SetCampaignWebhook(db *database.DB) gin.HandlerFunc {
+	svc := getWebhookService(db)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		var req service.SetWebhookRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			models.BindingError(c, err, "Invalid request body")
+			return
+		}
+
+		webhook, err := svc.SetWebhook(c.Request.Context(), campaignID, parseUUID(userIDStr), req)
+		if err != nil {
+			handleWebhookError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, webhook)
+	}
+}
+
+// DeleteCampaignWebhook removes the campaign's webhook (GM only).
+func DeleteCampaignWebhook(db *database.DB) gin.HandlerFunc {
+	svc := getWebhookService(db)
+
+	return func(c *gin.Context) {
+		userIDStr, ok := middleware.GetUserID(c)
+		if !ok {
+			models.UnauthorizedError(c)
+			return
+		}
+
+		campaignID := parseUUID(c.Param("id"))
+		if !campaignID.Valid {
+			models.ValidationError(c, "Invalid campaign ID format")
+			return
+		}
+
+		if err := svc.DeleteWebhook(c.Request.Context(), campaignID, parseUUID(userIDStr)); err != nil {
+			handleWebhookError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func handleWebhookError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidWebhookURL):
+		models.ValidationError(c, "Webhook URL must be a valid https:// URL")
+	case errors.Is(err, service.ErrInvalidWebhookEvents):
+		models.ValidationError(c, "Webhook events must be post_created, phase_transition, or roll_resolved")
+	case errors.Is(err, service.ErrInvalidWebhookSecret):
+		models.ValidationError(c, "Webhook secret must be at least 16 characters")
+	case errors.Is(err, service.ErrWebhookNotFound):
+		models.NotFoundError(c, "Webhook")
+	default:
+		handleServiceError(c, err)
+	}
+}
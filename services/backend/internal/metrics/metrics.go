@@ -0,0 +1,108 @@
+// Package metrics defines the Prometheus metrics exposed on /metrics.
+//
+// Label values must come from bounded sets (route templates, event names,
+// outcomes) so series cardinality does not grow with traffic.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "vanguard"
+
+// Outcome label values.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// UnmatchedRoute labels requests that did not match a registered route.
+const UnmatchedRoute = "unmatched"
+
+//nolint:gochecknoglobals // Metrics are process-wide and registered once
+var (
+	// HTTPRequests counts handled HTTP requests by method, route template, and status code.
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template, and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration observes request latency by method and route template.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method and route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// RollExecutionDuration observes how long rolling and saving dice takes.
+	RollExecutionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "roll_execution_duration_seconds",
+		Help:      "Time to roll dice and save the result, by outcome.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 12), //nolint:mnd // 1ms to ~4s
+	}, []string{"outcome"})
+
+	// BroadcastAttempts counts realtime broadcasts by event type.
+	BroadcastAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "broadcast_attempts_total",
+		Help:      "Realtime broadcast attempts by event type.",
+	}, []string{"event"})
+
+	// BroadcastFailures counts failed realtime broadcasts by event type.
+	BroadcastFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "broadcast_failures_total",
+		Help:      "Failed realtime broadcasts by event type.",
+	}, []string{"event"})
+
+	// EmailSends counts notification emails by outcome.
+	EmailSends = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "email_sends_total",
+		Help:      "Notification email sends by outcome.",
+	}, []string{"outcome"})
+)
+
+// Handler returns the HTTP handler that serves the metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveRollExecution records the duration of a roll execution started at start.
+func ObserveRollExecution(start time.Time, err error) {
+	RollExecutionDuration.WithLabelValues(outcome(err)).Observe(time.Since(start).Seconds())
+}
+
+// RecordBroadcast counts a broadcast attempt and, if it failed, the failure.
+func RecordBroadcast(event string, err error) {
+	BroadcastAttempts.WithLabelValues(event).Inc()
+	if err != nil {
+		BroadcastFailures.WithLabelValues(event).Inc()
+	}
+}
+
+// RecordEmailSend counts a notification email send.
+func RecordEmailSend(err error) {
+	EmailSends.WithLabelValues(outcome(err)).Inc()
+}
+
+// RegisterDBPool exposes the connection pool's statistics.
+func RegisterDBPool(pool *pgxpool.Pool) error {
+	return prometheus.Register(newPoolCollector(pool))
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return OutcomeFailure
+	}
+	return OutcomeSuccess
+}
@@ -21,6 +21,12 @@ const (
 	UserIDKey = "user_id"
 	// UserEmailKey is the context key for the authenticated user's email.
 	UserEmailKey = "user_email"
+	// UserRoleKey is the context key for the authenticated user's JWT role claim.
+	UserRoleKey = "user_role"
+
+	// RoleServiceRole is the Supabase role for service keys, used for platform
+	// administration and maintenance endpoints.
+	RoleServiceRole = "service_role"
 )
 
 // JWTValidator handles JWT validation using either JWKS or a symmetric secret.
@@ -172,7 +178,7 @@ func Auth(validator *JWTValidator) gin.HandlerFunc {
 		// Parse and validate JWT using the validator
 		token, err := jwt.ParseWithClaims(tokenString, new(Claims), validator.Keyfunc)
 		if err != nil {
-			handleTokenError(c, err)
+			handleTokenError(c, token, err)
 			return
 		}
 
@@ -186,6 +192,7 @@ func Auth(validator *JWTValidator) gin.HandlerFunc {
 		// Subject contains the user UUID from Supabase Auth
 		c.Set(UserIDKey, claims.Subject)
 		c.Set(UserEmailKey, claims.Email)
+		c.Set(UserRoleKey, claims.Role)
 		c.Next()
 	}
 }
@@ -213,7 +220,7 @@ func AuthWithJWKS(jwks *JWKS) gin.HandlerFunc {
 		// Parse and validate JWT using JWKS
 		token, err := jwt.ParseWithClaims(tokenString, new(Claims), jwks.keyFunc.Keyfunc)
 		if err != nil {
-			handleTokenError(c, err)
+			handleTokenError(c, token, err)
 			return
 		}
 
@@ -227,6 +234,7 @@ func AuthWithJWKS(jwks *JWKS) gin.HandlerFunc {
 		// Subject contains the user UUID from Supabase Auth
 		c.Set(UserIDKey, claims.Subject)
 		c.Set(UserEmailKey, claims.Email)
+		c.Set(UserRoleKey, claims.Role)
 		c.Next()
 	}
 }
@@ -234,6 +242,18 @@ func AuthWithJWKS(jwks *JWKS) gin.HandlerFunc {
 // OptionalAuth validates JWT if present but doesn't require it.
 // Use for routes that work with or without authentication.
 func OptionalAuth(jwks *JWKS) gin.HandlerFunc {
+	return optionalAuth(jwks.keyFunc.Keyfunc)
+}
+
+// OptionalAuthV2 is OptionalAuth using JWTValidator, so optional auth also works
+// with the HS256 secret used in local dev.
+func OptionalAuthV2(validator *JWTValidator) gin.HandlerFunc {
+	return optionalAuth(validator.Keyfunc)
+}
+
+// optionalAuth sets the user context when the request carries a valid token and
+// otherwise continues without it, whatever the validation failure.
+func optionalAuth(keyfunc jwt.Keyfunc) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -249,7 +269,7 @@ func OptionalAuth(jwks *JWKS) gin.HandlerFunc {
 
 		tokenString := parts[1]
 
-		token, err := jwt.ParseWithClaims(tokenString, new(Claims), jwks.keyFunc.Keyfunc)
+		token, err := jwt.ParseWithClaims(tokenString, new(Claims), keyfunc)
 		if err != nil {
 			// Don't abort, just continue without user context
 			c.Next()
@@ -264,6 +284,7 @@ func OptionalAuth(jwks *JWKS) gin.HandlerFunc {
 
 		c.Set(UserIDKey, claims.Subject)
 		c.Set(UserEmailKey, claims.Email)
+		c.Set(UserRoleKey, claims.Role)
 		c.Next()
 	}
 }
@@ -301,6 +322,34 @@ func RequireAuth(c *gin.Context) (string, bool) {
 	return userID, true
 }
 
+// GetUserRole extracts the JWT role claim from the Gin context.
+// Returns empty string and false if not authenticated or the token has no role.
+func GetUserRole(c *gin.Context) (string, bool) {
+	role, exists := c.Get(UserRoleKey)
+	if !exists {
+		return "", false
+	}
+	r, ok := role.(string)
+	return r, ok && r != ""
+}
+
+// RequireRole only lets requests through whose JWT role claim matches role,
+// returning 403 otherwise. It must run after Auth. Campaign-level permissions are
+// still checked by the handlers; this is a coarse platform-wide tier.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userRole, ok := GetUserRole(c); !ok || userRole != role {
+			c.JSON(http.StatusForbidden, gin.H{"error": gin.H{
+				"code":    "FORBIDDEN",
+				"message": "This endpoint requires the " + role + " role",
+			}})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
 // abortWithAuthError sends a standardized auth error response.
 func abortWithAuthError(c *gin.Context, code, message string) {
 	c.JSON(http.StatusUnauthorized, gin.H{"error": gin.H{
@@ -311,11 +360,24 @@ func abortWithAuthError(c *gin.Context, code, message string) {
 }
 
 // handleTokenError sends appropriate error response for token validation failures.
-func handleTokenError(c *gin.Context, err error) {
+// Expired tokens include their expiry time so the client can choose between a
+// silent refresh and a fresh login. jwt checks the signature before the claims,
+// so an expired token's claims have already been verified; other failures get
+// no claim details.
+func handleTokenError(c *gin.Context, token *jwt.Token, err error) {
 	var code, message string
 
 	switch {
 	case errors.Is(err, jwt.ErrTokenExpired):
+		if expiresAt := tokenExpiry(token); expiresAt != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": gin.H{
+				"code":      "TOKEN_EXPIRED",
+				"message":   "Token has expired",
+				"expiresAt": expiresAt.UTC(),
+			}})
+			c.Abort()
+			return
+		}
 		code = "TOKEN_EXPIRED"
 		message = "Token has expired"
 	case errors.Is(err, jwt.ErrTokenNotValidYet):
@@ -334,3 +396,15 @@ func handleTokenError(c *gin.Context, err error) {
 
 	abortWithAuthError(c, code, message)
 }
+
+// tokenExpiry returns the exp claim of a parsed token, or nil if there is none.
+func tokenExpiry(token *jwt.Token) *time.Time {
+	if token == nil || token.Claims == nil {
+		return nil
+	}
+	exp, err := token.Claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return nil
+	}
+	return &exp.Time
+}
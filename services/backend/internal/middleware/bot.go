@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BotIdentityKey is the context key for the authenticated bot's identity.
+const BotIdentityKey = "bot_identity"
+
+// ErrInvalidBotToken is returned by a BotTokenResolver for unknown or revoked tokens.
+var ErrInvalidBotToken = errors.New("invalid bot token")
+
+// BotIdentity is the service identity a bot token maps to. A bot acts on behalf
+// of the campaign's GM, but only within the narrow bot API.
+type BotIdentity struct {
+	TokenID    string
+	CampaignID string
+	GMUserID   string
+}
+
+// BotTokenResolver looks up the identity for a plaintext bot token.
+type BotTokenResolver func(ctx context.Context, token string) (*BotIdentity, error)
+
+// BotAuth validates campaign bot tokens. It deliberately does not set UserIDKey,
+// so user-facing handlers never treat a bot as an authenticated user.
+func BotAuth(resolve BotTokenResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			abortWithAuthError(c, "MISSING_AUTH", "Authorization header required")
+			return
+		}
+
+		parts := strings.SplitN(authHeader, " ", bearerTokenParts)
+		if len(parts) != bearerTokenParts || !strings.EqualFold(parts[0], "bearer") {
+			abortWithAuthError(c, "INVALID_AUTH_FORMAT", "Invalid authorization format. Use: Bearer <token>")
+			return
+		}
+
+		identity, err := resolve(c.Request.Context(), parts[1])
+		if err != nil {
+			if errors.Is(err, ErrInvalidBotToken) {
+				abortWithAuthError(c, "INVALID_BOT_TOKEN", "Bot token is invalid or has been revoked")
+				return
+			}
+			//nolint:sloglint // Error logging doesn't need structured logger injection
+			slog.ErrorContext(c.Request.Context(), "Failed to resolve bot token", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "An internal error occurred",
+			}})
+			c.Abort()
+			return
+		}
+
+		c.Set(BotIdentityKey, identity)
+		c.Next()
+	}
+}
+
+// GetBotIdentity extracts the bot identity from the Gin context.
+// Returns nil and false if the request was not authenticated with a bot token.
+func GetBotIdentity(c *gin.Context) (*BotIdentity, bool) {
+	value, exists := c.Get(BotIdentityKey)
+	if !exists {
+		return nil, false
+	}
+	identity, ok := value.(*BotIdentity)
+	return identity, ok && identity != nil
+}
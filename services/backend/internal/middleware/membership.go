@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+)
+
+// MembershipCache gives each request its own cache of campaign membership and GM
+// checks, so service calls that repeat the same check within a request reuse the
+// first answer. The cache lives on the request context and is gone when the
+// request ends.
+//
+// The saving depends on how many service calls a handler chains: a post create
+// checks membership and GM once each and is unchanged, while resolving a post
+// link drops from three membership queries to two (more when it falls back to
+// the scene or campaign).
+func MembershipCache() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(service.WithMembershipCache(c.Request.Context()))
+		c.Next()
+	}
+}
@@ -8,12 +8,19 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// APIError represents a standardized API error response.
+// APIError represents a standardized API error response. Fields lists the
+// individual problems behind a validation error, when known.
 type APIError struct {
-	Code      string    `json:"code"`
-	Message   string    `json:"message"`
-	Timestamp time.Time `json:"timestamp"`
-	RequestID string    `json:"requestId,omitempty"`
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	Fields    []FieldError `json:"fields,omitempty"`
+	Timestamp time.Time    `json:"timestamp"`
+	RequestID string       `json:"requestId,omitempty"`
+}
+
+// ErrorResponse is the envelope every error response is wrapped in.
+type ErrorResponse struct {
+	Error *APIError `json:"error"`
 }
 
 // NewAPIError creates a new API error with the given code and message.
@@ -40,7 +47,7 @@ const (
 // RespondError sends an error response to the client.
 func RespondError(c *gin.Context, status int, err *APIError) {
 	err.RequestID = c.GetString("requestId")
-	c.JSON(status, gin.H{"error": err})
+	c.JSON(status, ErrorResponse{Error: err})
 }
 
 // ValidationError sends a validation error response.
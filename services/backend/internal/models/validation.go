@@ -0,0 +1,138 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes one request field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// UseJSONFieldNames makes binding validation report fields by their JSON names
+// so field errors match what clients sent. Call once at startup.
+func UseJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+}
+
+// BindingError sends a validation error response for a failed ShouldBind call.
+// message stays the top-level summary; every field-level problem in err is
+// listed under fields. Malformed bodies carry no field errors.
+func BindingError(c *gin.Context, err error, message string) {
+	apiErr := NewAPIError(ErrCodeValidation, message)
+	apiErr.Fields = fieldErrors(err)
+	RespondError(c, http.StatusBadRequest, apiErr)
+}
+
+func fieldErrors(err error) []FieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			field := fieldPath(fe.Namespace())
+			fields = append(fields, FieldError{Field: field, Message: fieldMessage(field, fe)})
+		}
+		return fields
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []FieldError{{
+			Field:   typeErr.Field,
+			Message: fmt.Sprintf("%s must be a %s", typeErr.Field, jsonTypeName(typeErr.Type)),
+		}}
+	}
+	return nil
+}
+
+// fieldPath drops the request struct name from a validator namespace, turning
+// "CreateSceneRequest.title" into "title".
+func fieldPath(namespace string) string {
+	_, path, found := strings.Cut(namespace, ".")
+	if !found {
+		return namespace
+	}
+	return path
+}
+
+func fieldMessage(field string, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_if", "required_with", "required_without":
+		return field + " is required"
+	case "min", "gte":
+		return fmt.Sprintf("%s must be at least %s", field, sizeLimit(fe))
+	case "max", "lte":
+		return fmt.Sprintf("%s must be at most %s", field, sizeLimit(fe))
+	case "len":
+		return fmt.Sprintf("%s must be exactly %s", field, sizeLimit(fe))
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, fe.Param())
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s", field, fe.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "uuid", "uuid4":
+		return field + " must be a valid UUID"
+	case "email":
+		return field + " must be a valid email address"
+	case "url", "http_url":
+		return field + " must be a valid URL"
+	default:
+		return field + " is invalid"
+	}
+}
+
+// sizeLimit phrases a min/max/len parameter for the kind of value it bounds.
+func sizeLimit(fe validator.FieldError) string {
+	switch fe.Kind() {
+	case reflect.String:
+		return fe.Param() + " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fe.Param() + " items"
+	default:
+		return fe.Param()
+	}
+}
+
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "whole number"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return t.String()
+	}
+}
@@ -0,0 +1,157 @@
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	jsonContentType  = "application/json"
+	bearerSchemeName = "bearerAuth"
+)
+
+// Route documents one registered endpoint.
+type Route struct {
+	Method  string
+	Path    string // gin-style path, e.g. /api/v1/campaigns/:id
+	Tag     string
+	Summary string
+	Query   []string // optional string query parameters
+	// Request and Response are sample values of the bound and returned types.
+	// A nil Request means no body; a nil Response means an empty response.
+	Request  any
+	Response any
+	Status   int // success status; defaults to 200
+}
+
+// Builder accumulates routes into an OpenAPI document.
+type Builder struct {
+	doc           *Document
+	reflector     *reflector
+	errorResponse *Schema
+}
+
+// New creates a Builder. errorBody is a sample of the error envelope every
+// endpoint may return.
+func New(title, version string, errorBody any) *Builder {
+	r := newReflector()
+	return &Builder{
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    Info{Title: title, Version: version},
+			Paths:   make(map[string]map[string]*Operation),
+			Components: Components{
+				Schemas: r.schemas,
+				SecuritySchemes: map[string]*SecurityScheme{
+					bearerSchemeName: {
+						Type:         "http",
+						Scheme:       "bearer",
+						BearerFormat: "JWT",
+						Description:  "Supabase Auth access token",
+					},
+				},
+			},
+		},
+		reflector:     r,
+		errorResponse: r.schemaFor(errorBody),
+	}
+}
+
+// Add documents routes.
+func (b *Builder) Add(routes ...Route) {
+	for i := range routes {
+		b.add(&routes[i])
+	}
+}
+
+// Document returns the built document.
+func (b *Builder) Document() *Document {
+	return b.doc
+}
+
+func (b *Builder) add(route *Route) {
+	path, params := convertPath(route.Path)
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	//nolint:exhaustruct // Request body is set below when present
+	op := &Operation{
+		Tags:        []string{route.Tag},
+		Summary:     route.Summary,
+		OperationID: operationID(route.Method, route.Path),
+		Responses: map[string]*Response{
+			"default": {
+				Description: "Error",
+				Content:     map[string]MediaType{jsonContentType: {Schema: b.errorResponse}},
+			},
+		},
+		Security: []map[string][]string{{bearerSchemeName: {}}},
+	}
+
+	for _, name := range params {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"}, //nolint:exhaustruct // Plain string
+		})
+	}
+	for _, name := range route.Query {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     name,
+			In:       "query",
+			Required: false,
+			Schema:   &Schema{Type: "string"}, //nolint:exhaustruct // Plain string
+		})
+	}
+
+	if route.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{jsonContentType: {Schema: b.reflector.schemaFor(route.Request)}},
+		}
+	}
+
+	//nolint:exhaustruct // Content is set below when there is a body
+	success := &Response{Description: http.StatusText(status)}
+	if route.Response != nil {
+		success.Content = map[string]MediaType{jsonContentType: {Schema: b.reflector.schemaFor(route.Response)}}
+	}
+	op.Responses[strconv.Itoa(status)] = success
+
+	if b.doc.Paths[path] == nil {
+		b.doc.Paths[path] = make(map[string]*Operation)
+	}
+	b.doc.Paths[path][strings.ToLower(route.Method)] = op
+}
+
+// convertPath turns a gin path into an OpenAPI path and lists its parameters.
+func convertPath(ginPath string) (string, []string) {
+	segments := strings.Split(ginPath, "/")
+	var params []string
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+			params = append(params, name)
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID derives a stable ID such as "post_campaigns_id_scenes".
+func operationID(method, ginPath string) string {
+	path := strings.TrimPrefix(ginPath, "/api/v1")
+	id := strings.ToLower(method)
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.TrimPrefix(segment, ":")
+		if segment == "" {
+			continue
+		}
+		id += "_" + strings.ReplaceAll(segment, "-", "_")
+	}
+	return id
+}
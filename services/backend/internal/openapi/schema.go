@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Fields describes an inline JSON object, such as a gin.H response envelope.
+// Each value is a sample whose type determines the property's schema.
+type Fields map[string]any
+
+// componentPrefix is where named schemas are referenced from.
+const componentPrefix = "#/components/schemas/"
+
+//nolint:gochecknoglobals // Read-only lookup tables for type mapping
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+	fieldsType     = reflect.TypeFor[Fields]()
+
+	// pgtypeSchemas maps nullable pgtype values to the JSON they marshal to.
+	pgtypeSchemas = map[reflect.Type]Schema{
+		reflect.TypeFor[pgtype.UUID]():        {Type: "string", Format: "uuid", Nullable: true},
+		reflect.TypeFor[pgtype.Text]():        {Type: "string", Nullable: true},
+		reflect.TypeFor[pgtype.Timestamptz](): {Type: "string", Format: "date-time", Nullable: true},
+		reflect.TypeFor[pgtype.Timestamp]():   {Type: "string", Format: "date-time", Nullable: true},
+		reflect.TypeFor[pgtype.Date]():        {Type: "string", Format: "date", Nullable: true},
+		reflect.TypeFor[pgtype.Time]():        {Type: "string", Nullable: true},
+		reflect.TypeFor[pgtype.Bool]():        {Type: "boolean", Nullable: true},
+		reflect.TypeFor[pgtype.Int2]():        {Type: "integer", Format: "int32", Nullable: true},
+		reflect.TypeFor[pgtype.Int4]():        {Type: "integer", Format: "int32", Nullable: true},
+		reflect.TypeFor[pgtype.Int8]():        {Type: "integer", Format: "int64", Nullable: true},
+		reflect.TypeFor[pgtype.Float8]():      {Type: "number", Format: "double", Nullable: true},
+	}
+)
+
+// reflector converts Go types into schemas, collecting named structs as components.
+type reflector struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newReflector() *reflector {
+	return &reflector{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schemaFor returns the schema for a sample value.
+func (r *reflector) schemaFor(value any) *Schema {
+	if fields, ok := value.(Fields); ok {
+		return r.fieldsSchema(fields)
+	}
+	return r.schemaForType(reflect.TypeOf(value))
+}
+
+func (r *reflector) fieldsSchema(fields Fields) *Schema {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	//nolint:exhaustruct // Only object fields apply
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema, len(fields))}
+	for _, key := range keys {
+		schema.Properties[key] = r.schemaFor(fields[key])
+	}
+	schema.Required = keys
+	return schema
+}
+
+//nolint:exhaustruct,cyclop // Each kind sets only the schema fields that apply to it
+func (r *reflector) schemaForType(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	if known, ok := pgtypeSchemas[t]; ok {
+		return &known
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t == fieldsType:
+		return &Schema{Type: "object"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		inner := r.schemaForType(t.Elem())
+		if inner.Ref != "" {
+			return &Schema{AllOf: []*Schema{inner}, Nullable: true}
+		}
+		inner.Nullable = true
+		return inner
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		// A nil slice marshals to null
+		return &Schema{Type: "array", Items: r.schemaForType(t.Elem()), Nullable: t.Kind() == reflect.Slice}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaForType(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return &Schema{Ref: componentPrefix + r.component(t)}
+	default:
+		// interface{} and anything else without a fixed shape
+		return &Schema{}
+	}
+}
+
+// component registers a named struct once and returns its component name.
+func (r *reflector) component(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := r.schemas[name]; taken {
+		// Same name in another package, e.g. a generated row and a response type
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+	}
+
+	// Register before recursing so self-referencing types terminate
+	r.names[t] = name
+	r.schemas[name] = &Schema{}
+	*r.schemas[name] = *r.structSchema(t)
+	return name
+}
+
+//nolint:exhaustruct // Only object fields apply
+func (r *reflector) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(schema, t)
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// addFields adds a struct's JSON fields, flattening embedded structs the way encoding/json does.
+func (r *reflector) addFields(schema *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				r.addFields(schema, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = r.schemaForType(field.Type)
+		if !strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Pointer {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
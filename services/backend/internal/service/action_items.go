@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// maxActionItemMentions caps the unread mentions returned with action items.
+const maxActionItemMentions = 50
+
+// ActionItems lists everywhere a player needs to act, across all their campaigns.
+type ActionItems struct {
+	Campaigns []CampaignActionItems `json:"campaigns"`
+	Mentions  []MentionActionItem   `json:"mentions"`
+}
+
+// Count returns the total number of open actions: unpassed scenes, pending rolls, and
+// unread mentions.
+func (a *ActionItems) Count() int {
+	count := len(a.Mentions)
+	for _, campaign := range a.Campaigns {
+		count += len(campaign.UnpassedScenes) + len(campaign.PendingRolls)
+	}
+	return count
+}
+
+// CampaignActionItems lists a player's open actions in one campaign.
+type CampaignActionItems struct {
+	CampaignID           string           `json:"campaignId"`
+	CampaignTitle        string           `json:"campaignTitle"`
+	CurrentPhase         string           `json:"currentPhase"`
+	IsPaused             bool             `json:"isPaused"`
+	PhaseExpiresAt       *string          `json:"phaseExpiresAt"`
+	TimeRemainingSeconds *int64           `json:"timeRemainingSeconds"`
+	UnpassedScenes       []UnpassedScene  `json:"unpassedScenes"`
+	PendingRolls         []PendingRollRef `json:"pendingRolls"`
+}
+
+// UnpassedScene is a scene where one of the player's characters has not passed.
+type UnpassedScene struct {
+	SceneID       string `json:"sceneId"`
+	SceneTitle    string `json:"sceneTitle"`
+	CharacterID   string `json:"characterId"`
+	CharacterName string `json:"characterName"`
+}
+
+// PendingRollRef is a roll the GM requested from one of the player's characters.
+type PendingRollRef struct {
+	RollID        string `json:"rollId"`
+	SceneID       string `json:"sceneId"`
+	CharacterID   string `json:"characterId"`
+	CharacterName string `json:"characterName"`
+	Intention     string `json:"intention"`
+}
+
+// MentionActionItem is an unread @mention of the player.
+type MentionActionItem struct {
+	NotificationID string  `json:"notificationId"`
+	CampaignID     *string `json:"campaignId"`
+	SceneID        *string `json:"sceneId"`
+	PostID         *string `json:"postId"`
+	Body           string  `json:"body"`
+	CreatedAt      string  `json:"createdAt"`
+}
+
+// GetMyActionItems returns the campaigns where the user's characters still need to
+// pass in PC phase or answer a roll request, plus their unread mentions.
+// Campaigns with nothing to do are omitted.
+func (s *CampaignService) GetMyActionItems(ctx context.Context, userID pgtype.UUID) (*ActionItems, error) {
+	campaigns, err := s.queries.ListUserCampaigns(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := &ActionItems{
+		Campaigns: []CampaignActionItems{},
+		Mentions:  []MentionActionItem{},
+	}
+
+	for i := range campaigns {
+		campaignItems, itemsErr := s.campaignActionItems(ctx, &campaigns[i], userID)
+		if itemsErr != nil {
+			return nil, itemsErr
+		}
+		if len(campaignItems.UnpassedScenes) > 0 || len(campaignItems.PendingRolls) > 0 {
+			items.Campaigns = append(items.Campaigns, *campaignItems)
+		}
+	}
+
+	mentions, err := s.queries.GetUnreadNotificationsByType(ctx, generated.GetUnreadNotificationsByTypeParams{
+		UserID: userID,
+		Type:   NotifMentioned,
+		Limit:  maxActionItemMentions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range mentions {
+		items.Mentions = append(items.Mentions, MentionActionItem{
+			NotificationID: uuidToString(n.ID),
+			CampaignID:     optionalUUIDString(n.CampaignID),
+			SceneID:        optionalUUIDString(n.SceneID),
+			PostID:         optionalUUIDString(n.PostID),
+			Body:           n.Body,
+			CreatedAt:      n.CreatedAt.Time.Format(time.RFC3339),
+		})
+	}
+
+	return items, nil
+}
+
+// campaignActionItems collects unpassed scenes and pending rolls for the user's PCs in a campaign.
+func (s *CampaignService) campaignActionItems(
+	ctx context.Context,
+	campaign *generated.ListUserCampaignsRow,
+	userID pgtype.UUID,
+) (*CampaignActionItems, error) {
+	items := &CampaignActionItems{
+		CampaignID:     uuidToString(campaign.ID),
+		CampaignTitle:  campaign.Title,
+		CurrentPhase:   string(campaign.CurrentPhase),
+		IsPaused:       campaign.IsPaused,
+		UnpassedScenes: []UnpassedScene{},
+		PendingRolls:   []PendingRollRef{},
+	}
+	if campaign.CurrentPhaseExpiresAt.Valid {
+		expiresAt := campaign.CurrentPhaseExpiresAt.Time.Format(time.RFC3339)
+		remaining := max(int64(time.Until(campaign.CurrentPhaseExpiresAt.Time).Seconds()), 0)
+		items.PhaseExpiresAt = &expiresAt
+		items.TimeRemainingSeconds = &remaining
+	}
+
+	characters, err := s.queries.ListUserCharactersInCampaign(ctx, generated.ListUserCharactersInCampaignParams{
+		CampaignID: campaign.ID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	inPCPhase := campaign.CurrentPhase == generated.CampaignPhasePcPhase && !campaign.IsPaused
+	for _, char := range characters {
+		if char.CharacterType != generated.CharacterTypePc {
+			continue
+		}
+		charID := uuidToString(char.ID)
+
+		if inPCPhase {
+			unpassed, passErr := s.unpassedScenes(ctx, char.ID)
+			if passErr != nil {
+				return nil, passErr
+			}
+			for _, scene := range unpassed {
+				scene.CharacterID = charID
+				scene.CharacterName = char.DisplayName
+				items.UnpassedScenes = append(items.UnpassedScenes, scene)
+			}
+		}
+
+		rolls, rollErr := s.queries.GetPendingRollsForCharacter(ctx, char.ID)
+		if rollErr != nil {
+			return nil, rollErr
+		}
+		for _, roll := range rolls {
+			items.PendingRolls = append(items.PendingRolls, PendingRollRef{
+				RollID:        uuidToString(roll.ID),
+				SceneID:       uuidToString(roll.SceneID),
+				CharacterID:   charID,
+				CharacterName: char.DisplayName,
+				Intention:     roll.Intention,
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// unpassedScenes returns the active scenes where a character has not passed.
+func (s *CampaignService) unpassedScenes(ctx context.Context, characterID pgtype.UUID) ([]UnpassedScene, error) {
+	status, err := s.queries.GetCharacterPassStatus(ctx, characterID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // Character is not in any active scene
+		}
+		return nil, err
+	}
+
+	var scenes []struct {
+		SceneID    string `json:"scene_id"`
+		SceneTitle string `json:"scene_title"`
+		PassState  string `json:"pass_state"`
+	}
+	if unmarshalErr := json.Unmarshal(status.ScenesPassStates, &scenes); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	unpassed := []UnpassedScene{}
+	for _, scene := range scenes {
+		if scene.PassState == PassStateNone {
+			unpassed = append(unpassed, UnpassedScene{
+				SceneID:    scene.SceneID,
+				SceneTitle: scene.SceneTitle,
+			})
+		}
+	}
+	return unpassed, nil
+}
+
+// optionalUUIDString formats a nullable UUID as an optional string.
+func optionalUUIDString(id pgtype.UUID) *string {
+	if !id.Valid {
+		return nil
+	}
+	s := uuidToString(id)
+	return &s
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// GMAction names a GM administrative action recorded in the audit log.
+type GMAction string
+
+// GM actions recorded in the audit log.
+const (
+	GMActionForceTransition        GMAction = "force_transition"
+	GMActionForceReleaseLock       GMAction = "force_release_lock"
+	GMActionForceReleaseSceneLocks GMAction = "force_release_scene_locks"
+	GMActionOverrideIntention      GMAction = "override_intention"
+	GMActionInvalidateRoll         GMAction = "invalidate_roll"
+	GMActionRemoveMember           GMAction = "remove_member"
+	GMActionDeletePost             GMAction = "delete_post"
+	GMActionRemovePost             GMAction = "remove_post"
+	GMActionRestorePost            GMAction = "restore_post"
+	GMActionUnhidePost             GMAction = "unhide_post"
+	GMActionMovePost               GMAction = "move_post"
+	GMActionAttributePost          GMAction = "attribute_post"
+	GMActionCreateBotToken         GMAction = "create_bot_token"
+	GMActionRevokeBotToken         GMAction = "revoke_bot_token"
+)
+
+// Audit log target types.
+const (
+	AuditTargetCampaign    = "campaign"
+	AuditTargetScene       = "scene"
+	AuditTargetPost        = "post"
+	AuditTargetRoll        = "roll"
+	AuditTargetComposeLock = "compose_lock"
+	AuditTargetUser        = "user"
+	AuditTargetBotToken    = "bot_token"
+)
+
+// Audit log pagination limits.
+const (
+	DefaultAuditLogLimit = 50
+	MaxAuditLogLimit     = 100
+)
+
+// RecordGMAction appends an entry to a campaign's GM audit log. Pass a
+// transaction's queries to record the entry atomically with the action itself.
+func RecordGMAction(
+	ctx context.Context,
+	queries *generated.Queries,
+	campaignID, gmUserID pgtype.UUID,
+	action GMAction,
+	targetType string,
+	targetID pgtype.UUID,
+	detail map[string]any,
+) error {
+	if detail == nil {
+		detail = map[string]any{}
+	}
+	detailJSON, err := json.Marshal(detail)
+	if err != nil {
+		return err
+	}
+
+	return queries.CreateGmAuditEntry(ctx, generated.CreateGmAuditEntryParams{
+		CampaignID: campaignID,
+		GmUserID:   gmUserID,
+		Action:     string(action),
+		TargetType: targetType,
+		TargetID:   targetID,
+		Detail:     detailJSON,
+	})
+}
+
+// logGMAction records a GM action that has already been saved outside a
+// transaction. A failure is logged rather than failing the completed action.
+func logGMAction(
+	ctx context.Context,
+	queries *generated.Queries,
+	campaignID, gmUserID pgtype.UUID,
+	action GMAction,
+	targetType string,
+	targetID pgtype.UUID,
+	detail map[string]any,
+) {
+	err := RecordGMAction(ctx, queries, campaignID, gmUserID, action, targetType, targetID, detail)
+	if err != nil {
+		slog.Default().ErrorContext(ctx, "Failed to record GM action",
+			"campaignID", uuidToString(campaignID), "action", action, "error", err)
+	}
+}
+
+// AuditService handles reading the GM audit log.
+type AuditService struct {
+	queries *generated.Queries
+}
+
+// NewAuditService creates a new AuditService.
+func NewAuditService(pool *pgxpool.Pool) *AuditService {
+	return &AuditService{
+		queries: newQueries(pool),
+	}
+}
+
+// AuditEntryResponse represents one GM audit log entry in API responses.
+type AuditEntryResponse struct {
+	ID         string          `json:"id"`
+	GMUserID   *string         `json:"gmUserId"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"targetType"`
+	TargetID   *string         `json:"targetId"`
+	Detail     json.RawMessage `json:"detail"`
+	CreatedAt  string          `json:"createdAt"`
+}
+
+// AuditLogQuery pages through a campaign's audit log.
+type AuditLogQuery struct {
+	Limit  int32
+	Offset int32
+}
+
+// AuditLogPage is one page of audit log entries plus the total count.
+type AuditLogPage struct {
+	Entries []AuditEntryResponse `json:"entries"`
+	Total   int64                `json:"total"`
+	Limit   int32                `json:"limit"`
+	Offset  int32                `json:"offset"`
+}
+
+// GetAuditLog retrieves a page of the campaign's GM audit log, newest first (GM only).
+func (s *AuditService) GetAuditLog(
+	ctx context.Context,
+	campaignID, userID pgtype.UUID,
+	query AuditLogQuery,
+) (*AuditLogPage, error) {
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	if query.Limit <= 0 || query.Limit > MaxAuditLogLimit {
+		query.Limit = DefaultAuditLogLimit
+	}
+	query.Offset = max(query.Offset, 0)
+
+	entries, err := s.queries.ListGmAuditLog(ctx, generated.ListGmAuditLogParams{
+		CampaignID: campaignID,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.queries.CountGmAuditLog(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &AuditLogPage{
+		Entries: make([]AuditEntryResponse, 0, len(entries)),
+		Total:   total,
+		Limit:   query.Limit,
+		Offset:  query.Offset,
+	}
+	for _, entry := range entries {
+		page.Entries = append(page.Entries, AuditEntryResponse{
+			ID:         uuidToString(entry.ID),
+			GMUserID:   optionalUUIDString(entry.GmUserID),
+			Action:     entry.Action,
+			TargetType: entry.TargetType,
+			TargetID:   optionalUUIDString(entry.TargetID),
+			Detail:     json.RawMessage(entry.Detail),
+			CreatedAt:  entry.CreatedAt.Time.Format(time.RFC3339),
+		})
+	}
+
+	return page, nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// MaxAvatarHistory is how many previous avatars are kept per character. Older
+// ones are deleted from storage when a new avatar pushes them out.
+const MaxAvatarHistory = 5
+
+// ErrAvatarHistoryNotFound is returned when restoring an avatar that is not in
+// the character's history.
+var ErrAvatarHistoryNotFound = newCodedError(
+	http.StatusNotFound, "NOT_FOUND",
+	"Previous avatar not found",
+	"avatar history entry not found",
+)
+
+// AvatarHistoryEntry is a previous avatar that can be restored.
+type AvatarHistoryEntry struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	ReplacedAt time.Time `json:"replacedAt"`
+}
+
+// GetAvatarHistory lists a character's previous avatars, newest first (GM only).
+func (s *ImageService) GetAvatarHistory(
+	ctx context.Context,
+	campaignID, characterID, gmUserID uuid.UUID,
+) ([]AvatarHistoryEntry, error) {
+	if _, err := s.gmCharacter(ctx, campaignID, characterID, gmUserID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.queries.ListCharacterAvatarHistory(ctx, pgtype.UUID{Bytes: characterID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]AvatarHistoryEntry, 0, len(history))
+	for _, h := range history {
+		entries = append(entries, AvatarHistoryEntry{
+			ID:         uuidToString(h.ID),
+			URL:        h.AvatarUrl,
+			ReplacedAt: h.CreatedAt.Time,
+		})
+	}
+	return entries, nil
+}
+
+// RestoreAvatar makes a previous avatar the character's current one again (GM
+// only). The avatar it replaces moves into the history in its place, so nothing
+// is uploaded or deleted. It returns the restored avatar's URL.
+func (s *ImageService) RestoreAvatar(
+	ctx context.Context,
+	campaignID, characterID, gmUserID uuid.UUID,
+	historyID string,
+) (string, error) {
+	char, err := s.gmCharacter(ctx, campaignID, characterID, gmUserID)
+	if err != nil {
+		return "", err
+	}
+
+	entry, err := s.queries.GetCharacterAvatarHistoryEntry(ctx, generated.GetCharacterAvatarHistoryEntryParams{
+		ID:          parseUUIDString(historyID),
+		CharacterID: char.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrAvatarHistoryNotFound
+		}
+		return "", err
+	}
+
+	if _, err = s.queries.UpdateCharacterAvatar(ctx, generated.UpdateCharacterAvatarParams{
+		ID:        char.ID,
+		AvatarUrl: pgtype.Text{String: entry.AvatarUrl, Valid: true},
+	}); err != nil {
+		return "", fmt.Errorf("failed to update character avatar: %w", err)
+	}
+
+	if err = s.queries.DeleteCharacterAvatarHistoryEntry(ctx, entry.ID); err != nil {
+		return "", err
+	}
+
+	if char.AvatarUrl.Valid && char.AvatarUrl.String != "" {
+		if err = s.recordAvatarHistory(ctx, campaignID, char.ID, char.AvatarUrl.String); err != nil {
+			return "", err
+		}
+	}
+
+	return entry.AvatarUrl, nil
+}
+
+// gmCharacter checks the user is the campaign's GM and loads the character,
+// which must belong to the campaign.
+func (s *ImageService) gmCharacter(
+	ctx context.Context,
+	campaignID, characterID, gmUserID uuid.UUID,
+) (*generated.Character, error) {
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: pgtype.UUID{Bytes: campaignID, Valid: true},
+		UserID:     pgtype.UUID{Bytes: gmUserID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify GM status: %w", err)
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	char, err := s.queries.GetCharacter(ctx, pgtype.UUID{Bytes: characterID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCharacterNotFound
+		}
+		return nil, err
+	}
+	if char.CampaignID.Bytes != campaignID {
+		return nil, ErrCharacterNotFound
+	}
+	return &char, nil
+}
+
+// recordAvatarHistory moves a replaced avatar into the character's history, then
+// deletes whatever falls past MaxAvatarHistory.
+func (s *ImageService) recordAvatarHistory(
+	ctx context.Context,
+	campaignID uuid.UUID,
+	characterID pgtype.UUID,
+	avatarURL string,
+) error {
+	// Best effort, like other deletes; an unknown size is simply never released
+	size, _ := s.storage.GetFileSize(ctx, StorageBucket, avatarStoragePath(campaignID, avatarURL))
+
+	if _, err := s.queries.AddCharacterAvatarHistory(ctx, generated.AddCharacterAvatarHistoryParams{
+		CharacterID: characterID,
+		AvatarUrl:   avatarURL,
+		SizeBytes:   size,
+	}); err != nil {
+		return err
+	}
+
+	history, err := s.queries.ListCharacterAvatarHistory(ctx, characterID)
+	if err != nil {
+		return err
+	}
+	if len(history) <= MaxAvatarHistory {
+		return nil
+	}
+
+	for _, evicted := range history[MaxAvatarHistory:] {
+		if deleteErr := s.storage.Delete(
+			ctx, StorageBucket, avatarStoragePath(campaignID, evicted.AvatarUrl),
+		); deleteErr != nil {
+			// Intentionally ignoring storage delete errors
+			_ = deleteErr
+		}
+
+		if err = s.queries.DeleteCharacterAvatarHistoryEntry(ctx, evicted.ID); err != nil {
+			return err
+		}
+
+		if evicted.SizeBytes > 0 {
+			_, _ = s.queries.DecrementCampaignStorage(ctx, generated.DecrementCampaignStorageParams{
+				ID:               pgtype.UUID{Bytes: campaignID, Valid: true},
+				StorageUsedBytes: evicted.SizeBytes,
+			})
+		}
+	}
+	return nil
+}
+
+// avatarStoragePath is where a character avatar URL is stored in the bucket.
+func avatarStoragePath(campaignID uuid.UUID, avatarURL string) string {
+	return fmt.Sprintf("campaigns/%s/avatars/%s", campaignID, filepath.Base(avatarURL))
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// BotTokenPrefix marks campaign bot tokens so they are never mistaken for JWTs.
+const BotTokenPrefix = "vbt_"
+
+// Bot token limits.
+const (
+	MaxBotTokensPerCampaign = 10
+	maxBotTokenNameLen      = 50
+	botTokenSecretBytes     = 32
+	botTokenDisplayPrefix   = 12
+)
+
+// Bot token errors.
+var (
+	ErrBotTokenNotFound     = errors.New("bot token not found")
+	ErrInvalidBotToken      = errors.New("bot token is invalid or revoked")
+	ErrBotTokenLimitReached = errors.New("campaign has reached the bot token limit")
+	ErrInvalidBotTokenName  = errors.New("bot token name must be between 1 and 50 characters")
+)
+
+// BotTokenService manages campaign bot tokens. A bot token lets an external
+// integration post narrator posts and OOC messages in one campaign; it grants
+// nothing else.
+type BotTokenService struct {
+	queries *generated.Queries
+}
+
+// NewBotTokenService creates a new BotTokenService.
+func NewBotTokenService(pool *pgxpool.Pool) *BotTokenService {
+	return &BotTokenService{
+		queries: newQueries(pool),
+	}
+}
+
+// CreateBotTokenRequest represents the request to create a bot token.
+type CreateBotTokenRequest struct {
+	Name string `json:"name"`
+}
+
+// BotTokenResponse represents a bot token in API responses. The token itself
+// is only included once, when it is created.
+type BotTokenResponse struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	TokenPrefix string  `json:"tokenPrefix"`
+	Token       string  `json:"token,omitempty"`
+	CreatedBy   *string `json:"createdBy"`
+	LastUsedAt  *string `json:"lastUsedAt"`
+	CreatedAt   string  `json:"createdAt"`
+}
+
+// CreateToken issues a new bot token for the campaign (GM only).
+func (s *BotTokenService) CreateToken(
+	ctx context.Context,
+	campaignID, userID pgtype.UUID,
+	req CreateBotTokenRequest,
+) (*BotTokenResponse, error) {
+	if err := s.requireGM(ctx, campaignID, userID); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxBotTokenNameLen {
+		return nil, ErrInvalidBotTokenName
+	}
+
+	count, err := s.queries.CountActiveCampaignBotTokens(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxBotTokensPerCampaign {
+		return nil, ErrBotTokenLimitReached
+	}
+
+	token, err := generateBotToken()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.queries.CreateCampaignBotToken(ctx, generated.CreateCampaignBotTokenParams{
+		CampaignID:  campaignID,
+		CreatedBy:   userID,
+		Name:        name,
+		TokenHash:   hashBotToken(token),
+		TokenPrefix: token[:botTokenDisplayPrefix],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logGMAction(ctx, s.queries, campaignID, userID, GMActionCreateBotToken, AuditTargetBotToken, created.ID,
+		map[string]any{"name": name})
+
+	resp := botTokenToResponse(&created)
+	resp.Token = token
+	return resp, nil
+}
+
+// ListTokens returns the campaign's active bot tokens (GM only).
+func (s *BotTokenService) ListTokens(ctx context.Context, campaignID, userID pgtype.UUID) ([]BotTokenResponse, error) {
+	if err := s.requireGM(ctx, campaignID, userID); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.queries.ListCampaignBotTokens(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]BotTokenResponse, 0, len(tokens))
+	for i := range tokens {
+		result = append(result, *botTokenToResponse(&tokens[i]))
+	}
+	return result, nil
+}
+
+// RevokeToken revokes one of the campaign's bot tokens (GM only).
+func (s *BotTokenService) RevokeToken(ctx context.Context, campaignID, tokenID, userID pgtype.UUID) error {
+	if err := s.requireGM(ctx, campaignID, userID); err != nil {
+		return err
+	}
+
+	revoked, err := s.queries.RevokeCampaignBotToken(ctx, generated.RevokeCampaignBotTokenParams{
+		ID:         tokenID,
+		CampaignID: campaignID,
+	})
+	if err != nil {
+		return err
+	}
+	if revoked == 0 {
+		return ErrBotTokenNotFound
+	}
+
+	logGMAction(ctx, s.queries, campaignID, userID, GMActionRevokeBotToken, AuditTargetBotToken, tokenID, nil)
+	return nil
+}
+
+// ResolveToken maps a plaintext bot token to its campaign and the campaign's
+// current GM. Unknown, malformed, and revoked tokens return ErrInvalidBotToken.
+func (s *BotTokenService) ResolveToken(
+	ctx context.Context,
+	token string,
+) (*generated.GetActiveBotTokenByHashRow, error) {
+	if !strings.HasPrefix(token, BotTokenPrefix) {
+		return nil, ErrInvalidBotToken
+	}
+
+	row, err := s.queries.GetActiveBotTokenByHash(ctx, hashBotToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvalidBotToken
+		}
+		return nil, err
+	}
+
+	// Last-used is informational; a failure must not block the request
+	_ = s.queries.TouchBotTokenLastUsed(ctx, row.ID)
+
+	return &row, nil
+}
+
+func (s *BotTokenService) requireGM(ctx context.Context, campaignID, userID pgtype.UUID) error {
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return err
+	}
+	if !isGM {
+		return ErrNotGM
+	}
+	return nil
+}
+
+// generateBotToken generates a random token: the bot prefix followed by 64 hex characters.
+func generateBotToken() (string, error) {
+	secretBytes := make([]byte, botTokenSecretBytes)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", err
+	}
+	return BotTokenPrefix + hex.EncodeToString(secretBytes), nil
+}
+
+// hashBotToken returns the hex SHA-256 of a token, as stored in the database.
+func hashBotToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func botTokenToResponse(token *generated.CampaignBotToken) *BotTokenResponse {
+	resp := &BotTokenResponse{
+		ID:          uuidToString(token.ID),
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Token:       "",
+		CreatedBy:   optionalUUIDString(token.CreatedBy),
+		LastUsedAt:  nil,
+		CreatedAt:   token.CreatedAt.Time.Format(time.RFC3339),
+	}
+	if token.LastUsedAt.Valid {
+		lastUsed := token.LastUsedAt.Time.Format(time.RFC3339)
+		resp.LastUsedAt = &lastUsed
+	}
+	return resp
+}
@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/metrics"
 )
 
 // HTTP client timeout for broadcast requests.
@@ -36,20 +39,41 @@ func NewBroadcastService(supabaseURL, supabaseKey string) *BroadcastService {
 	}
 }
 
+// NewBroadcastServiceFromEnv creates a broadcast service from the SUPABASE_URL and
+// SUPABASE_SECRET_KEY (or legacy SUPABASE_SERVICE_ROLE_KEY) environment variables.
+// It returns nil when broadcasting is not configured.
+func NewBroadcastServiceFromEnv() *BroadcastService {
+	supabaseURL := os.Getenv("SUPABASE_URL")
+	supabaseKey := os.Getenv("SUPABASE_SECRET_KEY")
+	if supabaseKey == "" {
+		supabaseKey = os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
+	}
+
+	if supabaseURL == "" || supabaseKey == "" {
+		return nil
+	}
+	return NewBroadcastService(supabaseURL, supabaseKey)
+}
+
 // Event types for real-time broadcast.
 const (
 	EventPhaseTransition     = "phase_transition"
 	EventPostCreated         = "post_created"
 	EventPostUpdated         = "post_updated"
 	EventPostDeleted         = "post_deleted"
+	EventPostReaction        = "post_reaction"
 	EventComposeLockAcquired = "compose_lock_acquired"
 	EventComposeLockReleased = "compose_lock_released"
 	EventPassStateChanged    = "pass_state_changed"
 	EventCharacterJoined     = "character_joined"
 	EventCharacterLeft       = "character_left"
+	EventCharacterUpdated    = "character_updated"
 	EventRollCreated         = "roll_created"
 	EventRollResolved        = "roll_resolved"
 	EventTimeGateWarning     = "timegate_warning"
+	EventPhaseStatus         = "phase_status"
+	EventOocMessageCreated   = "ooc_message_created"
+	EventTypingPresence      = "typing_presence"
 )
 
 // PhaseTransitionEvent represents a phase transition broadcast.
@@ -62,6 +86,20 @@ type PhaseTransitionEvent struct {
 	Timestamp        string `json:"timestamp"`
 }
 
+// PhaseStatusEvent represents a change to a campaign's pass counts without a phase
+// transition, such as an expired time gate auto-passing characters.
+type PhaseStatusEvent struct {
+	Type        string `json:"type"`
+	CampaignID  string `json:"campaign_id"`
+	Phase       string `json:"phase"`
+	IsExpired   bool   `json:"is_expired"`
+	ExpiresAt   string `json:"expires_at,omitempty"`
+	PassedCount int64  `json:"passed_count"`
+	TotalCount  int64  `json:"total_count"`
+	AllPassed   bool   `json:"all_passed"`
+	Timestamp   string `json:"timestamp"`
+}
+
 // PostEvent represents a post CRUD broadcast.
 type PostEvent struct {
 	Type        string   `json:"type"`
@@ -74,6 +112,17 @@ type PostEvent struct {
 	Timestamp   string   `json:"timestamp"`
 }
 
+// PostReactionEvent represents a reaction count change on a post.
+type PostReactionEvent struct {
+	Type       string `json:"type"`
+	PostID     string `json:"post_id"`
+	SceneID    string `json:"scene_id"`
+	CampaignID string `json:"campaign_id"`
+	Emoji      string `json:"emoji"`
+	Count      int32  `json:"count"`
+	Timestamp  string `json:"timestamp"`
+}
+
 // ComposeLockEvent represents a compose lock broadcast (identity protected).
 type ComposeLockEvent struct {
 	Type       string `json:"type"`
@@ -84,6 +133,20 @@ type ComposeLockEvent struct {
 	// DO NOT include character_id or user_id (identity protection)
 }
 
+// TypingPresenceEvent represents someone typing in a scene. It is not persisted;
+// clients drop it at ExpiresAt unless a newer one arrives. Hidden typists are
+// identity protected.
+type TypingPresenceEvent struct {
+	Type          string `json:"type"`
+	SceneID       string `json:"scene_id"`
+	CampaignID    string `json:"campaign_id"`
+	CharacterID   string `json:"character_id,omitempty"`
+	CharacterName string `json:"character_name,omitempty"`
+	IsHidden      bool   `json:"is_hidden"`
+	ExpiresAt     string `json:"expires_at"`
+	Timestamp     string `json:"timestamp"`
+}
+
 // PassStateEvent represents a pass state change broadcast.
 type PassStateEvent struct {
 	Type        string `json:"type"`
@@ -103,6 +166,16 @@ type CharacterPresenceEvent struct {
 	Timestamp   string `json:"timestamp"`
 }
 
+// CharacterUpdatedEvent represents a change to a character's condition tags.
+type CharacterUpdatedEvent struct {
+	Type        string   `json:"type"`
+	CampaignID  string   `json:"campaign_id"`
+	SceneID     string   `json:"scene_id,omitempty"`
+	CharacterID string   `json:"character_id"`
+	Tags        []string `json:"tags"`
+	Timestamp   string   `json:"timestamp"`
+}
+
 // RollEvent represents a roll broadcast.
 type RollEvent struct {
 	Type        string `json:"type"`
@@ -111,13 +184,28 @@ type RollEvent struct {
 	SceneID     string `json:"scene_id"`
 	CampaignID  string `json:"campaign_id"`
 	CharacterID string `json:"character_id"`
-	Intention   string `json:"intention"`
+	Intention   string `json:"intention,omitempty"`
+	Note        string `json:"note,omitempty"`
 	Status      string `json:"status"`
 	Timestamp   string `json:"timestamp"`
 }
 
+// OocMessageEvent represents a new OOC message broadcast.
+type OocMessageEvent struct {
+	Type       string `json:"type"`
+	MessageID  string `json:"message_id"`
+	SceneID    string `json:"scene_id"`
+	CampaignID string `json:"campaign_id"`
+	UserID     string `json:"user_id"`
+	ParentID   string `json:"parent_id,omitempty"`
+	Body       string `json:"body"`
+	Timestamp  string `json:"timestamp"`
+}
+
 // broadcastMessage sends a message to a Supabase Realtime channel.
-func (s *BroadcastService) broadcastMessage(ctx context.Context, channel, event string, payload any) error {
+func (s *BroadcastService) broadcastMessage(ctx context.Context, channel, event string, payload any) (err error) {
+	defer func() { metrics.RecordBroadcast(event, err) }()
+
 	// Construct the broadcast request
 	body := map[string]any{
 		"type":    "broadcast",
@@ -182,6 +270,34 @@ func (s *BroadcastService) BroadcastPhaseTransition(
 	}
 }
 
+// BroadcastPhaseStatus broadcasts a campaign's current phase status.
+func (s *BroadcastService) BroadcastPhaseStatus(
+	ctx context.Context,
+	campaignID pgtype.UUID,
+	status *PhaseStatus,
+) {
+	//nolint:exhaustruct // ExpiresAt is set below when the phase has a time gate
+	event := PhaseStatusEvent{
+		Type:        EventPhaseStatus,
+		CampaignID:  uuidToString(campaignID),
+		Phase:       status.CurrentPhase,
+		IsExpired:   status.IsExpired,
+		PassedCount: status.PassedCount,
+		TotalCount:  status.TotalCount,
+		AllPassed:   status.AllPassed,
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+	}
+	if status.ExpiresAt != nil {
+		event.ExpiresAt = status.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	channel := fmt.Sprintf("campaign:%s", uuidToString(campaignID))
+	if err := s.broadcastMessage(ctx, channel, EventPhaseStatus, event); err != nil {
+		//nolint:sloglint // Error logging in broadcast doesn't need structured logger injection
+		slog.ErrorContext(ctx, "Failed to broadcast phase status", "error", err)
+	}
+}
+
 // BroadcastPostCreated broadcasts a post creation event.
 func (s *BroadcastService) BroadcastPostCreated(
 	ctx context.Context,
@@ -252,6 +368,30 @@ func (s *BroadcastService) BroadcastPostDeleted(
 	}
 }
 
+// BroadcastPostReaction broadcasts the new count for an emoji on a post.
+func (s *BroadcastService) BroadcastPostReaction(
+	ctx context.Context,
+	postID, sceneID, campaignID pgtype.UUID,
+	emoji string,
+	count int32,
+) {
+	event := PostReactionEvent{
+		Type:       EventPostReaction,
+		PostID:     uuidToString(postID),
+		SceneID:    uuidToString(sceneID),
+		CampaignID: uuidToString(campaignID),
+		Emoji:      emoji,
+		Count:      count,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+	}
+
+	channel := fmt.Sprintf("scene:%s", uuidToString(sceneID))
+	if err := s.broadcastMessage(ctx, channel, EventPostReaction, event); err != nil {
+		//nolint:sloglint // Error logging in broadcast doesn't need structured logger injection
+		slog.ErrorContext(ctx, "Failed to broadcast post reaction", "error", err)
+	}
+}
+
 // BroadcastComposeLockAcquired broadcasts a compose lock acquisition (identity protected).
 func (s *BroadcastService) BroadcastComposeLockAcquired(
 	ctx context.Context,
@@ -292,6 +432,29 @@ func (s *BroadcastService) BroadcastComposeLockReleased(
 	}
 }
 
+// BroadcastPresence broadcasts a typing indicator to the scene. The character is
+// left out for hidden posts (identity protected).
+func (s *BroadcastService) BroadcastPresence(ctx context.Context, presence *TypingPresence) {
+	event := TypingPresenceEvent{
+		Type:       EventTypingPresence,
+		SceneID:    uuidToString(presence.SceneID),
+		CampaignID: uuidToString(presence.CampaignID),
+		IsHidden:   presence.IsHidden,
+		ExpiresAt:  presence.ExpiresAt.UTC().Format(time.RFC3339),
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+	}
+	if !presence.IsHidden {
+		event.CharacterID = uuidToString(presence.CharacterID)
+		event.CharacterName = presence.CharacterName
+	}
+
+	channel := fmt.Sprintf("scene:%s", uuidToString(presence.SceneID))
+	if err := s.broadcastMessage(ctx, channel, EventTypingPresence, event); err != nil {
+		//nolint:sloglint // Error logging in broadcast doesn't need structured logger injection
+		slog.ErrorContext(ctx, "Failed to broadcast typing presence", "error", err)
+	}
+}
+
 // BroadcastPassStateChanged broadcasts a pass state change.
 func (s *BroadcastService) BroadcastPassStateChanged(
 	ctx context.Context,
@@ -361,11 +524,44 @@ func (s *BroadcastService) BroadcastCharacterLeftScene(
 	}
 }
 
-// BroadcastRollCreated broadcasts a roll creation event.
+// BroadcastCharacterUpdated broadcasts a character's new tags to the campaign channel,
+// and to the scene channel when the character is in an active scene.
+func (s *BroadcastService) BroadcastCharacterUpdated(
+	ctx context.Context,
+	campaignID, sceneID, characterID pgtype.UUID,
+	tags []string,
+) {
+	event := CharacterUpdatedEvent{
+		Type:        EventCharacterUpdated,
+		CampaignID:  uuidToString(campaignID),
+		CharacterID: uuidToString(characterID),
+		Tags:        tags,
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if sceneID.Valid {
+		event.SceneID = uuidToString(sceneID)
+		sceneChannel := fmt.Sprintf("scene:%s", uuidToString(sceneID))
+		if err := s.broadcastMessage(ctx, sceneChannel, EventCharacterUpdated, event); err != nil {
+			//nolint:sloglint // Error logging in broadcast doesn't need structured logger injection
+			slog.ErrorContext(ctx, "Failed to broadcast character updated to scene", "error", err)
+		}
+	}
+
+	campaignChannel := fmt.Sprintf("campaign:%s", uuidToString(campaignID))
+	if err := s.broadcastMessage(ctx, campaignChannel, EventCharacterUpdated, event); err != nil {
+		//nolint:sloglint // Error logging in broadcast doesn't need structured logger injection
+		slog.ErrorContext(ctx, "Failed to broadcast character updated to campaign", "error", err)
+	}
+}
+
+// BroadcastRollCreated broadcasts a roll creation event. The scene channel
+// reaches every member, so callers pass an empty intention and note for rolls
+// on restricted posts; witnesses then fetch the roll through GetRoll.
 func (s *BroadcastService) BroadcastRollCreated(
 	ctx context.Context,
 	rollID, postID, sceneID, campaignID, characterID pgtype.UUID,
-	intention string,
+	intention, note string,
 ) {
 	event := RollEvent{
 		Type:        EventRollCreated,
@@ -375,6 +571,7 @@ func (s *BroadcastService) BroadcastRollCreated(
 		CampaignID:  uuidToString(campaignID),
 		CharacterID: uuidToString(characterID),
 		Intention:   intention,
+		Note:        note,
 		Status:      "pending",
 		Timestamp:   time.Now().UTC().Format(time.RFC3339),
 	}
@@ -427,3 +624,29 @@ func (s *BroadcastService) BroadcastTimeGateWarning(
 		slog.ErrorContext(ctx, "Failed to broadcast time gate warning", "error", err)
 	}
 }
+
+// BroadcastOocMessageCreated broadcasts a new OOC message to the scene channel.
+func (s *BroadcastService) BroadcastOocMessageCreated(
+	ctx context.Context,
+	campaignID pgtype.UUID,
+	msg *OocMessageResponse,
+) {
+	event := OocMessageEvent{
+		Type:       EventOocMessageCreated,
+		MessageID:  msg.ID,
+		SceneID:    msg.SceneID,
+		CampaignID: uuidToString(campaignID),
+		UserID:     msg.UserID,
+		Body:       msg.Body,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+	}
+	if msg.ParentID != nil {
+		event.ParentID = *msg.ParentID
+	}
+
+	channel := fmt.Sprintf("scene:%s", msg.SceneID)
+	if err := s.broadcastMessage(ctx, channel, EventOocMessageCreated, event); err != nil {
+		//nolint:sloglint // Error logging in broadcast doesn't need structured logger injection
+		slog.ErrorContext(ctx, "Failed to broadcast OOC message", "error", err)
+	}
+}
@@ -1,10 +1,17 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -19,6 +26,7 @@ const (
 	defaultOOCVisibility    = "gm_only"
 	defaultSystemPresetName = "D&D 5e"
 	defaultDiceType         = "d20"
+	maxCampaignTitleLen     = 255
 )
 
 // CampaignService handles campaign business logic.
@@ -30,7 +38,7 @@ type CampaignService struct {
 // NewCampaignService creates a new CampaignService.
 func NewCampaignService(pool *pgxpool.Pool) *CampaignService {
 	return &CampaignService{
-		queries: generated.New(pool),
+		queries: newQueries(pool),
 		pool:    pool,
 	}
 }
@@ -48,23 +56,21 @@ func (s *CampaignService) CreateCampaign(
 	userID pgtype.UUID,
 	req CreateCampaignRequest,
 ) (*generated.Campaign, error) {
-	// Check campaign limit
-	count, err := s.queries.CountUserOwnedCampaigns(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
-	if count >= int64(MaxCampaignsPerUser) {
-		return nil, ErrCampaignLimitReached
+	// Check campaign limit (0 means the user is exempt)
+	if limit := campaignLimitFor(userID); limit > 0 {
+		count, err := s.queries.CountUserOwnedCampaigns(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if count >= int64(limit) {
+			return nil, &CampaignLimitError{Limit: limit}
+		}
 	}
 
-	// Use default settings if not provided
-	settings := defaultCampaignSettings()
-	if req.Settings != nil {
-		if validateErr := validateSettings(req.Settings); validateErr != nil {
-			return nil, validateErr
-		}
-		// Merge provided settings with defaults
-		maps.Copy(settings, req.Settings)
+	// Validate provided settings and fill in defaults
+	settings, err := normalizeSettings(req.Settings)
+	if err != nil {
+		return nil, err
 	}
 
 	settingsJSON, err := json.Marshal(settings)
@@ -72,6 +78,9 @@ func (s *CampaignService) CreateCampaign(
 		return nil, err
 	}
 
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
 	// Start transaction
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
@@ -110,6 +119,156 @@ func (s *CampaignService) CreateCampaign(
 	return &campaign, nil
 }
 
+// DuplicatedSceneHeader links a copied scene to the header image of its source scene.
+type DuplicatedSceneHeader struct {
+	SceneID   pgtype.UUID
+	SourceURL string
+}
+
+// DuplicateCampaignResult is a newly duplicated campaign and the scene headers
+// that still need to be copied in storage.
+type DuplicateCampaignResult struct {
+	Campaign     *generated.Campaign
+	SceneHeaders []DuplicatedSceneHeader
+}
+
+// DuplicateCampaign clones a campaign's settings, NPCs, and scenes into a new campaign
+// owned by the GM. Players, PCs, posts, rolls, and notifications are not copied, and
+// copied scenes start active with an empty roster. Scene header images are returned for
+// the caller to copy in storage, which also charges them to the new campaign.
+func (s *CampaignService) DuplicateCampaign(
+	ctx context.Context,
+	gmUserID, sourceCampaignID pgtype.UUID,
+	newTitle string,
+) (*DuplicateCampaignResult, error) {
+	source, err := s.queries.GetCampaign(ctx, sourceCampaignID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, err
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: sourceCampaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	// Check campaign limit (0 means the user is exempt)
+	if limit := campaignLimitFor(gmUserID); limit > 0 {
+		count, countErr := s.queries.CountUserOwnedCampaigns(ctx, gmUserID)
+		if countErr != nil {
+			return nil, countErr
+		}
+		if count >= int64(limit) {
+			return nil, &CampaignLimitError{Limit: limit}
+		}
+	}
+
+	title := strings.TrimSpace(newTitle)
+	if title == "" {
+		title = source.Title + " (Copy)"
+	}
+	if runes := []rune(title); len(runes) > maxCampaignTitleLen {
+		title = string(runes[:maxCampaignTitleLen])
+	}
+
+	characters, err := s.queries.ListCampaignCharacters(ctx, sourceCampaignID)
+	if err != nil {
+		return nil, err
+	}
+	scenes, err := s.queries.ListCampaignScenes(ctx, sourceCampaignID)
+	if err != nil {
+		return nil, err
+	}
+	// Keep the GM's manual ordering regardless of archive state
+	slices.SortStableFunc(scenes, func(a, b generated.Scene) int {
+		return cmp.Compare(a.SortOrder, b.SortOrder)
+	})
+
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := s.queries.WithTx(tx)
+
+	campaign, err := qtx.CreateCampaign(ctx, generated.CreateCampaignParams{
+		Title:       title,
+		Description: source.Description,
+		OwnerID:     gmUserID,
+		Settings:    source.Settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = qtx.AddCampaignMember(ctx, generated.AddCampaignMemberParams{
+		CampaignID: campaign.ID,
+		UserID:     gmUserID,
+		Role:       generated.MemberRoleGm,
+		Alias:      pgtype.Text{String: "", Valid: false},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy active NPCs; PCs belong to the players of the source campaign
+	for _, char := range characters {
+		if char.CharacterType != generated.CharacterTypeNpc || char.IsArchived {
+			continue
+		}
+		if _, err = qtx.CreateCharacter(ctx, generated.CreateCharacterParams{
+			CampaignID:    campaign.ID,
+			DisplayName:   char.DisplayName,
+			Description:   char.Description,
+			CharacterType: generated.CharacterTypeNpc,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	headers := []DuplicatedSceneHeader{}
+	for _, scene := range scenes {
+		created, createErr := qtx.CreateScene(ctx, generated.CreateSceneParams{
+			CampaignID:  campaign.ID,
+			Title:       scene.Title,
+			Description: scene.Description,
+		})
+		if createErr != nil {
+			return nil, createErr
+		}
+		if incrementErr := qtx.IncrementSceneCount(ctx, campaign.ID); incrementErr != nil {
+			return nil, incrementErr
+		}
+		if scene.HeaderImageUrl.Valid && scene.HeaderImageUrl.String != "" {
+			headers = append(headers, DuplicatedSceneHeader{
+				SceneID:   created.ID,
+				SourceURL: scene.HeaderImageUrl.String,
+			})
+		}
+	}
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return nil, commitErr
+	}
+
+	return &DuplicateCampaignResult{
+		Campaign:     &campaign,
+		SceneHeaders: headers,
+	}, nil
+}
+
 // GetCampaign retrieves a campaign with membership info for the user.
 func (s *CampaignService) GetCampaign(
 	ctx context.Context,
@@ -152,22 +311,24 @@ type UpdateCampaignRequest struct {
 	Settings    *map[string]any `json:"settings,omitempty"`
 }
 
-// UpdateCampaign updates a campaign (GM only).
+// UpdateCampaign updates a campaign (GM only). It returns a warning when the
+// campaign already has more players than its new player limit; existing players
+// stay, but new joins are blocked.
 func (s *CampaignService) UpdateCampaign(
 	ctx context.Context,
 	campaignID, userID pgtype.UUID,
 	req UpdateCampaignRequest,
-) (*generated.Campaign, error) {
+) (*generated.Campaign, string, error) {
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	if !isGM {
-		return nil, ErrNotGM
+		return nil, "", ErrNotGM
 	}
 
 	// Build update params
@@ -185,12 +346,13 @@ func (s *CampaignService) UpdateCampaign(
 	}
 
 	if req.Settings != nil {
-		if validateErr := validateSettings(*req.Settings); validateErr != nil {
-			return nil, validateErr
+		settings, validateErr := normalizeSettings(*req.Settings)
+		if validateErr != nil {
+			return nil, "", validateErr
 		}
-		settingsJSON, marshalErr := json.Marshal(*req.Settings)
+		settingsJSON, marshalErr := json.Marshal(settings)
 		if marshalErr != nil {
-			return nil, marshalErr
+			return nil, "", marshalErr
 		}
 		params.Settings = settingsJSON
 	}
@@ -198,12 +360,27 @@ func (s *CampaignService) UpdateCampaign(
 	campaign, err := s.queries.UpdateCampaign(ctx, params)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, ErrCampaignNotFound
+			return nil, "", ErrCampaignNotFound
 		}
-		return nil, err
+		return nil, "", err
 	}
 
-	return &campaign, nil
+	if req.Settings == nil {
+		return &campaign, "", nil
+	}
+
+	players, err := s.queries.CountCampaignPlayers(ctx, campaignID)
+	if err != nil {
+		return nil, "", err
+	}
+	if limit := MaxPlayers(campaign.Settings); players > int64(limit) {
+		return &campaign, fmt.Sprintf(
+			"Campaign has %d players, above the new limit of %d. No one new can join until it drops below.",
+			players, limit,
+		), nil
+	}
+
+	return &campaign, "", nil
 }
 
 // DeleteCampaign deletes a campaign (GM only, requires title confirmation).
@@ -213,7 +390,7 @@ func (s *CampaignService) DeleteCampaign(
 	confirmTitle string,
 ) error {
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -234,7 +411,7 @@ func (s *CampaignService) DeleteCampaign(
 	}
 
 	if campaign.Title != confirmTitle {
-		return errors.New("confirmation title does not match campaign title")
+		return ErrConfirmTitleMismatch
 	}
 
 	return s.queries.DeleteCampaign(ctx, campaignID)
@@ -246,7 +423,7 @@ func (s *CampaignService) PauseCampaign(
 	campaignID, userID pgtype.UUID,
 ) (*generated.Campaign, error) {
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -277,7 +454,7 @@ func (s *CampaignService) ResumeCampaign(
 	campaignID, userID pgtype.UUID,
 ) (*generated.Campaign, error) {
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -308,7 +485,7 @@ func (s *CampaignService) GetCampaignMembers(
 	campaignID, userID pgtype.UUID,
 ) ([]generated.GetCampaignMembersRow, error) {
 	// Verify user is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -322,12 +499,43 @@ func (s *CampaignService) GetCampaignMembers(
 	return s.queries.GetCampaignMembers(ctx, campaignID)
 }
 
+// GetCampaignMemberEmails returns the account emails of a campaign's members,
+// keyed by user ID (GM only).
+func (s *CampaignService) GetCampaignMemberEmails(
+	ctx context.Context,
+	campaignID, userID pgtype.UUID,
+) (map[[16]byte]string, error) {
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	rows, err := s.queries.ListCampaignMemberEmails(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make(map[[16]byte]string, len(rows))
+	for _, row := range rows {
+		if row.Email.Valid {
+			emails[row.UserID.Bytes] = row.Email.String
+		}
+	}
+	return emails, nil
+}
+
 // IsUserGM checks if a user is a GM of a campaign.
 func (s *CampaignService) IsUserGM(
 	ctx context.Context,
 	campaignID, userID pgtype.UUID,
 ) (bool, error) {
-	return s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	return isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -337,12 +545,18 @@ func (s *CampaignService) IsUserGM(
 
 func defaultCampaignSettings() map[string]any {
 	return map[string]any{
-		"timeGatePreset":          defaultTimeGatePreset,
-		"fogOfWar":                true,
-		"hiddenPosts":             true,
-		"oocVisibility":           defaultOOCVisibility,
-		"characterLimit":          defaultCharacterLimit,
-		"rollRequestTimeoutHours": defaultRollTimeoutHours,
+		"timeGatePreset":            defaultTimeGatePreset,
+		"fogOfWar":                  true,
+		"hiddenPosts":               true,
+		"oocVisibility":             defaultOOCVisibility,
+		"characterLimit":            defaultCharacterLimit,
+		"rollRequestTimeoutHours":   defaultRollTimeoutHours,
+		"gmInactivityDays":          GmInactivityDays,
+		"maxPlayers":                MaxCampaignMembers,
+		"playerEditWindowSeconds":   0,
+		"strictIntentions":          false,
+		"autoTransitionOnAllPassed": false,
+		"archiveOrphanedCharacters": false,
 		"systemPreset": map[string]any{
 			"name": defaultSystemPresetName,
 			"intentions": []string{
@@ -354,54 +568,312 @@ func defaultCampaignSettings() map[string]any {
 			},
 			"diceType": defaultDiceType,
 		},
+		"narrator": map[string]any{
+			"name":      DefaultNarratorName,
+			"avatarUrl": "",
+		},
 	}
 }
 
+// knownSettingsKeys lists the campaign settings keys the server understands.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var knownSettingsKeys = map[string]bool{
+	"timeGatePreset":            true,
+	"fogOfWar":                  true,
+	"hiddenPosts":               true,
+	"oocVisibility":             true,
+	"characterLimit":            true,
+	"rollRequestTimeoutHours":   true,
+	"gmInactivityDays":          true,
+	"maxPlayers":                true,
+	"playerEditWindowSeconds":   true,
+	"strictIntentions":          true,
+	"autoTransitionOnAllPassed": true,
+	"archiveOrphanedCharacters": true,
+	"systemPreset":              true,
+	"narrator":                  true,
+	"dicePresets":               true,
+}
+
+//nolint:gochecknoglobals // Set once at startup
+var allowUnknownSettings atomic.Bool
+
+// AllowUnknownSettings controls whether unrecognized settings keys are kept as-is
+// (for forward compatibility with newer clients) instead of being rejected.
+func AllowUnknownSettings(allow bool) {
+	allowUnknownSettings.Store(allow)
+}
+
+// SettingsError reports the campaign settings key that failed validation.
+// It unwraps to ErrInvalidSettings.
+type SettingsError struct {
+	Key string
+}
+
+func (e *SettingsError) Error() string {
+	return fmt.Sprintf("invalid campaign settings: %s", e.Key)
+}
+
+func (e *SettingsError) Unwrap() error {
+	return ErrInvalidSettings.WithMessage(fmt.Sprintf("Invalid campaign setting: %s", e.Key))
+}
+
+// normalizeSettings validates the provided settings and merges them over the defaults.
+// Legacy string-encoded characterLimit values are converted to numbers.
+func normalizeSettings(settings map[string]any) (map[string]any, error) {
+	normalized := defaultCampaignSettings()
+	if settings == nil {
+		return normalized, nil
+	}
+
+	provided := maps.Clone(settings)
+	if raw, ok := provided["characterLimit"].(string); ok {
+		if limit, err := strconv.Atoi(strings.TrimSpace(raw)); err == nil {
+			provided["characterLimit"] = limit
+		}
+	}
+
+	if err := validateSettings(provided); err != nil {
+		return nil, err
+	}
+
+	maps.Copy(normalized, provided)
+	return normalized, nil
+}
+
 func validateSettings(settings map[string]any) error {
+	// Reject unknown keys so typos don't silently fall back to defaults
+	if !allowUnknownSettings.Load() {
+		for key := range settings {
+			if !knownSettingsKeys[key] {
+				return &SettingsError{Key: key}
+			}
+		}
+	}
+
 	// Validate time gate preset
-	if timeGate, ok := settings["timeGatePreset"].(string); ok {
+	if rawTimeGate, ok := settings["timeGatePreset"]; ok {
+		timeGate, isString := rawTimeGate.(string)
 		validPresets := map[string]bool{"24h": true, "2d": true, "3d": true, "4d": true, "5d": true}
-		if !validPresets[timeGate] {
-			return ErrInvalidSettings
+		if !isString || !validPresets[timeGate] {
+			return &SettingsError{Key: "timeGatePreset"}
 		}
 	}
 
 	// Validate character limit
 	if charLimit, ok := settings["characterLimit"]; ok {
-		var limit int
-		switch v := charLimit.(type) {
-		case float64:
-			limit = int(v)
-		case int:
-			limit = v
-		default:
-			return ErrInvalidSettings
-		}
+		limit, isInt := settingInt(charLimit)
 		validLimits := map[int]bool{1000: true, 3000: true, 6000: true, 10000: true}
-		if !validLimits[limit] {
-			return ErrInvalidSettings
+		if !isInt || !validLimits[limit] {
+			return &SettingsError{Key: "characterLimit"}
 		}
 	}
 
 	// Validate OOC visibility
-	if oocVis, ok := settings["oocVisibility"].(string); ok {
-		if oocVis != "all" && oocVis != "gm_only" {
-			return ErrInvalidSettings
+	if rawOocVis, ok := settings["oocVisibility"]; ok {
+		oocVis, isString := rawOocVis.(string)
+		if !isString || (oocVis != "all" && oocVis != "gm_only") {
+			return &SettingsError{Key: "oocVisibility"}
 		}
 	}
 
 	// Validate booleans
-	if fog, ok := settings["fogOfWar"]; ok {
-		if _, isBool := fog.(bool); !isBool {
-			return ErrInvalidSettings
+	for _, key := range []string{
+		"fogOfWar",
+		"hiddenPosts",
+		"strictIntentions",
+		"autoTransitionOnAllPassed",
+		"archiveOrphanedCharacters",
+	} {
+		if value, ok := settings[key]; ok {
+			if _, isBool := value.(bool); !isBool {
+				return &SettingsError{Key: key}
+			}
+		}
+	}
+
+	// Validate roll request timeout
+	if rawHours, ok := settings["rollRequestTimeoutHours"]; ok {
+		hours, isInt := settingInt(rawHours)
+		if !isInt || hours <= 0 {
+			return &SettingsError{Key: "rollRequestTimeoutHours"}
+		}
+	}
+
+	// Validate suggested roll intentions
+	if preset, ok := settings["systemPreset"]; ok {
+		if err := validateSystemPreset(preset); err != nil {
+			return &SettingsError{Key: "systemPreset"}
+		}
+	}
+
+	// Validate narrator persona
+	if narrator, ok := settings["narrator"]; ok {
+		if err := validateNarratorSetting(narrator); err != nil {
+			return &SettingsError{Key: "narrator"}
 		}
 	}
 
-	if hidden, ok := settings["hiddenPosts"]; ok {
-		if _, isBool := hidden.(bool); !isBool {
-			return ErrInvalidSettings
+	// Validate campaign dice presets
+	if presets, ok := settings["dicePresets"]; ok {
+		if err := validateDicePresetsSetting(presets); err != nil {
+			return &SettingsError{Key: "dicePresets"}
+		}
+	}
+
+	// Validate GM inactivity window (0 disables abandonment claims)
+	if rawDays, ok := settings["gmInactivityDays"]; ok {
+		days, isInt := settingInt(rawDays)
+		if !isInt || (days != 0 && (days < MinGmInactivityDays || days > MaxGmInactivityDays)) {
+			return &SettingsError{Key: "gmInactivityDays"}
+		}
+	}
+
+	// Validate player limit (capped by the server-wide maximum)
+	if rawMax, ok := settings["maxPlayers"]; ok {
+		maxPlayers, isInt := settingInt(rawMax)
+		if !isInt || maxPlayers < 1 || maxPlayers > MaxCampaignMembers {
+			return &SettingsError{Key: "maxPlayers"}
+		}
+	}
+
+	// Validate player edit window (0 means players can edit without a time limit)
+	if rawSeconds, ok := settings["playerEditWindowSeconds"]; ok {
+		seconds, isInt := settingInt(rawSeconds)
+		if !isInt || seconds < 0 || seconds > maxPlayerEditWindowSeconds {
+			return &SettingsError{Key: "playerEditWindowSeconds"}
 		}
 	}
 
 	return nil
 }
+
+// validateSystemPreset checks the shape of the systemPreset setting.
+func validateSystemPreset(preset any) error {
+	presetMap, ok := preset.(map[string]any)
+	if !ok {
+		return ErrInvalidSettings
+	}
+
+	rawIntentions, ok := presetMap["intentions"]
+	if !ok {
+		return nil
+	}
+
+	switch intentions := rawIntentions.(type) {
+	case []string:
+		for _, intention := range intentions {
+			if strings.TrimSpace(intention) == "" {
+				return ErrInvalidSettings
+			}
+		}
+	case []any:
+		for _, raw := range intentions {
+			intention, isString := raw.(string)
+			if !isString || strings.TrimSpace(intention) == "" {
+				return ErrInvalidSettings
+			}
+		}
+	default:
+		return ErrInvalidSettings
+	}
+
+	return nil
+}
+
+// settingInt converts a numeric settings value to int.
+// JSON numbers decode as float64, so both float64 and int are accepted.
+func settingInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case float64:
+		if v != float64(int(v)) {
+			return 0, false
+		}
+		return int(v), true
+	case int:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// gmInactivityDays parses campaign settings and returns the GM inactivity window in days.
+// A value of 0 means abandonment claims are disabled. Stored values outside the
+// allowed range are clamped.
+func gmInactivityDays(settingsJSON []byte) int {
+	if len(settingsJSON) == 0 {
+		return GmInactivityDays
+	}
+
+	var settings map[string]any
+	if err := json.Unmarshal(settingsJSON, &settings); err != nil {
+		return GmInactivityDays
+	}
+
+	rawDays, ok := settings["gmInactivityDays"]
+	if !ok {
+		return GmInactivityDays
+	}
+
+	days, ok := settingInt(rawDays)
+	if !ok {
+		return GmInactivityDays
+	}
+
+	switch {
+	case days <= 0:
+		return 0
+	case days < MinGmInactivityDays:
+		return MinGmInactivityDays
+	case days > MaxGmInactivityDays:
+		return MaxGmInactivityDays
+	default:
+		return days
+	}
+}
+
+// MaxPlayers parses campaign settings and returns the campaign's effective player
+// limit. Missing or invalid values fall back to the server cap, and stored values
+// above it are clamped.
+func MaxPlayers(settingsJSON []byte) int {
+	if len(settingsJSON) == 0 {
+		return MaxCampaignMembers
+	}
+
+	var settings map[string]any
+	if err := json.Unmarshal(settingsJSON, &settings); err != nil {
+		return MaxCampaignMembers
+	}
+
+	rawMax, ok := settings["maxPlayers"]
+	if !ok {
+		return MaxCampaignMembers
+	}
+
+	maxPlayers, ok := settingInt(rawMax)
+	if !ok || maxPlayers < 1 {
+		return MaxCampaignMembers
+	}
+	return min(maxPlayers, MaxCampaignMembers)
+}
+
+// playerEditWindow parses campaign settings and returns how long after posting a
+// player may still edit or delete their post. Zero means no time limit.
+func playerEditWindow(settingsJSON []byte) time.Duration {
+	if len(settingsJSON) == 0 {
+		return 0
+	}
+
+	var settings map[string]any
+	if err := json.Unmarshal(settingsJSON, &settings); err != nil {
+		return 0
+	}
+
+	seconds, ok := settingInt(settings["playerEditWindowSeconds"])
+	if !ok || seconds <= 0 {
+		return 0
+	}
+	// Stored values predating the cap could overflow a Duration
+	return time.Duration(min(seconds, maxPlayerEditWindowSeconds)) * time.Second
+}
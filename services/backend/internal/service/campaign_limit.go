@@ -0,0 +1,80 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CampaignLimitError reports the per-user campaign limit that was hit.
+// It unwraps to ErrCampaignLimitReached.
+type CampaignLimitError struct {
+	Limit int
+}
+
+func (e *CampaignLimitError) Error() string {
+	return fmt.Sprintf("user has reached maximum campaign limit (%d)", e.Limit)
+}
+
+func (e *CampaignLimitError) Unwrap() error {
+	return ErrCampaignLimitReached.WithMessage(fmt.Sprintf("You can only create up to %d campaigns.", e.Limit))
+}
+
+// CampaignFullError reports the effective player limit of a full campaign.
+// It unwraps to ErrCampaignFull.
+type CampaignFullError struct {
+	Limit int
+}
+
+func (e *CampaignFullError) Error() string {
+	return fmt.Sprintf("campaign has reached its player limit (%d)", e.Limit)
+}
+
+func (e *CampaignFullError) Unwrap() error {
+	return ErrCampaignFull.WithMessage(
+		fmt.Sprintf("This campaign has reached the maximum number of players (%d).", e.Limit),
+	)
+}
+
+// campaignLimits holds the server-configured campaign creation limits.
+type campaignLimits struct {
+	mu            sync.RWMutex
+	perUser       int
+	exemptUserIDs map[string]bool
+}
+
+//nolint:gochecknoglobals // Server-wide limits are configured once at startup
+var limits = &campaignLimits{
+	perUser:       MaxCampaignsPerUser,
+	exemptUserIDs: map[string]bool{},
+}
+
+// ConfigureCampaignLimits sets the per-user campaign limit and the users exempt from it
+// (e.g. admin or paid accounts). A non-positive perUser keeps the default.
+func ConfigureCampaignLimits(perUser int, exemptUserIDs []string) {
+	limits.mu.Lock()
+	defer limits.mu.Unlock()
+
+	if perUser <= 0 {
+		perUser = MaxCampaignsPerUser
+	}
+	limits.perUser = perUser
+	limits.exemptUserIDs = make(map[string]bool, len(exemptUserIDs))
+	for _, id := range exemptUserIDs {
+		if id != "" {
+			limits.exemptUserIDs[id] = true
+		}
+	}
+}
+
+// campaignLimitFor returns the campaign limit for a user, or 0 if the user is exempt.
+func campaignLimitFor(userID pgtype.UUID) int {
+	limits.mu.RLock()
+	defer limits.mu.RUnlock()
+
+	if limits.exemptUserIDs[uuidToString(userID)] {
+		return 0
+	}
+	return limits.perUser
+}
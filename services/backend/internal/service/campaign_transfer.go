@@ -0,0 +1,722 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// CampaignBundleVersion is the current export format version. Imports of any
+// other version are rejected.
+const CampaignBundleVersion = 1
+
+// Import limits. Bundles over any of them are rejected before anything is written.
+const (
+	MaxCampaignBundleSize = 50 * 1024 * 1024 // 50MB request body
+	MaxBundleCharacters   = 500
+	MaxBundlePosts        = 50000
+	MaxBundleRolls        = 50000
+)
+
+// Campaign export/import errors.
+var (
+	ErrInvalidCampaignBundle = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Invalid campaign export file",
+		"campaign bundle is malformed",
+	)
+	ErrUnsupportedBundleVersion = newCodedError(
+		http.StatusBadRequest, "UNSUPPORTED_BUNDLE_VERSION",
+		fmt.Sprintf("This export file is not supported (expected version %d).", CampaignBundleVersion),
+		"unsupported campaign bundle version",
+	)
+	ErrCampaignBundleTooLarge = newCodedError(
+		http.StatusRequestEntityTooLarge, "BUNDLE_TOO_LARGE",
+		"This export file is too large to import.",
+		"campaign bundle exceeds import limits",
+	)
+)
+
+// CampaignBundle is a self-contained JSON export of a campaign.
+// Image URLs are referenced, not inlined, and on import only images stored for
+// the source campaign are kept.
+type CampaignBundle struct {
+	Version    int               `json:"version"`
+	ExportedAt string            `json:"exportedAt"`
+	Campaign   BundleCampaign    `json:"campaign"`
+	Characters []BundleCharacter `json:"characters"`
+	Scenes     []BundleScene     `json:"scenes"`
+	Posts      []BundlePost      `json:"posts"`
+	Rolls      []BundleRoll      `json:"rolls"`
+}
+
+// BundleCampaign is the campaign record in an export bundle. The ID identifies
+// the source campaign whose stored images the bundle may reference.
+type BundleCampaign struct {
+	ID          pgtype.UUID     `json:"id"`
+	Title       string          `json:"title"`
+	Description pgtype.Text     `json:"description"`
+	Settings    json.RawMessage `json:"settings"`
+}
+
+// BundleCharacter is a character in an export bundle.
+type BundleCharacter struct {
+	ID             pgtype.UUID        `json:"id"`
+	DisplayName    string             `json:"displayName"`
+	Description    pgtype.Text        `json:"description"`
+	AvatarURL      pgtype.Text        `json:"avatarUrl"`
+	Pronouns       pgtype.Text        `json:"pronouns"`
+	GmNotes        pgtype.Text        `json:"gmNotes"`
+	Tags           []string           `json:"tags"`
+	CharacterType  string             `json:"characterType"`
+	IsArchived     bool               `json:"isArchived"`
+	AssignedUserID pgtype.UUID        `json:"assignedUserId"`
+	CreatedAt      pgtype.Timestamptz `json:"createdAt"`
+}
+
+// BundleScene is a scene, including its roster and pass states, in an export bundle.
+type BundleScene struct {
+	ID             pgtype.UUID        `json:"id"`
+	Title          string             `json:"title"`
+	Description    pgtype.Text        `json:"description"`
+	HeaderImageURL pgtype.Text        `json:"headerImageUrl"`
+	CharacterIDs   []pgtype.UUID      `json:"characterIds"`
+	CharacterOrder []pgtype.UUID      `json:"characterOrder"`
+	PassStates     map[string]string  `json:"passStates"`
+	IsArchived     bool               `json:"isArchived"`
+	SortOrder      int32              `json:"sortOrder"`
+	CreatedAt      pgtype.Timestamptz `json:"createdAt"`
+}
+
+// BundlePost is a submitted post in an export bundle. Drafts are not exported.
+type BundlePost struct {
+	ID           pgtype.UUID        `json:"id"`
+	SceneID      pgtype.UUID        `json:"sceneId"`
+	CharacterID  pgtype.UUID        `json:"characterId"`
+	UserID       pgtype.UUID        `json:"userId"`
+	Blocks       json.RawMessage    `json:"blocks"`
+	OocText      pgtype.Text        `json:"oocText"`
+	Witnesses    []pgtype.UUID      `json:"witnesses"`
+	IsHidden     bool               `json:"isHidden"`
+	IsLocked     bool               `json:"isLocked"`
+	LockedAt     pgtype.Timestamptz `json:"lockedAt"`
+	EditedByGM   bool               `json:"editedByGm"`
+	Intention    pgtype.Text        `json:"intention"`
+	Modifier     pgtype.Int4        `json:"modifier"`
+	AuthoredByGM bool               `json:"authoredByGm"`
+	IsSystem     bool               `json:"isSystem"`
+	IsPinned     bool               `json:"isPinned"`
+	RemovedAt    pgtype.Timestamptz `json:"removedAt"`
+	CreatedAt    pgtype.Timestamptz `json:"createdAt"`
+	// Bundles from before the field was exported count as restricted
+	WitnessedByScene bool `json:"witnessedByScene"`
+}
+
+// BundleRoll is a dice roll in an export bundle.
+type BundleRoll struct {
+	ID                     pgtype.UUID        `json:"id"`
+	PostID                 pgtype.UUID        `json:"postId"`
+	SceneID                pgtype.UUID        `json:"sceneId"`
+	CharacterID            pgtype.UUID        `json:"characterId"`
+	Intention              string             `json:"intention"`
+	Modifier               int32              `json:"modifier"`
+	DiceType               string             `json:"diceType"`
+	DiceCount              int32              `json:"diceCount"`
+	Result                 []int32            `json:"result"`
+	Total                  pgtype.Int4        `json:"total"`
+	WasOverridden          bool               `json:"wasOverridden"`
+	OriginalIntention      pgtype.Text        `json:"originalIntention"`
+	OverrideReason         pgtype.Text        `json:"overrideReason"`
+	ManualResult           pgtype.Int4        `json:"manualResult"`
+	ManualResolutionReason pgtype.Text        `json:"manualResolutionReason"`
+	Status                 string             `json:"status"`
+	CreatedAt              pgtype.Timestamptz `json:"createdAt"`
+	RolledAt               pgtype.Timestamptz `json:"rolledAt"`
+	SuccessThreshold       pgtype.Int4        `json:"successThreshold"`
+	Successes              pgtype.Int4        `json:"successes"`
+	Note                   pgtype.Text        `json:"note"`
+}
+
+// exportPageSize is how many posts or rolls an export reads per query.
+const exportPageSize = 500
+
+// CampaignExport is a campaign prepared for export. Encode streams it in the
+// CampaignBundle format: characters and scenes are loaded up front, and posts
+// and rolls are read a page at a time as they are written.
+type CampaignExport struct {
+	queries    *generated.Queries
+	campaign   generated.Campaign
+	characters []generated.ListCampaignCharactersRow
+	scenes     []generated.Scene
+}
+
+// ExportCampaign loads a campaign's characters, scenes, and pass states for
+// export (GM only). Posts and rolls are read by Encode.
+func (s *CampaignService) ExportCampaign(
+	ctx context.Context,
+	gmUserID, campaignID pgtype.UUID,
+) (*CampaignExport, error) {
+	campaign, err := s.queries.GetCampaign(ctx, campaignID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, err
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	export := &CampaignExport{queries: s.queries, campaign: campaign}
+	if export.characters, err = s.queries.ListCampaignCharacters(ctx, campaignID); err != nil {
+		return nil, err
+	}
+	if export.scenes, err = s.queries.ListCampaignScenes(ctx, campaignID); err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// Encode writes the export to w as a CampaignBundle, reading posts and rolls as
+// it goes. An error after the first write leaves w holding a truncated bundle.
+func (e *CampaignExport) Encode(ctx context.Context, w io.Writer) error {
+	bw := &bundleWriter{w: w, enc: json.NewEncoder(w)}
+
+	bw.write(`{"version":`)
+	bw.encode(CampaignBundleVersion)
+	bw.write(`,"exportedAt":`)
+	bw.encode(time.Now().UTC().Format(time.RFC3339))
+	bw.write(`,"campaign":`)
+	bw.encode(BundleCampaign{
+		ID:          e.campaign.ID,
+		Title:       e.campaign.Title,
+		Description: e.campaign.Description,
+		Settings:    json.RawMessage(e.campaign.Settings),
+	})
+	writeBundleList(bw, "characters", e.characters, bundleCharacter)
+	writeBundleList(bw, "scenes", e.scenes, bundleScene)
+	writeBundlePages(bw, "posts", func(last *generated.Post) ([]generated.Post, error) {
+		params := generated.ListCampaignPostsForExportParams{CampaignID: e.campaign.ID, RowLimit: exportPageSize}
+		if last != nil {
+			params.CursorCreatedAt, params.CursorID = last.CreatedAt, last.ID
+		}
+		return e.queries.ListCampaignPostsForExport(ctx, params)
+	}, bundlePost)
+	writeBundlePages(bw, "rolls", func(last *generated.Roll) ([]generated.Roll, error) {
+		params := generated.ListCampaignRollsForExportParams{CampaignID: e.campaign.ID, RowLimit: exportPageSize}
+		if last != nil {
+			params.CursorCreatedAt, params.CursorID = last.CreatedAt, last.ID
+		}
+		return e.queries.ListCampaignRollsForExport(ctx, params)
+	}, bundleRoll)
+	bw.write("}")
+
+	return bw.err
+}
+
+// bundleWriter writes a bundle piece by piece, keeping the first error.
+type bundleWriter struct {
+	w   io.Writer
+	enc *json.Encoder
+	err error
+}
+
+func (bw *bundleWriter) write(s string) {
+	if bw.err == nil {
+		_, bw.err = io.WriteString(bw.w, s)
+	}
+}
+
+func (bw *bundleWriter) encode(v any) {
+	if bw.err == nil {
+		bw.err = bw.enc.Encode(v)
+	}
+}
+
+// writeBundleList writes a bundle field holding a list of records.
+func writeBundleList[T, B any](bw *bundleWriter, key string, rows []T, convert func(*T) B) {
+	bw.write(`,"` + key + `":[`)
+	for i := range rows {
+		if i > 0 {
+			bw.write(",")
+		}
+		bw.encode(convert(&rows[i]))
+	}
+	bw.write("]")
+}
+
+// writeBundlePages writes a bundle field holding a list of records read a page
+// at a time. next returns the page after last, or the first page if last is nil.
+func writeBundlePages[T, B any](bw *bundleWriter, key string, next func(last *T) ([]T, error), convert func(*T) B) {
+	bw.write(`,"` + key + `":[`)
+	var last *T
+	for count := 0; bw.err == nil; {
+		rows, err := next(last)
+		if err != nil {
+			bw.err = err
+			return
+		}
+		for i := range rows {
+			if count > 0 {
+				bw.write(",")
+			}
+			bw.encode(convert(&rows[i]))
+			count++
+		}
+		if len(rows) < exportPageSize {
+			break
+		}
+		last = &rows[len(rows)-1]
+	}
+	bw.write("]")
+}
+
+func bundleCharacter(char *generated.ListCampaignCharactersRow) BundleCharacter {
+	return BundleCharacter{
+		ID:             char.ID,
+		DisplayName:    char.DisplayName,
+		Description:    char.Description,
+		AvatarURL:      char.AvatarUrl,
+		Pronouns:       char.Pronouns,
+		GmNotes:        char.GmNotes,
+		Tags:           char.Tags,
+		CharacterType:  string(char.CharacterType),
+		IsArchived:     char.IsArchived,
+		AssignedUserID: char.AssignedUserID,
+		CreatedAt:      char.CreatedAt,
+	}
+}
+
+func bundleScene(scene *generated.Scene) BundleScene {
+	passStates := map[string]string{}
+	if len(scene.PassStates) > 0 {
+		_ = json.Unmarshal(scene.PassStates, &passStates)
+	}
+	return BundleScene{
+		ID:             scene.ID,
+		Title:          scene.Title,
+		Description:    scene.Description,
+		HeaderImageURL: scene.HeaderImageUrl,
+		CharacterIDs:   scene.CharacterIds,
+		CharacterOrder: scene.CharacterOrder,
+		PassStates:     passStates,
+		IsArchived:     scene.IsArchived,
+		SortOrder:      scene.SortOrder,
+		CreatedAt:      scene.CreatedAt,
+	}
+}
+
+func bundlePost(post *generated.Post) BundlePost {
+	return BundlePost{
+		ID:               post.ID,
+		SceneID:          post.SceneID,
+		CharacterID:      post.CharacterID,
+		UserID:           post.UserID,
+		Blocks:           json.RawMessage(post.Blocks),
+		OocText:          post.OocText,
+		Witnesses:        post.Witnesses,
+		IsHidden:         post.IsHidden,
+		IsLocked:         post.IsLocked,
+		LockedAt:         post.LockedAt,
+		EditedByGM:       post.EditedByGm,
+		Intention:        post.Intention,
+		Modifier:         post.Modifier,
+		AuthoredByGM:     post.AuthoredByGm,
+		IsSystem:         post.IsSystem,
+		IsPinned:         post.IsPinned,
+		RemovedAt:        post.DeletedAt,
+		CreatedAt:        post.CreatedAt,
+		WitnessedByScene: post.WitnessedByScene,
+	}
+}
+
+func bundleRoll(roll *generated.Roll) BundleRoll {
+	return BundleRoll{
+		ID:                     roll.ID,
+		PostID:                 roll.PostID,
+		SceneID:                roll.SceneID,
+		CharacterID:            roll.CharacterID,
+		Intention:              roll.Intention,
+		Modifier:               roll.Modifier,
+		DiceType:               roll.DiceType,
+		DiceCount:              roll.DiceCount,
+		Result:                 roll.Result,
+		Total:                  roll.Total,
+		WasOverridden:          roll.WasOverridden,
+		OriginalIntention:      roll.OriginalIntention,
+		OverrideReason:         roll.OverrideReason,
+		ManualResult:           roll.ManualResult,
+		ManualResolutionReason: roll.ManualResolutionReason,
+		Status:                 string(roll.Status),
+		CreatedAt:              roll.CreatedAt,
+		RolledAt:               roll.RolledAt,
+		SuccessThreshold:       roll.SuccessThreshold,
+		Successes:              roll.Successes,
+		Note:                   roll.Note,
+	}
+}
+
+// ImportCampaign recreates an exported campaign as a new campaign owned by the GM,
+// remapping every ID. Players are not imported: characters come back unassigned and
+// all posts and roll requests are attributed to the importing GM. assetPrefix is the
+// storage URL prefix of the bundle's source campaign; avatar and header image URLs
+// outside it are dropped, as are all of them unless the importing user is a GM of
+// the source campaign.
+func (s *CampaignService) ImportCampaign(
+	ctx context.Context,
+	gmUserID pgtype.UUID,
+	bundle *CampaignBundle,
+	assetPrefix string,
+) (*generated.Campaign, error) {
+	if bundle.Version != CampaignBundleVersion {
+		return nil, ErrUnsupportedBundleVersion
+	}
+
+	if len(bundle.Characters) > MaxBundleCharacters || len(bundle.Scenes) > MaxScenes ||
+		len(bundle.Posts) > MaxBundlePosts || len(bundle.Rolls) > MaxBundleRolls {
+		return nil, ErrCampaignBundleTooLarge
+	}
+
+	title := strings.TrimSpace(bundle.Campaign.Title)
+	if title == "" || len([]rune(title)) > maxCampaignTitleLen {
+		return nil, ErrInvalidCampaignBundle
+	}
+
+	var rawSettings map[string]any
+	if len(bundle.Campaign.Settings) > 0 {
+		if err := json.Unmarshal(bundle.Campaign.Settings, &rawSettings); err != nil {
+			return nil, ErrInvalidCampaignBundle
+		}
+	}
+	settings, err := normalizeSettings(rawSettings)
+	if err != nil {
+		return nil, err
+	}
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only keep images the importing GM could already use
+	if !bundle.Campaign.ID.Valid {
+		assetPrefix = ""
+	}
+	if assetPrefix != "" {
+		isSourceGM, gmErr := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+			CampaignID: bundle.Campaign.ID,
+			UserID:     gmUserID,
+		})
+		if gmErr != nil {
+			return nil, gmErr
+		}
+		if !isSourceGM {
+			assetPrefix = ""
+		}
+	}
+
+	// Check campaign limit (0 means the user is exempt)
+	if limit := campaignLimitFor(gmUserID); limit > 0 {
+		count, countErr := s.queries.CountUserOwnedCampaigns(ctx, gmUserID)
+		if countErr != nil {
+			return nil, countErr
+		}
+		if count >= int64(limit) {
+			return nil, &CampaignLimitError{Limit: limit}
+		}
+	}
+
+	ctx, cancel := withLongTxTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := s.queries.WithTx(tx)
+
+	campaign, err := qtx.CreateCampaign(ctx, generated.CreateCampaignParams{
+		Title:       title,
+		Description: bundle.Campaign.Description,
+		OwnerID:     gmUserID,
+		Settings:    settingsJSON,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = qtx.AddCampaignMember(ctx, generated.AddCampaignMemberParams{
+		CampaignID: campaign.ID,
+		UserID:     gmUserID,
+		Role:       generated.MemberRoleGm,
+		Alias:      pgtype.Text{String: "", Valid: false},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	characterIDs, err := importCharacters(ctx, qtx, campaign.ID, bundle.Characters, assetPrefix)
+	if err != nil {
+		return nil, err
+	}
+	sceneIDs, err := importScenes(ctx, qtx, campaign.ID, bundle.Scenes, characterIDs, assetPrefix)
+	if err != nil {
+		return nil, err
+	}
+	postIDs, err := importPosts(ctx, qtx, gmUserID, bundle.Posts, sceneIDs, characterIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := importRolls(ctx, qtx, gmUserID, bundle.Rolls, sceneIDs, characterIDs, postIDs); err != nil {
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return nil, commitErr
+	}
+
+	return &campaign, nil
+}
+
+// bundleAssetURL returns an imported image URL if it is stored under the source
+// campaign's prefix, and null otherwise.
+func bundleAssetURL(url pgtype.Text, assetPrefix string) pgtype.Text {
+	if !url.Valid || assetPrefix == "" || !strings.HasPrefix(url.String, assetPrefix) ||
+		strings.Contains(url.String, "..") {
+		return pgtype.Text{}
+	}
+	return url
+}
+
+// bundleIDMap maps IDs from an export bundle to the newly created rows.
+type bundleIDMap map[[16]byte]pgtype.UUID
+
+// lookup returns the new ID for an old one. Invalid (null) IDs map to null.
+func (m bundleIDMap) lookup(oldID pgtype.UUID) (pgtype.UUID, bool) {
+	if !oldID.Valid {
+		return pgtype.UUID{}, true
+	}
+	newID, ok := m[oldID.Bytes]
+	return newID, ok
+}
+
+// remapAll maps a list of IDs, dropping any that are not in the bundle.
+func (m bundleIDMap) remapAll(oldIDs []pgtype.UUID) []pgtype.UUID {
+	newIDs := make([]pgtype.UUID, 0, len(oldIDs))
+	for _, oldID := range oldIDs {
+		if newID, ok := m[oldID.Bytes]; ok && oldID.Valid {
+			newIDs = append(newIDs, newID)
+		}
+	}
+	return newIDs
+}
+
+func importCharacters(
+	ctx context.Context,
+	qtx *generated.Queries,
+	campaignID pgtype.UUID,
+	characters []BundleCharacter,
+	assetPrefix string,
+) (bundleIDMap, error) {
+	ids := bundleIDMap{}
+	for _, char := range characters {
+		charType := generated.CharacterType(char.CharacterType)
+		if !char.ID.Valid || strings.TrimSpace(char.DisplayName) == "" ||
+			(charType != generated.CharacterTypePc && charType != generated.CharacterTypeNpc) {
+			return nil, ErrInvalidCampaignBundle
+		}
+
+		tags, tagsErr := normalizeCharacterTags(char.Tags)
+		if tagsErr != nil {
+			return nil, ErrInvalidCampaignBundle
+		}
+
+		newID, err := qtx.ImportCharacter(ctx, generated.ImportCharacterParams{
+			CampaignID:    campaignID,
+			DisplayName:   char.DisplayName,
+			Description:   char.Description,
+			AvatarUrl:     bundleAssetURL(char.AvatarURL, assetPrefix),
+			CharacterType: charType,
+			IsArchived:    char.IsArchived,
+			CreatedAt:     bundleTimestamp(char.CreatedAt),
+			Pronouns:      char.Pronouns,
+			GmNotes:       char.GmNotes,
+			Tags:          tags,
+		})
+		if err != nil {
+			return nil, err
+		}
+		ids[char.ID.Bytes] = newID
+	}
+	return ids, nil
+}
+
+func importScenes(
+	ctx context.Context,
+	qtx *generated.Queries,
+	campaignID pgtype.UUID,
+	scenes []BundleScene,
+	characterIDs bundleIDMap,
+	assetPrefix string,
+) (bundleIDMap, error) {
+	ids := bundleIDMap{}
+	for _, scene := range scenes {
+		if !scene.ID.Valid || strings.TrimSpace(scene.Title) == "" {
+			return nil, ErrInvalidCampaignBundle
+		}
+
+		// Pass states are keyed by character ID
+		passStates := map[string]string{}
+		for oldCharID, state := range scene.PassStates {
+			newCharID, ok := characterIDs[parseUUIDString(oldCharID).Bytes]
+			if ok {
+				passStates[formatUUID(newCharID.Bytes[:])] = state
+			}
+		}
+		passStatesJSON, err := json.Marshal(passStates)
+		if err != nil {
+			return nil, err
+		}
+
+		newID, err := qtx.ImportScene(ctx, generated.ImportSceneParams{
+			CampaignID:     campaignID,
+			Title:          scene.Title,
+			Description:    scene.Description,
+			HeaderImageUrl: bundleAssetURL(scene.HeaderImageURL, assetPrefix),
+			CharacterIds:   characterIDs.remapAll(scene.CharacterIDs),
+			PassStates:     passStatesJSON,
+			IsArchived:     scene.IsArchived,
+			SortOrder:      scene.SortOrder,
+			CreatedAt:      bundleTimestamp(scene.CreatedAt),
+			CharacterOrder: characterIDs.remapAll(scene.CharacterOrder),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if incrementErr := qtx.IncrementSceneCount(ctx, campaignID); incrementErr != nil {
+			return nil, incrementErr
+		}
+		ids[scene.ID.Bytes] = newID
+	}
+	return ids, nil
+}
+
+func importPosts(
+	ctx context.Context,
+	qtx *generated.Queries,
+	gmUserID pgtype.UUID,
+	posts []BundlePost,
+	sceneIDs, characterIDs bundleIDMap,
+) (bundleIDMap, error) {
+	ids := bundleIDMap{}
+	for _, post := range posts {
+		sceneID, sceneOK := sceneIDs.lookup(post.SceneID)
+		characterID, charOK := characterIDs.lookup(post.CharacterID)
+		if !post.ID.Valid || !post.SceneID.Valid || !sceneOK || !charOK || !json.Valid(post.Blocks) {
+			return nil, ErrInvalidCampaignBundle
+		}
+
+		newID, err := qtx.ImportPost(ctx, generated.ImportPostParams{
+			SceneID:          sceneID,
+			CharacterID:      characterID,
+			UserID:           gmUserID,
+			Blocks:           post.Blocks,
+			OocText:          post.OocText,
+			Witnesses:        characterIDs.remapAll(post.Witnesses),
+			IsHidden:         post.IsHidden,
+			IsLocked:         post.IsLocked,
+			LockedAt:         post.LockedAt,
+			EditedByGm:       post.EditedByGM,
+			Intention:        post.Intention,
+			Modifier:         post.Modifier,
+			AuthoredByGm:     post.AuthoredByGM,
+			CreatedAt:        bundleTimestamp(post.CreatedAt),
+			IsSystem:         post.IsSystem,
+			IsPinned:         post.IsPinned,
+			DeletedAt:        post.RemovedAt,
+			WitnessedByScene: post.WitnessedByScene,
+		})
+		if err != nil {
+			return nil, err
+		}
+		ids[post.ID.Bytes] = newID
+	}
+	return ids, nil
+}
+
+func importRolls(
+	ctx context.Context,
+	qtx *generated.Queries,
+	gmUserID pgtype.UUID,
+	rolls []BundleRoll,
+	sceneIDs, characterIDs, postIDs bundleIDMap,
+) error {
+	for _, roll := range rolls {
+		sceneID, sceneOK := sceneIDs.lookup(roll.SceneID)
+		characterID, charOK := characterIDs.lookup(roll.CharacterID)
+		status := generated.RollStatus(roll.Status)
+		if !roll.SceneID.Valid || !roll.CharacterID.Valid || !sceneOK || !charOK ||
+			(status != generated.RollStatusPending &&
+				status != generated.RollStatusCompleted &&
+				status != generated.RollStatusInvalidated) {
+			return ErrInvalidCampaignBundle
+		}
+		// Rolls outlive deleted posts, so an unknown post just detaches the roll
+		postID, _ := postIDs.lookup(roll.PostID)
+
+		if err := qtx.ImportRoll(ctx, generated.ImportRollParams{
+			PostID:                 postID,
+			SceneID:                sceneID,
+			CharacterID:            characterID,
+			RequestedBy:            gmUserID,
+			Intention:              roll.Intention,
+			Modifier:               roll.Modifier,
+			DiceType:               roll.DiceType,
+			DiceCount:              roll.DiceCount,
+			Result:                 roll.Result,
+			Total:                  roll.Total,
+			WasOverridden:          roll.WasOverridden,
+			OriginalIntention:      roll.OriginalIntention,
+			Status:                 status,
+			OverrideReason:         roll.OverrideReason,
+			ManualResult:           roll.ManualResult,
+			ManualResolutionReason: roll.ManualResolutionReason,
+			CreatedAt:              bundleTimestamp(roll.CreatedAt),
+			RolledAt:               roll.RolledAt,
+			SuccessThreshold:       roll.SuccessThreshold,
+			Successes:              roll.Successes,
+			Note:                   roll.Note,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bundleTimestamp keeps an exported creation time, or uses now when it is missing.
+func bundleTimestamp(ts pgtype.Timestamptz) pgtype.Timestamptz {
+	if ts.Valid {
+		return ts
+	}
+	return pgtype.Timestamptz{Time: time.Now(), Valid: true}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestImportCampaignRejectsOversizedBundles(t *testing.T) {
+	tests := []struct {
+		name   string
+		bundle CampaignBundle
+	}{
+		{"characters", CampaignBundle{Characters: make([]BundleCharacter, MaxBundleCharacters+1)}},
+		{"scenes", CampaignBundle{Scenes: make([]BundleScene, MaxScenes+1)}},
+		{"posts", CampaignBundle{Posts: make([]BundlePost, MaxBundlePosts+1)}},
+		{"rolls", CampaignBundle{Rolls: make([]BundleRoll, MaxBundleRolls+1)}},
+	}
+
+	// The caps are checked before the database is touched
+	svc := &CampaignService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.bundle.Version = CampaignBundleVersion
+			tt.bundle.Campaign.Title = "Imported"
+			_, err := svc.ImportCampaign(context.Background(), pgtype.UUID{}, &tt.bundle, "")
+			if !errors.Is(err, ErrCampaignBundleTooLarge) {
+				t.Errorf("ImportCampaign() error = %v, want %v", err, ErrCampaignBundleTooLarge)
+			}
+		})
+	}
+}
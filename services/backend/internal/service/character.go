@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"net/http"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -12,9 +13,17 @@ import (
 
 // Character errors.
 var (
-	ErrCharacterNotFound   = errors.New("character not found")
-	ErrCharacterNotInScene = errors.New("character is not in this scene")
-	ErrCharacterArchived   = errors.New("character is archived")
+	ErrCharacterNotFound = newCodedError(
+		http.StatusNotFound, "NOT_FOUND",
+		"Character not found",
+		"character not found",
+	)
+	ErrCharacterNotInScene = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Character is not in this scene",
+		"character is not in this scene",
+	)
+	ErrCharacterArchived = errors.New("character is archived")
 )
 
 // CharacterService handles character business logic.
@@ -26,7 +35,7 @@ type CharacterService struct {
 // NewCharacterService creates a new CharacterService.
 func NewCharacterService(pool *pgxpool.Pool) *CharacterService {
 	return &CharacterService{
-		queries: generated.New(pool),
+		queries: newQueries(pool),
 		pool:    pool,
 	}
 }
@@ -46,7 +55,7 @@ func (s *CharacterService) CreateCharacter(
 	req CreateCharacterRequest,
 ) (*generated.ListCampaignCharactersRow, error) {
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -68,6 +77,9 @@ func (s *CharacterService) CreateCharacter(
 		charType = generated.CharacterTypePc
 	}
 
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
 	// Start transaction
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
@@ -124,7 +136,7 @@ func (s *CharacterService) GetCharacter(
 	}
 
 	// Verify user is a member of the campaign
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: char.CampaignID,
 		UserID:     userID,
 	})
@@ -135,6 +147,20 @@ func (s *CharacterService) GetCharacter(
 		return nil, ErrNotMember
 	}
 
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: char.CampaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// GM notes are private to the GM
+	gmNotes := char.GmNotes
+	if !isGM {
+		gmNotes = pgtype.Text{}
+	}
+
 	return &generated.ListCampaignCharactersRow{
 		ID:             char.ID,
 		CampaignID:     char.CampaignID,
@@ -145,6 +171,8 @@ func (s *CharacterService) GetCharacter(
 		IsArchived:     char.IsArchived,
 		CreatedAt:      char.CreatedAt,
 		UpdatedAt:      char.UpdatedAt,
+		Pronouns:       char.Pronouns,
+		GmNotes:        gmNotes,
 		AssignedUserID: char.AssignedUserID,
 		AssignedAt:     char.AssignedAt,
 	}, nil
@@ -156,7 +184,7 @@ func (s *CharacterService) ListCampaignCharacters(
 	campaignID, userID pgtype.UUID,
 ) ([]generated.ListCampaignCharactersRow, error) {
 	// Verify user is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -167,7 +195,27 @@ func (s *CharacterService) ListCampaignCharacters(
 		return nil, ErrNotMember
 	}
 
-	return s.queries.ListCampaignCharacters(ctx, campaignID)
+	characters, err := s.queries.ListCampaignCharacters(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// GM notes are private to the GM
+	if !isGM {
+		for i := range characters {
+			characters[i].GmNotes = pgtype.Text{}
+		}
+	}
+
+	return characters, nil
 }
 
 // UpdateCharacterRequest represents the request to update a character.
@@ -175,6 +223,8 @@ type UpdateCharacterRequest struct {
 	DisplayName   *string `json:"displayName,omitempty"`
 	Description   *string `json:"description,omitempty"`
 	CharacterType *string `json:"characterType,omitempty"`
+	Pronouns      *string `json:"pronouns,omitempty"`
+	GmNotes       *string `json:"gmNotes,omitempty"`
 }
 
 // UpdateCharacter updates a character (GM only).
@@ -193,7 +243,7 @@ func (s *CharacterService) UpdateCharacter(
 	}
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: char.CampaignID,
 		UserID:     userID,
 	})
@@ -211,6 +261,8 @@ func (s *CharacterService) UpdateCharacter(
 		Description:   char.Description,
 		AvatarUrl:     char.AvatarUrl,
 		CharacterType: char.CharacterType,
+		Pronouns:      char.Pronouns,
+		GmNotes:       char.GmNotes,
 	}
 
 	if req.DisplayName != nil {
@@ -230,6 +282,15 @@ func (s *CharacterService) UpdateCharacter(
 		}
 	}
 
+	// An empty string clears the optional fields
+	if req.Pronouns != nil {
+		params.Pronouns = pgtype.Text{String: *req.Pronouns, Valid: *req.Pronouns != ""}
+	}
+
+	if req.GmNotes != nil {
+		params.GmNotes = pgtype.Text{String: *req.GmNotes, Valid: *req.GmNotes != ""}
+	}
+
 	updated, err := s.queries.UpdateCharacter(ctx, params)
 	if err != nil {
 		return nil, err
@@ -253,7 +314,7 @@ func (s *CharacterService) ArchiveCharacter(
 	}
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: char.CampaignID,
 		UserID:     userID,
 	})
@@ -287,7 +348,7 @@ func (s *CharacterService) UnarchiveCharacter(
 	}
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: char.CampaignID,
 		UserID:     userID,
 	})
@@ -321,7 +382,7 @@ func (s *CharacterService) AssignCharacter(
 	}
 
 	// Verify caller is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: char.CampaignID,
 		UserID:     userID,
 	})
@@ -333,7 +394,7 @@ func (s *CharacterService) AssignCharacter(
 	}
 
 	// Verify target user is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: char.CampaignID,
 		UserID:     targetUserID,
 	})
@@ -366,7 +427,7 @@ func (s *CharacterService) UnassignCharacter(
 	}
 
 	// Verify caller is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: char.CampaignID,
 		UserID:     userID,
 	})
@@ -386,7 +447,7 @@ func (s *CharacterService) GetOrphanedCharacters(
 	campaignID, userID pgtype.UUID,
 ) ([]generated.Character, error) {
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// Character tag limits.
+const (
+	MaxCharacterTagLen = 32
+	MaxCharacterTags   = 10
+)
+
+// Character tag errors.
+var (
+	ErrInvalidCharacterTag  = errors.New("invalid character tag")
+	ErrTooManyCharacterTags = errors.New("too many character tags")
+)
+
+// AddCharacterTag adds a condition tag such as "poisoned" to a character (GM only).
+// Adding a tag the character already has is a no-op.
+func (s *CharacterService) AddCharacterTag(
+	ctx context.Context,
+	campaignID, characterID, userID pgtype.UUID,
+	tag string,
+) (*generated.Character, error) {
+	char, err := s.gmCampaignCharacter(ctx, campaignID, characterID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := normalizeCharacterTags(append(slices.Clone(char.Tags), tag))
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.queries.SetCharacterTags(ctx, generated.SetCharacterTagsParams{
+		ID:   characterID,
+		Tags: tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// RemoveCharacterTag removes a condition tag from a character (GM only).
+// Removing a tag the character does not have is a no-op.
+func (s *CharacterService) RemoveCharacterTag(
+	ctx context.Context,
+	campaignID, characterID, userID pgtype.UUID,
+	tag string,
+) (*generated.Character, error) {
+	char, err := s.gmCampaignCharacter(ctx, campaignID, characterID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return nil, ErrInvalidCharacterTag
+	}
+
+	tags := slices.DeleteFunc(slices.Clone(char.Tags), func(t string) bool {
+		return strings.EqualFold(t, tag)
+	})
+
+	updated, err := s.queries.SetCharacterTags(ctx, generated.SetCharacterTagsParams{
+		ID:   characterID,
+		Tags: tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// gmCampaignCharacter loads a character in the campaign and verifies the user is its GM.
+func (s *CharacterService) gmCampaignCharacter(
+	ctx context.Context,
+	campaignID, characterID, userID pgtype.UUID,
+) (generated.Character, error) {
+	char, err := s.queries.GetCharacter(ctx, characterID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return char, ErrCharacterNotFound
+		}
+		return char, err
+	}
+	if char.CampaignID != campaignID {
+		return char, ErrCharacterNotFound
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return char, err
+	}
+	if !isGM {
+		return char, ErrNotGM
+	}
+
+	return char, nil
+}
+
+// normalizeCharacterTags trims tags, drops case-insensitive duplicates, and enforces
+// the length and count limits. The result is never nil since the column is NOT NULL.
+func normalizeCharacterTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || utf8.RuneCountInString(tag) > MaxCharacterTagLen {
+			return nil, ErrInvalidCharacterTag
+		}
+		if slices.ContainsFunc(normalized, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			continue
+		}
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > MaxCharacterTags {
+		return nil, ErrTooManyCharacterTags
+	}
+	return normalized, nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeCharacterTags(t *testing.T) {
+	tooMany := make([]string, MaxCharacterTags+1)
+	for i := range tooMany {
+		tooMany[i] = strings.Repeat("x", i+1)
+	}
+
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr error
+	}{
+		{"empty", nil, []string{}, nil},
+		{"trims whitespace", []string{"  poisoned "}, []string{"poisoned"}, nil},
+		{"drops case-insensitive duplicates", []string{"Prone", "prone", "PRONE"}, []string{"Prone"}, nil},
+		{"at the length cap", []string{strings.Repeat("a", MaxCharacterTagLen)}, []string{strings.Repeat("a", MaxCharacterTagLen)}, nil},
+		{"counts runes, not bytes", []string{strings.Repeat("é", MaxCharacterTagLen)}, []string{strings.Repeat("é", MaxCharacterTagLen)}, nil},
+		{"blank", []string{"   "}, nil, ErrInvalidCharacterTag},
+		{"past the length cap", []string{strings.Repeat("a", MaxCharacterTagLen+1)}, nil, ErrInvalidCharacterTag},
+		{"at the count cap", tooMany[:MaxCharacterTags], tooMany[:MaxCharacterTags], nil},
+		{"past the count cap", tooMany, nil, ErrTooManyCharacterTags},
+		{"duplicates do not count toward the cap", append(slices.Clone(tooMany[:MaxCharacterTags]), "X"), tooMany[:MaxCharacterTags], nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeCharacterTags(tt.tags)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("normalizeCharacterTags(%q) error = %v, want %v", tt.tags, err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got == nil {
+				t.Fatalf("normalizeCharacterTags(%q) = nil, want non-nil", tt.tags)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("normalizeCharacterTags(%q) = %q, want %q", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"net/http"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -20,12 +21,25 @@ const (
 
 // Compose lock errors.
 var (
-	ErrLockNotFound      = errors.New("compose lock not found")
-	ErrLockAlreadyHeld   = errors.New("compose lock already held by another user")
-	ErrNotLockOwner      = errors.New("you do not own this compose lock")
-	ErrCharacterNotOwned = errors.New("you do not own this character")
-	ErrNotInPCPhase      = errors.New("posts can only be created during PC Phase")
-	ErrTimeGateExpired   = errors.New("time gate has expired, cannot compose posts")
+	ErrLockNotFound    = errors.New("compose lock not found")
+	ErrLockAlreadyHeld = errors.New("compose lock already held by another user")
+	ErrNotLockOwner    = errors.New("you do not own this compose lock")
+
+	ErrCharacterNotOwned = newCodedError(
+		http.StatusForbidden, "NOT_CHARACTER_OWNER",
+		"You do not own this character",
+		"you do not own this character",
+	)
+	ErrNotInPCPhase = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Posts can only be created during PC Phase",
+		"posts can only be created during PC Phase",
+	)
+	ErrTimeGateExpired = newCodedError(
+		http.StatusForbidden, "TIME_GATE_EXPIRED",
+		"Time gate has expired. Waiting for GM to transition phase.",
+		"time gate has expired, cannot compose posts",
+	)
 )
 
 // ComposeService handles compose lock business logic.
@@ -37,7 +51,7 @@ type ComposeService struct {
 // NewComposeService creates a new ComposeService.
 func NewComposeService(pool *pgxpool.Pool) *ComposeService {
 	return &ComposeService{
-		queries: generated.New(pool),
+		queries: newQueries(pool),
 		pool:    pool,
 	}
 }
@@ -78,7 +92,7 @@ func (s *ComposeService) AcquireLock(
 
 	// Verify PC Phase (players can only post during PC Phase)
 	// GMs can post during any phase
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: sceneWithCampaign.CampaignID,
 		UserID:     userID,
 	})
@@ -86,6 +100,10 @@ func (s *ComposeService) AcquireLock(
 		return nil, err
 	}
 
+	if sceneWithCampaign.IsFrozen {
+		return nil, ErrSceneFrozen
+	}
+
 	if !isGM && sceneWithCampaign.CurrentPhase != generated.CampaignPhasePcPhase {
 		return nil, ErrNotInPCPhase
 	}
@@ -96,7 +114,7 @@ func (s *ComposeService) AcquireLock(
 			time.Now().After(sceneWithCampaign.CurrentPhaseExpiresAt.Time) {
 			// Time gate expired - auto-pass all characters
 			passSvc := NewPassService(s.pool)
-			if passErr := passSvc.AutoPassAllCharacters(ctx, sceneWithCampaign.CampaignID); passErr != nil {
+			if _, passErr := passSvc.AutoPassAllCharacters(ctx, sceneWithCampaign.CampaignID); passErr != nil {
 				// Log error but continue - auto-pass is best-effort
 				_ = passErr
 			}
@@ -124,23 +142,8 @@ func (s *ComposeService) AcquireLock(
 		return nil, err
 	}
 
-	assignment, err := s.queries.GetCharacterAssignment(ctx, characterID)
-	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
-		return nil, err
-	}
-
-	// For NPCs or unassigned characters, only GM can use them
-	if errors.Is(err, pgx.ErrNoRows) || !assignment.UserID.Valid {
-		if !isGM {
-			return nil, ErrCharacterNotOwned
-		}
-	} else if assignment.UserID != userID {
-		return nil, ErrCharacterNotOwned
-	}
-
-	// Check for NPC - only GM can post as NPCs
-	if char.CharacterType == generated.CharacterTypeNpc && !isGM {
-		return nil, ErrCharacterNotOwned
+	if controlErr := s.verifyCharacterControl(ctx, userID, &char, isGM); controlErr != nil {
+		return nil, controlErr
 	}
 
 	// Check if lock already exists
@@ -206,8 +209,10 @@ func (s *ComposeService) AcquireLock(
 }
 
 // HeartbeatRequest represents a heartbeat request to refresh lock expiration.
+// When Blocks is present the current draft is saved alongside the refresh.
 type HeartbeatRequest struct {
-	LockID string `json:"lockId"`
+	LockID string      `json:"lockId"`
+	Blocks []PostBlock `json:"blocks,omitempty"`
 }
 
 // HeartbeatResponse represents the response from a heartbeat.
@@ -215,9 +220,13 @@ type HeartbeatResponse struct {
 	Acknowledged     bool   `json:"acknowledged"`
 	ExpiresAt        string `json:"expiresAt"`
 	RemainingSeconds int    `json:"remainingSeconds"`
+	DraftSaved       bool   `json:"draftSaved"`
+	Warning          string `json:"warning,omitempty"`
 }
 
-// Heartbeat refreshes a compose lock's expiration time.
+// Heartbeat refreshes a compose lock's expiration time. If the request carries draft
+// blocks they are upserted into the scene+character draft so lock expiry doesn't lose work,
+// within the same per-user draft cap as SaveDraft.
 func (s *ComposeService) Heartbeat(
 	ctx context.Context,
 	userID pgtype.UUID,
@@ -250,13 +259,99 @@ func (s *ComposeService) Heartbeat(
 		return nil, updateErr
 	}
 
+	// Auto-save the draft only when blocks were sent, keeping plain heartbeats cheap
+	draftSaved := false
+	var warning string
+	if req.Blocks != nil {
+		var saveErr error
+		warning, saveErr = NewDraftService(s.pool).saveDraftBlocks(ctx, userID, &lock, req.Blocks)
+		if saveErr != nil {
+			return nil, saveErr
+		}
+		draftSaved = true
+	}
+
 	return &HeartbeatResponse{
 		Acknowledged:     true,
 		ExpiresAt:        expiresAt.Format(time.RFC3339),
 		RemainingSeconds: LockTimeoutMinutes * SecondsPerMinute,
+		DraftSaved:       draftSaved,
+		Warning:          warning,
 	}, nil
 }
 
+// MaxHeartbeatBatch is the maximum number of locks refreshed in one batch heartbeat.
+const MaxHeartbeatBatch = 25
+
+// ErrHeartbeatBatchTooLarge is returned when a batch heartbeat lists too many locks.
+var ErrHeartbeatBatchTooLarge = errors.New("too many locks in heartbeat batch")
+
+// LockHeartbeat is the refreshed expiry of one lock in a batch heartbeat.
+type LockHeartbeat struct {
+	LockID           string `json:"lockId"`
+	ExpiresAt        string `json:"expiresAt"`
+	RemainingSeconds int    `json:"remainingSeconds"`
+}
+
+// HeartbeatManyResponse lists the refreshed locks and the lock IDs that were skipped.
+type HeartbeatManyResponse struct {
+	Locks   []LockHeartbeat `json:"locks"`
+	Skipped []string        `json:"skipped"`
+}
+
+// HeartbeatMany refreshes every listed lock the user owns in a single update.
+// Locks that do not exist, are malformed, or belong to someone else are skipped.
+func (s *ComposeService) HeartbeatMany(
+	ctx context.Context,
+	userID pgtype.UUID,
+	lockIDs []string,
+) (*HeartbeatManyResponse, error) {
+	if len(lockIDs) > MaxHeartbeatBatch {
+		return nil, ErrHeartbeatBatchTooLarge
+	}
+
+	lockUUIDs := make([]pgtype.UUID, 0, len(lockIDs))
+	for _, id := range lockIDs {
+		if lockUUID := parseUUIDString(id); lockUUID.Valid {
+			lockUUIDs = append(lockUUIDs, lockUUID)
+		}
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(LockTimeoutMinutes * time.Minute)
+
+	refreshed, err := s.queries.UpdateComposeLocksActivity(ctx, generated.UpdateComposeLocksActivityParams{
+		UserID:         userID,
+		Column2:        lockUUIDs,
+		LastActivityAt: pgtype.Timestamptz{Time: now, Valid: true, InfinityModifier: pgtype.Finite},
+		ExpiresAt:      pgtype.Timestamptz{Time: expiresAt, Valid: true, InfinityModifier: pgtype.Finite},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &HeartbeatManyResponse{
+		Locks:   make([]LockHeartbeat, 0, len(refreshed)),
+		Skipped: []string{},
+	}
+	refreshedIDs := make(map[[16]byte]bool, len(refreshed))
+	for _, lock := range refreshed {
+		refreshedIDs[lock.ID.Bytes] = true
+		resp.Locks = append(resp.Locks, LockHeartbeat{
+			LockID:           formatUUID(lock.ID.Bytes[:]),
+			ExpiresAt:        lock.ExpiresAt.Time.Format(time.RFC3339),
+			RemainingSeconds: LockTimeoutMinutes * SecondsPerMinute,
+		})
+	}
+	for _, id := range lockIDs {
+		if lockUUID := parseUUIDString(id); !lockUUID.Valid || !refreshedIDs[lockUUID.Bytes] {
+			resp.Skipped = append(resp.Skipped, id)
+		}
+	}
+
+	return resp, nil
+}
+
 // ReleaseLock releases a compose lock.
 func (s *ComposeService) ReleaseLock(
 	ctx context.Context,
@@ -304,7 +399,7 @@ func (s *ComposeService) ForceReleaseLock(
 	}
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -315,7 +410,58 @@ func (s *ComposeService) ForceReleaseLock(
 		return ErrNotGM
 	}
 
-	return s.queries.DeleteComposeLock(ctx, lockUUID)
+	if err := s.queries.DeleteComposeLock(ctx, lockUUID); err != nil {
+		return err
+	}
+
+	logGMAction(ctx, s.queries, scene.CampaignID, userID, GMActionForceReleaseLock,
+		AuditTargetComposeLock, lockUUID, map[string]any{
+			"sceneId":     uuidToString(lock.SceneID),
+			"holderId":    uuidToString(lock.UserID),
+			"characterId": uuidToString(lock.CharacterID),
+		})
+	return nil
+}
+
+// ForceReleaseSceneLocks releases every active lock in a scene by GM force.
+// It returns the number of locks released.
+func (s *ComposeService) ForceReleaseSceneLocks(
+	ctx context.Context,
+	gmUserID pgtype.UUID,
+	sceneID string,
+) (int64, error) {
+	sceneUUID := parseUUIDString(sceneID)
+
+	scene, err := s.queries.GetScene(ctx, sceneUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrSceneNotFound
+		}
+		return 0, err
+	}
+
+	// Verify user is GM
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: scene.CampaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !isGM {
+		return 0, ErrNotGM
+	}
+
+	released, err := s.queries.DeleteSceneComposeLocks(ctx, sceneUUID)
+	if err != nil {
+		return 0, err
+	}
+
+	if released > 0 {
+		logGMAction(ctx, s.queries, scene.CampaignID, gmUserID, GMActionForceReleaseSceneLocks,
+			AuditTargetScene, sceneUUID, map[string]any{"released": released})
+	}
+	return released, nil
 }
 
 // UpdateLockHidden updates whether a compose lock is for a hidden post.
@@ -358,6 +504,35 @@ type SceneLockInfo struct {
 	IsHidden        bool   `json:"isHidden"`
 }
 
+// verifyCharacterControl checks the user may post as the character: players as
+// the PCs assigned to them, the GM as NPCs and unassigned characters.
+func (s *ComposeService) verifyCharacterControl(
+	ctx context.Context,
+	userID pgtype.UUID,
+	char *generated.Character,
+	isGM bool,
+) error {
+	assignment, err := s.queries.GetCharacterAssignment(ctx, char.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	// For NPCs or unassigned characters, only GM can use them
+	if errors.Is(err, pgx.ErrNoRows) || !assignment.UserID.Valid {
+		if !isGM {
+			return ErrCharacterNotOwned
+		}
+	} else if assignment.UserID != userID {
+		return ErrCharacterNotOwned
+	}
+
+	// Check for NPC - only GM can post as NPCs
+	if char.CharacterType == generated.CharacterTypeNpc && !isGM {
+		return ErrCharacterNotOwned
+	}
+	return nil
+}
+
 // GetSceneLocks returns all active locks in a scene.
 func (s *ComposeService) GetSceneLocks(
 	ctx context.Context,
@@ -375,7 +550,7 @@ func (s *ComposeService) GetSceneLocks(
 		return nil, false, err
 	}
 
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -387,7 +562,7 @@ func (s *ComposeService) GetSceneLocks(
 	}
 
 	// Check if user is GM (for full lock visibility)
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -440,3 +615,65 @@ func (s *ComposeService) GetSceneLocks(
 
 	return result, isGM, nil
 }
+
+// CampaignLockInfo is an active lock in a campaign-wide view, for the GM.
+type CampaignLockInfo struct {
+	SceneLockInfo
+
+	SceneTitle string `json:"sceneTitle"`
+	UserAlias  string `json:"userAlias,omitempty"`
+	AcquiredAt string `json:"acquiredAt"`
+}
+
+// GetCampaignLocks returns all active locks across a campaign's scenes (GM only).
+// Identities are always shown, including for hidden posts.
+func (s *ComposeService) GetCampaignLocks(
+	ctx context.Context,
+	gmUserID, campaignID pgtype.UUID,
+) ([]CampaignLockInfo, error) {
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	// Delete expired locks first
+	if deleteErr := s.queries.DeleteExpiredComposeLocks(ctx, pgtype.Timestamptz{
+		Time:             time.Now(),
+		Valid:            true,
+		InfinityModifier: pgtype.Finite,
+	}); deleteErr != nil {
+		return nil, deleteErr
+	}
+
+	locks, err := s.queries.GetComposeLocksByCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]CampaignLockInfo, 0, len(locks))
+	for _, lock := range locks {
+		result = append(result, CampaignLockInfo{
+			SceneLockInfo: SceneLockInfo{
+				ID:              formatUUID(lock.ID.Bytes[:]),
+				SceneID:         formatUUID(lock.SceneID.Bytes[:]),
+				CharacterID:     formatUUID(lock.CharacterID.Bytes[:]),
+				UserID:          formatUUID(lock.UserID.Bytes[:]),
+				CharacterName:   lock.CharacterName,
+				CharacterAvatar: lock.CharacterAvatar.String,
+				ExpiresAt:       lock.ExpiresAt.Time.Format(time.RFC3339),
+				IsHidden:        lock.IsHidden,
+			},
+			SceneTitle: lock.SceneTitle,
+			UserAlias:  lock.UserAlias.String,
+			AcquiredAt: lock.AcquiredAt.Time.Format(time.RFC3339),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,53 @@
+//go:build integration
+
+package service
+
+import "testing"
+
+func TestHeartbeatAutoSaveStaysWithinDraftLimit(t *testing.T) {
+	ConfigureDraftLimit(1)
+	t.Cleanup(func() { ConfigureDraftLimit(0) })
+
+	tc := newTestCampaign(t, nil)
+	player := tc.addPlayer()
+	elsewhere := tc.newScene("Elsewhere")
+	tc.addToScene(elsewhere, player.characterID)
+	tc.transition(PhasePCPhase)
+
+	characterID := uuidToString(player.characterID)
+	drafts := NewDraftService(tc.pool)
+	if _, err := drafts.SaveDraft(tc.ctx, player.userID, SaveDraftRequest{
+		SceneID:     uuidToString(elsewhere.ID),
+		CharacterID: characterID,
+		Blocks:      []PostBlock{{Type: "action", Content: "An older draft.", Order: 0}},
+	}); err != nil {
+		t.Fatalf("save draft: %v", err)
+	}
+
+	compose := NewComposeService(tc.pool)
+	lock, err := compose.AcquireLock(tc.ctx, player.userID, AcquireLockRequest{
+		SceneID:     uuidToString(tc.scene.ID),
+		CharacterID: characterID,
+	})
+	if err != nil {
+		t.Fatalf("acquire lock: %v", err)
+	}
+	resp, err := compose.Heartbeat(tc.ctx, player.userID, HeartbeatRequest{
+		LockID: lock.LockID,
+		Blocks: []PostBlock{{Type: "action", Content: "Work in progress.", Order: 0}},
+	})
+	if err != nil {
+		t.Fatalf("heartbeat: %v", err)
+	}
+	if !resp.DraftSaved || resp.Warning == "" {
+		t.Errorf("heartbeat = %+v, want a saved draft with an eviction warning", resp)
+	}
+
+	saved, err := drafts.ListUserDrafts(tc.ctx, player.userID)
+	if err != nil {
+		t.Fatalf("list drafts: %v", err)
+	}
+	if len(saved) != 1 || saved[0].SceneID != uuidToString(tc.scene.ID) {
+		t.Fatalf("drafts = %+v, want only the heartbeat's draft", saved)
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// TypingPresenceTTL is how long clients show a typing indicator before dropping
+// it unless the typist refreshes it.
+const TypingPresenceTTL = 8 * time.Second
+
+// TypingPresenceRequest is a client reporting that it is typing as a character.
+type TypingPresenceRequest struct {
+	CharacterID string `json:"characterId"`
+	IsHidden    bool   `json:"isHidden"` // Whether the user is composing a hidden post
+}
+
+// TypingPresence is a checked typing report ready to broadcast. Unlike a compose
+// lock it claims nothing and is never stored.
+type TypingPresence struct {
+	SceneID       pgtype.UUID
+	CampaignID    pgtype.UUID
+	CharacterID   pgtype.UUID
+	CharacterName string
+	IsHidden      bool
+	ExpiresAt     time.Time
+}
+
+// ResolveTypingPresence checks that the user may type as the character in the
+// scene and returns the presence to broadcast.
+func (s *ComposeService) ResolveTypingPresence(
+	ctx context.Context,
+	userID pgtype.UUID,
+	sceneID string,
+	req TypingPresenceRequest,
+) (*TypingPresence, error) {
+	sceneUUID := parseUUIDString(sceneID)
+	characterID := parseUUIDString(req.CharacterID)
+
+	scene, err := s.queries.GetScene(ctx, sceneUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSceneNotFound
+		}
+		return nil, err
+	}
+
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
+		CampaignID: scene.CampaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrNotMember
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: scene.CampaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	inScene, err := s.queries.IsCharacterInScene(ctx, generated.IsCharacterInSceneParams{
+		ID:      sceneUUID,
+		Column2: characterID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !inScene {
+		return nil, ErrCharacterNotInScene
+	}
+
+	char, err := s.queries.GetCharacter(ctx, characterID)
+	if err != nil {
+		return nil, err
+	}
+	if controlErr := s.verifyCharacterControl(ctx, userID, &char, isGM); controlErr != nil {
+		return nil, controlErr
+	}
+
+	return &TypingPresence{
+		SceneID:       sceneUUID,
+		CampaignID:    scene.CampaignID,
+		CharacterID:   char.ID,
+		CharacterName: char.DisplayName,
+		IsHidden:      req.IsHidden,
+		ExpiresAt:     time.Now().Add(TypingPresenceTTL),
+	}, nil
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// Query timeouts. Phase transitions and roll batches run more statements per call,
+// so their services get the longer budget.
+const (
+	DefaultQueryTimeout = 5 * time.Second
+	LongQueryTimeout    = 30 * time.Second
+)
+
+// ErrDBTimeout is returned when a database call runs past its timeout.
+var ErrDBTimeout = newCodedError(
+	http.StatusServiceUnavailable, "DB_TIMEOUT",
+	"The server is busy. Please try again in a moment.",
+	"database call timed out",
+)
+
+//nolint:gochecknoglobals // Set once at startup
+var (
+	queryTimeout     atomic.Int64
+	longQueryTimeout atomic.Int64
+)
+
+// ConfigureQueryTimeouts sets the per-call database timeouts. A non-positive
+// value keeps the corresponding default.
+func ConfigureQueryTimeouts(standard, long time.Duration) {
+	queryTimeout.Store(int64(standard))
+	longQueryTimeout.Store(int64(long))
+}
+
+func standardTimeout() time.Duration {
+	if d := queryTimeout.Load(); d > 0 {
+		return time.Duration(d)
+	}
+	return DefaultQueryTimeout
+}
+
+func longTimeout() time.Duration {
+	if d := longQueryTimeout.Load(); d > 0 {
+		return time.Duration(d)
+	}
+	return LongQueryTimeout
+}
+
+// newQueries returns queries whose calls are each bounded by the standard timeout.
+func newQueries(db generated.DBTX) *generated.Queries {
+	return generated.New(&timeoutDB{db: db, timeout: standardTimeout})
+}
+
+// NewQueries returns queries bounded by the standard timeout, for handlers and
+// workers that query the database or build services outside this package.
+func NewQueries(db generated.DBTX) *generated.Queries {
+	return newQueries(db)
+}
+
+// newLongQueries returns queries whose calls are each bounded by the long timeout.
+func newLongQueries(db generated.DBTX) *generated.Queries {
+	return generated.New(&timeoutDB{db: db, timeout: longTimeout})
+}
+
+// withTxTimeout bounds a transaction by the standard timeout. Statements run
+// through queries.WithTx bypass timeoutDB, so the transaction's context carries
+// the deadline instead.
+func withTxTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, standardTimeout())
+}
+
+// withLongTxTimeout bounds a transaction by the long timeout.
+func withLongTxTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, longTimeout())
+}
+
+// timeoutDB applies a timeout to every call made through it. For queries the
+// timeout is released when the rows are closed or the row is scanned, since
+// results are read after the call returns.
+type timeoutDB struct {
+	db      generated.DBTX
+	timeout func() time.Duration
+}
+
+func (t *timeoutDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	callCtx, cancel := context.WithTimeout(ctx, t.timeout())
+	defer cancel()
+
+	tag, err := t.db.Exec(callCtx, sql, args...)
+	return tag, timeoutError(callCtx, ctx, err)
+}
+
+func (t *timeoutDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	callCtx, cancel := context.WithTimeout(ctx, t.timeout())
+
+	rows, err := t.db.Query(callCtx, sql, args...)
+	if err != nil {
+		cancel()
+		return nil, timeoutError(callCtx, ctx, err)
+	}
+	return &timeoutRows{Rows: rows, callCtx: callCtx, parent: ctx, cancel: cancel}, nil
+}
+
+func (t *timeoutDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	callCtx, cancel := context.WithTimeout(ctx, t.timeout())
+
+	return &timeoutRow{row: t.db.QueryRow(callCtx, sql, args...), callCtx: callCtx, parent: ctx, cancel: cancel}
+}
+
+type timeoutRows struct {
+	pgx.Rows
+
+	callCtx context.Context //nolint:containedctx // Needed to report the timeout after the call returns
+	parent  context.Context //nolint:containedctx // Needed to report the timeout after the call returns
+	cancel  context.CancelFunc
+}
+
+func (r *timeoutRows) Close() {
+	r.Rows.Close()
+	r.cancel()
+}
+
+func (r *timeoutRows) Err() error {
+	return timeoutError(r.callCtx, r.parent, r.Rows.Err())
+}
+
+type timeoutRow struct {
+	row     pgx.Row
+	callCtx context.Context //nolint:containedctx // Needed to report the timeout after the call returns
+	parent  context.Context //nolint:containedctx // Needed to report the timeout after the call returns
+	cancel  context.CancelFunc
+}
+
+func (r *timeoutRow) Scan(dest ...any) error {
+	defer r.cancel()
+	return timeoutError(r.callCtx, r.parent, r.row.Scan(dest...))
+}
+
+// timeoutError reports err as ErrDBTimeout when the call's own deadline expired.
+// A cancelled or expired parent context is left as is: the caller gave up, the
+// database did not.
+func timeoutError(callCtx, parent context.Context, err error) error {
+	if err == nil || parent.Err() != nil || !errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrDBTimeout, err)
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/dice"
+)
+
+// Campaign dice preset limits.
+const (
+	MaxCampaignDicePresets = 50
+	MaxDicePresetNameLen   = 50
+)
+
+// Campaign dice preset errors.
+var (
+	ErrInvalidDicePreset  = errors.New("invalid dice preset")
+	ErrDicePresetNotFound = errors.New("dice preset not found")
+	ErrTooManyDicePresets = errors.New("campaign has reached the dice preset limit")
+)
+
+// CreateDicePresetRequest represents the request to add a campaign dice preset.
+type CreateDicePresetRequest struct {
+	Name      string `json:"name"`
+	DiceType  string `json:"diceType"`
+	DiceCount int    `json:"diceCount"`
+	Modifier  int    `json:"modifier"`
+}
+
+// CampaignDicePresetsResponse lists the presets available in a campaign.
+type CampaignDicePresetsResponse struct {
+	Campaign []dice.PresetExpansion `json:"campaign"`
+	Global   []dice.PresetExpansion `json:"global"`
+}
+
+// ListCampaignDicePresets returns the campaign's own presets and the global fallbacks.
+func (s *RollService) ListCampaignDicePresets(
+	ctx context.Context,
+	userID pgtype.UUID,
+	campaignID string,
+) (*CampaignDicePresetsResponse, error) {
+	campaignUUID := parseUUIDStringRoll(campaignID)
+
+	campaign, err := s.queries.GetCampaign(ctx, campaignUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, err
+	}
+
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
+		CampaignID: campaignUUID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrNotMember
+	}
+
+	return &CampaignDicePresetsResponse{
+		Campaign: campaignDicePresets(campaign.Settings),
+		Global:   dice.PresetExpansions(),
+	}, nil
+}
+
+// CreateCampaignDicePreset adds a preset to the campaign, replacing any existing
+// preset with the same name (GM only).
+func (s *RollService) CreateCampaignDicePreset(
+	ctx context.Context,
+	userID pgtype.UUID,
+	campaignID string,
+	req CreateDicePresetRequest,
+) (*dice.PresetExpansion, error) {
+	preset := dice.PresetExpansion{
+		Name:      strings.TrimSpace(req.Name),
+		DiceType:  req.DiceType,
+		DiceCount: req.DiceCount,
+		Modifier:  req.Modifier,
+	}
+	if err := validateDicePreset(preset); err != nil {
+		return nil, err
+	}
+
+	campaign, err := s.getCampaignAsGM(ctx, userID, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	upsert := func(presets []dice.PresetExpansion) ([]dice.PresetExpansion, error) {
+		for i := range presets {
+			if strings.EqualFold(presets[i].Name, preset.Name) {
+				presets[i] = preset
+				return presets, nil
+			}
+		}
+		if len(presets) >= MaxCampaignDicePresets {
+			return nil, ErrTooManyDicePresets
+		}
+		return append(presets, preset), nil
+	}
+	if err := s.updateCampaignDicePresets(ctx, campaign.ID, upsert); err != nil {
+		return nil, err
+	}
+
+	return &preset, nil
+}
+
+// DeleteCampaignDicePreset removes a preset from the campaign (GM only).
+func (s *RollService) DeleteCampaignDicePreset(
+	ctx context.Context,
+	userID pgtype.UUID,
+	campaignID, name string,
+) error {
+	campaign, err := s.getCampaignAsGM(ctx, userID, campaignID)
+	if err != nil {
+		return err
+	}
+
+	remove := func(presets []dice.PresetExpansion) ([]dice.PresetExpansion, error) {
+		remaining := make([]dice.PresetExpansion, 0, len(presets))
+		for _, preset := range presets {
+			if !strings.EqualFold(preset.Name, name) {
+				remaining = append(remaining, preset)
+			}
+		}
+		if len(remaining) == len(presets) {
+			return nil, ErrDicePresetNotFound
+		}
+		return remaining, nil
+	}
+	return s.updateCampaignDicePresets(ctx, campaign.ID, remove)
+}
+
+// getCampaignAsGM loads a campaign after verifying the user is its GM.
+func (s *RollService) getCampaignAsGM(
+	ctx context.Context,
+	userID pgtype.UUID,
+	campaignID string,
+) (*generated.Campaign, error) {
+	campaignUUID := parseUUIDStringRoll(campaignID)
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignUUID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	campaign, err := s.queries.GetCampaign(ctx, campaignUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, err
+	}
+
+	return &campaign, nil
+}
+
+// updateCampaignDicePresets applies change to the campaign's presets and writes them
+// back into the settings. The campaign row stays locked from read to write so
+// concurrent edits (two GMs, or two tabs) apply in turn instead of overwriting.
+func (s *RollService) updateCampaignDicePresets(
+	ctx context.Context,
+	campaignID pgtype.UUID,
+	change func([]dice.PresetExpansion) ([]dice.PresetExpansion, error),
+) error {
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
+	// Start transaction
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := s.queries.WithTx(tx)
+
+	campaign, err := qtx.GetCampaignForUpdate(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+
+	presets, err := change(campaignDicePresets(campaign.Settings))
+	if err != nil {
+		return err
+	}
+
+	settings := map[string]any{}
+	if len(campaign.Settings) > 0 {
+		if unmarshalErr := json.Unmarshal(campaign.Settings, &settings); unmarshalErr != nil {
+			return unmarshalErr
+		}
+	}
+	settings["dicePresets"] = presets
+
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	if _, updateErr := qtx.UpdateCampaignSettings(ctx, generated.UpdateCampaignSettingsParams{
+		ID:       campaign.ID,
+		Settings: settingsJSON,
+	}); updateErr != nil {
+		return updateErr
+	}
+
+	return tx.Commit(ctx)
+}
+
+// campaignDicePresets parses campaign settings and returns the campaign's presets.
+func campaignDicePresets(settingsJSON []byte) []dice.PresetExpansion {
+	presets := []dice.PresetExpansion{}
+	if len(settingsJSON) == 0 {
+		return presets
+	}
+
+	var settings struct {
+		DicePresets []dice.PresetExpansion `json:"dicePresets"`
+	}
+	if err := json.Unmarshal(settingsJSON, &settings); err != nil {
+		return presets
+	}
+
+	if settings.DicePresets != nil {
+		presets = settings.DicePresets
+	}
+	return presets
+}
+
+// findDicePreset returns the preset with the given name (case-insensitive), or nil.
+func findDicePreset(presets []dice.PresetExpansion, name string) *dice.PresetExpansion {
+	for i := range presets {
+		if strings.EqualFold(presets[i].Name, name) {
+			return &presets[i]
+		}
+	}
+	return nil
+}
+
+// validateDicePreset checks a preset's name, dice type, count, and modifier.
+func validateDicePreset(preset dice.PresetExpansion) error {
+	if preset.Name == "" || len(preset.Name) > MaxDicePresetNameLen {
+		return ErrInvalidDicePreset
+	}
+	if !dice.IsValidDiceType(preset.DiceType) {
+		return ErrInvalidDicePreset
+	}
+	if err := dice.ValidateDiceCount(preset.DiceCount); err != nil {
+		return ErrInvalidDiceCount
+	}
+	if err := dice.ValidateModifier(preset.Modifier); err != nil {
+		return ErrInvalidModifier
+	}
+	if err := dice.ValidateRollSize(preset.DiceType, preset.DiceCount, MaxRollSize()); err != nil {
+		return ErrRollTooLarge
+	}
+	return nil
+}
+
+// validateDicePresetsSetting checks the dicePresets setting supplied in a campaign update.
+func validateDicePresetsSetting(raw any) error {
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return ErrInvalidSettings
+	}
+
+	var presets []dice.PresetExpansion
+	if err := json.Unmarshal(encoded, &presets); err != nil {
+		return ErrInvalidSettings
+	}
+	if len(presets) > MaxCampaignDicePresets {
+		return ErrInvalidSettings
+	}
+
+	for _, preset := range presets {
+		if validateDicePreset(preset) != nil {
+			return ErrInvalidSettings
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/dice"
+)
+
+func TestValidateDicePreset(t *testing.T) {
+	tests := []struct {
+		name    string
+		preset  dice.PresetExpansion
+		wantErr error
+	}{
+		{"valid", dice.PresetExpansion{Name: "Attack", DiceType: "d20", DiceCount: 1, Modifier: 5}, nil},
+		{"largest roll", dice.PresetExpansion{Name: "Big", DiceType: "d100", DiceCount: 20}, nil},
+		{"missing name", dice.PresetExpansion{DiceType: "d20", DiceCount: 1}, ErrInvalidDicePreset},
+		{"bad dice type", dice.PresetExpansion{Name: "Odd", DiceType: "d0", DiceCount: 1}, ErrInvalidDicePreset},
+		{"too many dice", dice.PresetExpansion{Name: "Pile", DiceType: "d4", DiceCount: 101}, ErrInvalidDiceCount},
+		{
+			"bad modifier",
+			dice.PresetExpansion{Name: "Boost", DiceType: "d20", DiceCount: 1, Modifier: 101},
+			ErrInvalidModifier,
+		},
+		{"roll too large", dice.PresetExpansion{Name: "Huge", DiceType: "d100", DiceCount: 21}, ErrRollTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateDicePreset(tt.preset); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateDicePreset(%+v) = %v, want %v", tt.preset, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"sync/atomic"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -16,6 +18,26 @@ var (
 	ErrDraftNotFound = errors.New("draft not found")
 )
 
+// MaxDraftsPerUser is the default number of drafts a user may keep before the oldest
+// are deleted to make room.
+const MaxDraftsPerUser = 100
+
+//nolint:gochecknoglobals // Set once at startup
+var draftLimitPerUser atomic.Int64
+
+// ConfigureDraftLimit sets how many drafts a user may keep. A non-positive limit keeps the default.
+func ConfigureDraftLimit(perUser int) {
+	draftLimitPerUser.Store(int64(perUser))
+}
+
+// draftLimit returns the configured per-user draft limit.
+func draftLimit() int64 {
+	if limit := draftLimitPerUser.Load(); limit > 0 {
+		return limit
+	}
+	return MaxDraftsPerUser
+}
+
 // DraftService handles compose draft business logic.
 type DraftService struct {
 	queries *generated.Queries
@@ -25,7 +47,7 @@ type DraftService struct {
 // NewDraftService creates a new DraftService.
 func NewDraftService(pool *pgxpool.Pool) *DraftService {
 	return &DraftService{
-		queries: generated.New(pool),
+		queries: newQueries(pool),
 		pool:    pool,
 	}
 }
@@ -41,20 +63,24 @@ type SaveDraftRequest struct {
 	IsHidden    bool        `json:"isHidden"`
 }
 
-// DraftResponse represents a draft in the API response.
+// DraftResponse represents a draft in the API response. GetDraft sets IsStale when the
+// character submitted a post after the draft was last saved.
 type DraftResponse struct {
-	ID            string      `json:"id"`
-	SceneID       string      `json:"sceneId"`
-	CharacterID   string      `json:"characterId"`
-	UserID        string      `json:"userId"`
-	Blocks        []PostBlock `json:"blocks"`
-	OOCText       *string     `json:"oocText"`
-	Intention     *string     `json:"intention"`
-	Modifier      *int        `json:"modifier"`
-	IsHidden      bool        `json:"isHidden"`
-	SceneTitle    *string     `json:"sceneTitle,omitempty"`
-	CharacterName *string     `json:"characterName,omitempty"`
-	UpdatedAt     string      `json:"updatedAt"`
+	ID               string      `json:"id"`
+	SceneID          string      `json:"sceneId"`
+	CharacterID      string      `json:"characterId"`
+	UserID           string      `json:"userId"`
+	Blocks           []PostBlock `json:"blocks"`
+	OOCText          *string     `json:"oocText"`
+	Intention        *string     `json:"intention"`
+	Modifier         *int        `json:"modifier"`
+	IsHidden         bool        `json:"isHidden"`
+	SceneTitle       *string     `json:"sceneTitle,omitempty"`
+	CharacterName    *string     `json:"characterName,omitempty"`
+	UpdatedAt        string      `json:"updatedAt"`
+	Warning          string      `json:"warning,omitempty"`
+	IsStale          bool        `json:"isStale"`
+	StaleSincePostID *string     `json:"staleSincePostId,omitempty"`
 }
 
 // SaveDraft saves or updates a compose draft.
@@ -78,7 +104,7 @@ func (s *DraftService) SaveDraft(
 	}
 
 	// Check user is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: sceneWithCampaign.CampaignID,
 		UserID:     userID,
 	})
@@ -90,7 +116,7 @@ func (s *DraftService) SaveDraft(
 	}
 
 	// Check GM status
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: sceneWithCampaign.CampaignID,
 		UserID:     userID,
 	})
@@ -157,6 +183,12 @@ func (s *DraftService) SaveDraft(
 		modifier = pgtype.Int4{Int32: int32(*req.Modifier), Valid: true}
 	}
 
+	// Make room under the per-user cap before adding a new draft
+	warning, err := s.enforceDraftLimit(ctx, userID, sceneID, characterID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Upsert draft
 	draft, err := s.queries.UpsertComposeDraft(ctx, generated.UpsertComposeDraftParams{
 		SceneID:     sceneID,
@@ -172,7 +204,90 @@ func (s *DraftService) SaveDraft(
 		return nil, err
 	}
 
-	return s.draftToResponse(&draft), nil
+	resp := s.draftToResponse(&draft)
+	resp.Warning = warning
+	return resp, nil
+}
+
+// saveDraftBlocks auto-saves the blocks being composed under a lock, leaving the
+// draft's other fields alone. Like SaveDraft it stays within the per-user cap and
+// returns its warning.
+func (s *DraftService) saveDraftBlocks(
+	ctx context.Context,
+	userID pgtype.UUID,
+	lock *generated.ComposeLock,
+	blocks []PostBlock,
+) (string, error) {
+	blocksJSON, err := json.Marshal(blocks)
+	if err != nil {
+		return "", err
+	}
+
+	warning, err := s.enforceDraftLimit(ctx, userID, lock.SceneID, lock.CharacterID)
+	if err != nil {
+		return "", err
+	}
+
+	if upsertErr := s.queries.UpsertComposeDraftBlocks(ctx, generated.UpsertComposeDraftBlocksParams{
+		SceneID:     lock.SceneID,
+		CharacterID: lock.CharacterID,
+		UserID:      userID,
+		Blocks:      blocksJSON,
+		IsHidden:    lock.IsHidden,
+	}); upsertErr != nil {
+		return "", upsertErr
+	}
+	return warning, nil
+}
+
+// enforceDraftLimit deletes the user's oldest drafts when saving a new one would exceed
+// the per-user cap. Updating an existing draft never evicts anything. It returns a warning
+// when drafts were deleted or the user is close to the cap.
+func (s *DraftService) enforceDraftLimit(
+	ctx context.Context,
+	userID, sceneID, characterID pgtype.UUID,
+) (string, error) {
+	_, err := s.queries.GetComposeDraft(ctx, generated.GetComposeDraftParams{
+		SceneID:     sceneID,
+		CharacterID: characterID,
+	})
+	if err == nil {
+		return "", nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", err
+	}
+
+	count, err := s.queries.CountUserDrafts(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	limit := draftLimit()
+	if count >= limit {
+		//nolint:gosec // Excess is bounded by the draft count.
+		excess := int32(count - limit + 1)
+		if _, deleteErr := s.queries.DeleteOldestUserDrafts(ctx, generated.DeleteOldestUserDraftsParams{
+			UserID: userID,
+			Limit:  excess,
+		}); deleteErr != nil {
+			return "", deleteErr
+		}
+		return "Draft saved. Oldest draft was auto-deleted.", nil
+	}
+
+	// Warn once the user is within 10% of the cap
+	if total := count + 1; total >= limit-limit/10 {
+		return fmt.Sprintf("Approaching draft limit (%d/%d)", total, limit), nil
+	}
+
+	return "", nil
+}
+
+// PurgeInaccessibleDrafts deletes drafts for scenes or characters their authors can no
+// longer access. It is run periodically by the background draft cleanup worker.
+func (s *DraftService) PurgeInaccessibleDrafts(ctx context.Context) (int64, error) {
+	return s.queries.PurgeInaccessibleDrafts(ctx)
 }
 
 // GetDraft retrieves a compose draft.
@@ -196,7 +311,24 @@ func (s *DraftService) GetDraft(
 		return nil, err
 	}
 
-	return s.draftToResponse(&draft), nil
+	resp := s.draftToResponse(&draft)
+
+	// Flag the draft if a newer post was submitted so the client can prompt to discard it
+	postID, err := s.queries.GetLatestCharacterPostSince(ctx, generated.GetLatestCharacterPostSinceParams{
+		SceneID:     sceneUUID,
+		CharacterID: characterUUID,
+		CreatedAt:   draft.UpdatedAt,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	if err == nil {
+		staleSince := formatUUID(postID.Bytes[:])
+		resp.IsStale = true
+		resp.StaleSincePostID = &staleSince
+	}
+
+	return resp, nil
 }
 
 // DeleteDraft deletes a compose draft.
@@ -246,18 +378,21 @@ func (s *DraftService) ListUserDrafts(
 
 func (s *DraftService) draftToResponse(d *generated.ComposeDraft) *DraftResponse {
 	resp := &DraftResponse{
-		ID:            formatUUID(d.ID.Bytes[:]),
-		SceneID:       formatUUID(d.SceneID.Bytes[:]),
-		CharacterID:   formatUUID(d.CharacterID.Bytes[:]),
-		UserID:        formatUUID(d.UserID.Bytes[:]),
-		Blocks:        nil,
-		OOCText:       nil,
-		Intention:     nil,
-		Modifier:      nil,
-		IsHidden:      d.IsHidden,
-		SceneTitle:    nil,
-		CharacterName: nil,
-		UpdatedAt:     d.UpdatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		ID:               formatUUID(d.ID.Bytes[:]),
+		SceneID:          formatUUID(d.SceneID.Bytes[:]),
+		CharacterID:      formatUUID(d.CharacterID.Bytes[:]),
+		UserID:           formatUUID(d.UserID.Bytes[:]),
+		Blocks:           nil,
+		OOCText:          nil,
+		Intention:        nil,
+		Modifier:         nil,
+		IsHidden:         d.IsHidden,
+		SceneTitle:       nil,
+		CharacterName:    nil,
+		UpdatedAt:        d.UpdatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		Warning:          "",
+		IsStale:          false,
+		StaleSincePostID: nil,
 	}
 
 	// Parse blocks
@@ -284,18 +419,21 @@ func (s *DraftService) draftToResponse(d *generated.ComposeDraft) *DraftResponse
 
 func (s *DraftService) listDraftRowToResponse(d *generated.ListUserDraftsRow) *DraftResponse {
 	resp := &DraftResponse{
-		ID:            formatUUID(d.ID.Bytes[:]),
-		SceneID:       formatUUID(d.SceneID.Bytes[:]),
-		CharacterID:   formatUUID(d.CharacterID.Bytes[:]),
-		UserID:        formatUUID(d.UserID.Bytes[:]),
-		Blocks:        nil,
-		OOCText:       nil,
-		Intention:     nil,
-		Modifier:      nil,
-		IsHidden:      d.IsHidden,
-		SceneTitle:    &d.SceneTitle,
-		CharacterName: &d.CharacterName,
-		UpdatedAt:     d.UpdatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		ID:               formatUUID(d.ID.Bytes[:]),
+		SceneID:          formatUUID(d.SceneID.Bytes[:]),
+		CharacterID:      formatUUID(d.CharacterID.Bytes[:]),
+		UserID:           formatUUID(d.UserID.Bytes[:]),
+		Blocks:           nil,
+		OOCText:          nil,
+		Intention:        nil,
+		Modifier:         nil,
+		IsHidden:         d.IsHidden,
+		SceneTitle:       &d.SceneTitle,
+		CharacterName:    &d.CharacterName,
+		UpdatedAt:        d.UpdatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		Warning:          "",
+		IsStale:          false,
+		StaleSincePostID: nil,
 	}
 
 	// Parse blocks
@@ -1,37 +1,139 @@
 package service
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+)
+
+// CodedError is a service error that carries a machine-readable code, the HTTP
+// status it maps to, and a message that is safe to show users. Handlers respond
+// with these directly instead of translating each sentinel themselves.
+type CodedError struct {
+	Code    string
+	Status  int
+	Message string
+	err     error
+}
+
+// newCodedError creates a sentinel error. detail is the internal description
+// returned by Error and written to logs.
+func newCodedError(status int, code, message, detail string) *CodedError {
+	return &CodedError{Code: code, Status: status, Message: message, err: errors.New(detail)}
+}
+
+func (e *CodedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *CodedError) Unwrap() error {
+	return e.err
+}
+
+// WithMessage returns a copy of e with a more specific user-facing message.
+// The copy still matches e with errors.Is.
+func (e *CodedError) WithMessage(message string) *CodedError {
+	return &CodedError{Code: e.Code, Status: e.Status, Message: message, err: e}
+}
 
 // Campaign errors.
 var (
-	ErrCampaignLimitReached = errors.New("user has reached maximum campaign limit (5)")
-	ErrNotGM                = errors.New("only the GM can perform this action")
-	ErrCampaignNotFound     = errors.New("campaign not found")
-	ErrInvalidSettings      = errors.New("invalid campaign settings")
-	ErrNotMember            = errors.New("user is not a member of this campaign")
+	ErrCampaignLimitReached = newCodedError(
+		http.StatusForbidden, "CAMPAIGN_LIMIT",
+		"You have reached the maximum number of campaigns.",
+		"user has reached maximum campaign limit",
+	)
+	ErrNotGM = newCodedError(
+		http.StatusForbidden, "NOT_GM",
+		"Only the GM can perform this action.",
+		"only the GM can perform this action",
+	)
+	ErrCampaignNotFound = newCodedError(
+		http.StatusNotFound, "NOT_FOUND",
+		"Campaign not found",
+		"campaign not found",
+	)
+	ErrInvalidSettings = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Invalid campaign settings",
+		"invalid campaign settings",
+	)
+	ErrNotMember = newCodedError(
+		http.StatusForbidden, "NOT_MEMBER",
+		"You are not a member of this campaign.",
+		"user is not a member of this campaign",
+	)
+	ErrConfirmTitleMismatch = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Confirmation title does not match the campaign title",
+		"confirmation title does not match campaign title",
+	)
 )
 
 // Invite errors.
 var (
-	ErrInviteLimitReached = errors.New("too many active invites (max ~100)")
-	ErrInviteExpired      = errors.New("invite link has expired")
-	ErrInviteUsed         = errors.New("invite link has already been used")
-	ErrInviteRevoked      = errors.New("invite link has been revoked")
-	ErrInviteNotFound     = errors.New("invite link not found")
-	ErrCampaignFull       = errors.New("campaign has reached player limit (50)")
+	ErrInviteLimitReached = newCodedError(
+		http.StatusForbidden, "INVITE_LIMIT",
+		"Too many active invites. Please revoke some before creating new ones.",
+		"too many active invites (max ~100)",
+	)
+	ErrInviteExpired = newCodedError(
+		http.StatusGone, "INVITE_EXPIRED",
+		"This invite link has expired. Ask the GM for a new one.",
+		"invite link has expired",
+	)
+	ErrInviteUsed = newCodedError(
+		http.StatusGone, "INVITE_USED",
+		"This invite link has already been used.",
+		"invite link has already been used",
+	)
+	ErrInviteRevoked = newCodedError(
+		http.StatusGone, "INVITE_REVOKED",
+		"This invite link has been revoked.",
+		"invite link has been revoked",
+	)
+	ErrInviteNotFound = newCodedError(
+		http.StatusNotFound, "NOT_FOUND",
+		"Invite not found",
+		"invite link not found",
+	)
+	ErrCampaignFull = newCodedError(
+		http.StatusForbidden, "CAMPAIGN_FULL",
+		"This campaign has reached the maximum number of players.",
+		"campaign has reached its player limit",
+	)
 )
 
 // Membership errors.
 var (
-	ErrAlreadyMember   = errors.New("user is already a member of this campaign")
-	ErrCannotLeaveAsGM = errors.New("GM cannot leave campaign (transfer role first)")
-	ErrGmNotAbandoned  = errors.New("GM is still active (not past 30-day threshold)")
+	ErrAlreadyMember = newCodedError(
+		http.StatusConflict, "ALREADY_MEMBER",
+		"You are already a member of this campaign.",
+		"user is already a member of this campaign",
+	)
+	ErrCannotLeaveAsGM = newCodedError(
+		http.StatusForbidden, "CANNOT_LEAVE_AS_GM",
+		"You must transfer the GM role before leaving the campaign.",
+		"GM cannot leave campaign (transfer role first)",
+	)
+	ErrGmNotAbandoned = newCodedError(
+		http.StatusForbidden, "GM_NOT_ABANDONED",
+		"The GM is still active. You can only claim the role after the campaign's inactivity window.",
+		"GM is still active (not past inactivity threshold)",
+	)
+	ErrGmClaimDisabled = newCodedError(
+		http.StatusForbidden, "GM_CLAIM_DISABLED",
+		"GM role claims are disabled for this campaign.",
+		"GM abandonment claims are disabled for this campaign",
+	)
 )
 
 // Limits.
 const (
-	MaxCampaignsPerUser = 5
-	MaxCampaignMembers  = 50
-	MaxActiveInvites    = 100
-	GmInactivityDays    = 30
+	MaxCampaignsPerUser      = 5 // Default; overridable with CAMPAIGN_LIMIT_PER_USER
+	MaxCampaignMembers       = 50
+	PlayerLimitWarningMargin = 5
+	MaxActiveInvites         = 100
+	GmInactivityDays         = 30
+	MinGmInactivityDays      = 7
+	MaxGmInactivityDays      = 180
 )
@@ -3,11 +3,14 @@ package service
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"image"
 	"io"
+	"mime"
 	"mime/multipart"
+	"net/http"
 	"path/filepath"
 	"strings"
 
@@ -16,6 +19,7 @@ import (
 	_ "image/png"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	// Register webp decoder for image validation.
@@ -28,9 +32,17 @@ import (
 const (
 	MaxFileSize   = 20 * 1024 * 1024  // 20MB
 	MaxDimension  = 4000              // 4000px max width/height
-	StorageLimit  = 500 * 1024 * 1024 // 500MB per campaign
+	StorageLimit  = 500 * 1024 * 1024 // Default per-campaign limit; admins can override it
 	StorageBucket = "campaign-assets"
 
+	// Bounds for per-campaign limits set through the admin API.
+	bytesPerMB        = 1024 * 1024
+	MinStorageLimitMB = 100
+	MaxStorageLimitMB = 10 * 1024 // 10GB
+
+	// Per-user storage for profile uploads, separate from campaigns.
+	UserStorageLimit = 5 * 1024 * 1024 // 5MB per user
+
 	// Storage warning thresholds (percentage).
 	storageWarningMedium   = 80
 	storageWarningHigh     = 90
@@ -45,7 +57,11 @@ var (
 	ErrFileTooLarge        = errors.New("file too large (max 20MB)")
 	ErrImageTooLarge       = errors.New("image dimensions too large (max 4000x4000px)")
 	ErrInvalidFormat       = errors.New("unsupported format (use PNG, JPG, or WebP)")
-	ErrStorageLimitReached = errors.New("campaign storage limit reached (500MB)")
+	ErrStorageLimitReached = errors.New("campaign storage limit reached")
+	ErrInvalidStorageLimit = fmt.Errorf("storage limit must be between %dMB and %dMB",
+		MinStorageLimitMB, MaxStorageLimitMB)
+
+	ErrUserStorageLimitReached = errors.New("profile storage limit reached (5MB)")
 )
 
 // ImageService handles image upload operations.
@@ -80,8 +96,12 @@ func (s *ImageService) GetStorageStatus(
 		return nil, fmt.Errorf("failed to get campaign: %w", err)
 	}
 
+	limitBytes, err := s.campaignStorageLimit(ctx, campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	usedBytes := campaign.StorageUsedBytes
-	limitBytes := int64(StorageLimit)
 	percentage := float64(usedBytes) / float64(limitBytes) * percentageMultiplier
 
 	status := &StorageStatus{
@@ -103,47 +123,83 @@ func (s *ImageService) GetStorageStatus(
 	return status, nil
 }
 
-// UploadAvatar uploads an avatar image for a character.
-//
-//nolint:dupl // Upload methods share similar structure but handle different entities
+// SetStorageLimit sets a campaign's storage limit (platform admins only; the
+// route is restricted to the service role). It returns the new storage status.
+func (s *ImageService) SetStorageLimit(
+	ctx context.Context,
+	campaignID uuid.UUID,
+	limitMB int,
+) (*StorageStatus, error) {
+	if limitMB < MinStorageLimitMB || limitMB > MaxStorageLimitMB {
+		return nil, ErrInvalidStorageLimit
+	}
+
+	campaignUUID := pgtype.UUID{Bytes: campaignID, Valid: true}
+	if _, err := s.queries.GetCampaign(ctx, campaignUUID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, fmt.Errorf("failed to get campaign: %w", err)
+	}
+
+	if _, err := s.queries.SetCampaignStorageLimit(ctx, generated.SetCampaignStorageLimitParams{
+		CampaignID:        campaignUUID,
+		StorageLimitBytes: int64(limitMB) * bytesPerMB,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.GetStorageStatus(ctx, campaignID)
+}
+
+// campaignStorageLimit returns the campaign's storage limit in bytes: the limit an
+// admin set, or the default.
+func (s *ImageService) campaignStorageLimit(ctx context.Context, campaignID pgtype.UUID) (int64, error) {
+	limit, err := s.queries.GetCampaignStorageLimit(ctx, campaignID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return StorageLimit, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get storage limit: %w", err)
+	}
+	return limit, nil
+}
+
+// checkStorageLimit reports whether adding size bytes would take the campaign past
+// its storage limit. The error names the limit so the GM knows what they hit.
+func (s *ImageService) checkStorageLimit(ctx context.Context, campaign *generated.Campaign, size int64) error {
+	limit, err := s.campaignStorageLimit(ctx, campaign.ID)
+	if err != nil {
+		return err
+	}
+	if campaign.StorageUsedBytes+size > limit {
+		return fmt.Errorf("%w (%dMB)", ErrStorageLimitReached, limit/bytesPerMB)
+	}
+	return nil
+}
+
+// UploadAvatar uploads an avatar image for a character. The avatar it replaces
+// is kept in the character's avatar history.
 func (s *ImageService) UploadAvatar(
 	ctx context.Context,
 	campaignID, characterID, gmUserID uuid.UUID,
 	file multipart.File,
 	header *multipart.FileHeader,
 ) (string, error) {
-	// Verify GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
-		CampaignID: pgtype.UUID{Bytes: campaignID, Valid: true},
-		UserID:     pgtype.UUID{Bytes: gmUserID, Valid: true},
-	})
+	// Verify GM and that the character belongs to the campaign
+	char, err := s.gmCharacter(ctx, campaignID, characterID, gmUserID)
 	if err != nil {
-		return "", fmt.Errorf("failed to verify GM status: %w", err)
-	}
-	if !isGM {
-		return "", ErrNotGM
-	}
-
-	// Verify character belongs to campaign
-	charCampaignID, err := s.queries.GetCharacterCampaignID(
-		ctx,
-		pgtype.UUID{Bytes: characterID, Valid: true},
-	)
-	if err != nil {
-		return "", fmt.Errorf("character not found: %w", err)
-	}
-	if charCampaignID.Bytes != campaignID {
-		return "", errors.New("character does not belong to this campaign")
+		return "", err
 	}
 
-	// Validate and upload
+	// Validate and upload under a new name so the previous avatar stays restorable
 	url, fileSize, err := s.validateAndUpload(
 		ctx,
 		campaignID,
 		file,
 		header,
 		"avatars",
-		characterID.String(),
+		characterID.String()+"-"+uuid.NewString(),
 	)
 	if err != nil {
 		return "", err
@@ -167,6 +223,12 @@ func (s *ImageService) UploadAvatar(
 		return "", fmt.Errorf("failed to update storage usage: %w", err)
 	}
 
+	if char.AvatarUrl.Valid && char.AvatarUrl.String != "" && char.AvatarUrl.String != url {
+		if err = s.recordAvatarHistory(ctx, campaignID, char.ID, char.AvatarUrl.String); err != nil {
+			return "", fmt.Errorf("failed to record avatar history: %w", err)
+		}
+	}
+
 	return url, nil
 }
 
@@ -176,7 +238,7 @@ func (s *ImageService) DeleteAvatar(
 	campaignID, characterID, gmUserID uuid.UUID,
 ) error {
 	// Verify GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: pgtype.UUID{Bytes: campaignID, Valid: true},
 		UserID:     pgtype.UUID{Bytes: gmUserID, Valid: true},
 	})
@@ -198,7 +260,7 @@ func (s *ImageService) DeleteAvatar(
 	}
 
 	// Delete from storage
-	path := fmt.Sprintf("campaigns/%s/avatars/%s", campaignID, filepath.Base(char.AvatarUrl.String))
+	path := avatarStoragePath(campaignID, char.AvatarUrl.String)
 	fileSize, _ := s.storage.GetFileSize(ctx, StorageBucket, path)
 
 	if deleteErr := s.storage.Delete(ctx, StorageBucket, path); deleteErr != nil {
@@ -233,7 +295,7 @@ func (s *ImageService) UploadSceneHeader(
 	header *multipart.FileHeader,
 ) (string, error) {
 	// Verify GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: pgtype.UUID{Bytes: campaignID, Valid: true},
 		UserID:     pgtype.UUID{Bytes: gmUserID, Valid: true},
 	})
@@ -296,7 +358,7 @@ func (s *ImageService) DeleteSceneHeader(
 	campaignID, sceneID, gmUserID uuid.UUID,
 ) error {
 	// Verify GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: pgtype.UUID{Bytes: campaignID, Valid: true},
 		UserID:     pgtype.UUID{Bytes: gmUserID, Valid: true},
 	})
@@ -381,6 +443,168 @@ func (s *ImageService) DeleteSceneHeaderByURL(
 	}
 }
 
+// ReconcileStorageUsage resets campaign storage usage that was released more than
+// once and went negative, and returns how many campaigns were fixed. File sizes
+// are only known best-effort, so a double delete could otherwise hide real usage
+// from the storage limit.
+func (s *ImageService) ReconcileStorageUsage(ctx context.Context) (int64, error) {
+	return s.queries.ResetNegativeCampaignStorage(ctx)
+}
+
+// CampaignAssetPrefix returns the URL prefix shared by every image stored for a
+// campaign.
+func (s *ImageService) CampaignAssetPrefix(campaignID uuid.UUID) string {
+	return s.storage.PublicURL(StorageBucket, fmt.Sprintf("campaigns/%s/", campaignID))
+}
+
+// CopySceneHeader copies a scene header image from a campaign into a scene and
+// charges its size to the scene's campaign, subject to its storage limit. Used when
+// duplicating campaigns and cloning scenes. It returns the copied image's URL, or ""
+// if the source header no longer exists.
+func (s *ImageService) CopySceneHeader(
+	ctx context.Context,
+	sourceCampaignID, campaignID, sceneID uuid.UUID,
+	sourceURL string,
+) (string, error) {
+	sourcePath := fmt.Sprintf("campaigns/%s/scenes/%s", sourceCampaignID, filepath.Base(sourceURL))
+	fileSize, err := s.storage.GetFileSize(ctx, StorageBucket, sourcePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get header size: %w", err)
+	}
+	if fileSize == 0 {
+		return "", nil // Source header no longer exists
+	}
+
+	campaign, err := s.queries.GetCampaign(ctx, pgtype.UUID{Bytes: campaignID, Valid: true})
+	if err != nil {
+		return "", fmt.Errorf("failed to get campaign: %w", err)
+	}
+	if err := s.checkStorageLimit(ctx, &campaign, fileSize); err != nil {
+		return "", err
+	}
+
+	destPath := fmt.Sprintf(
+		"campaigns/%s/scenes/%s%s",
+		campaignID,
+		sceneID,
+		filepath.Ext(sourceURL),
+	)
+	url, err := s.storage.Copy(ctx, StorageBucket, sourcePath, destPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to copy scene header: %w", err)
+	}
+
+	_, err = s.queries.UpdateSceneHeaderImage(ctx, generated.UpdateSceneHeaderImageParams{
+		ID:             pgtype.UUID{Bytes: sceneID, Valid: true},
+		HeaderImageUrl: pgtype.Text{String: url, Valid: true},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update scene header: %w", err)
+	}
+
+	_, err = s.queries.IncrementCampaignStorage(ctx, generated.IncrementCampaignStorageParams{
+		ID:               pgtype.UUID{Bytes: campaignID, Valid: true},
+		StorageUsedBytes: fileSize,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update storage usage: %w", err)
+	}
+
+	return url, nil
+}
+
+// UploadUserAvatar uploads a profile avatar for a user. It is stored under the user's own
+// prefix and charged to their profile storage rather than to any campaign.
+func (s *ImageService) UploadUserAvatar(
+	ctx context.Context,
+	userID uuid.UUID,
+	file multipart.File,
+	header *multipart.FileHeader,
+) (string, error) {
+	// Check file size
+	if header.Size > MaxFileSize {
+		return "", ErrFileTooLarge
+	}
+
+	// The avatar is the only per-user upload, so replacing it frees everything charged so far
+	if header.Size > UserStorageLimit {
+		return "", ErrUserStorageLimitReached
+	}
+
+	userUUID := pgtype.UUID{Bytes: userID, Valid: true}
+	var oldURL string
+	profile, err := s.queries.GetUserProfile(ctx, userUUID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("failed to get profile: %w", err)
+	}
+	if profile.AvatarUrl.Valid {
+		oldURL = profile.AvatarUrl.String
+	}
+
+	fileContent, contentType, ext, err := readImage(file, header)
+	if err != nil {
+		return "", err
+	}
+
+	// Upload to storage
+	path := fmt.Sprintf("users/%s/avatar.%s", userID, ext)
+	url, err := s.storage.Upload(ctx, StorageBucket, path, contentType, bytes.NewReader(fileContent))
+	if err != nil {
+		return "", fmt.Errorf("failed to upload: %w", err)
+	}
+
+	// A previous avatar in another format is not overwritten by the upload
+	if oldURL != "" && filepath.Base(oldURL) != filepath.Base(path) {
+		oldPath := fmt.Sprintf("users/%s/%s", userID, filepath.Base(oldURL))
+		_ = s.storage.Delete(ctx, StorageBucket, oldPath) // Intentionally ignoring storage delete errors
+	}
+
+	_, err = s.queries.SetUserProfileAvatar(ctx, generated.SetUserProfileAvatarParams{
+		UserID:           userUUID,
+		AvatarUrl:        pgtype.Text{String: url, Valid: true},
+		StorageUsedBytes: header.Size,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update profile avatar: %w", err)
+	}
+
+	return url, nil
+}
+
+// DeleteUserAvatar deletes a user's profile avatar and releases its storage.
+func (s *ImageService) DeleteUserAvatar(ctx context.Context, userID uuid.UUID) error {
+	userUUID := pgtype.UUID{Bytes: userID, Valid: true}
+	profile, err := s.queries.GetUserProfile(ctx, userUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil // No profile, so no avatar to delete
+		}
+		return fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	if !profile.AvatarUrl.Valid || profile.AvatarUrl.String == "" {
+		return nil // No avatar to delete
+	}
+
+	// Delete from storage
+	path := fmt.Sprintf("users/%s/%s", userID, filepath.Base(profile.AvatarUrl.String))
+	if deleteErr := s.storage.Delete(ctx, StorageBucket, path); deleteErr != nil {
+		// Intentionally ignoring storage delete errors
+		_ = deleteErr
+	}
+
+	_, err = s.queries.SetUserProfileAvatar(ctx, generated.SetUserProfileAvatarParams{
+		UserID:           userUUID,
+		AvatarUrl:        pgtype.Text{String: "", Valid: false},
+		StorageUsedBytes: 0,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clear profile avatar: %w", err)
+	}
+
+	return nil
+}
+
 // validateAndUpload validates the image and uploads it to storage.
 func (s *ImageService) validateAndUpload(
 	ctx context.Context,
@@ -399,32 +623,58 @@ func (s *ImageService) validateAndUpload(
 	if err != nil {
 		return "", 0, fmt.Errorf("failed to get campaign: %w", err)
 	}
-	if campaign.StorageUsedBytes+header.Size > StorageLimit {
-		return "", 0, ErrStorageLimitReached
+	if err := s.checkStorageLimit(ctx, &campaign, header.Size); err != nil {
+		return "", 0, err
+	}
+
+	fileContent, contentType, ext, err := readImage(file, header)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// Upload to storage
+	path := fmt.Sprintf("campaigns/%s/%s/%s.%s", campaignID, folder, filename, ext)
+	url, err := s.storage.Upload(
+		ctx,
+		StorageBucket,
+		path,
+		contentType,
+		bytes.NewReader(fileContent),
+	)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to upload: %w", err)
 	}
 
+	return url, header.Size, nil
+}
+
+// readImage reads an uploaded image and checks its format and dimensions. The format
+// is sniffed from the content and must match the type and extension the upload
+// declares; animated images are rejected. It returns the file content with the
+// content type and file extension to store it under.
+func readImage(file multipart.File, header *multipart.FileHeader) ([]byte, string, string, error) {
 	// Read file content
 	fileContent, err := io.ReadAll(file)
 	if err != nil {
-		return "", 0, fmt.Errorf("failed to read file: %w", err)
+		return nil, "", "", fmt.Errorf("failed to read file: %w", err)
 	}
 
 	// Decode image to validate
 	img, format, err := image.Decode(bytes.NewReader(fileContent))
 	if err != nil {
-		return "", 0, ErrInvalidFormat
+		return nil, "", "", ErrInvalidFormat
 	}
 
 	// Validate format
 	format = strings.ToLower(format)
 	if format != "png" && format != imageFormatJPEG && format != "webp" {
-		return "", 0, ErrInvalidFormat
+		return nil, "", "", ErrInvalidFormat
 	}
 
 	// Check dimensions
 	bounds := img.Bounds()
 	if bounds.Dx() > MaxDimension || bounds.Dy() > MaxDimension {
-		return "", 0, ErrImageTooLarge
+		return nil, "", "", ErrImageTooLarge
 	}
 
 	// Determine content type
@@ -433,24 +683,79 @@ func (s *ImageService) validateAndUpload(
 		contentType = "image/jpeg"
 	}
 
+	// The decoder and content sniffing must agree, and so must what the client declared
+	if http.DetectContentType(fileContent) != contentType || !declaredFormatMatches(header, contentType) {
+		return nil, "", "", ErrInvalidFormat
+	}
+
+	// Only the first frame would be decoded and shown
+	if isAnimatedImage(fileContent, format) {
+		return nil, "", "", ErrInvalidFormat
+	}
+
 	// Determine extension
 	ext := format
 	if format == imageFormatJPEG {
 		ext = "jpg"
 	}
 
-	// Upload to storage
-	path := fmt.Sprintf("campaigns/%s/%s/%s.%s", campaignID, folder, filename, ext)
-	url, err := s.storage.Upload(
-		ctx,
-		StorageBucket,
-		path,
-		contentType,
-		bytes.NewReader(fileContent),
-	)
-	if err != nil {
-		return "", 0, fmt.Errorf("failed to upload: %w", err)
+	return fileContent, contentType, ext, nil
+}
+
+// declaredFormatMatches reports whether the upload's declared content type and
+// file extension agree with its sniffed content type. Declarations that name no
+// image format, such as application/octet-stream or a missing extension, are not
+// held against the upload.
+func declaredFormatMatches(header *multipart.FileHeader, contentType string) bool {
+	if mediaType, _, err := mime.ParseMediaType(header.Header.Get("Content-Type")); err == nil &&
+		strings.HasPrefix(mediaType, "image/") {
+		if mediaType == "image/jpg" {
+			mediaType = "image/jpeg"
+		}
+		if mediaType != contentType {
+			return false
+		}
+	}
+
+	switch strings.ToLower(filepath.Ext(header.Filename)) {
+	case ".png":
+		return contentType == "image/png"
+	case ".jpg", ".jpeg":
+		return contentType == "image/jpeg"
+	case ".webp":
+		return contentType == "image/webp"
+	default:
+		return true
 	}
+}
 
-	return url, header.Size, nil
+// isAnimatedImage reports whether a PNG or WebP holds an animation: an APNG acTL
+// chunk ahead of the image data, or a WebP VP8X header with the animation flag set.
+func isAnimatedImage(content []byte, format string) bool {
+	switch format {
+	case "png":
+		const signatureLen, chunkHeaderLen, crcLen = 8, 8, 4
+		for offset := signatureLen; offset+chunkHeaderLen <= len(content); {
+			length := int(binary.BigEndian.Uint32(content[offset:]))
+			switch string(content[offset+4 : offset+chunkHeaderLen]) {
+			case "acTL":
+				return true
+			case "IDAT":
+				return false
+			}
+			if length < 0 || length > len(content) {
+				return false
+			}
+			offset += chunkHeaderLen + length + crcLen
+		}
+		return false
+	case "webp":
+		// RIFF header (12 bytes), then the first chunk; VP8X flags follow its 8-byte header
+		const vp8xFlagsOffset, animationFlag = 20, 0x02
+		return len(content) > vp8xFlagsOffset &&
+			string(content[12:16]) == "VP8X" &&
+			content[vp8xFlagsOffset]&animationFlag != 0
+	default:
+		return false
+	}
 }
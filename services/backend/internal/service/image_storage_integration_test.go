@@ -0,0 +1,70 @@
+//go:build integration
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/storage"
+)
+
+func TestDoubleAvatarDeleteKeepsStorageUsageNonNegative(t *testing.T) {
+	const avatarSize = 1000
+
+	// A storage API that still reports the file after it was deleted, as when two
+	// deletes race
+	fakeStorage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"size": 1000}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer fakeStorage.Close()
+
+	tc := newTestCampaign(t, nil)
+	player := tc.addPlayer()
+	images := NewImageService(tc.queries, storage.NewClient(fakeStorage.URL, "test-key"))
+
+	if _, err := tc.queries.IncrementCampaignStorage(tc.ctx, generated.IncrementCampaignStorageParams{
+		ID:               tc.campaign.ID,
+		StorageUsedBytes: avatarSize,
+	}); err != nil {
+		t.Fatalf("charge avatar storage: %v", err)
+	}
+
+	for range 2 {
+		if _, err := tc.queries.UpdateCharacterAvatar(tc.ctx, generated.UpdateCharacterAvatarParams{
+			ID:        player.characterID,
+			AvatarUrl: pgtype.Text{String: fakeStorage.URL + "/avatar.png", Valid: true},
+		}); err != nil {
+			t.Fatalf("set avatar: %v", err)
+		}
+		if err := images.DeleteAvatar(tc.ctx, uuid.UUID(tc.campaign.ID.Bytes),
+			uuid.UUID(player.characterID.Bytes), uuid.UUID(tc.gm.Bytes)); err != nil {
+			t.Fatalf("delete avatar: %v", err)
+		}
+	}
+
+	used, err := tc.queries.GetCampaignStorage(tc.ctx, tc.campaign.ID)
+	if err != nil {
+		t.Fatalf("get storage usage: %v", err)
+	}
+	if used != 0 {
+		t.Errorf("storage used = %d after deleting the avatar twice, want 0", used)
+	}
+
+	fixed, err := images.ReconcileStorageUsage(tc.ctx)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if fixed != 0 {
+		t.Errorf("reconcile fixed %d campaigns, want none to have gone negative", fixed)
+	}
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"mime/multipart"
+	"net/textproto"
+	"testing"
+)
+
+// stillWebP is a 1x1 lossless WebP.
+const stillWebP = "UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA=="
+
+// uploadFile adapts in-memory content to an uploaded multipart file.
+type uploadFile struct {
+	*bytes.Reader
+}
+
+func (uploadFile) Close() error { return nil }
+
+func upload(content []byte, filename, contentType string) (multipart.File, *multipart.FileHeader) {
+	header := &multipart.FileHeader{
+		Filename: filename,
+		Header:   textproto.MIMEHeader{"Content-Type": {contentType}},
+		Size:     int64(len(content)),
+	}
+	return uploadFile{bytes.NewReader(content)}, header
+}
+
+func pngFixture(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func jpegFixture(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 16, 16)), nil); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// apngFixture inserts an acTL chunk after the IHDR chunk of a still PNG, which is
+// how an APNG announces its animation.
+func apngFixture(t *testing.T) []byte {
+	t.Helper()
+	still := pngFixture(t)
+	const ihdrEnd = 8 + 8 + 13 + 4 // Signature, chunk header, IHDR data, CRC
+
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint32(data[0:], 2) // Frames
+	binary.BigEndian.PutUint32(data[4:], 0) // Loop forever
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, "acTL"...)
+	chunk = append(chunk, data...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	animated := append([]byte{}, still[:ihdrEnd]...)
+	animated = append(animated, chunk...)
+	return append(animated, still[ihdrEnd:]...)
+}
+
+func animatedGIFFixture(t *testing.T) []byte {
+	t.Helper()
+	palette := color.Palette{color.Black, color.White}
+	frames := []*image.Paletted{
+		image.NewPaletted(image.Rect(0, 0, 2, 2), palette),
+		image.NewPaletted(image.Rect(0, 0, 2, 2), palette),
+	}
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, &gif.GIF{Image: frames, Delay: []int{10, 10}}); err != nil {
+		t.Fatalf("encode gif: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// animatedWebPFixture wraps the still WebP's frame in an extended (VP8X) file with
+// the animation flag set.
+func animatedWebPFixture(t *testing.T) []byte {
+	t.Helper()
+	still, err := base64.StdEncoding.DecodeString(stillWebP)
+	if err != nil {
+		t.Fatalf("decode webp fixture: %v", err)
+	}
+	frame := still[12:] // The VP8L chunk
+
+	riffChunk := func(fourCC string, data []byte) []byte {
+		chunk := append([]byte(fourCC), binary.LittleEndian.AppendUint32(nil, uint32(len(data)))...)
+		chunk = append(chunk, data...)
+		if len(data)%2 == 1 {
+			chunk = append(chunk, 0)
+		}
+		return chunk
+	}
+
+	vp8x := make([]byte, 10)
+	vp8x[0] = 0x02 // Animation flag; canvas is 1x1 (stored minus one)
+	anim := make([]byte, 6)
+	anmf := append(make([]byte, 16), frame...)
+
+	body := []byte("WEBP")
+	body = append(body, riffChunk("VP8X", vp8x)...)
+	body = append(body, riffChunk("ANIM", anim)...)
+	body = append(body, riffChunk("ANMF", anmf)...)
+	return riffChunk("RIFF", body)
+}
+
+func TestReadImageAcceptsDeclaredFormats(t *testing.T) {
+	still, err := base64.StdEncoding.DecodeString(stillWebP)
+	if err != nil {
+		t.Fatalf("decode webp fixture: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		content         []byte
+		filename        string
+		contentType     string
+		wantContentType string
+		wantExt         string
+	}{
+		{"png", pngFixture(t), "map.png", "image/png", "image/png", "png"},
+		{"jpeg", jpegFixture(t), "map.jpg", "image/jpeg", "image/jpeg", "jpg"},
+		{"webp", still, "map.webp", "image/webp", "image/webp", "webp"},
+		{"generic declared type", pngFixture(t), "map", "application/octet-stream", "image/png", "png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, header := upload(tt.content, tt.filename, tt.contentType)
+			_, contentType, ext, err := readImage(file, header)
+			if err != nil {
+				t.Fatalf("readImage() error = %v", err)
+			}
+			if contentType != tt.wantContentType || ext != tt.wantExt {
+				t.Errorf("readImage() = %q, %q, want %q, %q", contentType, ext, tt.wantContentType, tt.wantExt)
+			}
+		})
+	}
+}
+
+func TestReadImageRejectsMismatchedAndAnimatedImages(t *testing.T) {
+	pngData, jpegData := pngFixture(t), jpegFixture(t)
+
+	tests := []struct {
+		name        string
+		content     []byte
+		filename    string
+		contentType string
+	}{
+		{"spoofed MIME type", pngFixture(t), "map.png", "image/jpeg"},
+		{"wrong extension", pngFixture(t), "map.jpg", "image/png"},
+		{"not an image", []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>"), "map.png", "image/png"},
+		{"animated GIF", animatedGIFFixture(t), "map.gif", "image/gif"},
+		{"animated PNG", apngFixture(t), "map.png", "image/png"},
+		{"animated WebP", animatedWebPFixture(t), "map.webp", "image/webp"},
+		{"truncated PNG", pngData[:len(pngData)/2], "map.png", "image/png"},
+		{"truncated JPEG", jpegData[:len(jpegData)/2], "map.jpg", "image/jpeg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, header := upload(tt.content, tt.filename, tt.contentType)
+			if _, _, _, err := readImage(file, header); !errors.Is(err, ErrInvalidFormat) {
+				t.Errorf("readImage() error = %v, want ErrInvalidFormat", err)
+			}
+		})
+	}
+}
+
+func TestIsAnimatedImage(t *testing.T) {
+	still, err := base64.StdEncoding.DecodeString(stillWebP)
+	if err != nil {
+		t.Fatalf("decode webp fixture: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		content []byte
+		format  string
+		want    bool
+	}{
+		{"still PNG", pngFixture(t), "png", false},
+		{"APNG", apngFixture(t), "png", true},
+		{"still WebP", still, "webp", false},
+		{"animated WebP", animatedWebPFixture(t), "webp", true},
+		{"truncated WebP", still[:10], "webp", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAnimatedImage(tt.content, tt.format); got != tt.want {
+				t.Errorf("isAnimatedImage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
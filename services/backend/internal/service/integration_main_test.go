@@ -0,0 +1,165 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/testdb"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testdb.Main(m))
+}
+
+// testCampaign is a migrated database holding one campaign, its GM and one scene.
+type testCampaign struct {
+	t        *testing.T
+	ctx      context.Context
+	pool     *pgxpool.Pool
+	queries  *generated.Queries
+	gm       pgtype.UUID
+	campaign *generated.Campaign
+	scene    *generated.Scene
+	players  int
+}
+
+// testPlayer is a player member and the PC assigned to them.
+type testPlayer struct {
+	userID      pgtype.UUID
+	characterID pgtype.UUID
+}
+
+// newTestCampaign creates a campaign (with the given settings over the defaults)
+// and one scene on a fresh database.
+func newTestCampaign(t *testing.T, settings map[string]any) *testCampaign {
+	t.Helper()
+
+	ctx := context.Background()
+	pool := testdb.New(t)
+	gm := testdb.CreateUser(t, pool, "gm@example.com")
+
+	campaign, err := NewCampaignService(pool).CreateCampaign(ctx, gm, CreateCampaignRequest{
+		Title:       "Test Campaign",
+		Description: "",
+		Settings:    settings,
+	})
+	if err != nil {
+		t.Fatalf("create campaign: %v", err)
+	}
+
+	tc := &testCampaign{
+		t:        t,
+		ctx:      ctx,
+		pool:     pool,
+		queries:  generated.New(pool),
+		gm:       gm,
+		campaign: campaign,
+	}
+	tc.scene = tc.newScene("Opening Scene")
+	return tc
+}
+
+// newScene creates another scene in the campaign.
+func (tc *testCampaign) newScene(title string) *generated.Scene {
+	tc.t.Helper()
+
+	resp, err := NewSceneService(tc.pool).CreateScene(tc.ctx, tc.campaign.ID, tc.gm, CreateSceneRequest{
+		Title:       title,
+		Description: "",
+	})
+	if err != nil {
+		tc.t.Fatalf("create scene: %v", err)
+	}
+	return resp.Scene
+}
+
+// addPlayer joins a new user to the campaign and gives them a PC in the scene.
+func (tc *testCampaign) addPlayer() testPlayer {
+	tc.t.Helper()
+
+	tc.players++
+	userID := testdb.CreateUser(tc.t, tc.pool, fmt.Sprintf("player%d@example.com", tc.players))
+	if _, err := tc.queries.AddCampaignMember(tc.ctx, generated.AddCampaignMemberParams{
+		CampaignID: tc.campaign.ID,
+		UserID:     userID,
+		Role:       generated.MemberRolePlayer,
+		Alias:      pgtype.Text{},
+	}); err != nil {
+		tc.t.Fatalf("add member: %v", err)
+	}
+
+	assignTo := uuidToString(userID)
+	characters := NewCharacterService(tc.pool)
+	character, err := characters.CreateCharacter(tc.ctx, tc.campaign.ID, tc.gm, CreateCharacterRequest{
+		DisplayName:   fmt.Sprintf("Hero %d", tc.players),
+		Description:   "",
+		CharacterType: string(generated.CharacterTypePc),
+		AssignToUser:  &assignTo,
+	})
+	if err != nil {
+		tc.t.Fatalf("create character: %v", err)
+	}
+
+	tc.addToScene(tc.scene, character.ID)
+	return testPlayer{userID: userID, characterID: character.ID}
+}
+
+// addToScene puts a character into a scene.
+func (tc *testCampaign) addToScene(scene *generated.Scene, characterID pgtype.UUID) {
+	tc.t.Helper()
+
+	if _, err := NewSceneService(tc.pool).AddCharacterToScene(tc.ctx, scene.ID, characterID, tc.gm); err != nil {
+		tc.t.Fatalf("add character to scene: %v", err)
+	}
+}
+
+// transition moves the campaign to a phase as the GM and fails the test on error.
+func (tc *testCampaign) transition(phase string) {
+	tc.t.Helper()
+
+	if _, err := tc.tryTransition(phase); err != nil {
+		tc.t.Fatalf("transition to %s: %v", phase, err)
+	}
+}
+
+func (tc *testCampaign) tryTransition(phase string) (*generated.Campaign, error) {
+	return NewPhaseService(tc.pool).TransitionPhase(tc.ctx, tc.campaign.ID, tc.gm, TransitionPhaseRequest{
+		ToPhase: phase,
+	})
+}
+
+// post submits a post as the user, in character when characterID is valid.
+func (tc *testCampaign) post(userID, characterID pgtype.UUID, req CreatePostRequest) (*PostResponse, error) {
+	req.SceneID = uuidToString(tc.scene.ID)
+	if characterID.Valid {
+		id := uuidToString(characterID)
+		req.CharacterID = &id
+	}
+	if req.Blocks == nil {
+		req.Blocks = []PostBlock{{Type: "action", Content: "Something happens.", Order: 0}}
+	}
+	return NewPostService(tc.pool).CreatePost(tc.ctx, userID, req, true)
+}
+
+// visiblePostIDs lists the IDs of the scene's posts the user can see.
+func (tc *testCampaign) visiblePostIDs(userID pgtype.UUID) map[string]bool {
+	tc.t.Helper()
+
+	posts, err := NewPostService(tc.pool).ListScenePosts(tc.ctx, userID, uuidToString(tc.scene.ID), nil)
+	if err != nil {
+		tc.t.Fatalf("list scene posts: %v", err)
+	}
+	ids := make(map[string]bool, len(posts))
+	for _, p := range posts {
+		ids[p.ID] = true
+	}
+	return ids
+}
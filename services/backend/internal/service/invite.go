@@ -27,7 +27,7 @@ type InviteService struct {
 // NewInviteService creates a new InviteService.
 func NewInviteService(pool *pgxpool.Pool) *InviteService {
 	return &InviteService{
-		queries: generated.New(pool),
+		queries: newQueries(pool),
 		pool:    pool,
 	}
 }
@@ -38,7 +38,7 @@ func (s *InviteService) CreateInviteLink(
 	campaignID, userID pgtype.UUID,
 ) (*generated.InviteLink, error) {
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -126,7 +126,7 @@ func (s *InviteService) UseInviteCode(
 	}
 
 	// Check if already a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: invite.CampaignID,
 		UserID:     userID,
 	})
@@ -137,14 +137,21 @@ func (s *InviteService) UseInviteCode(
 		return nil, ErrAlreadyMember
 	}
 
-	// Check campaign member limit
-	memberCount, err := s.queries.GetCampaignMemberCount(ctx, invite.CampaignID)
+	// Check the campaign's player limit
+	campaign, err := s.queries.GetCampaign(ctx, invite.CampaignID)
 	if err != nil {
 		return nil, err
 	}
-	if memberCount >= int64(MaxCampaignMembers) {
-		return nil, ErrCampaignFull
+	playerCount, err := s.queries.CountCampaignPlayers(ctx, invite.CampaignID)
+	if err != nil {
+		return nil, err
 	}
+	if limit := MaxPlayers(campaign.Settings); playerCount >= int64(limit) {
+		return nil, &CampaignFullError{Limit: limit}
+	}
+
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
 
 	// Start transaction
 	tx, err := s.pool.Begin(ctx)
@@ -182,12 +189,7 @@ func (s *InviteService) UseInviteCode(
 	if commitErr := tx.Commit(ctx); commitErr != nil {
 		return nil, commitErr
 	}
-
-	// Get the campaign to return
-	campaign, err := s.queries.GetCampaign(ctx, invite.CampaignID)
-	if err != nil {
-		return nil, err
-	}
+	forgetMembership(ctx, invite.CampaignID)
 
 	return &campaign, nil
 }
@@ -198,7 +200,7 @@ func (s *InviteService) ListCampaignInvites(
 	campaignID, userID pgtype.UUID,
 ) ([]generated.InviteLink, error) {
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -218,7 +220,7 @@ func (s *InviteService) RevokeInvite(
 	inviteID, campaignID, userID pgtype.UUID,
 ) error {
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
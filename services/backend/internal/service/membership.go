@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
 	"github.com/jackc/pgx/v5"
@@ -19,88 +20,189 @@ type MembershipService struct {
 // NewMembershipService creates a new MembershipService.
 func NewMembershipService(pool *pgxpool.Pool) *MembershipService {
 	return &MembershipService{
-		queries: generated.New(pool),
+		queries: newQueries(pool),
 		pool:    pool,
 	}
 }
 
-// LeaveCampaign allows a player to leave a campaign.
-func (s *MembershipService) LeaveCampaign(ctx context.Context, campaignID, userID pgtype.UUID) error {
+// ReleasedCharacter is a character that lost its player when a member left or was removed.
+type ReleasedCharacter struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Archived bool   `json:"archived"`
+}
+
+// LeaveCampaign allows a player to leave a campaign. Their characters are unassigned
+// and returned so the GM can be told which ones are now orphaned.
+func (s *MembershipService) LeaveCampaign(
+	ctx context.Context,
+	campaignID, userID pgtype.UUID,
+) ([]ReleasedCharacter, error) {
 	// Get campaign to check if user is GM
 	campaign, err := s.queries.GetCampaign(ctx, campaignID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return ErrCampaignNotFound
+			return nil, ErrCampaignNotFound
 		}
-		return err
+		return nil, err
 	}
 
 	// GM cannot leave without transferring role
 	if campaign.OwnerID.Valid && campaign.OwnerID.Bytes == userID.Bytes {
-		return ErrCannotLeaveAsGM
+		return nil, ErrCannotLeaveAsGM
 	}
 
 	// Check if user is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !isMember {
-		return ErrNotMember
+		return nil, ErrNotMember
 	}
 
-	// Remove membership
-	return s.queries.RemoveCampaignMember(ctx, generated.RemoveCampaignMemberParams{
-		CampaignID: campaignID,
-		UserID:     userID,
-	})
+	return s.removeMemberAndReleaseCharacters(ctx, &campaign, userID)
 }
 
 // RemoveMember allows GM to remove a player from the campaign.
-func (s *MembershipService) RemoveMember(ctx context.Context, campaignID, gmUserID, targetUserID pgtype.UUID) error {
+// The player's characters are unassigned and returned.
+func (s *MembershipService) RemoveMember(
+	ctx context.Context,
+	campaignID, gmUserID, targetUserID pgtype.UUID,
+) ([]ReleasedCharacter, error) {
 	// Verify requester is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     gmUserID,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !isGM {
-		return ErrNotGM
+		return nil, ErrNotGM
 	}
 
 	// Cannot remove self (must transfer first)
 	if targetUserID.Bytes == gmUserID.Bytes {
-		return errors.New("cannot remove yourself as GM (transfer role first)")
+		return nil, errors.New("cannot remove yourself as GM (transfer role first)")
 	}
 
 	// Check if target is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: campaignID,
 		UserID:     targetUserID,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !isMember {
-		return ErrNotMember
+		return nil, ErrNotMember
 	}
 
-	// Remove membership
-	return s.queries.RemoveCampaignMember(ctx, generated.RemoveCampaignMemberParams{
-		CampaignID: campaignID,
-		UserID:     targetUserID,
+	campaign, err := s.queries.GetCampaign(ctx, campaignID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, err
+	}
+
+	released, err := s.removeMemberAndReleaseCharacters(ctx, &campaign, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	releasedIDs := make([]string, len(released))
+	for i, char := range released {
+		releasedIDs[i] = char.ID
+	}
+	logGMAction(ctx, s.queries, campaignID, gmUserID, GMActionRemoveMember,
+		AuditTargetUser, targetUserID, map[string]any{"releasedCharacterIds": releasedIDs})
+
+	return released, nil
+}
+
+// removeMemberAndReleaseCharacters removes a membership and unassigns the member's
+// characters in one transaction. PCs are archived too when the campaign's
+// archiveOrphanedCharacters setting is on.
+func (s *MembershipService) removeMemberAndReleaseCharacters(
+	ctx context.Context,
+	campaign *generated.Campaign,
+	userID pgtype.UUID,
+) ([]ReleasedCharacter, error) {
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := s.queries.WithTx(tx)
+
+	unassigned, err := qtx.UnassignUserCharactersInCampaign(ctx, generated.UnassignUserCharactersInCampaignParams{
+		CampaignID: campaign.ID,
+		UserID:     userID,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	archive := archiveOrphanedCharacters(campaign.Settings)
+	released := make([]ReleasedCharacter, 0, len(unassigned))
+	for _, char := range unassigned {
+		archived := archive && char.CharacterType == generated.CharacterTypePc
+		if archived {
+			if _, archiveErr := qtx.ArchiveCharacter(ctx, char.ID); archiveErr != nil {
+				return nil, archiveErr
+			}
+		}
+		released = append(released, ReleasedCharacter{
+			ID:       uuidToString(char.ID),
+			Name:     char.DisplayName,
+			Archived: archived,
+		})
+	}
+
+	if removeErr := qtx.RemoveCampaignMember(ctx, generated.RemoveCampaignMemberParams{
+		CampaignID: campaign.ID,
+		UserID:     userID,
+	}); removeErr != nil {
+		return nil, removeErr
+	}
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return nil, commitErr
+	}
+	forgetMembership(ctx, campaign.ID)
+
+	return released, nil
+}
+
+// archiveOrphanedCharacters parses campaign settings and reports whether PCs left
+// without a player should be archived.
+func archiveOrphanedCharacters(settingsJSON []byte) bool {
+	if len(settingsJSON) == 0 {
+		return false
+	}
+
+	var settings struct {
+		ArchiveOrphanedCharacters bool `json:"archiveOrphanedCharacters"`
+	}
+	if err := json.Unmarshal(settingsJSON, &settings); err != nil {
+		return false
+	}
+	return settings.ArchiveOrphanedCharacters
 }
 
 // TransferGmRole transfers GM role to another member.
 func (s *MembershipService) TransferGmRole(ctx context.Context, campaignID, currentGmID, newGmID pgtype.UUID) error {
 	// Verify requester is current GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     currentGmID,
 	})
@@ -112,7 +214,7 @@ func (s *MembershipService) TransferGmRole(ctx context.Context, campaignID, curr
 	}
 
 	// Verify new GM is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: campaignID,
 		UserID:     newGmID,
 	})
@@ -123,6 +225,9 @@ func (s *MembershipService) TransferGmRole(ctx context.Context, campaignID, curr
 		return errors.New("new GM must be a campaign member")
 	}
 
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
 	// Start transaction
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
@@ -167,11 +272,88 @@ func (s *MembershipService) TransferGmRole(ctx context.Context, campaignID, curr
 		return err
 	}
 
-	return tx.Commit(ctx)
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return commitErr
+	}
+	forgetMembership(ctx, campaignID)
+
+	return nil
 }
 
-// ClaimAbandonedGmRole allows a player to claim GM role after 30 days of GM inactivity.
+// GmClaimEligibility describes whether a member can currently claim the GM role.
+type GmClaimEligibility struct {
+	Eligible         bool `json:"eligible"`
+	ClaimsEnabled    bool `json:"claimsEnabled"`
+	InactivityWindow int  `json:"inactivityWindowDays"`
+	DaysInactive     int  `json:"daysInactive"`
+	DaysRemaining    int  `json:"daysRemaining"`
+}
+
+// GetGmClaimEligibility previews whether the user could claim the GM role right now.
+func (s *MembershipService) GetGmClaimEligibility(
+	ctx context.Context,
+	campaignID, userID pgtype.UUID,
+) (*GmClaimEligibility, error) {
+	campaign, err := s.queries.GetCampaign(ctx, campaignID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, err
+	}
+
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
+		CampaignID: campaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrNotMember
+	}
+
+	inactivity, err := s.queries.CheckGmInactivity(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	window := gmInactivityDays(campaign.Settings)
+	daysInactive := int(inactivity.DaysInactive)
+	isCurrentGM := campaign.OwnerID.Valid && campaign.OwnerID.Bytes == userID.Bytes
+
+	eligibility := &GmClaimEligibility{
+		Eligible:         false,
+		ClaimsEnabled:    window > 0,
+		InactivityWindow: window,
+		DaysInactive:     daysInactive,
+		DaysRemaining:    0,
+	}
+	if window > 0 {
+		eligibility.DaysRemaining = max(window-daysInactive, 0)
+		eligibility.Eligible = daysInactive >= window && !isCurrentGM
+	}
+
+	return eligibility, nil
+}
+
+// ClaimAbandonedGmRole allows a player to claim GM role once the GM has been inactive
+// for longer than the campaign's configured inactivity window.
 func (s *MembershipService) ClaimAbandonedGmRole(ctx context.Context, campaignID, claimantUserID pgtype.UUID) error {
+	// Get campaign settings and current GM (if exists)
+	campaign, err := s.queries.GetCampaign(ctx, campaignID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCampaignNotFound
+		}
+		return err
+	}
+
+	window := gmInactivityDays(campaign.Settings)
+	if window == 0 {
+		return ErrGmClaimDisabled
+	}
+
 	// Check GM inactivity
 	inactivity, err := s.queries.CheckGmInactivity(ctx, campaignID)
 	if err != nil {
@@ -181,12 +363,12 @@ func (s *MembershipService) ClaimAbandonedGmRole(ctx context.Context, campaignID
 		return err
 	}
 
-	if inactivity.DaysInactive < GmInactivityDays {
+	if int(inactivity.DaysInactive) < window {
 		return ErrGmNotAbandoned
 	}
 
 	// Verify claimant is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: campaignID,
 		UserID:     claimantUserID,
 	})
@@ -197,11 +379,8 @@ func (s *MembershipService) ClaimAbandonedGmRole(ctx context.Context, campaignID
 		return errors.New("must be a campaign member to claim GM role")
 	}
 
-	// Get current GM (if exists)
-	campaign, err := s.queries.GetCampaign(ctx, campaignID)
-	if err != nil {
-		return err
-	}
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
 
 	// Start transaction
 	tx, err := s.pool.Begin(ctx)
@@ -247,5 +426,10 @@ func (s *MembershipService) ClaimAbandonedGmRole(ctx context.Context, campaignID
 		return err
 	}
 
-	return tx.Commit(ctx)
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return commitErr
+	}
+	forgetMembership(ctx, campaignID)
+
+	return nil
 }
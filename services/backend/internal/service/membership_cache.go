@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// membershipCacheKey is the context key for the request's membership cache.
+type membershipCacheKey struct{}
+
+// membershipKey identifies one user in one campaign.
+type membershipKey struct {
+	campaignID [16]byte
+	userID     [16]byte
+}
+
+// membershipCache remembers IsCampaignMember and IsUserGM results for the
+// lifetime of one request. A single request often repeats the same checks (a post
+// create asks whether the author is the GM several times), so each answer is
+// looked up once. Anything that changes membership or roles calls
+// forgetMembership so later checks in the request see the change.
+type membershipCache struct {
+	mu      sync.Mutex
+	members map[membershipKey]bool
+	gms     map[membershipKey]bool
+}
+
+// WithMembershipCache returns a context that caches campaign membership and GM
+// checks made with it. Contexts without a cache query every time.
+func WithMembershipCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, membershipCacheKey{}, &membershipCache{
+		members: map[membershipKey]bool{},
+		gms:     map[membershipKey]bool{},
+	})
+}
+
+func membershipCacheFrom(ctx context.Context) *membershipCache {
+	cache, _ := ctx.Value(membershipCacheKey{}).(*membershipCache)
+	return cache
+}
+
+// isCampaignMember is IsCampaignMember through the request's membership cache.
+func isCampaignMember(
+	ctx context.Context,
+	q *generated.Queries,
+	arg generated.IsCampaignMemberParams,
+) (bool, error) {
+	return cachedMembership(ctx, arg.CampaignID, arg.UserID,
+		func(c *membershipCache) map[membershipKey]bool { return c.members },
+		func() (bool, error) { return q.IsCampaignMember(ctx, arg) })
+}
+
+// isUserGM is IsUserGM through the request's membership cache.
+func isUserGM(
+	ctx context.Context,
+	q *generated.Queries,
+	arg generated.IsUserGMParams,
+) (bool, error) {
+	return cachedMembership(ctx, arg.CampaignID, arg.UserID,
+		func(c *membershipCache) map[membershipKey]bool { return c.gms },
+		func() (bool, error) { return q.IsUserGM(ctx, arg) })
+}
+
+func cachedMembership(
+	ctx context.Context,
+	campaignID, userID pgtype.UUID,
+	entries func(*membershipCache) map[membershipKey]bool,
+	query func() (bool, error),
+) (bool, error) {
+	cache := membershipCacheFrom(ctx)
+	if cache == nil {
+		return query()
+	}
+
+	key := membershipKey{campaignID: campaignID.Bytes, userID: userID.Bytes}
+	cache.mu.Lock()
+	result, ok := entries(cache)[key]
+	cache.mu.Unlock()
+	if ok {
+		return result, nil
+	}
+
+	result, err := query()
+	if err != nil {
+		return false, err
+	}
+
+	cache.mu.Lock()
+	entries(cache)[key] = result
+	cache.mu.Unlock()
+
+	return result, nil
+}
+
+// forgetMembership drops cached membership and GM answers for a campaign after
+// its members or roles change.
+func forgetMembership(ctx context.Context, campaignID pgtype.UUID) {
+	cache := membershipCacheFrom(ctx)
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for key := range cache.members {
+		if key.campaignID == campaignID.Bytes {
+			delete(cache.members, key)
+		}
+	}
+	for key := range cache.gms {
+		if key.campaignID == campaignID.Bytes {
+			delete(cache.gms, key)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// resolveMentions finds @mentions of member aliases or character names in the OOC
+// text and returns the mentioned users who can witness the post, excluding the author.
+// The GM can witness every post; players only through an assigned witnessing character.
+func resolveMentions(
+	ctx context.Context,
+	queries *generated.Queries,
+	campaignID, authorID pgtype.UUID,
+	oocText pgtype.Text,
+	witnesses []pgtype.UUID,
+) ([]pgtype.UUID, error) {
+	mentions := []pgtype.UUID{}
+	if !oocText.Valid || !strings.Contains(oocText.String, "@") {
+		return mentions, nil
+	}
+	text := strings.ToLower(oocText.String)
+
+	members, err := queries.GetCampaignMembers(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	characters, err := queries.ListCampaignCharacters(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	canWitness := make(map[[16]byte]bool)
+	for _, member := range members {
+		if member.Role == generated.MemberRoleGm {
+			canWitness[member.UserID.Bytes] = true
+		}
+	}
+	for _, char := range characters {
+		if char.AssignedUserID.Valid && slices.Contains(witnesses, char.ID) {
+			canWitness[char.AssignedUserID.Bytes] = true
+		}
+	}
+
+	seen := make(map[[16]byte]bool)
+	addMention := func(userID pgtype.UUID, name string) {
+		if !userID.Valid || userID == authorID || seen[userID.Bytes] || !canWitness[userID.Bytes] {
+			return
+		}
+		if containsMention(text, strings.ToLower(name)) {
+			seen[userID.Bytes] = true
+			mentions = append(mentions, userID)
+		}
+	}
+
+	for _, member := range members {
+		if member.Alias.Valid {
+			addMention(member.UserID, member.Alias.String)
+		}
+	}
+	for _, char := range characters {
+		if !char.IsArchived {
+			addMention(char.AssignedUserID, char.DisplayName)
+		}
+	}
+
+	return mentions, nil
+}
+
+// containsMention reports whether text contains "@name" ending at a word boundary.
+// Both arguments are expected to be lowercased.
+func containsMention(text, name string) bool {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false
+	}
+
+	needle := "@" + name
+	for offset := 0; offset < len(text); {
+		idx := strings.Index(text[offset:], needle)
+		if idx < 0 {
+			return false
+		}
+		end := offset + idx + len(needle)
+		if end == len(text) {
+			return true
+		}
+		if next, _ := utf8.DecodeRuneInString(text[end:]); !unicode.IsLetter(next) && !unicode.IsDigit(next) {
+			return true
+		}
+		offset = end
+	}
+	return false
+}
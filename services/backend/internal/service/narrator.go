@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Narrator persona defaults and limits.
+const (
+	DefaultNarratorName  = "Narrator"
+	MaxNarratorNameLen   = 100
+	MaxNarratorAvatarLen = 2048
+)
+
+// narratorPersona is the display identity used for GM posts without a character.
+type narratorPersona struct {
+	name      string
+	avatarURL string
+}
+
+// narratorFor loads the narrator persona for a campaign. It returns nil if the
+// campaign cannot be loaded, in which case narrator posts keep a blank author.
+func (s *PostService) narratorFor(ctx context.Context, campaignID pgtype.UUID) *narratorPersona {
+	campaign, err := s.queries.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return nil
+	}
+
+	name, avatarURL := narratorSettings(campaign.Settings)
+	return &narratorPersona{
+		name:      name,
+		avatarURL: avatarURL,
+	}
+}
+
+// applyTo fills in the narrator name and avatar on a post that has no character.
+// Only GMs can post without one, so this covers every narrator post, including
+// those by co-GMs and by earlier owners of the campaign. System posts keep their
+// own blank author.
+func (n *narratorPersona) applyTo(resp *PostResponse) {
+	if n == nil || resp.CharacterID != nil || resp.IsSystem {
+		return
+	}
+
+	name := n.name
+	resp.CharacterName = &name
+	if n.avatarURL != "" {
+		avatar := n.avatarURL
+		resp.CharacterAvatar = &avatar
+	}
+}
+
+// narratorSettings parses campaign settings and returns the narrator name and avatar URL.
+func narratorSettings(settingsJSON []byte) (string, string) {
+	if len(settingsJSON) == 0 {
+		return DefaultNarratorName, ""
+	}
+
+	var settings struct {
+		Narrator struct {
+			Name      string `json:"name"`
+			AvatarURL string `json:"avatarUrl"`
+		} `json:"narrator"`
+	}
+	if err := json.Unmarshal(settingsJSON, &settings); err != nil {
+		return DefaultNarratorName, ""
+	}
+
+	name := strings.TrimSpace(settings.Narrator.Name)
+	if name == "" {
+		name = DefaultNarratorName
+	}
+	return name, settings.Narrator.AvatarURL
+}
+
+// validateNarratorSetting checks the shape of the narrator setting.
+func validateNarratorSetting(raw any) error {
+	narrator, ok := raw.(map[string]any)
+	if !ok {
+		return ErrInvalidSettings
+	}
+
+	if rawName, hasName := narrator["name"]; hasName {
+		name, isString := rawName.(string)
+		if !isString || len(name) > MaxNarratorNameLen {
+			return ErrInvalidSettings
+		}
+	}
+
+	if rawAvatar, hasAvatar := narrator["avatarUrl"]; hasAvatar {
+		avatar, isString := rawAvatar.(string)
+		if !isString || len(avatar) > MaxNarratorAvatarLen {
+			return ErrInvalidSettings
+		}
+		if avatar != "" {
+			parsed, err := url.Parse(avatar)
+			if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+				return ErrInvalidSettings
+			}
+		}
+	}
+
+	return nil
+}
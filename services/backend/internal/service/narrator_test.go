@@ -0,0 +1,33 @@
+package service
+
+import "testing"
+
+func TestNarratorApplyTo(t *testing.T) {
+	narrator := &narratorPersona{name: "The Storyteller", avatarURL: "https://example.com/narrator.png"}
+	characterID := "00000000-0000-0000-0000-000000000001"
+	characterName := "Aria"
+
+	tests := []struct {
+		name string
+		resp PostResponse
+		want *string
+	}{
+		{"narrator post", PostResponse{}, &narrator.name},
+		{
+			"character post keeps its character",
+			PostResponse{CharacterID: &characterID, CharacterName: &characterName},
+			&characterName,
+		},
+		{"system post stays blank", PostResponse{IsSystem: true}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			narrator.applyTo(&tt.resp)
+			got := tt.resp.CharacterName
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("CharacterName = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
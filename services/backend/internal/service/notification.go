@@ -3,13 +3,17 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/metrics"
 )
 
 // Time constants for notification calculations.
@@ -22,6 +26,16 @@ const (
 	timeGateWarning1h  = 1
 )
 
+// queueFlushBatchSize is the number of queued notifications claimed per flush round.
+const queueFlushBatchSize = 100
+
+// Notification errors.
+var (
+	ErrNotificationNotFound    = errors.New("notification not found")
+	ErrSnoozeNotInFuture       = errors.New("snooze time must be in the future")
+	ErrInvalidNotificationType = errors.New("invalid notification type")
+)
+
 // emptyUUID returns an invalid/empty UUID for optional fields.
 func emptyUUID() pgtype.UUID {
 	return pgtype.UUID{Bytes: [16]byte{}, Valid: false}
@@ -51,6 +65,12 @@ const (
 	NotifUnresolvedRollsExist  = "unresolved_rolls_exist"
 	NotifCampaignAtPlayerLimit = "campaign_at_player_limit"
 	NotifSceneLimitWarning     = "scene_limit_warning"
+	NotifMentioned             = "mentioned"
+	NotifPhaseAutoTransitioned = "phase_auto_transitioned"
+	NotifCharactersOrphaned    = "characters_orphaned"
+
+	// NotifTestNotification is a notification users send themselves to check delivery.
+	NotifTestNotification = "test_notification"
 )
 
 // NotificationService handles notification creation and delivery.
@@ -87,6 +107,13 @@ func (s *NotificationService) CreateNotification(
 	ctx context.Context,
 	params CreateNotificationParams,
 ) (*generated.Notification, error) {
+	// Respect per-type channel preferences
+	typePrefs := s.getTypePreferences(ctx, params.UserID)
+	inAppEnabled := typePrefs.IsEnabled(params.Type, ChannelInApp)
+	if !inAppEnabled && !typePrefs.IsEnabled(params.Type, ChannelEmail) {
+		return nil, nil //nolint:nilnil // Muted notification types are intentionally skipped
+	}
+
 	// Marshal metadata to JSON
 	metadataJSON, err := json.Marshal(params.Metadata)
 	if err != nil {
@@ -112,53 +139,136 @@ func (s *NotificationService) CreateNotification(
 		return nil, fmt.Errorf("failed to create notification: %w", err)
 	}
 
+	// Email-only types are stored already read so they don't surface in-app
+	if !inAppEnabled {
+		notification, err = s.queries.MarkNotificationAsRead(ctx, generated.MarkNotificationAsReadParams{
+			ID:     notification.ID,
+			UserID: notification.UserID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notification: %w", err)
+		}
+	}
+
 	// Handle email delivery asynchronously
 	go s.handleEmailDelivery(context.Background(), &notification)
 
 	return &notification, nil
 }
 
+// Email delivery decisions.
+const (
+	EmailDeliverySent       = "sent"
+	EmailDeliveryQueued     = "queued"
+	EmailDeliverySuppressed = "suppressed"
+)
+
+// Reasons a notification email was suppressed.
+const (
+	EmailSuppressedDisabled     = "email_disabled"
+	EmailSuppressedTypeDisabled = "type_disabled"
+	EmailSuppressedDigest       = "digest"
+	EmailSuppressedQuietHours   = "quiet_hours"
+)
+
+// EmailDelivery is what happened to a notification's email.
+type EmailDelivery struct {
+	Decision         string `json:"decision"`
+	SuppressedReason string `json:"suppressedReason,omitempty"`
+}
+
 // handleEmailDelivery handles email notification delivery based on user preferences.
 func (s *NotificationService) handleEmailDelivery(ctx context.Context, notification *generated.Notification) {
-	// Get notification preferences
-	prefs, err := s.queries.GetNotificationPreferences(ctx, notification.UserID)
+	if _, err := s.deliverEmail(ctx, notification, false); err != nil {
+		//nolint:sloglint // Error logging doesn't need structured logger injection
+		slog.Error("Failed to deliver notification email", "error", err)
+	}
+}
+
+// deliverEmail sends, queues or suppresses a notification's email according to the
+// user's preferences and quiet hours. Digest users get the notification in their
+// digest instead, so it is reported as suppressed here.
+func (s *NotificationService) deliverEmail(
+	ctx context.Context,
+	notification *generated.Notification,
+	bypassQuietHours bool,
+) (EmailDelivery, error) {
+	reason, err := s.emailPreferenceReason(ctx, notification.UserID, notification.Type)
 	if err != nil {
-		// No preferences set, use defaults (skip email)
-		return
+		return EmailDelivery{Decision: EmailDeliverySuppressed, SuppressedReason: ""}, err
+	}
+	if reason != "" {
+		return EmailDelivery{Decision: EmailDeliverySuppressed, SuppressedReason: reason}, nil
 	}
 
-	if !prefs.EmailEnabled {
-		return
+	if !bypassQuietHours && s.heldByQuietHours(ctx, notification.UserID, notification.IsUrgent) {
+		// Queue for later
+		s.queueForLater(ctx, notification)
+		return EmailDelivery{Decision: EmailDeliveryQueued, SuppressedReason: ""}, nil
 	}
 
-	switch prefs.EmailFrequency {
-	case generated.NotificationFrequencyOff:
-		return
+	if err := s.sendImmediateEmail(ctx, notification); err != nil {
+		return EmailDelivery{Decision: EmailDeliverySuppressed, SuppressedReason: ""}, err
+	}
+	return EmailDelivery{Decision: EmailDeliverySent, SuppressedReason: ""}, nil
+}
 
-	case generated.NotificationFrequencyRealtime:
-		// Check quiet hours
-		inQuietHours := s.isInQuietHours(ctx, notification.UserID)
-
-		if inQuietHours {
-			if notification.IsUrgent {
-				// Check if urgent bypass is enabled
-				quietHours, qhErr := s.queries.GetQuietHours(ctx, notification.UserID)
-				if qhErr == nil && quietHours.UrgentBypass {
-					// Send immediately despite quiet hours
-					s.sendImmediateEmail(ctx, notification)
-					return
-				}
-			}
-			// Queue for later
-			s.queueForLater(ctx, notification)
-		} else {
-			s.sendImmediateEmail(ctx, notification)
+// emailPreferenceReason returns why the user's email preferences rule out a
+// realtime email for the notification type, or "" if they allow it.
+func (s *NotificationService) emailPreferenceReason(
+	ctx context.Context,
+	userID pgtype.UUID,
+	notificationType string,
+) (string, error) {
+	prefs, err := s.queries.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// No preferences set, use defaults (skip email)
+			return EmailSuppressedDisabled, nil
 		}
+		return "", err
+	}
 
-	case generated.NotificationFrequencyDigestDaily, generated.NotificationFrequencyDigestWeekly:
-		// Will be handled by cron job
-		return
+	switch {
+	case !prefs.EmailEnabled || prefs.EmailFrequency == generated.NotificationFrequencyOff:
+		return EmailSuppressedDisabled, nil
+	case !ParseNotificationTypePreferences(prefs.TypePreferences).IsEnabled(notificationType, ChannelEmail):
+		return EmailSuppressedTypeDisabled, nil
+	case prefs.EmailFrequency != generated.NotificationFrequencyRealtime:
+		// Will be handled by the digest cron job
+		return EmailSuppressedDigest, nil
 	}
+
+	return "", nil
+}
+
+// getTypePreferences returns the user's per-type notification preferences.
+// Users without saved preferences get an empty map, meaning everything is enabled.
+func (s *NotificationService) getTypePreferences(
+	ctx context.Context,
+	userID pgtype.UUID,
+) NotificationTypePreferences {
+	prefs, err := s.queries.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		return NotificationTypePreferences{}
+	}
+	return ParseNotificationTypePreferences(prefs.TypePreferences)
+}
+
+// heldByQuietHours reports whether the user's quiet hours hold back a notification
+// email. Urgent notifications go through when the user allows urgent bypass.
+func (s *NotificationService) heldByQuietHours(ctx context.Context, userID pgtype.UUID, isUrgent bool) bool {
+	if !s.isInQuietHours(ctx, userID) {
+		return false
+	}
+	if isUrgent {
+		// Check if urgent bypass is enabled
+		quietHours, err := s.queries.GetQuietHours(ctx, userID)
+		if err == nil && quietHours.UrgentBypass {
+			return false
+		}
+	}
+	return true
 }
 
 // isInQuietHours checks if the current time is within the user's quiet hours.
@@ -169,6 +279,12 @@ func (s *NotificationService) isInQuietHours(ctx context.Context, userID pgtype.
 		return false
 	}
 
+	return quietHoursActive(&quietHours, time.Now())
+}
+
+// quietHoursActive reports whether the instant now falls inside the quiet hours,
+// read in the user's timezone. Unknown timezones fall back to UTC.
+func quietHoursActive(quietHours *generated.QuietHour, now time.Time) bool {
 	if !quietHours.Enabled {
 		return false
 	}
@@ -180,19 +296,30 @@ func (s *NotificationService) isInQuietHours(ctx context.Context, userID pgtype.
 	}
 
 	// Get current time in user's timezone
-	now := time.Now().In(loc)
-	currentMinutes := now.Hour()*minutesPerHour + now.Minute()
+	local := now.In(loc)
+	currentMinutes := local.Hour()*minutesPerHour + local.Minute()
 
 	// Parse start and end times
 	startMinutes := int(quietHours.StartTime.Microseconds / microsecondsPerMin)
 	endMinutes := int(quietHours.EndTime.Microseconds / microsecondsPerMin)
 
-	// Handle overnight quiet hours (e.g., 22:00 - 08:00)
-	if startMinutes > endMinutes {
+	return isWithinQuietWindow(currentMinutes, startMinutes, endMinutes)
+}
+
+// isWithinQuietWindow reports whether a minute-of-day falls inside a quiet window.
+// The start minute is inclusive and the end minute is exclusive in both the daytime
+// and overnight cases. A window whose start equals its end covers the whole day.
+func isWithinQuietWindow(currentMinutes, startMinutes, endMinutes int) bool {
+	switch {
+	case startMinutes == endMinutes:
+		// Same start and end means quiet all day
+		return true
+	case startMinutes > endMinutes:
+		// Overnight quiet hours (e.g., 22:00 - 08:00)
 		return currentMinutes >= startMinutes || currentMinutes < endMinutes
+	default:
+		return currentMinutes >= startMinutes && currentMinutes < endMinutes
 	}
-
-	return currentMinutes >= startMinutes && currentMinutes < endMinutes
 }
 
 // queueForLater queues a notification for delivery after quiet hours end.
@@ -202,48 +329,135 @@ func (s *NotificationService) queueForLater(ctx context.Context, notification *g
 		return
 	}
 
-	// Calculate delivery time
+	deliveryTime := quietHoursEnd(&quietHours, time.Now())
+	_, err = s.queries.QueueNotification(ctx, generated.QueueNotificationParams{
+		UserID:         notification.UserID,
+		NotificationID: notification.ID,
+		DeliverAfter:   pgtype.Timestamptz{Time: deliveryTime, Valid: true, InfinityModifier: pgtype.Finite},
+	})
+	if err != nil {
+		//nolint:sloglint // Error logging doesn't need structured logger injection
+		slog.Error("Failed to queue notification", "error", err)
+	}
+}
+
+// requeueForLater puts a claimed queue entry back until the user's quiet hours
+// next end.
+func (s *NotificationService) requeueForLater(ctx context.Context, item *generated.NotificationQueue) {
+	quietHours, err := s.queries.GetQuietHours(ctx, item.UserID)
+	if err != nil {
+		return
+	}
+
+	deliveryTime := quietHoursEnd(&quietHours, time.Now())
+	err = s.queries.RequeueNotification(ctx, generated.RequeueNotificationParams{
+		ID:           item.ID,
+		DeliverAfter: pgtype.Timestamptz{Time: deliveryTime, Valid: true, InfinityModifier: pgtype.Finite},
+	})
+	if err != nil {
+		//nolint:sloglint // Error logging doesn't need structured logger injection
+		slog.Error("Failed to requeue notification", "error", err)
+	}
+}
+
+// quietHoursEnd returns the next time after now, in UTC, that the quiet hours'
+// end time comes round in the user's timezone. An all-day window is still
+// active then, so deliveries due at that time re-check it.
+func quietHoursEnd(quietHours *generated.QuietHour, now time.Time) time.Time {
 	loc, err := time.LoadLocation(quietHours.Timezone)
 	if err != nil {
 		loc = time.UTC
 	}
 
-	now := time.Now().In(loc)
+	local := now.In(loc)
 	endMinutes := int(quietHours.EndTime.Microseconds / microsecondsPerMin)
 	endHour := endMinutes / minutesPerHour
 	endMin := endMinutes % minutesPerHour
 
 	deliveryTime := time.Date(
-		now.Year(), now.Month(), now.Day(),
+		local.Year(), local.Month(), local.Day(),
 		endHour, endMin, 0, 0, loc,
 	)
 
-	if deliveryTime.Before(now) {
+	if !deliveryTime.After(local) {
 		deliveryTime = deliveryTime.Add(hoursPerDay * time.Hour)
 	}
+	return deliveryTime.UTC()
+}
 
-	_, err = s.queries.QueueNotification(ctx, generated.QueueNotificationParams{
-		UserID:         notification.UserID,
-		NotificationID: notification.ID,
-		DeliverAfter:   pgtype.Timestamptz{Time: deliveryTime.UTC(), Valid: true, InfinityModifier: pgtype.Finite},
-	})
+// FlushDueQueued delivers queued notifications whose quiet hours have ended.
+// Entries are claimed (marked delivered) before sending, so a crash mid-flush drops
+// at most the claimed batch rather than sending twice. Items whose owner has since
+// turned email off are dropped. Returns the number of emails sent.
+func (s *NotificationService) FlushDueQueued(ctx context.Context) (int, error) {
+	sent := 0
+	for {
+		queued, err := s.queries.ClaimDueQueuedNotifications(ctx, queueFlushBatchSize)
+		if err != nil {
+			return sent, fmt.Errorf("failed to claim queued notifications: %w", err)
+		}
+
+		for _, item := range queued {
+			if s.deliverQueuedNotification(ctx, item) {
+				sent++
+			}
+		}
+
+		if len(queued) < queueFlushBatchSize {
+			return sent, nil
+		}
+	}
+}
+
+// deliverQueuedNotification sends a claimed queue entry if the user still wants email for it.
+// Entries still inside the user's quiet hours are put back until the window next ends.
+func (s *NotificationService) deliverQueuedNotification(ctx context.Context, item generated.NotificationQueue) bool {
+	notification, err := s.queries.GetNotification(ctx, item.NotificationID)
 	if err != nil {
+		return false
+	}
+
+	// Already emailed (e.g. urgent bypass or an earlier flush)
+	if notification.EmailSentAt.Valid {
+		return false
+	}
+
+	prefs, err := s.queries.GetNotificationPreferences(ctx, notification.UserID)
+	if err != nil || !prefs.EmailEnabled || prefs.EmailFrequency != generated.NotificationFrequencyRealtime {
+		return false
+	}
+
+	if !ParseNotificationTypePreferences(prefs.TypePreferences).IsEnabled(notification.Type, ChannelEmail) {
+		return false
+	}
+
+	// The window may have been changed since the entry was queued, and an
+	// all-day window never ends
+	if s.heldByQuietHours(ctx, notification.UserID, notification.IsUrgent) {
+		s.requeueForLater(ctx, &item)
+		return false
+	}
+
+	if err := s.sendImmediateEmail(ctx, &notification); err != nil {
 		//nolint:sloglint // Error logging doesn't need structured logger injection
-		slog.Error("Failed to queue notification", "error", err)
+		slog.Error("Failed to send queued notification email", "error", err)
+		return false
 	}
+	return true
 }
 
 // sendImmediateEmail sends an email notification immediately.
-func (s *NotificationService) sendImmediateEmail(ctx context.Context, notification *generated.Notification) {
+func (s *NotificationService) sendImmediateEmail(ctx context.Context, notification *generated.Notification) error {
 	// TODO: Implement email sending via Resend or similar service
 	// For now, just mark as sent
 	err := s.queries.MarkNotificationEmailSent(ctx, notification.ID)
+	metrics.RecordEmailSend(err)
 	if err != nil {
-		//nolint:sloglint // Error logging doesn't need structured logger injection
-		slog.Error("Failed to mark notification email as sent", "error", err)
+		return fmt.Errorf("failed to mark notification email as sent: %w", err)
 	}
 	//nolint:sloglint // Info logging doesn't need structured logger injection
 	slog.Info("Would send email for notification", "id", notification.ID.Bytes, "title", notification.Title)
+	return nil
 }
 
 // NotifyPCPhaseStarted notifies all PCs in a campaign that PC Phase has started.
@@ -392,6 +606,41 @@ func (s *NotificationService) NotifyGMHiddenPost(
 	return createErr
 }
 
+// NotifyMentioned notifies users who were @mentioned in a post's OOC text.
+func (s *NotificationService) NotifyMentioned(
+	ctx context.Context,
+	campaignID pgtype.UUID,
+	sceneID pgtype.UUID,
+	postID pgtype.UUID,
+	userIDs []pgtype.UUID,
+	sceneName string,
+) error {
+	for _, userID := range userIDs {
+		_, err := s.CreateNotification(ctx, CreateNotificationParams{
+			UserID:      userID,
+			CampaignID:  campaignID,
+			SceneID:     sceneID,
+			PostID:      postID,
+			CharacterID: emptyUUID(),
+			Type:        NotifMentioned,
+			Title:       "You Were Mentioned",
+			Body:        fmt.Sprintf("You were mentioned in a post in %s", sceneName),
+			Link: fmt.Sprintf(
+				"/campaigns/%s/scenes/%s/posts/%s",
+				uuidToString(campaignID),
+				uuidToString(sceneID),
+				uuidToString(postID),
+			),
+			IsUrgent: false,
+			Metadata: nil,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // NotifyAllCharactersPassed notifies the GM when all characters have passed.
 func (s *NotificationService) NotifyAllCharactersPassed(
 	ctx context.Context,
@@ -419,6 +668,108 @@ func (s *NotificationService) NotifyAllCharactersPassed(
 	return createErr
 }
 
+// NotifyPassStateCleared notifies the GM when a character's pass was cleared by posting.
+func (s *NotificationService) NotifyPassStateCleared(
+	ctx context.Context,
+	campaignID pgtype.UUID,
+	sceneID pgtype.UUID,
+	characterID pgtype.UUID,
+	characterName string,
+	sceneName string,
+) error {
+	gmUserID, err := s.queries.GetGMUserID(ctx, campaignID)
+	if err != nil {
+		return fmt.Errorf("failed to get GM: %w", err)
+	}
+
+	_, createErr := s.CreateNotification(ctx, CreateNotificationParams{
+		UserID:      gmUserID,
+		CampaignID:  campaignID,
+		SceneID:     sceneID,
+		PostID:      emptyUUID(),
+		CharacterID: characterID,
+		Type:        NotifPassStateCleared,
+		Title:       "Pass Cleared",
+		Body:        fmt.Sprintf("%s posted in %s and is no longer passing", characterName, sceneName),
+		Link: fmt.Sprintf(
+			"/campaigns/%s/scenes/%s",
+			uuidToString(campaignID),
+			uuidToString(sceneID),
+		),
+		IsUrgent: false,
+		Metadata: nil,
+	})
+	return createErr
+}
+
+// NotifyPhaseAutoTransitioned tells the GM the campaign moved to GM phase on its own
+// because every PC passed.
+func (s *NotificationService) NotifyPhaseAutoTransitioned(
+	ctx context.Context,
+	campaignID pgtype.UUID,
+	campaignTitle string,
+) error {
+	gmUserID, err := s.queries.GetGMUserID(ctx, campaignID)
+	if err != nil {
+		return fmt.Errorf("failed to get GM: %w", err)
+	}
+
+	_, createErr := s.CreateNotification(ctx, CreateNotificationParams{
+		UserID:      gmUserID,
+		CampaignID:  campaignID,
+		SceneID:     emptyUUID(),
+		PostID:      emptyUUID(),
+		CharacterID: emptyUUID(),
+		Type:        NotifPhaseAutoTransitioned,
+		Title:       "GM Phase Started Automatically",
+		Body: fmt.Sprintf(
+			"All PCs passed in %s, so the campaign moved to GM Phase automatically.",
+			campaignTitle,
+		),
+		Link:     fmt.Sprintf("/campaigns/%s", uuidToString(campaignID)),
+		IsUrgent: true,
+		Metadata: nil,
+	})
+	return createErr
+}
+
+// NotifyCharactersOrphaned tells the GM which characters lost their player
+// when a member left the campaign.
+func (s *NotificationService) NotifyCharactersOrphaned(
+	ctx context.Context,
+	campaignID pgtype.UUID,
+	characterNames []string,
+	archived bool,
+) error {
+	gmUserID, err := s.queries.GetGMUserID(ctx, campaignID)
+	if err != nil {
+		return fmt.Errorf("failed to get GM: %w", err)
+	}
+
+	body := fmt.Sprintf(
+		"A player left the campaign. These characters are now unassigned: %s",
+		strings.Join(characterNames, ", "),
+	)
+	if archived {
+		body += ". Player characters were archived."
+	}
+
+	_, createErr := s.CreateNotification(ctx, CreateNotificationParams{
+		UserID:      gmUserID,
+		CampaignID:  campaignID,
+		SceneID:     emptyUUID(),
+		PostID:      emptyUUID(),
+		CharacterID: emptyUUID(),
+		Type:        NotifCharactersOrphaned,
+		Title:       "Characters Need a Player",
+		Body:        body,
+		Link:        fmt.Sprintf("/campaigns/%s", uuidToString(campaignID)),
+		IsUrgent:    false,
+		Metadata:    map[string]any{"characters": characterNames},
+	})
+	return createErr
+}
+
 // NotifyTimeGateWarning notifies users about time gate expiration.
 func (s *NotificationService) NotifyTimeGateWarning(
 	ctx context.Context,
@@ -560,22 +911,147 @@ func (s *NotificationService) NotifyComposeLockReleased(
 	return nil
 }
 
-// GetNotifications retrieves notifications for a user.
+// NotifyCampaignPlayerLimit warns the GM that a campaign is near or at its player limit.
+func (s *NotificationService) NotifyCampaignPlayerLimit(
+	ctx context.Context,
+	campaignID pgtype.UUID,
+	campaignTitle string,
+	memberCount int64,
+	memberLimit int64,
+) error {
+	gmUserID, err := s.queries.GetGMUserID(ctx, campaignID)
+	if err != nil {
+		return fmt.Errorf("failed to get GM: %w", err)
+	}
+
+	atLimit := memberCount >= memberLimit
+	title := "Approaching Player Limit"
+	body := fmt.Sprintf("%s has %d of %d players", campaignTitle, memberCount, memberLimit)
+	if atLimit {
+		title = "Player Limit Reached"
+		body = fmt.Sprintf("%s is full (%d/%d players). New players cannot join.", campaignTitle, memberCount, memberLimit)
+	}
+
+	_, createErr := s.CreateNotification(ctx, CreateNotificationParams{
+		UserID:      gmUserID,
+		CampaignID:  campaignID,
+		SceneID:     emptyUUID(),
+		PostID:      emptyUUID(),
+		CharacterID: emptyUUID(),
+		Type:        NotifCampaignAtPlayerLimit,
+		Title:       title,
+		Body:        body,
+		Link:        fmt.Sprintf("/campaigns/%s", uuidToString(campaignID)),
+		IsUrgent:    false,
+		Metadata: map[string]any{
+			"current": memberCount,
+			"limit":   memberLimit,
+			"atLimit": atLimit,
+		},
+	})
+	return createErr
+}
+
+// NotifySceneLimitWarning warns the GM that a campaign is approaching its scene limit.
+func (s *NotificationService) NotifySceneLimitWarning(
+	ctx context.Context,
+	campaignID pgtype.UUID,
+	campaignTitle string,
+	sceneCount int64,
+	sceneLimit int64,
+) error {
+	gmUserID, err := s.queries.GetGMUserID(ctx, campaignID)
+	if err != nil {
+		return fmt.Errorf("failed to get GM: %w", err)
+	}
+
+	_, createErr := s.CreateNotification(ctx, CreateNotificationParams{
+		UserID:      gmUserID,
+		CampaignID:  campaignID,
+		SceneID:     emptyUUID(),
+		PostID:      emptyUUID(),
+		CharacterID: emptyUUID(),
+		Type:        NotifSceneLimitWarning,
+		Title:       "Approaching Scene Limit",
+		Body: fmt.Sprintf(
+			"%s has %d of %d scenes. The oldest archived scene is deleted when the limit is exceeded.",
+			campaignTitle,
+			sceneCount,
+			sceneLimit,
+		),
+		Link:     fmt.Sprintf("/campaigns/%s", uuidToString(campaignID)),
+		IsUrgent: false,
+		Metadata: map[string]any{
+			"current": sceneCount,
+			"limit":   sceneLimit,
+		},
+	})
+	return createErr
+}
+
+// NotificationFilter narrows a notification list. Empty fields match everything.
+type NotificationFilter struct {
+	Types  []string
+	IsRead *bool
+}
+
+// GetNotifications retrieves notifications for a user, newest first. Types must be
+// notification type constants; unknown types return ErrInvalidNotificationType.
 func (s *NotificationService) GetNotifications(
 	ctx context.Context,
 	userID pgtype.UUID,
+	filter NotificationFilter,
 	limit, offset int32,
 ) ([]generated.Notification, error) {
+	for _, notifType := range filter.Types {
+		if !isNotificationType(notifType) {
+			return nil, ErrInvalidNotificationType
+		}
+	}
+
+	var isRead pgtype.Bool
+	if filter.IsRead != nil {
+		isRead = pgtype.Bool{Bool: *filter.IsRead, Valid: true}
+	}
+
 	return s.queries.GetNotificationsByUser(ctx, generated.GetNotificationsByUserParams{
+		UserID:    userID,
+		Types:     filter.Types,
+		IsRead:    isRead,
+		RowLimit:  limit,
+		RowOffset: offset,
+	})
+}
+
+// GetNotification retrieves a single notification owned by the user, with campaign and scene titles.
+// Notifications belonging to other users are reported as not found.
+func (s *NotificationService) GetNotification(
+	ctx context.Context,
+	userID pgtype.UUID,
+	notificationID pgtype.UUID,
+) (*generated.GetUserNotificationRow, error) {
+	notification, err := s.queries.GetUserNotification(ctx, generated.GetUserNotificationParams{
+		ID:     notificationID,
 		UserID: userID,
-		Limit:  limit,
-		Offset: offset,
 	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, err
+	}
+
+	return &notification, nil
 }
 
-// GetUnreadCount returns the count of unread notifications for a user.
+// GetUnreadCount returns the count of unread notifications for a user, read from
+// the user's unread counters rather than counting notifications.
 func (s *NotificationService) GetUnreadCount(ctx context.Context, userID pgtype.UUID) (int64, error) {
-	return s.queries.GetUnreadNotificationCount(ctx, userID)
+	counter, err := s.queries.GetUnreadNotificationCounter(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return counter.UnreadCount, nil
 }
 
 // MarkAsRead marks a notification as read.
@@ -590,11 +1066,65 @@ func (s *NotificationService) MarkAsRead(
 	})
 }
 
+// Snooze hides a notification from unread lists and counts until the given time.
+func (s *NotificationService) Snooze(
+	ctx context.Context,
+	userID pgtype.UUID,
+	notificationID pgtype.UUID,
+	until time.Time,
+) (*generated.Notification, error) {
+	if !until.After(time.Now()) {
+		return nil, ErrSnoozeNotInFuture
+	}
+
+	notification, err := s.queries.SnoozeNotification(ctx, generated.SnoozeNotificationParams{
+		ID:          notificationID,
+		UserID:      userID,
+		SnoozeUntil: pgtype.Timestamptz{Time: until.UTC(), Valid: true, InfinityModifier: pgtype.Finite},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+// ReconcileUnreadCounts recounts unread notifications and corrects any unread
+// counter that has drifted, returning how many were fixed.
+func (s *NotificationService) ReconcileUnreadCounts(ctx context.Context) (int64, error) {
+	return s.queries.ReconcileNotificationUnreadCounts(ctx)
+}
+
+// ClearExpiredSnoozes resurfaces notifications whose snooze time has passed. Clearing
+// the snooze is what counts them as unread again.
+func (s *NotificationService) ClearExpiredSnoozes(ctx context.Context) (int64, error) {
+	return s.queries.ClearExpiredSnoozes(ctx)
+}
+
 // MarkAllAsRead marks all notifications for a user as read.
 func (s *NotificationService) MarkAllAsRead(ctx context.Context, userID pgtype.UUID) (int64, error) {
 	return s.queries.MarkAllNotificationsAsRead(ctx, userID)
 }
 
+// DeleteAllRead deletes all of a user's read notifications. Unread notifications are kept.
+func (s *NotificationService) DeleteAllRead(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	return s.queries.DeleteReadNotifications(ctx, userID)
+}
+
+// DeleteAllByCampaign deletes all of a user's notifications for a campaign.
+func (s *NotificationService) DeleteAllByCampaign(
+	ctx context.Context,
+	userID, campaignID pgtype.UUID,
+) (int64, error) {
+	return s.queries.DeleteCampaignNotifications(ctx, generated.DeleteCampaignNotificationsParams{
+		UserID:     userID,
+		CampaignID: campaignID,
+	})
+}
+
 // Helper to convert UUID to string.
 func uuidToString(id pgtype.UUID) string {
 	if !id.Valid {
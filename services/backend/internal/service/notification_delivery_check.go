@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// testNotificationLifetime is how long a test notification is kept. It only needs to
+// outlive quiet hours, so a queued test email can still be delivered.
+const testNotificationLifetime = hoursPerDay * time.Hour
+
+// SendTestNotification sends the user a test notification through the real email
+// delivery path, so they can check their settings, and reports what happened to
+// it. Email preferences and quiet hours apply unless bypassQuietHours is set.
+// The test notification is stored already read and is deleted once delivery is
+// decided, unless its email was queued for after quiet hours.
+func (s *NotificationService) SendTestNotification(
+	ctx context.Context,
+	userID pgtype.UUID,
+	bypassQuietHours bool,
+) (*EmailDelivery, error) {
+	metadataJSON, err := json.Marshal(map[string]any{"test": true})
+	if err != nil {
+		return nil, err
+	}
+
+	notification, err := s.queries.CreateNotification(ctx, generated.CreateNotificationParams{
+		UserID:      userID,
+		Title:       "Test notification",
+		Body:        "This is a test notification. If you received it, your notification emails are working.",
+		Type:        NotifTestNotification,
+		CampaignID:  emptyUUID(),
+		SceneID:     emptyUUID(),
+		PostID:      emptyUUID(),
+		CharacterID: emptyUUID(),
+		IsUrgent:    false,
+		Link:        pgtype.Text{String: "", Valid: false},
+		Metadata:    metadataJSON,
+		Column12:    time.Now().Add(testNotificationLifetime),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create test notification: %w", err)
+	}
+
+	// Keep the test out of the unread list and counts
+	notification, err = s.queries.MarkNotificationAsRead(ctx, generated.MarkNotificationAsReadParams{
+		ID:     notification.ID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create test notification: %w", err)
+	}
+
+	delivery, err := s.deliverEmail(ctx, &notification, bypassQuietHours)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.Decision != EmailDeliveryQueued {
+		if deleteErr := s.queries.DeleteNotification(ctx, generated.DeleteNotificationParams{
+			ID:     notification.ID,
+			UserID: userID,
+		}); deleteErr != nil {
+			//nolint:sloglint // Error logging doesn't need structured logger injection
+			slog.Error("Failed to delete test notification", "error", deleteErr)
+		}
+	}
+
+	return &delivery, nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// Digest periods used when no previous digest has been sent.
+const (
+	dailyDigestPeriod  = hoursPerDay * time.Hour
+	weeklyDigestPeriod = 7 * hoursPerDay * time.Hour
+)
+
+// ErrInvalidDigestFrequency is returned when a digest is requested for a non-digest frequency.
+var ErrInvalidDigestFrequency = errors.New("frequency must be digest_daily or digest_weekly")
+
+// DigestItem is a single notification entry in a digest.
+type DigestItem struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	Link      string    `json:"link,omitempty"`
+	IsUrgent  bool      `json:"is_urgent"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DigestCampaignGroup groups digest items by campaign.
+type DigestCampaignGroup struct {
+	CampaignID    string       `json:"campaign_id,omitempty"`
+	CampaignTitle string       `json:"campaign_title"`
+	Count         int          `json:"count"`
+	Items         []DigestItem `json:"items"`
+}
+
+// Digest is the grouped payload of an email digest.
+type Digest struct {
+	Frequency   string                `json:"frequency"`
+	PeriodStart time.Time             `json:"period_start"`
+	PeriodEnd   time.Time             `json:"period_end"`
+	TotalCount  int                   `json:"total_count"`
+	Campaigns   []DigestCampaignGroup `json:"campaigns"`
+}
+
+// BuildDigest assembles the digest payload for a user without sending it or marking
+// any notification as emailed. Notifications are unread ones created since the last
+// digest of the same frequency (or the default period), excluding types the user has
+// muted for email.
+func (s *NotificationService) BuildDigest(
+	ctx context.Context,
+	userID pgtype.UUID,
+	frequency generated.NotificationFrequency,
+	now time.Time,
+) (*Digest, error) {
+	var period time.Duration
+	switch frequency {
+	case generated.NotificationFrequencyDigestDaily:
+		period = dailyDigestPeriod
+	case generated.NotificationFrequencyDigestWeekly:
+		period = weeklyDigestPeriod
+	case generated.NotificationFrequencyRealtime, generated.NotificationFrequencyOff:
+		return nil, ErrInvalidDigestFrequency
+	default:
+		return nil, ErrInvalidDigestFrequency
+	}
+
+	periodStart := now.Add(-period)
+	lastDigest, err := s.queries.GetLastDigestSent(ctx, generated.GetLastDigestSentParams{
+		UserID:     userID,
+		DigestType: string(frequency),
+	})
+	if err == nil && lastDigest.SentAt.Valid && lastDigest.SentAt.Time.After(periodStart) {
+		periodStart = lastDigest.SentAt.Time
+	}
+
+	notifications, err := s.queries.GetNotificationsSince(ctx, generated.GetNotificationsSinceParams{
+		UserID:    userID,
+		CreatedAt: pgtype.Timestamptz{Time: periodStart, Valid: true, InfinityModifier: pgtype.Finite},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	typePrefs := s.getTypePreferences(ctx, userID)
+
+	digest := &Digest{
+		Frequency:   string(frequency),
+		PeriodStart: periodStart,
+		PeriodEnd:   now,
+		TotalCount:  0,
+		Campaigns:   []DigestCampaignGroup{},
+	}
+	groupIndex := make(map[string]int)
+
+	for _, n := range notifications {
+		if !typePrefs.IsEnabled(n.Type, ChannelEmail) {
+			continue
+		}
+
+		key := uuidToString(n.CampaignID)
+		idx, ok := groupIndex[key]
+		if !ok {
+			idx = len(digest.Campaigns)
+			groupIndex[key] = idx
+			digest.Campaigns = append(digest.Campaigns, DigestCampaignGroup{
+				CampaignID:    key,
+				CampaignTitle: s.digestCampaignTitle(ctx, n.CampaignID),
+				Count:         0,
+				Items:         []DigestItem{},
+			})
+		}
+
+		group := &digest.Campaigns[idx]
+		group.Items = append(group.Items, DigestItem{
+			ID:        uuidToString(n.ID),
+			Type:      n.Type,
+			Title:     n.Title,
+			Body:      n.Body,
+			Link:      n.Link.String,
+			IsUrgent:  n.IsUrgent,
+			CreatedAt: n.CreatedAt.Time,
+		})
+		group.Count++
+		digest.TotalCount++
+	}
+
+	return digest, nil
+}
+
+// digestCampaignTitle resolves a campaign title for digest grouping.
+func (s *NotificationService) digestCampaignTitle(ctx context.Context, campaignID pgtype.UUID) string {
+	if !campaignID.Valid {
+		return "General"
+	}
+	campaign, err := s.queries.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return "Unknown campaign"
+	}
+	return campaign.Title
+}
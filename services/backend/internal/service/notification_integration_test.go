@@ -0,0 +1,68 @@
+//go:build integration
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/testdb"
+)
+
+func TestExpiredSnoozeCountsAsUnreadAgain(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	user := testdb.CreateUser(t, tc.pool, "reader@example.com")
+	svc := NewNotificationService(&database.DB{Pool: tc.pool}, generated.New(tc.pool))
+
+	var notificationID pgtype.UUID
+	if err := tc.pool.QueryRow(tc.ctx,
+		"INSERT INTO notifications (user_id, title, body, type) VALUES ($1, 'Hi', 'Hello', 'new_post') RETURNING id",
+		user).Scan(&notificationID); err != nil {
+		t.Fatalf("insert notification: %v", err)
+	}
+
+	unread := func() int64 {
+		t.Helper()
+		count, err := svc.GetUnreadCount(tc.ctx, user)
+		if err != nil {
+			t.Fatalf("unread count: %v", err)
+		}
+		return count
+	}
+
+	if got := unread(); got != 1 {
+		t.Fatalf("unread after create = %d, want 1", got)
+	}
+
+	if _, err := svc.Snooze(tc.ctx, user, notificationID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("snooze: %v", err)
+	}
+	if got := unread(); got != 0 {
+		t.Fatalf("unread while snoozed = %d, want 0", got)
+	}
+
+	// Let the snooze run out, then have the worker clear it
+	if _, err := tc.pool.Exec(tc.ctx,
+		"UPDATE notifications SET snooze_until = NOW() - INTERVAL '1 minute' WHERE id = $1",
+		notificationID); err != nil {
+		t.Fatalf("expire snooze: %v", err)
+	}
+	if _, err := svc.ClearExpiredSnoozes(tc.ctx); err != nil {
+		t.Fatalf("clear expired snoozes: %v", err)
+	}
+	if got := unread(); got != 1 {
+		t.Fatalf("unread after snooze expired = %d, want 1", got)
+	}
+
+	fixed, err := svc.ReconcileUnreadCounts(tc.ctx)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if fixed != 0 {
+		t.Fatalf("reconcile fixed %d counters, want 0", fixed)
+	}
+}
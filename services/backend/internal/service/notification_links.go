@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Link resolution statuses.
+const (
+	LinkStatusOK        = "ok"
+	LinkStatusDeleted   = "deleted"
+	LinkStatusForbidden = "forbidden"
+)
+
+// ErrInvalidLink is returned when a link is not a campaign, scene, or post path.
+var ErrInvalidLink = newCodedError(
+	http.StatusBadRequest, "VALIDATION_ERROR",
+	"Link is not a recognized campaign, scene, or post link",
+	"invalid notification link",
+)
+
+// linkSegments are the path segments a notification link may contain, in order.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var linkSegments = []string{"campaigns", "scenes", "posts"}
+
+// ResolvedLink reports whether a notification link still leads somewhere the user
+// can go. Link is the normalized path when the status is ok; Fallback is the
+// nearest parent page that is still reachable when it is not.
+type ResolvedLink struct {
+	Status     string `json:"status"`
+	Link       string `json:"link,omitempty"`
+	CampaignID string `json:"campaign_id,omitempty"`
+	SceneID    string `json:"scene_id,omitempty"`
+	PostID     string `json:"post_id,omitempty"`
+	Fallback   string `json:"fallback,omitempty"`
+}
+
+// linkTarget holds the IDs named in a link. Trailing IDs are invalid when the
+// link stops short of them.
+type linkTarget struct {
+	campaignID pgtype.UUID
+	sceneID    pgtype.UUID
+	postID     pgtype.UUID
+}
+
+// ResolveLink checks that the campaign, scene, or post a notification link points
+// at still exists and is visible to the user. Posts resolve to the scene they are
+// in now, so links to moved posts still work.
+func (s *NotificationService) ResolveLink(
+	ctx context.Context,
+	userID pgtype.UUID,
+	link string,
+) (*ResolvedLink, error) {
+	target, err := parseNotificationLink(link)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := s.resolveLinkTarget(ctx, userID, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolved.Status != LinkStatusOK {
+		resolved.Fallback = s.linkFallback(ctx, userID, target)
+	}
+
+	return resolved, nil
+}
+
+// resolveLinkTarget applies the same access checks as the campaign, scene, and
+// post endpoints.
+func (s *NotificationService) resolveLinkTarget(
+	ctx context.Context,
+	userID pgtype.UUID,
+	target linkTarget,
+) (*ResolvedLink, error) {
+	switch {
+	case target.postID.Valid:
+		post, err := NewPostService(s.db.Pool).GetPost(ctx, userID, uuidToString(target.postID))
+		if err != nil {
+			return linkFailure(err)
+		}
+		scene, err := NewSceneService(s.db.Pool).GetScene(ctx, parseUUIDString(post.SceneID), userID)
+		if err != nil {
+			return linkFailure(err)
+		}
+		return resolvedLink(scene.CampaignID, scene.ID, target.postID), nil
+
+	case target.sceneID.Valid:
+		scene, err := NewSceneService(s.db.Pool).GetScene(ctx, target.sceneID, userID)
+		if err != nil {
+			return linkFailure(err)
+		}
+		return resolvedLink(scene.CampaignID, scene.ID, pgtype.UUID{}), nil
+
+	default:
+		if _, err := NewCampaignService(s.db.Pool).GetCampaign(ctx, target.campaignID, userID); err != nil {
+			return linkFailure(err)
+		}
+		return resolvedLink(target.campaignID, pgtype.UUID{}, pgtype.UUID{}), nil
+	}
+}
+
+// linkFallback returns the closest parent of target that the user can still open,
+// or an empty string if there is none. Lookup errors just end the search.
+func (s *NotificationService) linkFallback(
+	ctx context.Context,
+	userID pgtype.UUID,
+	target linkTarget,
+) string {
+	var parents []linkTarget
+	if target.postID.Valid {
+		parents = append(parents, linkTarget{campaignID: target.campaignID, sceneID: target.sceneID})
+	}
+	if target.sceneID.Valid {
+		parents = append(parents, linkTarget{campaignID: target.campaignID})
+	}
+
+	for _, parent := range parents {
+		resolved, err := s.resolveLinkTarget(ctx, userID, parent)
+		if err != nil {
+			return ""
+		}
+		if resolved.Status == LinkStatusOK {
+			return resolved.Link
+		}
+	}
+
+	return ""
+}
+
+// linkFailure turns an access check error into a link status. Posts the user
+// did not witness report as deleted, matching the post endpoints.
+func linkFailure(err error) (*ResolvedLink, error) {
+	switch {
+	case errors.Is(err, ErrCampaignNotFound),
+		errors.Is(err, ErrSceneNotFound),
+		errors.Is(err, ErrPostNotFound):
+		return &ResolvedLink{Status: LinkStatusDeleted}, nil
+	case errors.Is(err, ErrNotMember):
+		return &ResolvedLink{Status: LinkStatusForbidden}, nil
+	default:
+		return nil, err
+	}
+}
+
+func resolvedLink(campaignID, sceneID, postID pgtype.UUID) *ResolvedLink {
+	resolved := &ResolvedLink{
+		Status:     LinkStatusOK,
+		CampaignID: uuidToString(campaignID),
+		Link:       fmt.Sprintf("/campaigns/%s", uuidToString(campaignID)),
+	}
+	if sceneID.Valid {
+		resolved.SceneID = uuidToString(sceneID)
+		resolved.Link += "/scenes/" + resolved.SceneID
+	}
+	if postID.Valid {
+		resolved.PostID = uuidToString(postID)
+		resolved.Link += "/posts/" + resolved.PostID
+	}
+	return resolved
+}
+
+// parseNotificationLink reads a link of the form
+// /campaigns/{id}[/scenes/{id}[/posts/{id}]].
+func parseNotificationLink(link string) (linkTarget, error) {
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return linkTarget{}, ErrInvalidLink
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts)%2 != 0 || len(parts) > 2*len(linkSegments) {
+		return linkTarget{}, ErrInvalidLink
+	}
+
+	ids := make([]pgtype.UUID, len(linkSegments))
+	for i := 0; i < len(parts); i += 2 {
+		if parts[i] != linkSegments[i/2] {
+			return linkTarget{}, ErrInvalidLink
+		}
+		id := parseUUIDString(parts[i+1])
+		if !id.Valid {
+			return linkTarget{}, ErrInvalidLink
+		}
+		ids[i/2] = id
+	}
+
+	return linkTarget{campaignID: ids[0], sceneID: ids[1], postID: ids[2]}, nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Notification delivery channels used in per-type preferences.
+const (
+	ChannelInApp = "in_app"
+	ChannelEmail = "email"
+)
+
+// ErrInvalidTypePreferences is returned when per-type preferences reference unknown types or channels.
+var ErrInvalidTypePreferences = errors.New("invalid notification type preferences")
+
+// NotificationTypePreferences maps a notification type to per-channel enabled flags.
+// Types or channels that are not present are treated as enabled.
+type NotificationTypePreferences map[string]map[string]bool
+
+// KnownNotificationTypes returns every notification type a user can configure.
+func KnownNotificationTypes() []string {
+	return []string{
+		NotifPCPhaseStarted,
+		NotifNewPostInScene,
+		NotifRollRequested,
+		NotifIntentionOverridden,
+		NotifCharacterAddedScene,
+		NotifComposeLockReleased,
+		NotifTimeGateWarning24h,
+		NotifTimeGateWarning6h,
+		NotifTimeGateWarning1h,
+		NotifPassStateCleared,
+		NotifGMRoleAvailable,
+		NotifAllCharactersPassed,
+		NotifTimeGateExpired,
+		NotifHiddenPostSubmitted,
+		NotifPlayerJoined,
+		NotifPlayerRollSubmitted,
+		NotifUnresolvedRollsExist,
+		NotifCampaignAtPlayerLimit,
+		NotifSceneLimitWarning,
+		NotifMentioned,
+		NotifPhaseAutoTransitioned,
+		NotifCharactersOrphaned,
+	}
+}
+
+// isNotificationType reports whether t is a notification type the service creates.
+func isNotificationType(t string) bool {
+	return t == NotifTestNotification || slices.Contains(KnownNotificationTypes(), t)
+}
+
+// ParseNotificationTypePreferences decodes stored per-type preferences.
+// Invalid or empty JSON yields an empty map, meaning everything is enabled.
+func ParseNotificationTypePreferences(raw []byte) NotificationTypePreferences {
+	prefs := NotificationTypePreferences{}
+	if len(raw) == 0 {
+		return prefs
+	}
+	if err := json.Unmarshal(raw, &prefs); err != nil {
+		return NotificationTypePreferences{}
+	}
+	return prefs
+}
+
+// IsEnabled reports whether the notification type is enabled for the channel.
+func (p NotificationTypePreferences) IsEnabled(notifType, channel string) bool {
+	channels, ok := p[notifType]
+	if !ok {
+		return true
+	}
+	enabled, ok := channels[channel]
+	if !ok {
+		return true
+	}
+	return enabled
+}
+
+// WithDefaults returns a copy containing every known type and channel, filling gaps with enabled.
+func (p NotificationTypePreferences) WithDefaults() NotificationTypePreferences {
+	full := make(NotificationTypePreferences, len(KnownNotificationTypes()))
+	for _, notifType := range KnownNotificationTypes() {
+		full[notifType] = map[string]bool{
+			ChannelInApp: p.IsEnabled(notifType, ChannelInApp),
+			ChannelEmail: p.IsEnabled(notifType, ChannelEmail),
+		}
+	}
+	return full
+}
+
+// Validate checks that all keys are known notification types and channels.
+func (p NotificationTypePreferences) Validate() error {
+	known := make(map[string]bool, len(KnownNotificationTypes()))
+	for _, notifType := range KnownNotificationTypes() {
+		known[notifType] = true
+	}
+
+	for notifType, channels := range p {
+		if !known[notifType] {
+			return fmt.Errorf("%w: unknown notification type %q", ErrInvalidTypePreferences, notifType)
+		}
+		for channel := range channels {
+			if channel != ChannelInApp && channel != ChannelEmail {
+				return fmt.Errorf("%w: unknown channel %q", ErrInvalidTypePreferences, channel)
+			}
+		}
+	}
+
+	return nil
+}
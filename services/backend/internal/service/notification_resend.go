@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// ErrEmailResendTooSoon is returned when a notification's email was sent less than
+// a minute ago.
+var ErrEmailResendTooSoon = newCodedError(
+	http.StatusTooManyRequests, "RATE_LIMITED",
+	"This email was sent less than a minute ago. Please try again later.",
+	"notification email was sent less than a minute ago",
+)
+
+// ResendEmailResult reports whether a resent notification email went out, and if
+// not, why it was suppressed.
+type ResendEmailResult struct {
+	Sent             bool   `json:"sent"`
+	SuppressedReason string `json:"suppressedReason,omitempty"`
+}
+
+// ResendEmail sends the email for one of the user's notifications again, e.g. after
+// a bounce. The user's email preferences and quiet hours apply as they would for a
+// new notification, except that nothing is queued: an email held by quiet hours is
+// reported as suppressed. A notification's email can be sent at most once a minute.
+func (s *NotificationService) ResendEmail(
+	ctx context.Context,
+	userID, notificationID pgtype.UUID,
+) (*ResendEmailResult, error) {
+	userNotification, err := s.GetNotification(ctx, userID, notificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	reason, err := s.emailPreferenceReason(ctx, userID, userNotification.Type)
+	if err != nil {
+		return nil, err
+	}
+	if reason == "" && s.heldByQuietHours(ctx, userID, userNotification.IsUrgent) {
+		reason = EmailSuppressedQuietHours
+	}
+	if reason != "" {
+		return &ResendEmailResult{Sent: false, SuppressedReason: reason}, nil
+	}
+
+	notification, err := s.queries.ClaimNotificationEmailResend(ctx, generated.ClaimNotificationEmailResendParams{
+		ID:     notificationID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmailResendTooSoon
+		}
+		return nil, err
+	}
+
+	if err = s.sendImmediateEmail(ctx, &notification); err != nil {
+		return nil, err
+	}
+
+	return &ResendEmailResult{Sent: true, SuppressedReason: ""}, nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+var errMarkSentFailed = errors.New("connection reset")
+
+// failingExecDB fails every statement executed through it.
+type failingExecDB struct{}
+
+func (failingExecDB) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errMarkSentFailed
+}
+
+func (failingExecDB) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, errMarkSentFailed
+}
+
+func (failingExecDB) QueryRow(context.Context, string, ...any) pgx.Row {
+	return nil
+}
+
+func TestSendImmediateEmailReportsFailure(t *testing.T) {
+	svc := &NotificationService{db: nil, queries: generated.New(failingExecDB{})}
+
+	//nolint:exhaustruct // Only the ID is needed to mark the email sent
+	notification := &generated.Notification{ID: pgtype.UUID{Bytes: [16]byte{1}, Valid: true}}
+	if err := svc.sendImmediateEmail(context.Background(), notification); !errors.Is(err, errMarkSentFailed) {
+		t.Fatalf("sendImmediateEmail() = %v, want the mark-sent error", err)
+	}
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // Zone lookups must not depend on the host's zoneinfo
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+func clock(hour, minute int) int {
+	return hour*minutesPerHour + minute
+}
+
+func quietTime(hour, minute int) pgtype.Time {
+	return pgtype.Time{Microseconds: int64(clock(hour, minute)) * microsecondsPerMin, Valid: true}
+}
+
+func TestIsWithinQuietWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		current int
+		start   int
+		end     int
+		want    bool
+	}{
+		{"daytime before start", clock(8, 59), clock(9, 0), clock(17, 0), false},
+		{"daytime at start is inclusive", clock(9, 0), clock(9, 0), clock(17, 0), true},
+		{"daytime inside", clock(12, 30), clock(9, 0), clock(17, 0), true},
+		{"daytime at end is exclusive", clock(17, 0), clock(9, 0), clock(17, 0), false},
+		{"overnight at start is inclusive", clock(22, 0), clock(22, 0), clock(8, 0), true},
+		{"overnight before midnight", clock(23, 59), clock(22, 0), clock(8, 0), true},
+		{"overnight at midnight", clock(0, 0), clock(22, 0), clock(8, 0), true},
+		{"overnight after midnight", clock(7, 59), clock(22, 0), clock(8, 0), true},
+		{"overnight at end is exclusive", clock(8, 0), clock(22, 0), clock(8, 0), false},
+		{"overnight daytime gap", clock(15, 0), clock(22, 0), clock(8, 0), false},
+		{"ending at midnight", clock(23, 0), clock(20, 0), clock(0, 0), true},
+		{"ending at midnight excludes midnight", clock(0, 0), clock(20, 0), clock(0, 0), false},
+		{"equal start and end covers the day", clock(3, 0), clock(10, 0), clock(10, 0), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isWithinQuietWindow(tt.current, tt.start, tt.end); got != tt.want {
+				t.Errorf("isWithinQuietWindow(%d, %d, %d) = %v, want %v",
+					tt.current, tt.start, tt.end, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietHoursActive(t *testing.T) {
+	overnight := func(timezone string) *generated.QuietHour {
+		return &generated.QuietHour{
+			Enabled:   true,
+			StartTime: quietTime(22, 0),
+			EndTime:   quietTime(8, 0),
+			Timezone:  timezone,
+		}
+	}
+	// A window off the hour that crosses midnight, checked at Berlin wall-clock times
+	partial := &generated.QuietHour{
+		Enabled:   true,
+		StartTime: quietTime(22, 30),
+		EndTime:   quietTime(6, 15),
+		Timezone:  "Europe/Berlin",
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		quietHours *generated.QuietHour
+		now        time.Time
+		want       bool
+	}{
+		{
+			name:       "disabled",
+			quietHours: &generated.QuietHour{Enabled: false, Timezone: "UTC"},
+			now:        time.Date(2026, 1, 15, 23, 0, 0, 0, time.UTC),
+			want:       false,
+		},
+		{
+			// 06:00 UTC is 22:00 the previous evening in Los Angeles
+			name:       "quiet in the user's zone but not UTC",
+			quietHours: overnight("America/Los_Angeles"),
+			now:        time.Date(2026, 1, 15, 6, 0, 0, 0, time.UTC),
+			want:       true,
+		},
+		{
+			// 23:00 UTC is 08:00 the next morning in Tokyo
+			name:       "quiet in UTC but not the user's zone",
+			quietHours: overnight("Asia/Tokyo"),
+			now:        time.Date(2026, 1, 15, 23, 0, 0, 0, time.UTC),
+			want:       false,
+		},
+		{
+			// 07:30 local on the day clocks spring forward in New York
+			name:       "daylight saving change",
+			quietHours: overnight("America/New_York"),
+			now:        time.Date(2026, 3, 8, 11, 30, 0, 0, time.UTC),
+			want:       true,
+		},
+		{
+			name:       "minute before a 22:30 start",
+			quietHours: partial,
+			now:        time.Date(2026, 1, 15, 22, 29, 0, 0, berlin),
+			want:       false,
+		},
+		{
+			name:       "at a 22:30 start",
+			quietHours: partial,
+			now:        time.Date(2026, 1, 15, 22, 30, 0, 0, berlin),
+			want:       true,
+		},
+		{
+			name:       "midnight inside a 22:30-06:15 window",
+			quietHours: partial,
+			now:        time.Date(2026, 1, 16, 0, 0, 0, 0, berlin),
+			want:       true,
+		},
+		{
+			name:       "minute before a 06:15 end",
+			quietHours: partial,
+			now:        time.Date(2026, 1, 16, 6, 14, 0, 0, berlin),
+			want:       true,
+		},
+		{
+			name:       "at a 06:15 end",
+			quietHours: partial,
+			now:        time.Date(2026, 1, 16, 6, 15, 0, 0, berlin),
+			want:       false,
+		},
+		{
+			name:       "unknown zone falls back to UTC",
+			quietHours: overnight("Not/AZone"),
+			now:        time.Date(2026, 1, 15, 23, 0, 0, 0, time.UTC),
+			want:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quietHoursActive(tt.quietHours, tt.now); got != tt.want {
+				t.Errorf("quietHoursActive(%s) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietHoursEnd(t *testing.T) {
+	window := func(start, end pgtype.Time) *generated.QuietHour {
+		return &generated.QuietHour{Enabled: true, StartTime: start, EndTime: end, Timezone: "Europe/Berlin"}
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		quietHours *generated.QuietHour
+		now        time.Time
+		want       time.Time
+	}{
+		{
+			name:       "overnight window ends the next morning",
+			quietHours: window(quietTime(22, 0), quietTime(8, 0)),
+			now:        time.Date(2026, 1, 15, 23, 0, 0, 0, berlin),
+			want:       time.Date(2026, 1, 16, 8, 0, 0, 0, berlin),
+		},
+		{
+			name:       "after midnight ends the same morning",
+			quietHours: window(quietTime(22, 0), quietTime(8, 0)),
+			now:        time.Date(2026, 1, 16, 2, 0, 0, 0, berlin),
+			want:       time.Date(2026, 1, 16, 8, 0, 0, 0, berlin),
+		},
+		{
+			// Flushing exactly at the end must not requeue for the same instant
+			name:       "all-day window at its end moves a day on",
+			quietHours: window(quietTime(9, 0), quietTime(9, 0)),
+			now:        time.Date(2026, 1, 16, 9, 0, 0, 0, berlin),
+			want:       time.Date(2026, 1, 17, 9, 0, 0, 0, berlin),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quietHoursEnd(tt.quietHours, tt.now); !got.Equal(tt.want) {
+				t.Errorf("quietHoursEnd(%s) = %s, want %s", tt.now, got, tt.want)
+			}
+		})
+	}
+}
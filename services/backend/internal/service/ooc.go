@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// MaxOocMessageLen is the maximum length of an OOC message body in characters.
+const MaxOocMessageLen = 2000
+
+// OOC message errors.
+var (
+	ErrOocMessageNotFound = errors.New("OOC message not found")
+	ErrInvalidOocMessage  = errors.New("OOC message must be between 1 and 2000 characters")
+	ErrInvalidOocParent   = errors.New("replies must reference a top-level message in the same scene")
+	ErrNotOocAuthor       = errors.New("only the author or GM can delete this message")
+)
+
+// OocService handles out-of-character scene discussion.
+// OOC messages are not posts: they ignore witness rules, never lock, and never touch pass state.
+type OocService struct {
+	queries *generated.Queries
+	pool    *pgxpool.Pool
+}
+
+// NewOocService creates a new OocService.
+func NewOocService(pool *pgxpool.Pool) *OocService {
+	return &OocService{
+		queries: newQueries(pool),
+		pool:    pool,
+	}
+}
+
+// CreateOocMessageRequest represents the request to post an OOC message.
+type CreateOocMessageRequest struct {
+	Body     string  `json:"body"`
+	ParentID *string `json:"parentId"`
+}
+
+// OocMessageResponse represents an OOC message in API responses.
+type OocMessageResponse struct {
+	ID        string  `json:"id"`
+	SceneID   string  `json:"sceneId"`
+	UserID    string  `json:"userId"`
+	ParentID  *string `json:"parentId"`
+	Body      string  `json:"body"`
+	CreatedAt string  `json:"createdAt"`
+}
+
+// CreateOocMessage adds an OOC message to a scene. Any campaign member may post.
+func (s *OocService) CreateOocMessage(
+	ctx context.Context,
+	campaignID, sceneID, userID pgtype.UUID,
+	req CreateOocMessageRequest,
+) (*OocMessageResponse, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" || len([]rune(body)) > MaxOocMessageLen {
+		return nil, ErrInvalidOocMessage
+	}
+
+	if _, err := s.verifySceneAccess(ctx, campaignID, sceneID, userID); err != nil {
+		return nil, err
+	}
+
+	parentID := pgtype.UUID{}
+	if req.ParentID != nil && *req.ParentID != "" {
+		parent, err := s.queries.GetOocMessage(ctx, parseUUIDString(*req.ParentID))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrInvalidOocParent
+			}
+			return nil, err
+		}
+		if parent.SceneID != sceneID || parent.ParentID.Valid {
+			return nil, ErrInvalidOocParent
+		}
+		parentID = parent.ID
+	}
+
+	msg, err := s.queries.CreateOocMessage(ctx, generated.CreateOocMessageParams{
+		SceneID:  sceneID,
+		UserID:   userID,
+		ParentID: parentID,
+		Body:     body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return oocMessageToResponse(&msg), nil
+}
+
+// ListOocMessages returns a scene's OOC messages, oldest first.
+func (s *OocService) ListOocMessages(
+	ctx context.Context,
+	campaignID, sceneID, userID pgtype.UUID,
+) ([]OocMessageResponse, error) {
+	if _, err := s.verifySceneAccess(ctx, campaignID, sceneID, userID); err != nil {
+		return nil, err
+	}
+
+	messages, err := s.queries.ListSceneOocMessages(ctx, sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]OocMessageResponse, 0, len(messages))
+	for i := range messages {
+		result = append(result, *oocMessageToResponse(&messages[i]))
+	}
+	return result, nil
+}
+
+// DeleteOocMessage deletes an OOC message and its replies. Only the author or the GM may delete.
+func (s *OocService) DeleteOocMessage(
+	ctx context.Context,
+	campaignID, sceneID, messageID, userID pgtype.UUID,
+) error {
+	isGM, err := s.verifySceneAccess(ctx, campaignID, sceneID, userID)
+	if err != nil {
+		return err
+	}
+
+	msg, err := s.queries.GetOocMessage(ctx, messageID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrOocMessageNotFound
+		}
+		return err
+	}
+	if msg.SceneID != sceneID {
+		return ErrOocMessageNotFound
+	}
+	if msg.UserID != userID && !isGM {
+		return ErrNotOocAuthor
+	}
+
+	return s.queries.DeleteOocMessage(ctx, messageID)
+}
+
+// verifySceneAccess checks that the scene belongs to the campaign and the user is a member.
+// It reports whether the user is the campaign's GM.
+func (s *OocService) verifySceneAccess(
+	ctx context.Context,
+	campaignID, sceneID, userID pgtype.UUID,
+) (bool, error) {
+	scene, err := s.queries.GetScene(ctx, sceneID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, ErrSceneNotFound
+		}
+		return false, err
+	}
+	if scene.CampaignID != campaignID {
+		return false, ErrSceneNotFound
+	}
+
+	member, err := s.queries.GetCampaignMember(ctx, generated.GetCampaignMemberParams{
+		CampaignID: campaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, ErrNotMember
+		}
+		return false, err
+	}
+
+	return member.Role == generated.MemberRoleGm, nil
+}
+
+func oocMessageToResponse(msg *generated.OocMessage) *OocMessageResponse {
+	var parentID *string
+	if msg.ParentID.Valid {
+		id := formatUUID(msg.ParentID.Bytes[:])
+		parentID = &id
+	}
+
+	return &OocMessageResponse{
+		ID:        formatUUID(msg.ID.Bytes[:]),
+		SceneID:   formatUUID(msg.SceneID.Bytes[:]),
+		UserID:    formatUUID(msg.UserID.Bytes[:]),
+		ParentID:  parentID,
+		Body:      msg.Body,
+		CreatedAt: msg.CreatedAt.Time.Format(time.RFC3339),
+	}
+}
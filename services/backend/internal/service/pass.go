@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"slices"
 	"time"
 
@@ -14,10 +15,31 @@ import (
 )
 
 // Pass errors (using existing errors from other services where applicable).
-// Note: ErrTimeGateExpired is defined in compose.go and reused here.
 var (
-	ErrCannotPassPendingRolls = errors.New("cannot pass with pending rolls")
-	ErrInvalidPassState       = errors.New("invalid pass state")
+	// ErrNotInPCPhase and ErrTimeGateExpired from compose.go, worded for passing
+	ErrPassNotInPCPhase    = ErrNotInPCPhase.WithMessage("Can only pass during PC phase")
+	ErrPassTimeGateExpired = ErrTimeGateExpired.WithMessage("Phase has expired. Waiting for GM to transition.")
+
+	ErrCannotPassPendingRolls = newCodedError(
+		http.StatusBadRequest, "PENDING_ROLLS",
+		"Cannot pass with pending rolls",
+		"cannot pass with pending rolls",
+	)
+	ErrInvalidPassState = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Invalid pass state",
+		"invalid pass state",
+	)
+	ErrHardPassNotCleared = newCodedError(
+		http.StatusForbidden, "HARD_PASS",
+		"Hard passes can only be cleared by the GM with force=true",
+		"hard passes can only be cleared by the GM with force",
+	)
+	ErrPassCampaignMismatch = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Scene or character does not belong to this campaign",
+		"scene or character does not belong to this campaign",
+	)
 )
 
 // Valid pass states.
@@ -36,7 +58,7 @@ type PassService struct {
 // NewPassService creates a new PassService.
 func NewPassService(pool *pgxpool.Pool) *PassService {
 	return &PassService{
-		queries: generated.New(pool),
+		queries: newQueries(pool),
 		pool:    pool,
 	}
 }
@@ -65,14 +87,27 @@ type SetPassRequest struct {
 	PassState   string      `binding:"required,oneof=none passed hard_passed" json:"passState"`
 }
 
-// SetPass sets the pass state for a character in a scene.
+// SetPass sets the pass state for a character in a scene. The scene and character
+// must both belong to campaignID, the campaign named in the request URL.
+func (s *PassService) SetPass(
+	ctx context.Context,
+	userID pgtype.UUID,
+	campaignID, sceneID, characterID pgtype.UUID,
+	passState string,
+) error {
+	return s.setPass(ctx, userID, campaignID, sceneID, characterID, passState, false)
+}
+
+// setPass sets the pass state for a character. Clearing a hard pass requires the GM
+// and force, since hard passes are set by time gate expiry.
 //
 //nolint:gocognit,nestif // GM authorization logic requires nested permission checks
-func (s *PassService) SetPass(
+func (s *PassService) setPass(
 	ctx context.Context,
 	userID pgtype.UUID,
-	sceneID, characterID pgtype.UUID,
+	campaignID, sceneID, characterID pgtype.UUID,
 	passState string,
+	force bool,
 ) error {
 	// Validate pass state
 	if passState != PassStateNone && passState != PassStatePassed && passState != PassStateHardPassed {
@@ -87,14 +122,29 @@ func (s *PassService) SetPass(
 		}
 		return err
 	}
+	if scene.CampaignID != campaignID {
+		return ErrPassCampaignMismatch
+	}
+
+	// Character must exist and belong to the same campaign, even for the GM
+	char, err := s.queries.GetCharacter(ctx, characterID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCharacterNotFound
+		}
+		return err
+	}
+	if char.CampaignID != campaignID {
+		return ErrPassCampaignMismatch
+	}
 
 	// Check campaign is in PC phase
 	if scene.CurrentPhase != generated.CampaignPhasePcPhase {
-		return ErrNotInPCPhase
+		return ErrPassNotInPCPhase
 	}
 
 	// Verify user is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -106,7 +156,7 @@ func (s *PassService) SetPass(
 	}
 
 	// Check if user owns the character (or is GM)
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -114,19 +164,25 @@ func (s *PassService) SetPass(
 		return err
 	}
 
+	if force && !isGM {
+		return ErrNotGM
+	}
+
+	// Hard passes are system-enforced; only an explicit GM force may clear them
+	if passState == PassStateNone && !force {
+		var passStates map[string]string
+		if unmarshalErr := json.Unmarshal(scene.PassStates, &passStates); unmarshalErr != nil {
+			passStates = make(map[string]string)
+		}
+		if passStates[formatPgtypeUUID(characterID)] == PassStateHardPassed {
+			return ErrHardPassNotCleared
+		}
+	}
+
 	if !isGM {
 		// Check if time gate has expired (players cannot pass after expiration)
 		if scene.CurrentPhaseExpiresAt.Valid && time.Now().After(scene.CurrentPhaseExpiresAt.Time) {
-			return ErrTimeGateExpired
-		}
-
-		// Get character to verify it exists
-		_, charErr := s.queries.GetCharacter(ctx, characterID)
-		if charErr != nil {
-			if errors.Is(charErr, pgx.ErrNoRows) {
-				return ErrCharacterNotFound
-			}
-			return charErr
+			return ErrPassTimeGateExpired
 		}
 
 		// Check if character is assigned to user
@@ -174,23 +230,26 @@ func (s *PassService) SetPass(
 }
 
 // ClearPass clears (sets to 'none') the pass state for a character.
+// With force the GM can also clear a hard pass.
 func (s *PassService) ClearPass(
 	ctx context.Context,
 	userID pgtype.UUID,
-	sceneID, characterID pgtype.UUID,
+	campaignID, sceneID, characterID pgtype.UUID,
+	force bool,
 ) error {
-	return s.SetPass(ctx, userID, sceneID, characterID, PassStateNone)
+	return s.setPass(ctx, userID, campaignID, sceneID, characterID, PassStateNone, force)
 }
 
 // AutoClearPass clears pass on post (unless hard passed). This is called internally.
+// It reports whether a regular pass was cleared so callers can broadcast the change.
 func (s *PassService) AutoClearPass(
 	ctx context.Context,
 	sceneID, characterID pgtype.UUID,
-) error {
+) (bool, error) {
 	// Get current pass state
 	scene, err := s.queries.GetScene(ctx, sceneID)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// Check current pass state from JSONB
@@ -203,18 +262,20 @@ func (s *PassService) AutoClearPass(
 	currentState := passStates[charIDStr]
 
 	// Only clear if regular pass (not hard pass)
-	if currentState == PassStatePassed {
-		_, clearErr := s.queries.SetCharacterPassState(ctx, generated.SetCharacterPassStateParams{
-			ID:      sceneID,
-			Column2: charIDStr,
-			Column3: PassStateNone,
-		})
-		if clearErr != nil {
-			return clearErr
-		}
+	if currentState != PassStatePassed {
+		return false, nil
 	}
 
-	return nil
+	_, clearErr := s.queries.SetCharacterPassState(ctx, generated.SetCharacterPassStateParams{
+		ID:      sceneID,
+		Column2: charIDStr,
+		Column3: PassStateNone,
+	})
+	if clearErr != nil {
+		return false, clearErr
+	}
+
+	return true, nil
 }
 
 // GetCampaignPassSummary returns the pass summary for a campaign.
@@ -223,7 +284,7 @@ func (s *PassService) GetCampaignPassSummary(
 	campaignID, userID pgtype.UUID,
 ) (*CampaignPassSummary, error) {
 	// Verify user is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -308,7 +369,7 @@ func (s *PassService) GetCampaignPassSummary(
 // GetScenePassStates returns pass states for a specific scene.
 func (s *PassService) GetScenePassStates(
 	ctx context.Context,
-	sceneID, userID pgtype.UUID,
+	campaignID, sceneID, userID pgtype.UUID,
 ) (map[string]string, error) {
 	// Get scene with campaign
 	scene, err := s.queries.GetSceneWithCampaign(ctx, sceneID)
@@ -318,9 +379,12 @@ func (s *PassService) GetScenePassStates(
 		}
 		return nil, err
 	}
+	if scene.CampaignID != campaignID {
+		return nil, ErrPassCampaignMismatch
+	}
 
 	// Verify user is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -345,27 +409,132 @@ func (s *PassService) GetScenePassStates(
 	return passStates, nil
 }
 
-// AutoPassAllCharacters sets all unpassed PCs in the campaign to "passed" state.
-// Called lazily when time gate has expired and a user interacts with the system.
-func (s *PassService) AutoPassAllCharacters(ctx context.Context, campaignID pgtype.UUID) error {
+// AutoPassAllCharacters sets all unpassed PCs in the campaign to "passed" state,
+// returning how many characters it passed. Called when the time gate has expired,
+// lazily when a user interacts with the system and by the time gate worker.
+func (s *PassService) AutoPassAllCharacters(ctx context.Context, campaignID pgtype.UUID) (int, error) {
 	scenes, err := s.queries.GetAllActiveScenesInCampaign(ctx, campaignID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
+	passed := 0
 	for _, scene := range scenes {
 		// Process each scene individually, continue on error (best effort)
-		_ = s.autoPassCharactersInScene(ctx, scene)
+		scenePassed, _ := s.autoPassCharactersInScene(ctx, scene)
+		passed += scenePassed
 	}
 
-	return nil
+	return passed, nil
+}
+
+// GMPassedCharacter identifies a character the GM passed with GMPassAll.
+type GMPassedCharacter struct {
+	SceneID     string `json:"sceneId"`
+	CharacterID string `json:"characterId"`
 }
 
-// autoPassCharactersInScene marks all unpassed PCs in a single scene as passed.
+// GMPassAll sets every unpassed PC in the campaign's active scenes to "passed" (GM only).
+// Unlike time gate expiry this uses a regular pass, so players can still post and clear it.
+func (s *PassService) GMPassAll(
+	ctx context.Context,
+	campaignID, gmUserID pgtype.UUID,
+) ([]GMPassedCharacter, error) {
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	campaign, err := s.queries.GetCampaign(ctx, campaignID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, err
+	}
+	if campaign.CurrentPhase != generated.CampaignPhasePcPhase {
+		return nil, ErrPassNotInPCPhase
+	}
+
+	scenes, err := s.queries.GetAllActiveScenesInCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	passed := []GMPassedCharacter{}
+	for _, scene := range scenes {
+		sceneChanges, sceneErr := s.gmPassCharactersInScene(ctx, scene)
+		if sceneErr != nil {
+			return nil, sceneErr
+		}
+		passed = append(passed, sceneChanges...)
+	}
+
+	return passed, nil
+}
+
+// gmPassCharactersInScene marks PCs with no pass state in a single scene as passed.
+func (s *PassService) gmPassCharactersInScene(
+	ctx context.Context,
+	scene generated.Scene,
+) ([]GMPassedCharacter, error) {
+	var passStates map[string]string
+	if unmarshalErr := json.Unmarshal(scene.PassStates, &passStates); unmarshalErr != nil {
+		passStates = make(map[string]string)
+	}
+
+	chars, err := s.queries.GetSceneCharacters(ctx, scene.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	passed := []GMPassedCharacter{}
+	for _, char := range chars {
+		if char.CharacterType != generated.CharacterTypePc {
+			continue
+		}
+
+		charIDStr := formatPgtypeUUID(char.ID)
+		if state := passStates[charIDStr]; state == "" || state == PassStateNone {
+			passStates[charIDStr] = PassStatePassed
+			passed = append(passed, GMPassedCharacter{
+				SceneID:     formatPgtypeUUID(scene.ID),
+				CharacterID: charIDStr,
+			})
+		}
+	}
+
+	if len(passed) == 0 {
+		return passed, nil
+	}
+
+	passStatesJSON, err := json.Marshal(passStates)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.queries.UpdateScenePassStates(ctx, generated.UpdateScenePassStatesParams{
+		ID:         scene.ID,
+		PassStates: passStatesJSON,
+	}); err != nil {
+		return nil, err
+	}
+
+	return passed, nil
+}
+
+// autoPassCharactersInScene marks all unpassed PCs in a single scene as passed,
+// returning how many it passed.
 func (s *PassService) autoPassCharactersInScene(
 	ctx context.Context,
 	scene generated.Scene,
-) error {
+) (int, error) {
 	var passStates map[string]string
 	if unmarshalErr := json.Unmarshal(scene.PassStates, &passStates); unmarshalErr != nil {
 		passStates = make(map[string]string)
@@ -373,10 +542,10 @@ func (s *PassService) autoPassCharactersInScene(
 
 	chars, charsErr := s.queries.GetSceneCharacters(ctx, scene.ID)
 	if charsErr != nil {
-		return charsErr
+		return 0, charsErr
 	}
 
-	needsUpdate := false
+	passed := 0
 	for _, char := range chars {
 		if char.CharacterType != generated.CharacterTypePc {
 			continue
@@ -387,36 +556,35 @@ func (s *PassService) autoPassCharactersInScene(
 			// Use hard_passed for time gate expiration (system-enforced, can't be cleared)
 			// This upgrades both "none" and "passed" to "hard_passed"
 			passStates[charIDStr] = PassStateHardPassed
-			needsUpdate = true
+			passed++
 		}
 	}
 
-	if !needsUpdate {
-		return nil
+	if passed == 0 {
+		return 0, nil
 	}
 
 	passStatesJSON, marshalErr := json.Marshal(passStates)
 	if marshalErr != nil {
-		return marshalErr
+		return 0, marshalErr
 	}
 
-	_, updateErr := s.queries.UpdateScenePassStates(ctx, generated.UpdateScenePassStatesParams{
+	if _, updateErr := s.queries.UpdateScenePassStates(ctx, generated.UpdateScenePassStatesParams{
 		ID:         scene.ID,
 		PassStates: passStatesJSON,
-	})
+	}); updateErr != nil {
+		return 0, updateErr
+	}
 
-	return updateErr
+	return passed, nil
 }
 
 // checkCharacterHasPendingRolls checks if a character has any pending rolls.
 func (s *PassService) checkCharacterHasPendingRolls(
-	_ context.Context,
-	_ pgtype.UUID,
+	ctx context.Context,
+	characterID pgtype.UUID,
 ) (bool, error) {
-	// This would query the rolls table - for now return false as rolls aren't implemented yet
-	// When Phase 8 is implemented, this should check:
-	// SELECT EXISTS(SELECT 1 FROM rolls WHERE character_id = $1 AND status = 'pending')
-	return false, nil
+	return s.queries.CharacterHasPendingRolls(ctx, characterID)
 }
 
 // UUID formatting constants.
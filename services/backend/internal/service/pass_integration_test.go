@@ -0,0 +1,54 @@
+//go:build integration
+
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPassRejectsSceneFromAnotherCampaign(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	player := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	other, err := NewCampaignService(tc.pool).CreateCampaign(tc.ctx, tc.gm, CreateCampaignRequest{
+		Title:       "Other Campaign",
+		Description: "",
+		Settings:    nil,
+	})
+	if err != nil {
+		t.Fatalf("create other campaign: %v", err)
+	}
+	otherScene, err := NewSceneService(tc.pool).CreateScene(tc.ctx, other.ID, tc.gm, CreateSceneRequest{
+		Title:       "Elsewhere",
+		Description: "",
+	})
+	if err != nil {
+		t.Fatalf("create other scene: %v", err)
+	}
+
+	passes := NewPassService(tc.pool)
+	if err = passes.SetPass(
+		tc.ctx, player.userID, tc.campaign.ID, otherScene.Scene.ID, player.characterID, PassStatePassed,
+	); !errors.Is(err, ErrPassCampaignMismatch) {
+		t.Errorf("pass in another campaign's scene: got %v, want ErrPassCampaignMismatch", err)
+	}
+	if err = passes.SetPass(
+		tc.ctx, tc.gm, other.ID, otherScene.Scene.ID, player.characterID, PassStatePassed,
+	); !errors.Is(err, ErrPassCampaignMismatch) {
+		t.Errorf("pass for another campaign's character: got %v, want ErrPassCampaignMismatch", err)
+	}
+	if _, err = passes.GetScenePassStates(
+		tc.ctx, tc.campaign.ID, otherScene.Scene.ID, player.userID,
+	); !errors.Is(err, ErrPassCampaignMismatch) {
+		t.Errorf("pass states of another campaign's scene: got %v, want ErrPassCampaignMismatch", err)
+	}
+
+	// The scene's own campaign still accepts the pass
+	if err = passes.SetPass(
+		tc.ctx, player.userID, tc.campaign.ID, tc.scene.ID, player.characterID, PassStatePassed,
+	); err != nil {
+		t.Errorf("pass in own scene: %v", err)
+	}
+}
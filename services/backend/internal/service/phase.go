@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -56,7 +58,7 @@ type PhaseService struct {
 // NewPhaseService creates a new PhaseService.
 func NewPhaseService(pool *pgxpool.Pool) *PhaseService {
 	return &PhaseService{
-		queries: generated.New(pool),
+		queries: newLongQueries(pool),
 		pool:    pool,
 	}
 }
@@ -74,6 +76,9 @@ type PhaseStatus struct {
 	AllPassed       bool       `json:"allPassed"`
 	CanTransition   bool       `json:"canTransition"`
 	TransitionBlock string     `json:"transitionBlock,omitempty"`
+	// AutoPassedCount is how many characters this status check auto-passed
+	// because the time gate had expired.
+	AutoPassedCount int `json:"-"`
 }
 
 // GetPhaseStatus returns the current phase status of a campaign.
@@ -84,7 +89,7 @@ func (s *PhaseService) GetPhaseStatus(
 	campaignID, userID pgtype.UUID,
 ) (*PhaseStatus, error) {
 	// Verify user is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -185,7 +190,7 @@ func (s *PhaseService) GetPhaseStatus(
 	if status.IsExpired && status.CurrentPhase == PhasePCPhase {
 		// Auto-pass all characters (lazy processing)
 		passSvc := NewPassService(s.pool)
-		_ = passSvc.AutoPassAllCharacters(ctx, campaignID) // Best effort
+		status.AutoPassedCount, _ = passSvc.AutoPassAllCharacters(ctx, campaignID) // Best effort
 
 		// Update counts to reflect auto-pass (all characters now passed)
 		status.PassedCount = totalCount
@@ -204,21 +209,147 @@ func (s *PhaseService) GetPhaseStatus(
 	return status, nil
 }
 
+// ExpiredTimeGate is a campaign whose expired time gate auto-passed characters
+// or, with autoTransitionOnAllPassed on, moved it to GM phase.
+type ExpiredTimeGate struct {
+	CampaignID pgtype.UUID
+	// Status holds the new pass counts, or nil if no character was newly passed.
+	Status *PhaseStatus
+	// Transitioned is the campaign after an automatic move to GM phase, or nil.
+	Transitioned *generated.Campaign
+}
+
+// AutoPassExpiredTimeGates auto-passes the characters of every unpaused campaign
+// whose PC Phase time gate has expired, so the gate takes effect even when nobody
+// is using the campaign, then applies the automatic GM phase transition. Only
+// campaigns where characters were newly passed or the phase changed are returned.
+// A campaign that fails is logged and skipped so it cannot hold up the others, and
+// nothing is done while another instance holds the time gate worker lock.
+func (s *PhaseService) AutoPassExpiredTimeGates(ctx context.Context) ([]ExpiredTimeGate, error) {
+	// The advisory lock lives as long as this transaction, which is only held open
+	// for the lock; the work itself runs on other connections
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	acquired, err := s.queries.WithTx(tx).TryTimeGateWorkerLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, nil
+	}
+
+	campaigns, err := s.queries.GetExpiredTimeGateCampaigns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	passSvc := NewPassService(s.pool)
+	expired := []ExpiredTimeGate{}
+	for _, campaign := range campaigns {
+		gate, gateErr := s.expireTimeGate(ctx, passSvc, &campaign)
+		if gateErr != nil {
+			//nolint:sloglint // Error logging doesn't need structured logger injection
+			slog.ErrorContext(ctx, "Failed to process expired time gate",
+				"campaign_id", uuidToString(campaign.ID), "error", gateErr)
+			continue
+		}
+		if gate.Status == nil && gate.Transitioned == nil {
+			continue
+		}
+		expired = append(expired, gate)
+	}
+
+	return expired, nil
+}
+
+// expireTimeGate auto-passes the characters of one campaign with an expired time
+// gate and applies the automatic GM phase transition.
+func (s *PhaseService) expireTimeGate(
+	ctx context.Context,
+	passSvc *PassService,
+	campaign *generated.Campaign,
+) (ExpiredTimeGate, error) {
+	gate := ExpiredTimeGate{CampaignID: campaign.ID, Status: nil, Transitioned: nil}
+
+	autoPassed, err := passSvc.AutoPassAllCharacters(ctx, campaign.ID)
+	if err != nil {
+		return gate, err
+	}
+
+	if autoPassed > 0 {
+		passedCount, countErr := s.queries.CountPassedCharactersInCampaign(ctx, campaign.ID)
+		if countErr != nil {
+			return gate, countErr
+		}
+		unpassedCount, countErr := s.queries.CountUnpassedCharactersInCampaign(ctx, campaign.ID)
+		if countErr != nil {
+			return gate, countErr
+		}
+
+		expiresAt := campaign.CurrentPhaseExpiresAt.Time
+		//nolint:exhaustruct // Only the fields that change on expiry are reported
+		gate.Status = &PhaseStatus{
+			CurrentPhase:    PhasePCPhase,
+			ExpiresAt:       &expiresAt,
+			IsExpired:       true,
+			PassedCount:     passedCount,
+			TotalCount:      passedCount + unpassedCount,
+			AllPassed:       unpassedCount == 0,
+			AutoPassedCount: autoPassed,
+		}
+	}
+
+	// Characters may already have been auto-passed by a lazy status check, so
+	// the transition is attempted either way
+	gate.Transitioned, err = s.AutoTransitionIfAllPassed(ctx, campaign.ID)
+	if err != nil {
+		return gate, err
+	}
+
+	return gate, nil
+}
+
+// Transition blocker codes returned by CheckTransition.
+const (
+	BlockerAlreadyInPhase = "ALREADY_IN_PHASE"
+	BlockerCampaignPaused = "CAMPAIGN_PAUSED"
+	BlockerComposeLocks   = "ACTIVE_COMPOSE_LOCKS"
+	BlockerPendingRolls   = "PENDING_ROLLS"
+	BlockerNotAllPassed   = "NOT_ALL_PASSED"
+)
+
+// TransitionBlocker describes one thing preventing a phase transition.
+type TransitionBlocker struct {
+	Code       string              `json:"code"`
+	Message    string              `json:"message"`
+	Count      int64               `json:"count"`
+	Characters []CharacterPassInfo `json:"characters,omitempty"`
+}
+
+// TransitionCheck is the result of a dry-run phase transition.
+type TransitionCheck struct {
+	ToPhase       string              `json:"toPhase"`
+	CanTransition bool                `json:"canTransition"`
+	Blockers      []TransitionBlocker `json:"blockers"`
+}
+
 // TransitionPhaseRequest represents a request to transition phases.
 type TransitionPhaseRequest struct {
 	ToPhase string `binding:"required,oneof=pc_phase gm_phase" json:"toPhase"`
 }
 
 // TransitionPhase transitions the campaign to a new phase.
-//
-//nolint:gocognit,nestif,funlen // Phase transition guards require nested condition checks
 func (s *PhaseService) TransitionPhase(
 	ctx context.Context,
 	campaignID, userID pgtype.UUID,
 	req TransitionPhaseRequest,
 ) (*generated.Campaign, error) {
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -229,6 +360,22 @@ func (s *PhaseService) TransitionPhase(
 		return nil, ErrNotGM
 	}
 
+	return s.transitionPhase(ctx, campaignID, req.ToPhase, true)
+}
+
+// transitionPhase moves the campaign to toPhase once the transition guards pass,
+// resetting every pass state. byGM records the transition as GM activity.
+//
+//nolint:gocognit,nestif // Phase transition guards require nested condition checks
+func (s *PhaseService) transitionPhase(
+	ctx context.Context,
+	campaignID pgtype.UUID,
+	toPhase string,
+	byGM bool,
+) (*generated.Campaign, error) {
+	ctx, cancel := withLongTxTimeout(ctx)
+	defer cancel()
+
 	// Start transaction
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
@@ -238,8 +385,8 @@ func (s *PhaseService) TransitionPhase(
 
 	qtx := s.queries.WithTx(tx)
 
-	// Get current campaign state
-	campaign, err := qtx.GetCampaign(ctx, campaignID)
+	// Get current campaign state, locked so concurrent transitions serialize
+	campaign, err := qtx.GetCampaignForUpdate(ctx, campaignID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrCampaignNotFound
@@ -248,7 +395,7 @@ func (s *PhaseService) TransitionPhase(
 	}
 
 	// Check if already in target phase
-	if string(campaign.CurrentPhase) == req.ToPhase {
+	if string(campaign.CurrentPhase) == toPhase {
 		return nil, ErrAlreadyInPhase
 	}
 
@@ -258,47 +405,16 @@ func (s *PhaseService) TransitionPhase(
 	}
 
 	// Apply transition guards based on direction
-	if req.ToPhase == PhaseGMPhase {
+	if toPhase == PhaseGMPhase {
 		// PC -> GM transition requires additional checks
-
-		// Check for active compose locks
-		activeLocks, lockErr := qtx.CountActiveLocksInCampaign(ctx, campaignID)
-		if lockErr != nil {
-			return nil, lockErr
-		}
-		if activeLocks > 0 {
-			return nil, ErrActiveComposeLocks
-		}
-
-		// Check for pending rolls
-		pendingRolls, rollErr := qtx.CountPendingRollsInCampaign(ctx, campaignID)
-		if rollErr != nil {
-			return nil, rollErr
-		}
-		if pendingRolls > 0 {
-			return nil, ErrPendingRolls
-		}
-
-		// Check if all characters have passed (only if there are characters)
-		allPassed, passErr := qtx.CheckAllCharactersPassed(ctx, campaignID)
-		if passErr != nil {
-			return nil, passErr
-		}
-
-		// Count total characters to know if we need pass check
-		unpassedCount, countErr := qtx.CountUnpassedCharactersInCampaign(ctx, campaignID)
-		if countErr != nil {
-			return nil, countErr
-		}
-
-		if unpassedCount > 0 && !allPassed {
-			return nil, ErrNotAllPassed
+		if guardErr := checkGMPhaseGuards(ctx, qtx, campaignID); guardErr != nil {
+			return nil, guardErr
 		}
 	}
 
 	// Calculate expiration time for PC phase
 	var expiresAt pgtype.Timestamptz
-	if req.ToPhase == PhasePCPhase {
+	if toPhase == PhasePCPhase {
 		// Get time gate preset from settings
 		phaseStatus, statusErr := qtx.GetCampaignPhaseStatus(ctx, campaignID)
 		if statusErr != nil {
@@ -317,10 +433,9 @@ func (s *PhaseService) TransitionPhase(
 	}
 
 	// Perform the transition
-	toPhase := generated.CampaignPhase(req.ToPhase)
 	updatedCampaign, err := qtx.TransitionCampaignPhase(ctx, generated.TransitionCampaignPhaseParams{
 		ID:                    campaignID,
-		CurrentPhase:          toPhase,
+		CurrentPhase:          generated.CampaignPhase(toPhase),
 		CurrentPhaseExpiresAt: expiresAt,
 	})
 	if err != nil {
@@ -333,8 +448,10 @@ func (s *PhaseService) TransitionPhase(
 	}
 
 	// Update GM activity timestamp
-	if gmErr := qtx.UpdateGmActivity(ctx, campaignID); gmErr != nil {
-		return nil, gmErr
+	if byGM {
+		if gmErr := qtx.UpdateGmActivity(ctx, campaignID); gmErr != nil {
+			return nil, gmErr
+		}
 	}
 
 	if commitErr := tx.Commit(ctx); commitErr != nil {
@@ -344,6 +461,157 @@ func (s *PhaseService) TransitionPhase(
 	return &updatedCampaign, nil
 }
 
+// CheckTransition reports every blocker for a transition without performing it (GM only).
+// Compose locks are reported as a count only to protect the composer's identity.
+func (s *PhaseService) CheckTransition(
+	ctx context.Context,
+	campaignID, userID pgtype.UUID,
+	toPhase string,
+) (*TransitionCheck, error) {
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	campaign, err := s.queries.GetCampaign(ctx, campaignID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, err
+	}
+
+	blockers := []TransitionBlocker{}
+
+	if string(campaign.CurrentPhase) == toPhase {
+		blockers = append(blockers, TransitionBlocker{
+			Code:    BlockerAlreadyInPhase,
+			Message: "Campaign is already in this phase",
+			Count:   1,
+		})
+	}
+
+	if campaign.IsPaused {
+		blockers = append(blockers, TransitionBlocker{
+			Code:    BlockerCampaignPaused,
+			Message: "Campaign is paused",
+			Count:   1,
+		})
+	}
+
+	if toPhase == PhaseGMPhase {
+		gmBlockers, blockErr := s.gmPhaseBlockers(ctx, campaignID)
+		if blockErr != nil {
+			return nil, blockErr
+		}
+		blockers = append(blockers, gmBlockers...)
+	}
+
+	return &TransitionCheck{
+		ToPhase:       toPhase,
+		CanTransition: len(blockers) == 0,
+		Blockers:      blockers,
+	}, nil
+}
+
+// gmPhaseBlockers collects every guard that would stop a PC -> GM transition.
+func (s *PhaseService) gmPhaseBlockers(
+	ctx context.Context,
+	campaignID pgtype.UUID,
+) ([]TransitionBlocker, error) {
+	blockers := []TransitionBlocker{}
+
+	activeLocks, err := s.queries.CountActiveLocksInCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if activeLocks > 0 {
+		blockers = append(blockers, TransitionBlocker{
+			Code:    BlockerComposeLocks,
+			Message: "Players are still composing posts",
+			Count:   activeLocks,
+		})
+	}
+
+	pendingRolls, err := s.queries.CountPendingRollsInCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if pendingRolls > 0 {
+		blockers = append(blockers, TransitionBlocker{
+			Code:    BlockerPendingRolls,
+			Message: "There are pending rolls to resolve",
+			Count:   pendingRolls,
+		})
+	}
+
+	unpassed, err := s.unpassedCharacters(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if len(unpassed) > 0 {
+		blockers = append(blockers, TransitionBlocker{
+			Code:       BlockerNotAllPassed,
+			Message:    "Not all characters have passed",
+			Count:      int64(len(unpassed)),
+			Characters: unpassed,
+		})
+	}
+
+	return blockers, nil
+}
+
+// unpassedCharacters lists PCs in active scenes that have not passed, once per scene.
+func (s *PhaseService) unpassedCharacters(
+	ctx context.Context,
+	campaignID pgtype.UUID,
+) ([]CharacterPassInfo, error) {
+	sceneStates, err := s.queries.GetAllPassStatesInCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	unpassed := []CharacterPassInfo{}
+	for _, scene := range sceneStates {
+		var passStates map[string]string
+		if unmarshalErr := json.Unmarshal(scene.PassStates, &passStates); unmarshalErr != nil {
+			passStates = make(map[string]string)
+		}
+
+		sceneChars, charErr := s.queries.GetSceneCharacters(ctx, scene.SceneID)
+		if charErr != nil {
+			return nil, charErr
+		}
+
+		for _, char := range sceneChars {
+			if char.CharacterType != generated.CharacterTypePc || char.IsArchived {
+				continue
+			}
+
+			charIDStr := formatPgtypeUUID(char.ID)
+			if state := passStates[charIDStr]; state != "" && state != PassStateNone {
+				continue
+			}
+
+			unpassed = append(unpassed, CharacterPassInfo{
+				CharacterID:   charIDStr,
+				CharacterName: char.DisplayName,
+				PassState:     PassStateNone,
+				SceneID:       formatPgtypeUUID(scene.SceneID),
+				SceneTitle:    scene.SceneTitle,
+			})
+		}
+	}
+
+	return unpassed, nil
+}
+
 // ForceTransitionPhase allows GM to force transition without checks (for edge cases).
 func (s *PhaseService) ForceTransitionPhase(
 	ctx context.Context,
@@ -351,7 +619,7 @@ func (s *PhaseService) ForceTransitionPhase(
 	req TransitionPhaseRequest,
 ) (*generated.Campaign, error) {
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -362,6 +630,9 @@ func (s *PhaseService) ForceTransitionPhase(
 		return nil, ErrNotGM
 	}
 
+	ctx, cancel := withLongTxTimeout(ctx)
+	defer cancel()
+
 	// Start transaction
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
@@ -425,9 +696,122 @@ func (s *PhaseService) ForceTransitionPhase(
 		return nil, gmErr
 	}
 
+	if auditErr := RecordGMAction(ctx, qtx, campaignID, userID, GMActionForceTransition,
+		AuditTargetCampaign, campaignID, map[string]any{
+			"fromPhase": campaign.CurrentPhase,
+			"toPhase":   req.ToPhase,
+		}); auditErr != nil {
+		return nil, auditErr
+	}
+
 	if commitErr := tx.Commit(ctx); commitErr != nil {
 		return nil, commitErr
 	}
 
 	return &updatedCampaign, nil
 }
+
+// checkGMPhaseGuards verifies a campaign can move from PC phase to GM phase:
+// no active compose locks, no pending rolls, and every PC has passed.
+func checkGMPhaseGuards(ctx context.Context, qtx *generated.Queries, campaignID pgtype.UUID) error {
+	// Check for active compose locks
+	activeLocks, err := qtx.CountActiveLocksInCampaign(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+	if activeLocks > 0 {
+		return ErrActiveComposeLocks
+	}
+
+	// Check for pending rolls
+	pendingRolls, err := qtx.CountPendingRollsInCampaign(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+	if pendingRolls > 0 {
+		return ErrPendingRolls
+	}
+
+	// Check if all characters have passed (only if there are characters)
+	allPassed, err := qtx.CheckAllCharactersPassed(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+
+	// Count total characters to know if we need pass check
+	unpassedCount, err := qtx.CountUnpassedCharactersInCampaign(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+
+	if unpassedCount > 0 && !allPassed {
+		return ErrNotAllPassed
+	}
+
+	return nil
+}
+
+// AutoTransitionIfAllPassed moves the campaign to GM phase when the
+// autoTransitionOnAllPassed setting is on and every PC has passed. It goes through
+// the same transition as a GM would, and returns the updated campaign, or nil if no
+// transition happened. Call it wherever the last pass can complete: passing, time
+// gate expiry, and compose lock release.
+func (s *PhaseService) AutoTransitionIfAllPassed(
+	ctx context.Context,
+	campaignID pgtype.UUID,
+) (*generated.Campaign, error) {
+	campaign, err := s.queries.GetCampaign(ctx, campaignID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, err
+	}
+
+	if !autoTransitionOnAllPassed(campaign.Settings) ||
+		campaign.CurrentPhase != generated.CampaignPhasePcPhase ||
+		campaign.IsPaused {
+		return nil, nil //nolint:nilnil // No transition is not an error
+	}
+
+	// Only transition once at least one PC has actually passed
+	passedCount, err := s.queries.CountPassedCharactersInCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if passedCount == 0 {
+		return nil, nil //nolint:nilnil // No transition is not an error
+	}
+
+	// The transition re-checks the phase under a row lock, so two passes landing
+	// together transition at most once
+	updatedCampaign, err := s.transitionPhase(ctx, campaignID, PhaseGMPhase, false)
+	if err != nil {
+		if errors.Is(err, ErrAlreadyInPhase) ||
+			errors.Is(err, ErrCampaignPaused) ||
+			errors.Is(err, ErrActiveComposeLocks) ||
+			errors.Is(err, ErrPendingRolls) ||
+			errors.Is(err, ErrNotAllPassed) {
+			return nil, nil //nolint:nilnil // Blocked transitions are expected
+		}
+		return nil, err
+	}
+
+	return updatedCampaign, nil
+}
+
+// autoTransitionOnAllPassed reports whether the campaign moves to GM phase automatically
+// once every PC has passed.
+func autoTransitionOnAllPassed(settingsJSON []byte) bool {
+	if len(settingsJSON) == 0 {
+		return false
+	}
+
+	var settings struct {
+		AutoTransitionOnAllPassed bool `json:"autoTransitionOnAllPassed"`
+	}
+	if err := json.Unmarshal(settingsJSON, &settings); err != nil {
+		return false
+	}
+	return settings.AutoTransitionOnAllPassed
+}
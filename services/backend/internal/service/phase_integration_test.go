@@ -0,0 +1,231 @@
+//go:build integration
+
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+func TestPlayersCanOnlyPostInPCPhase(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	player := tc.addPlayer()
+
+	// Campaigns start in GM phase
+	if _, err := tc.post(player.userID, player.characterID, CreatePostRequest{}); !errors.Is(err, ErrNotInPCPhase) {
+		t.Fatalf("post in GM phase: got %v, want ErrNotInPCPhase", err)
+	}
+
+	// The GM may narrate in any phase
+	if _, err := tc.post(tc.gm, pgtype.UUID{}, CreatePostRequest{}); err != nil {
+		t.Fatalf("GM narration in GM phase: %v", err)
+	}
+
+	tc.transition(PhasePCPhase)
+	if _, err := tc.post(player.userID, player.characterID, CreatePostRequest{}); err != nil {
+		t.Fatalf("post in PC phase: %v", err)
+	}
+}
+
+func TestPlayersCannotPostAfterTimeGateExpires(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	player := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	if err := tc.queries.UpdateCampaignPhase(tc.ctx, generated.UpdateCampaignPhaseParams{
+		ID:           tc.campaign.ID,
+		CurrentPhase: generated.CampaignPhasePcPhase,
+		CurrentPhaseExpiresAt: pgtype.Timestamptz{
+			Time:             time.Now().Add(-time.Minute),
+			Valid:            true,
+			InfinityModifier: pgtype.Finite,
+		},
+	}); err != nil {
+		t.Fatalf("expire time gate: %v", err)
+	}
+
+	if _, err := tc.post(player.userID, player.characterID, CreatePostRequest{}); !errors.Is(err, ErrTimeGateExpired) {
+		t.Fatalf("post after expiry: got %v, want ErrTimeGateExpired", err)
+	}
+}
+
+func TestGMPhaseRequiresEveryPCToPass(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	first := tc.addPlayer()
+	second := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	passes := NewPassService(tc.pool)
+	if err := passes.SetPass(tc.ctx, first.userID, tc.campaign.ID, tc.scene.ID, first.characterID,
+		PassStatePassed); err != nil {
+		t.Fatalf("first pass: %v", err)
+	}
+
+	if _, err := tc.tryTransition(PhaseGMPhase); !errors.Is(err, ErrNotAllPassed) {
+		t.Fatalf("transition with one PC unpassed: got %v, want ErrNotAllPassed", err)
+	}
+
+	if err := passes.SetPass(tc.ctx, second.userID, tc.campaign.ID, tc.scene.ID, second.characterID,
+		PassStatePassed); err != nil {
+		t.Fatalf("second pass: %v", err)
+	}
+
+	campaign, err := tc.tryTransition(PhaseGMPhase)
+	if err != nil {
+		t.Fatalf("transition with every PC passed: %v", err)
+	}
+	if campaign.CurrentPhase != generated.CampaignPhaseGmPhase {
+		t.Fatalf("phase = %s, want gm_phase", campaign.CurrentPhase)
+	}
+
+	// Pass states are reset for the next round
+	scene, err := tc.queries.GetScene(tc.ctx, tc.scene.ID)
+	if err != nil {
+		t.Fatalf("get scene: %v", err)
+	}
+	var states map[string]string
+	if unmarshalErr := json.Unmarshal(scene.PassStates, &states); unmarshalErr != nil {
+		t.Fatalf("parse pass states: %v", unmarshalErr)
+	}
+	for _, player := range []testPlayer{first, second} {
+		if state := states[uuidToString(player.characterID)]; state == PassStatePassed {
+			t.Errorf("pass state for %s survived the transition", uuidToString(player.characterID))
+		}
+	}
+}
+
+func TestAutoTransitionWaitsForComposeLockRelease(t *testing.T) {
+	tc := newTestCampaign(t, map[string]any{"autoTransitionOnAllPassed": true})
+	first := tc.addPlayer()
+	second := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	compose := NewComposeService(tc.pool)
+	lock, err := compose.AcquireLock(tc.ctx, first.userID, AcquireLockRequest{
+		SceneID:     uuidToString(tc.scene.ID),
+		CharacterID: uuidToString(first.characterID),
+	})
+	if err != nil {
+		t.Fatalf("acquire lock: %v", err)
+	}
+
+	passes := NewPassService(tc.pool)
+	for _, p := range []testPlayer{first, second} {
+		if passErr := passes.SetPass(tc.ctx, p.userID, tc.campaign.ID, tc.scene.ID, p.characterID,
+			PassStatePassed); passErr != nil {
+			t.Fatalf("pass: %v", passErr)
+		}
+	}
+
+	phases := NewPhaseService(tc.pool)
+	campaign, err := phases.AutoTransitionIfAllPassed(tc.ctx, tc.campaign.ID)
+	if err != nil || campaign != nil {
+		t.Fatalf("auto transition while composing: got %v, %v; want no transition", campaign, err)
+	}
+
+	if releaseErr := compose.ReleaseLock(tc.ctx, first.userID, lock.LockID); releaseErr != nil {
+		t.Fatalf("release lock: %v", releaseErr)
+	}
+	campaign, err = phases.AutoTransitionIfAllPassed(tc.ctx, tc.campaign.ID)
+	if err != nil {
+		t.Fatalf("auto transition after release: %v", err)
+	}
+	if campaign == nil || campaign.CurrentPhase != generated.CampaignPhaseGmPhase {
+		t.Fatalf("campaign after release = %+v, want gm_phase", campaign)
+	}
+}
+
+func TestExpiredTimeGateAutoTransitions(t *testing.T) {
+	tc := newTestCampaign(t, map[string]any{"autoTransitionOnAllPassed": true})
+	tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	if err := tc.queries.UpdateCampaignPhase(tc.ctx, generated.UpdateCampaignPhaseParams{
+		ID:           tc.campaign.ID,
+		CurrentPhase: generated.CampaignPhasePcPhase,
+		CurrentPhaseExpiresAt: pgtype.Timestamptz{
+			Time:             time.Now().Add(-time.Minute),
+			Valid:            true,
+			InfinityModifier: pgtype.Finite,
+		},
+	}); err != nil {
+		t.Fatalf("expire time gate: %v", err)
+	}
+
+	expired, err := NewPhaseService(tc.pool).AutoPassExpiredTimeGates(tc.ctx)
+	if err != nil {
+		t.Fatalf("auto-pass expired time gates: %v", err)
+	}
+
+	var gate *ExpiredTimeGate
+	for i := range expired {
+		if expired[i].CampaignID == tc.campaign.ID {
+			gate = &expired[i]
+		}
+	}
+	if gate == nil || gate.Status == nil || gate.Transitioned == nil {
+		t.Fatalf("expired gate = %+v, want auto-passed and transitioned", gate)
+	}
+	if gate.Transitioned.CurrentPhase != generated.CampaignPhaseGmPhase {
+		t.Errorf("phase = %s, want gm_phase", gate.Transitioned.CurrentPhase)
+	}
+	if gate.Status.PassedCount != 1 || !gate.Status.AllPassed {
+		t.Errorf("status = %+v, want the character auto-passed", gate.Status)
+	}
+}
+
+func TestExpiredTimeGatesSkippedWhileWorkerLockHeld(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	if err := tc.queries.UpdateCampaignPhase(tc.ctx, generated.UpdateCampaignPhaseParams{
+		ID:           tc.campaign.ID,
+		CurrentPhase: generated.CampaignPhasePcPhase,
+		CurrentPhaseExpiresAt: pgtype.Timestamptz{
+			Time:             time.Now().Add(-time.Minute),
+			Valid:            true,
+			InfinityModifier: pgtype.Finite,
+		},
+	}); err != nil {
+		t.Fatalf("expire time gate: %v", err)
+	}
+
+	// Another instance is mid-pass
+	tx, err := tc.pool.Begin(tc.ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(tc.ctx) }()
+	if acquired, lockErr := tc.queries.WithTx(tx).TryTimeGateWorkerLock(tc.ctx); lockErr != nil || !acquired {
+		t.Fatalf("take worker lock: acquired=%v err=%v", acquired, lockErr)
+	}
+
+	phaseSvc := NewPhaseService(tc.pool)
+	expired, err := phaseSvc.AutoPassExpiredTimeGates(tc.ctx)
+	if err != nil {
+		t.Fatalf("auto-pass while locked: %v", err)
+	}
+	if len(expired) != 0 {
+		t.Fatalf("expired while locked = %+v, want nothing processed", expired)
+	}
+
+	_ = tx.Rollback(tc.ctx)
+	expired, err = phaseSvc.AutoPassExpiredTimeGates(tc.ctx)
+	if err != nil {
+		t.Fatalf("auto-pass after release: %v", err)
+	}
+	found := false
+	for _, gate := range expired {
+		found = found || gate.CampaignID == tc.campaign.ID
+	}
+	if !found {
+		t.Errorf("expired after release = %+v, want the campaign processed", expired)
+	}
+}
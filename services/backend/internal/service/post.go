@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
 	"slices"
 	"time"
 
@@ -16,12 +18,61 @@ import (
 
 // Post errors.
 var (
-	ErrPostNotFound      = errors.New("post not found")
-	ErrPostLocked        = errors.New("post is locked and cannot be edited")
-	ErrNotPostOwner      = errors.New("you do not own this post")
-	ErrCannotEditAsGM    = errors.New("GMs cannot edit player posts")
-	ErrNotInCorrectPhase = errors.New("action not allowed in current phase")
-	ErrNotMostRecentPost = errors.New("can only edit the most recent post")
+	ErrPostNotFound = newCodedError(
+		http.StatusNotFound, "NOT_FOUND",
+		"Post not found",
+		"post not found",
+	)
+	ErrPostLocked = newCodedError(
+		http.StatusForbidden, "POST_LOCKED",
+		"Post is locked and cannot be edited",
+		"post is locked and cannot be edited",
+	)
+	ErrNotPostOwner = newCodedError(
+		http.StatusForbidden, "NOT_POST_OWNER",
+		"You do not own this post",
+		"you do not own this post",
+	)
+	ErrCannotEditAsGM = newCodedError(
+		http.StatusForbidden, "CANNOT_EDIT_AS_GM",
+		"GMs cannot edit player posts",
+		"GMs cannot edit player posts",
+	)
+	ErrNotInCorrectPhase = newCodedError(
+		http.StatusBadRequest, "WRONG_PHASE",
+		"This action is not allowed in the current phase",
+		"action not allowed in current phase",
+	)
+	ErrNotMostRecentPost = newCodedError(
+		http.StatusForbidden, "NOT_MOST_RECENT",
+		"Can only edit the most recent post",
+		"can only edit the most recent post",
+	)
+	ErrWitnessNotInScene = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Witnesses must be characters in this scene",
+		"witness not in scene",
+	)
+	ErrWitnessesMissingAuthor = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Your character must be one of the post's witnesses",
+		"explicit witnesses omit the author's character",
+	)
+	ErrWitnessesNotAllowed = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Witnesses can only be chosen for visible posts that are submitted right away",
+		"explicit witnesses on a hidden or draft post",
+	)
+	ErrPostAlreadySubmitted = newCodedError(
+		http.StatusConflict, "POST_ALREADY_SUBMITTED",
+		"Post has already been submitted",
+		"post is already submitted",
+	)
+	ErrPostNotHidden = newCodedError(
+		http.StatusConflict, "POST_NOT_HIDDEN",
+		"Post is not hidden",
+		"post is not hidden",
+	)
 )
 
 // PostService handles post business logic.
@@ -33,7 +84,7 @@ type PostService struct {
 // NewPostService creates a new PostService.
 func NewPostService(pool *pgxpool.Pool) *PostService {
 	return &PostService{
-		queries: generated.New(pool),
+		queries: newQueries(pool),
 		pool:    pool,
 	}
 }
@@ -54,6 +105,10 @@ type CreatePostRequest struct {
 	Intention   *string     `json:"intention"`
 	Modifier    *int        `json:"modifier"`
 	IsHidden    bool        `json:"isHidden"`
+	IsSystem    bool        `json:"-"` // set by the server for generated narrator posts
+	// Witnesses, when set, replaces the scene's default witnesses with a subset of
+	// the scene's characters. Non-GM authors must include their own character.
+	Witnesses []string `json:"witnesses,omitempty"`
 }
 
 // PostResponse represents a post in the API response.
@@ -70,11 +125,17 @@ type PostResponse struct {
 	IsLocked        bool        `json:"isLocked"`
 	LockedAt        *string     `json:"lockedAt"`
 	EditedByGM      bool        `json:"editedByGm"`
+	AuthoredByGM    bool        `json:"authoredByGm"`
+	IsSystem        bool        `json:"isSystem"`
+	IsPinned        bool        `json:"isPinned"`
+	Mentions        []string    `json:"mentions"`
 	Intention       *string     `json:"intention"`
 	Modifier        *int        `json:"modifier"`
 	CharacterName   *string     `json:"characterName"`
 	CharacterAvatar *string     `json:"characterAvatar"`
 	CharacterType   *string     `json:"characterType"`
+	IsRemoved       bool        `json:"isRemoved"`
+	RemovedAt       *string     `json:"removedAt"`
 	CreatedAt       string      `json:"createdAt"`
 	UpdatedAt       string      `json:"updatedAt"`
 }
@@ -100,7 +161,7 @@ func (s *PostService) CreatePost(
 	}
 
 	// Check user is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: sceneWithCampaign.CampaignID,
 		UserID:     userID,
 	})
@@ -111,8 +172,13 @@ func (s *PostService) CreatePost(
 		return nil, ErrNotMember
 	}
 
+	// Frozen scenes accept no new posts, even from the GM
+	if sceneWithCampaign.IsFrozen {
+		return nil, ErrSceneFrozen
+	}
+
 	// Check GM status
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: sceneWithCampaign.CampaignID,
 		UserID:     userID,
 	})
@@ -135,6 +201,7 @@ func (s *PostService) CreatePost(
 
 	// Handle character validation
 	var characterID pgtype.UUID
+	authoredByGM := false
 	if req.CharacterID != nil {
 		characterID = parseUUIDString(*req.CharacterID)
 
@@ -173,11 +240,32 @@ func (s *PostService) CreatePost(
 		if char.CharacterType == generated.CharacterTypeNpc && !isGM {
 			return nil, ErrCharacterNotOwned
 		}
+
+		// Flag the GM speaking for a player character assigned to someone else
+		authoredByGM = isGM &&
+			char.CharacterType != generated.CharacterTypeNpc &&
+			assignErr == nil && assignment.UserID.Valid && assignment.UserID != userID
 	} else if !isGM {
 		// Narrator posts require GM
 		return nil, ErrNotGM
 	}
 
+	// Validate explicitly chosen witnesses
+	var explicitWitnesses []pgtype.UUID
+	if req.Witnesses != nil {
+		// System posts may copy the witnesses of a hidden post they describe
+		if (req.IsHidden && !req.IsSystem) || !submitImmediately {
+			return nil, ErrWitnessesNotAllowed
+		}
+		explicitWitnesses, err = sceneWitnesses(sceneWithCampaign.CharacterIds, req.Witnesses)
+		if err != nil {
+			return nil, err
+		}
+		if !isGM && !slices.Contains(explicitWitnesses, characterID) {
+			return nil, ErrWitnessesMissingAuthor
+		}
+	}
+
 	// Marshal blocks to JSON (ensure empty array if nil)
 	blocks := req.Blocks
 	if blocks == nil {
@@ -190,17 +278,12 @@ func (s *PostService) CreatePost(
 
 	// Prepare witnesses (ensure empty slice, not nil)
 	witnesses := make([]pgtype.UUID, 0)
-	if submitImmediately {
-		if req.IsHidden {
-			// Hidden posts: only the author's character is a witness
-			// (so they can see their own hidden post)
-			if characterID.Valid {
-				witnesses = append(witnesses, characterID)
-			}
-		} else {
-			// Regular posts: all scene characters are witnesses
-			witnesses = append(witnesses, sceneWithCampaign.CharacterIds...)
-		}
+	if submitImmediately && req.Witnesses != nil {
+		witnesses = append(witnesses, explicitWitnesses...)
+	} else if submitImmediately {
+		witnesses = append(witnesses, submittedWitnesses(
+			sceneWithCampaign.DefaultWitnessMode, sceneWithCampaign.CharacterIds, characterID, req.IsHidden,
+		)...)
 	}
 
 	// Prepare optional fields
@@ -220,6 +303,9 @@ func (s *PostService) CreatePost(
 		modifier = pgtype.Int4{Int32: int32(*req.Modifier), Valid: true}
 	}
 
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
 	// Start transaction
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
@@ -231,23 +317,31 @@ func (s *PostService) CreatePost(
 
 	// Create post
 	post, err := qtx.CreatePost(ctx, generated.CreatePostParams{
-		SceneID:     sceneID,
-		CharacterID: characterID,
-		UserID:      userID,
-		Blocks:      blocksJSON,
-		OocText:     oocText,
-		Witnesses:   witnesses,
-		IsHidden:    req.IsHidden,
-		IsDraft:     !submitImmediately,
-		Intention:   intention,
-		Modifier:    modifier,
+		SceneID:      sceneID,
+		CharacterID:  characterID,
+		UserID:       userID,
+		Blocks:       blocksJSON,
+		OocText:      oocText,
+		Witnesses:    witnesses,
+		IsHidden:     req.IsHidden,
+		IsDraft:      !submitImmediately,
+		Intention:    intention,
+		Modifier:     modifier,
+		AuthoredByGm: authoredByGM,
+		IsSystem:     req.IsSystem,
+		WitnessedByScene: submitImmediately && req.Witnesses == nil &&
+			witnessedByScene(sceneWithCampaign.DefaultWitnessMode, req.IsHidden),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// If submitting immediately, lock the previous post
+	// If submitting immediately, record mentions and lock the previous post
 	if submitImmediately {
+		if mentionErr := s.recordMentions(ctx, qtx, &post, sceneWithCampaign.CampaignID); mentionErr != nil {
+			return nil, mentionErr
+		}
+
 		prevPost, prevErr := qtx.GetPreviousPost(ctx, generated.GetPreviousPostParams{
 			SceneID:   sceneID,
 			CreatedAt: post.CreatedAt,
@@ -271,7 +365,7 @@ func (s *PostService) CreatePost(
 		return nil, commitErr
 	}
 
-	return s.postToResponse(&post), nil
+	return s.postToResponse(&post, s.narratorFor(ctx, sceneWithCampaign.CampaignID)), nil
 }
 
 // SubmitPost submits a draft post.
@@ -299,7 +393,7 @@ func (s *PostService) SubmitPost(
 
 	// Verify it's a draft
 	if !post.IsDraft {
-		return nil, errors.New("post is already submitted")
+		return nil, ErrPostAlreadySubmitted
 	}
 
 	// Get scene for witnesses
@@ -308,19 +402,17 @@ func (s *PostService) SubmitPost(
 		return nil, err
 	}
 
-	// Prepare witnesses
-	var witnesses []pgtype.UUID
-	if isHidden {
-		// Hidden posts: only the author's character is a witness
-		// (so they can see their own hidden post)
-		if post.CharacterID.Valid {
-			witnesses = append(witnesses, post.CharacterID)
-		}
-	} else {
-		// Regular posts: all scene characters are witnesses
-		witnesses = scene.CharacterIds
+	// Frozen scenes accept no new posts, drafts included
+	if scene.IsFrozen {
+		return nil, ErrSceneFrozen
 	}
 
+	// Prepare witnesses
+	witnesses := submittedWitnesses(scene.DefaultWitnessMode, scene.CharacterIds, post.CharacterID, isHidden)
+
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
 	// Start transaction
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
@@ -332,14 +424,19 @@ func (s *PostService) SubmitPost(
 
 	// Submit post
 	submittedPost, err := qtx.SubmitPost(ctx, generated.SubmitPostParams{
-		ID:        postUUID,
-		Witnesses: witnesses,
-		IsHidden:  isHidden,
+		ID:               postUUID,
+		Witnesses:        witnesses,
+		IsHidden:         isHidden,
+		WitnessedByScene: witnessedByScene(scene.DefaultWitnessMode, isHidden),
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if mentionErr := s.recordMentions(ctx, qtx, &submittedPost, scene.CampaignID); mentionErr != nil {
+		return nil, mentionErr
+	}
+
 	// Lock previous post
 	prevPost, prevErr := qtx.GetPreviousPost(ctx, generated.GetPreviousPostParams{
 		SceneID:   post.SceneID,
@@ -361,7 +458,72 @@ func (s *PostService) SubmitPost(
 		return nil, commitErr
 	}
 
-	return s.postToResponse(&submittedPost), nil
+	return s.postToResponse(&submittedPost, s.narratorFor(ctx, scene.CampaignID)), nil
+}
+
+// submittedWitnesses returns who witnesses a post when it is submitted. Hidden posts
+// are witnessed only by the author's character (so they can see their own hidden
+// post); other posts follow the scene's default witness mode.
+func submittedWitnesses(
+	mode string,
+	sceneCharacterIDs []pgtype.UUID,
+	characterID pgtype.UUID,
+	isHidden bool,
+) []pgtype.UUID {
+	if !isHidden && mode == WitnessModeAll {
+		return sceneCharacterIDs
+	}
+	if (isHidden || mode == WitnessModeAuthor) && characterID.Valid {
+		return []pgtype.UUID{characterID}
+	}
+	return nil
+}
+
+// witnessedByScene reports whether a post submitted with the scene's default
+// witnesses goes to every character in the scene.
+func witnessedByScene(mode string, isHidden bool) bool {
+	return !isHidden && mode == WitnessModeAll
+}
+
+// IsScenePublic reports whether a post is visible to everyone in its scene: it is
+// not hidden and every character in the scene witnessed it. Whispers, subset
+// witnesses and author-only posts are restricted. Anything sent beyond the post's
+// witnesses (scene broadcasts, webhooks) should be limited to public posts.
+func IsScenePublic(isHidden bool, witnesses, sceneCharacterIDs []pgtype.UUID) bool {
+	if isHidden {
+		return false
+	}
+	for _, id := range sceneCharacterIDs {
+		if !slices.Contains(witnesses, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordMentions resolves @mentions in a submitted post and stores them on the post.
+func (s *PostService) recordMentions(
+	ctx context.Context,
+	qtx *generated.Queries,
+	post *generated.Post,
+	campaignID pgtype.UUID,
+) error {
+	mentions, err := resolveMentions(ctx, qtx, campaignID, post.UserID, post.OocText, post.Witnesses)
+	if err != nil {
+		return err
+	}
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	if err := qtx.SetPostMentions(ctx, generated.SetPostMentionsParams{
+		ID:       post.ID,
+		Mentions: mentions,
+	}); err != nil {
+		return err
+	}
+	post.Mentions = mentions
+	return nil
 }
 
 // UpdatePostRequest represents the request to update a post.
@@ -391,6 +553,9 @@ func (s *PostService) UpdatePost(
 		}
 		return nil, err
 	}
+	if post.DeletedAt.Valid {
+		return nil, ErrPostRemoved
+	}
 
 	// Get scene for GM check
 	scene, err := s.queries.GetScene(ctx, post.SceneID)
@@ -398,7 +563,7 @@ func (s *PostService) UpdatePost(
 		return nil, err
 	}
 
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -417,12 +582,20 @@ func (s *PostService) UpdatePost(
 		return nil, ErrNotPostOwner
 	}
 
+	if scene.IsFrozen {
+		return nil, ErrSceneFrozen
+	}
+
 	// Non-GM users can only edit the most recent post in the scene
 	if !isGM && isOwner {
 		lastPost, lastErr := s.queries.GetLastScenePost(ctx, post.SceneID)
 		if lastErr == nil && lastPost.ID != postUUID {
 			return nil, ErrNotMostRecentPost
 		}
+
+		if windowErr := s.checkPlayerEditWindow(ctx, &post, scene.CampaignID); windowErr != nil {
+			return nil, windowErr
+		}
 	}
 
 	// Build update params
@@ -466,7 +639,7 @@ func (s *PostService) UpdatePost(
 		return nil, err
 	}
 
-	return s.postToResponse(&updatedPost), nil
+	return s.postToResponse(&updatedPost, s.narratorFor(ctx, scene.CampaignID)), nil
 }
 
 // DeletePost deletes a post (GM or owner of unlocked most-recent post).
@@ -493,7 +666,7 @@ func (s *PostService) DeletePost(
 	}
 
 	// Check GM status
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -520,8 +693,15 @@ func (s *PostService) DeletePost(
 		if lastErr == nil && lastPost.ID != postUUID {
 			return ErrNotMostRecentPost
 		}
+
+		if windowErr := s.checkPlayerEditWindow(ctx, &post, scene.CampaignID); windowErr != nil {
+			return windowErr
+		}
 	}
 
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
 	// Start transaction
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
@@ -539,6 +719,20 @@ func (s *PostService) DeletePost(
 		return deleteErr
 	}
 
+	// Deleting someone else's post is a GM moderation action
+	if !isOwner {
+		auditErr := RecordGMAction(ctx, qtx, scene.CampaignID, userID, GMActionDeletePost,
+			AuditTargetPost, postUUID, map[string]any{
+				"sceneId":     uuidToString(post.SceneID),
+				"authorId":    uuidToString(post.UserID),
+				"characterId": uuidToString(post.CharacterID),
+				"createdAt":   post.CreatedAt.Time.Format(time.RFC3339),
+			})
+		if auditErr != nil {
+			return auditErr
+		}
+	}
+
 	// Unlock previous post
 	prevPost, prevErr := qtx.GetPreviousPost(ctx, generated.GetPreviousPostParams{
 		SceneID:   post.SceneID,
@@ -574,7 +768,7 @@ func (s *PostService) ListScenePosts(
 	}
 
 	// Verify membership
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -586,7 +780,7 @@ func (s *PostService) ListScenePosts(
 	}
 
 	// Check GM status
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -636,9 +830,10 @@ func (s *PostService) ListScenePosts(
 	}
 
 	// Convert to response
+	narrator := s.narratorFor(ctx, scene.CampaignID)
 	var result []PostResponse
 	for _, p := range posts {
-		result = append(result, *s.listPostRowToResponse(&p))
+		result = append(result, *s.listPostRowToResponse(&p, narrator))
 	}
 
 	return result, nil
@@ -660,14 +855,29 @@ func (s *PostService) GetPost(
 		return nil, err
 	}
 
-	// Get scene for access check
-	scene, err := s.queries.GetScene(ctx, post.SceneID)
+	scene, err := s.verifyPostAccess(ctx, userID, post.SceneID, post.Witnesses)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.postWithCharacterToResponse(&post, s.narratorFor(ctx, scene.CampaignID)), nil
+}
+
+// verifyPostAccess checks that the user can see a post: the GM sees every post,
+// players only through a character that witnessed it. It returns the post's scene.
+func (s *PostService) verifyPostAccess(
+	ctx context.Context,
+	userID pgtype.UUID,
+	sceneID pgtype.UUID,
+	witnesses []pgtype.UUID,
+) (*generated.Scene, error) {
+	scene, err := s.queries.GetScene(ctx, sceneID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Verify membership
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -679,38 +889,33 @@ func (s *PostService) GetPost(
 	}
 
 	// Check GM or witness access
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if isGM {
+		return &scene, nil
+	}
 
-	if !isGM {
-		// Check if user has a character that witnessed the post
-		userChars, charsErr := s.queries.GetUserCharactersInScene(ctx, generated.GetUserCharactersInSceneParams{
-			ID:     post.SceneID,
-			UserID: userID,
-		})
-		if charsErr != nil {
-			return nil, charsErr
-		}
-
-		hasAccess := false
-		for _, char := range userChars {
-			if slices.Contains(post.Witnesses, char.ID) {
-				hasAccess = true
-				break
-			}
-		}
+	// Check if user has a character that witnessed the post
+	userChars, err := s.queries.GetUserCharactersInScene(ctx, generated.GetUserCharactersInSceneParams{
+		ID:     sceneID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		if !hasAccess {
-			return nil, ErrPostNotFound // Hide existence
+	for _, char := range userChars {
+		if slices.Contains(witnesses, char.ID) {
+			return &scene, nil
 		}
 	}
 
-	return s.postWithCharacterToResponse(&post), nil
+	return nil, ErrPostNotFound // Hide existence
 }
 
 // UnhidePostRequest represents the request to unhide a post.
@@ -720,7 +925,8 @@ type UnhidePostRequest struct {
 
 // UnhidePost reveals a hidden post (GM only).
 // If witnesses is empty/nil, adds all current scene characters as witnesses.
-// Otherwise uses the provided witness list.
+// Otherwise uses the provided witness list. Posts held back by a scene's default
+// witness mode are not hidden; use UpdatePostWitnesses to reveal those.
 func (s *PostService) UnhidePost(
 	ctx context.Context,
 	userID pgtype.UUID,
@@ -740,7 +946,7 @@ func (s *PostService) UnhidePost(
 
 	// Verify post is actually hidden
 	if !post.IsHidden {
-		return nil, errors.New("post is not hidden")
+		return nil, ErrPostNotHidden
 	}
 
 	// Get scene
@@ -750,7 +956,7 @@ func (s *PostService) UnhidePost(
 	}
 
 	// Only GM can unhide
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -782,7 +988,18 @@ func (s *PostService) UnhidePost(
 		return nil, err
 	}
 
-	return s.postToResponse(&updatedPost), nil
+	witnessIDs := make([]string, len(witnesses))
+	for i, w := range witnesses {
+		witnessIDs[i] = uuidToString(w)
+	}
+	logGMAction(ctx, s.queries, scene.CampaignID, userID, GMActionUnhidePost,
+		AuditTargetPost, postUUID, map[string]any{
+			"sceneId":   uuidToString(post.SceneID),
+			"authorId":  uuidToString(post.UserID),
+			"witnesses": witnessIDs,
+		})
+
+	return s.postToResponse(&updatedPost, s.narratorFor(ctx, scene.CampaignID)), nil
 }
 
 // UpdatePostWitnessesRequest represents the request to update post witnesses.
@@ -815,7 +1032,7 @@ func (s *PostService) UpdatePostWitnesses(
 	}
 
 	// Only GM can update witnesses
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -826,30 +1043,41 @@ func (s *PostService) UpdatePostWitnesses(
 		return nil, ErrNotGM
 	}
 
-	// Validate all witness IDs are characters in the scene
-	sceneCharIDs := make(map[string]bool)
-	for _, charID := range scene.CharacterIds {
-		sceneCharIDs[formatUUID(charID.Bytes[:])] = true
-	}
-
-	witnesses := make([]pgtype.UUID, 0, len(req.Witnesses))
-	for _, wID := range req.Witnesses {
-		if !sceneCharIDs[wID] {
-			return nil, errors.New("witness not in scene: " + wID)
-		}
-		witnesses = append(witnesses, parseUUIDString(wID))
+	witnesses, err := sceneWitnesses(scene.CharacterIds, req.Witnesses)
+	if err != nil {
+		return nil, err
 	}
 
 	// Update witnesses
+	// The post stays whole-scene only while every character in the scene still witnesses it
 	updatedPost, err := s.queries.EditPostWitnesses(ctx, generated.EditPostWitnessesParams{
-		ID:        postUUID,
-		Witnesses: witnesses,
+		ID:               postUUID,
+		Witnesses:        witnesses,
+		WitnessedByScene: post.WitnessedByScene && IsScenePublic(post.IsHidden, witnesses, scene.CharacterIds),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return s.postToResponse(&updatedPost), nil
+	return s.postToResponse(&updatedPost, s.narratorFor(ctx, scene.CampaignID)), nil
+}
+
+// sceneWitnesses parses witness IDs, checking that each is a character in the scene.
+func sceneWitnesses(sceneCharacterIDs []pgtype.UUID, ids []string) ([]pgtype.UUID, error) {
+	sceneCharIDs := make(map[string]bool)
+	for _, charID := range sceneCharacterIDs {
+		sceneCharIDs[formatUUID(charID.Bytes[:])] = true
+	}
+
+	witnesses := make([]pgtype.UUID, 0, len(ids))
+	for _, wID := range ids {
+		if !sceneCharIDs[wID] {
+			return nil, fmt.Errorf("%w: %s", ErrWitnessNotInScene, wID)
+		}
+		witnesses = append(witnesses, parseUUIDString(wID))
+	}
+
+	return witnesses, nil
 }
 
 // ListHiddenPosts lists all hidden posts in a scene (GM only).
@@ -870,7 +1098,7 @@ func (s *PostService) ListHiddenPosts(
 	}
 
 	// Only GM can view hidden posts list
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -886,9 +1114,10 @@ func (s *PostService) ListHiddenPosts(
 		return nil, err
 	}
 
+	narrator := s.narratorFor(ctx, scene.CampaignID)
 	var result []PostResponse
 	for _, p := range posts {
-		result = append(result, *s.listHiddenPostRowToResponse(&p))
+		result = append(result, *s.listHiddenPostRowToResponse(&p, narrator))
 	}
 
 	return result, nil
@@ -911,18 +1140,26 @@ func (a listHiddenPostRowAdapter) getIsDraft() bool                 { return a.p
 func (a listHiddenPostRowAdapter) getIsLocked() bool                { return a.p.IsLocked }
 func (a listHiddenPostRowAdapter) getLockedAt() pgtype.Timestamptz  { return a.p.LockedAt }
 func (a listHiddenPostRowAdapter) getEditedByGm() bool              { return a.p.EditedByGm }
+func (a listHiddenPostRowAdapter) getAuthoredByGm() bool            { return a.p.AuthoredByGm }
+func (a listHiddenPostRowAdapter) getMentions() []pgtype.UUID       { return a.p.Mentions }
+func (a listHiddenPostRowAdapter) getIsSystem() bool                { return a.p.IsSystem }
+func (a listHiddenPostRowAdapter) getIsPinned() bool                { return a.p.IsPinned }
 func (a listHiddenPostRowAdapter) getIntention() pgtype.Text        { return a.p.Intention }
 func (a listHiddenPostRowAdapter) getModifier() pgtype.Int4         { return a.p.Modifier }
 func (a listHiddenPostRowAdapter) getCreatedAt() pgtype.Timestamptz { return a.p.CreatedAt }
 func (a listHiddenPostRowAdapter) getUpdatedAt() pgtype.Timestamptz { return a.p.UpdatedAt }
+func (a listHiddenPostRowAdapter) getDeletedAt() pgtype.Timestamptz { return a.p.DeletedAt }
 func (a listHiddenPostRowAdapter) getCharacterName() pgtype.Text    { return a.p.CharacterName }
 func (a listHiddenPostRowAdapter) getCharacterAvatar() pgtype.Text  { return a.p.CharacterAvatar }
 func (a listHiddenPostRowAdapter) getCharacterType() generated.NullCharacterType {
 	return a.p.CharacterType
 }
 
-func (s *PostService) listHiddenPostRowToResponse(p *generated.ListHiddenPostsInSceneRow) *PostResponse {
-	return buildPostResponse(listHiddenPostRowAdapter{p: p})
+func (s *PostService) listHiddenPostRowToResponse(
+	p *generated.ListHiddenPostsInSceneRow,
+	narrator *narratorPersona,
+) *PostResponse {
+	return buildPostResponse(listHiddenPostRowAdapter{p: p}, narrator)
 }
 
 // Helper functions
@@ -941,10 +1178,15 @@ type postData interface {
 	getIsLocked() bool
 	getLockedAt() pgtype.Timestamptz
 	getEditedByGm() bool
+	getAuthoredByGm() bool
+	getMentions() []pgtype.UUID
+	getIsSystem() bool
+	getIsPinned() bool
 	getIntention() pgtype.Text
 	getModifier() pgtype.Int4
 	getCreatedAt() pgtype.Timestamptz
 	getUpdatedAt() pgtype.Timestamptz
+	getDeletedAt() pgtype.Timestamptz
 	getCharacterName() pgtype.Text
 	getCharacterAvatar() pgtype.Text
 	getCharacterType() generated.NullCharacterType
@@ -967,10 +1209,15 @@ func (a postDataAdapter) getIsDraft() bool                 { return a.p.IsDraft
 func (a postDataAdapter) getIsLocked() bool                { return a.p.IsLocked }
 func (a postDataAdapter) getLockedAt() pgtype.Timestamptz  { return a.p.LockedAt }
 func (a postDataAdapter) getEditedByGm() bool              { return a.p.EditedByGm }
+func (a postDataAdapter) getAuthoredByGm() bool            { return a.p.AuthoredByGm }
+func (a postDataAdapter) getMentions() []pgtype.UUID       { return a.p.Mentions }
+func (a postDataAdapter) getIsSystem() bool                { return a.p.IsSystem }
+func (a postDataAdapter) getIsPinned() bool                { return a.p.IsPinned }
 func (a postDataAdapter) getIntention() pgtype.Text        { return a.p.Intention }
 func (a postDataAdapter) getModifier() pgtype.Int4         { return a.p.Modifier }
 func (a postDataAdapter) getCreatedAt() pgtype.Timestamptz { return a.p.CreatedAt }
 func (a postDataAdapter) getUpdatedAt() pgtype.Timestamptz { return a.p.UpdatedAt }
+func (a postDataAdapter) getDeletedAt() pgtype.Timestamptz { return a.p.DeletedAt }
 func (a postDataAdapter) getCharacterName() pgtype.Text    { return pgtype.Text{} }
 func (a postDataAdapter) getCharacterAvatar() pgtype.Text  { return pgtype.Text{} }
 func (a postDataAdapter) getCharacterType() generated.NullCharacterType {
@@ -994,10 +1241,15 @@ func (a listPostRowAdapter) getIsDraft() bool                              { ret
 func (a listPostRowAdapter) getIsLocked() bool                             { return a.p.IsLocked }
 func (a listPostRowAdapter) getLockedAt() pgtype.Timestamptz               { return a.p.LockedAt }
 func (a listPostRowAdapter) getEditedByGm() bool                           { return a.p.EditedByGm }
+func (a listPostRowAdapter) getAuthoredByGm() bool                         { return a.p.AuthoredByGm }
+func (a listPostRowAdapter) getMentions() []pgtype.UUID                    { return a.p.Mentions }
+func (a listPostRowAdapter) getIsSystem() bool                             { return a.p.IsSystem }
+func (a listPostRowAdapter) getIsPinned() bool                             { return a.p.IsPinned }
 func (a listPostRowAdapter) getIntention() pgtype.Text                     { return a.p.Intention }
 func (a listPostRowAdapter) getModifier() pgtype.Int4                      { return a.p.Modifier }
 func (a listPostRowAdapter) getCreatedAt() pgtype.Timestamptz              { return a.p.CreatedAt }
 func (a listPostRowAdapter) getUpdatedAt() pgtype.Timestamptz              { return a.p.UpdatedAt }
+func (a listPostRowAdapter) getDeletedAt() pgtype.Timestamptz              { return a.p.DeletedAt }
 func (a listPostRowAdapter) getCharacterName() pgtype.Text                 { return a.p.CharacterName }
 func (a listPostRowAdapter) getCharacterAvatar() pgtype.Text               { return a.p.CharacterAvatar }
 func (a listPostRowAdapter) getCharacterType() generated.NullCharacterType { return a.p.CharacterType }
@@ -1019,10 +1271,15 @@ func (a postWithCharacterAdapter) getIsDraft() bool                 { return a.p
 func (a postWithCharacterAdapter) getIsLocked() bool                { return a.p.IsLocked }
 func (a postWithCharacterAdapter) getLockedAt() pgtype.Timestamptz  { return a.p.LockedAt }
 func (a postWithCharacterAdapter) getEditedByGm() bool              { return a.p.EditedByGm }
+func (a postWithCharacterAdapter) getAuthoredByGm() bool            { return a.p.AuthoredByGm }
+func (a postWithCharacterAdapter) getMentions() []pgtype.UUID       { return a.p.Mentions }
+func (a postWithCharacterAdapter) getIsSystem() bool                { return a.p.IsSystem }
+func (a postWithCharacterAdapter) getIsPinned() bool                { return a.p.IsPinned }
 func (a postWithCharacterAdapter) getIntention() pgtype.Text        { return a.p.Intention }
 func (a postWithCharacterAdapter) getModifier() pgtype.Int4         { return a.p.Modifier }
 func (a postWithCharacterAdapter) getCreatedAt() pgtype.Timestamptz { return a.p.CreatedAt }
 func (a postWithCharacterAdapter) getUpdatedAt() pgtype.Timestamptz { return a.p.UpdatedAt }
+func (a postWithCharacterAdapter) getDeletedAt() pgtype.Timestamptz { return a.p.DeletedAt }
 func (a postWithCharacterAdapter) getCharacterName() pgtype.Text    { return a.p.CharacterName }
 func (a postWithCharacterAdapter) getCharacterAvatar() pgtype.Text  { return a.p.CharacterAvatar }
 func (a postWithCharacterAdapter) getCharacterType() generated.NullCharacterType {
@@ -1030,7 +1287,8 @@ func (a postWithCharacterAdapter) getCharacterType() generated.NullCharacterType
 }
 
 // buildPostResponse constructs a PostResponse from any postData implementation.
-func buildPostResponse(p postData) *PostResponse {
+// GM posts without a character are attributed to the campaign's narrator persona.
+func buildPostResponse(p postData, narrator *narratorPersona) *PostResponse {
 	postID := p.getID()
 	sceneID := p.getSceneID()
 	userID := p.getUserID()
@@ -1050,11 +1308,17 @@ func buildPostResponse(p postData) *PostResponse {
 		IsLocked:        p.getIsLocked(),
 		LockedAt:        nil,
 		EditedByGM:      p.getEditedByGm(),
+		AuthoredByGM:    p.getAuthoredByGm(),
+		IsSystem:        p.getIsSystem(),
+		IsPinned:        p.getIsPinned(),
+		Mentions:        []string{},
 		Intention:       nil,
 		Modifier:        nil,
 		CharacterName:   nil,
 		CharacterAvatar: nil,
 		CharacterType:   nil,
+		IsRemoved:       false,
+		RemovedAt:       nil,
 		CreatedAt:       createdAt.Time.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:       updatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -1077,6 +1341,10 @@ func buildPostResponse(p postData) *PostResponse {
 		resp.Witnesses = append(resp.Witnesses, formatUUID(w.Bytes[:]))
 	}
 
+	for _, m := range p.getMentions() {
+		resp.Mentions = append(resp.Mentions, formatUUID(m.Bytes[:]))
+	}
+
 	if lockedAt := p.getLockedAt(); lockedAt.Valid {
 		lockedAtStr := lockedAt.Time.Format("2006-01-02T15:04:05Z07:00")
 		resp.LockedAt = &lockedAtStr
@@ -1102,17 +1370,37 @@ func buildPostResponse(p postData) *PostResponse {
 		resp.CharacterType = &ct
 	}
 
+	// Removed posts keep their place in the scene but not their content
+	if deletedAt := p.getDeletedAt(); deletedAt.Valid {
+		removedAtStr := deletedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+		resp.IsRemoved = true
+		resp.RemovedAt = &removedAtStr
+		resp.Blocks = []PostBlock{{Type: "action", Content: removedPostPlaceholder, Order: 0}}
+		resp.OOCText = nil
+		resp.Intention = nil
+		resp.Modifier = nil
+		resp.Mentions = []string{}
+	}
+
+	narrator.applyTo(resp)
+
 	return resp
 }
 
-func (s *PostService) postToResponse(p *generated.Post) *PostResponse {
-	return buildPostResponse(postDataAdapter{p: p})
+func (s *PostService) postToResponse(p *generated.Post, narrator *narratorPersona) *PostResponse {
+	return buildPostResponse(postDataAdapter{p: p}, narrator)
 }
 
-func (s *PostService) listPostRowToResponse(p *generated.ListScenePostsRow) *PostResponse {
-	return buildPostResponse(listPostRowAdapter{p: p})
+func (s *PostService) listPostRowToResponse(
+	p *generated.ListScenePostsRow,
+	narrator *narratorPersona,
+) *PostResponse {
+	return buildPostResponse(listPostRowAdapter{p: p}, narrator)
 }
 
-func (s *PostService) postWithCharacterToResponse(p *generated.GetPostWithCharacterRow) *PostResponse {
-	return buildPostResponse(postWithCharacterAdapter{p: p})
+func (s *PostService) postWithCharacterToResponse(
+	p *generated.GetPostWithCharacterRow,
+	narrator *narratorPersona,
+) *PostResponse {
+	return buildPostResponse(postWithCharacterAdapter{p: p}, narrator)
 }
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// Post attribution errors.
+var (
+	ErrPostNotNarrator = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Only narrator posts can be attributed to a character",
+		"post already has a character or is a system post",
+	)
+	ErrAttributeNotNPC = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Posts can only be attributed to an NPC",
+		"target character is not an NPC",
+	)
+)
+
+// AttributePost gives a narrator post to an NPC in the post's scene (GM only), for
+// when the GM voiced an NPC before creating a character for it. The NPC is added
+// to the post's witnesses so it sees its own post.
+func (s *PostService) AttributePost(
+	ctx context.Context,
+	gmUserID pgtype.UUID,
+	postID, characterID string,
+) (*PostResponse, error) {
+	post, err := s.queries.GetPost(ctx, parseUUIDString(postID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if post.IsDraft {
+		return nil, ErrPostNotFound
+	}
+
+	scene, err := s.queries.GetScene(ctx, post.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: scene.CampaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	if post.CharacterID.Valid || post.IsSystem {
+		return nil, ErrPostNotNarrator
+	}
+	if post.DeletedAt.Valid {
+		return nil, ErrPostRemoved
+	}
+	if scene.IsFrozen {
+		return nil, ErrSceneFrozen
+	}
+
+	character, err := s.queries.GetCharacter(ctx, parseUUIDString(characterID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCharacterNotFound
+		}
+		return nil, err
+	}
+	if character.CampaignID != scene.CampaignID {
+		return nil, ErrCharacterNotFound
+	}
+	if character.CharacterType != generated.CharacterTypeNpc {
+		return nil, ErrAttributeNotNPC
+	}
+	if !slices.Contains(scene.CharacterIds, character.ID) {
+		return nil, ErrCharacterNotInScene
+	}
+
+	witnesses := post.Witnesses
+	if !slices.Contains(witnesses, character.ID) {
+		witnesses = append(slices.Clone(witnesses), character.ID)
+	}
+
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
+	// Start transaction
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := s.queries.WithTx(tx)
+
+	if _, attrErr := qtx.AttributePost(ctx, generated.AttributePostParams{
+		ID:          post.ID,
+		CharacterID: character.ID,
+		Witnesses:   witnesses,
+	}); attrErr != nil {
+		return nil, attrErr
+	}
+
+	auditErr := RecordGMAction(ctx, qtx, scene.CampaignID, gmUserID, GMActionAttributePost,
+		AuditTargetPost, post.ID, map[string]any{
+			"sceneId":       uuidToString(post.SceneID),
+			"characterId":   uuidToString(character.ID),
+			"characterName": character.DisplayName,
+		})
+	if auditErr != nil {
+		return nil, auditErr
+	}
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return nil, commitErr
+	}
+
+	// Reload with the character joined so the response carries its name
+	attributed, err := s.queries.GetPostWithCharacter(ctx, post.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.postWithCharacterToResponse(&attributed, s.narratorFor(ctx, scene.CampaignID)), nil
+}
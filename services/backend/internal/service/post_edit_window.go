@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// maxPlayerEditWindowSeconds caps the playerEditWindowSeconds setting at 30 days.
+const maxPlayerEditWindowSeconds = 30 * 24 * 60 * 60
+
+// ErrEditWindowClosed is returned when a player edits or deletes their post after
+// the campaign's playerEditWindowSeconds has passed.
+var ErrEditWindowClosed = newCodedError(
+	http.StatusForbidden, "EDIT_WINDOW_CLOSED",
+	"The edit window for this post has closed",
+	"player edit window has closed",
+)
+
+// EditWindowClosedError reports when a player's edit window on a post closed.
+// It unwraps to ErrEditWindowClosed.
+type EditWindowClosedError struct {
+	ClosedAt time.Time
+}
+
+func (e *EditWindowClosedError) Error() string {
+	return fmt.Sprintf("player edit window closed at %s", e.ClosedAt.Format(time.RFC3339))
+}
+
+func (e *EditWindowClosedError) Unwrap() error {
+	return ErrEditWindowClosed.WithMessage(
+		fmt.Sprintf("The edit window for this post closed at %s.", e.ClosedAt.Format(time.RFC3339)),
+	)
+}
+
+// checkPlayerEditWindow rejects a player's change to their own submitted post once
+// the campaign's edit window, counted from when the post was submitted, has passed.
+// Callers skip it for the GM.
+func (s *PostService) checkPlayerEditWindow(
+	ctx context.Context,
+	post *generated.Post,
+	campaignID pgtype.UUID,
+) error {
+	if post.IsDraft {
+		return nil
+	}
+
+	campaign, err := s.queries.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+
+	window := playerEditWindow(campaign.Settings)
+	if window == 0 {
+		return nil
+	}
+
+	submittedAt := post.SubmittedAt
+	if !submittedAt.Valid {
+		submittedAt = post.CreatedAt
+	}
+	closedAt := submittedAt.Time.Add(window)
+	if time.Now().After(closedAt) {
+		return &EditWindowClosedError{ClosedAt: closedAt.UTC()}
+	}
+	return nil
+}
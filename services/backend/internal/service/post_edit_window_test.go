@@ -0,0 +1,57 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPlayerEditWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings string
+		want     time.Duration
+	}{
+		{"no settings", ``, 0},
+		{"not set", `{}`, 0},
+		{"zero means no limit", `{"playerEditWindowSeconds": 0}`, 0},
+		{"negative", `{"playerEditWindowSeconds": -5}`, 0},
+		{"ten minutes", `{"playerEditWindowSeconds": 600}`, 10 * time.Minute},
+		{"at the cap", `{"playerEditWindowSeconds": 2592000}`, 30 * 24 * time.Hour},
+		{"stored past the cap", `{"playerEditWindowSeconds": 9999999999}`, 30 * 24 * time.Hour},
+		{"would overflow a Duration", `{"playerEditWindowSeconds": 1000000000000000}`, 30 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := playerEditWindow([]byte(tt.settings)); got != tt.want {
+				t.Errorf("playerEditWindow(%s) = %v, want %v", tt.settings, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSettingsCapsPlayerEditWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		seconds any
+		wantErr bool
+	}{
+		{"no limit", 0, false},
+		{"one hour", 3600, false},
+		{"at the cap", maxPlayerEditWindowSeconds, false},
+		{"past the cap", maxPlayerEditWindowSeconds + 1, true},
+		{"huge", math.MaxInt64, true},
+		{"negative", -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSettings(map[string]any{"playerEditWindowSeconds": tt.seconds})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSettings(playerEditWindowSeconds=%v) error = %v, wantErr %v",
+					tt.seconds, err, tt.wantErr)
+			}
+		})
+	}
+}
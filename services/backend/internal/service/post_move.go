@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// Post move errors.
+var (
+	ErrMoveAcrossCampaigns = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Posts can only be moved to a scene in the same campaign",
+		"target scene is in a different campaign",
+	)
+	ErrPostAlreadyInScene = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"The post is already in that scene",
+		"post is already in the target scene",
+	)
+	ErrMoveAuthorNotInScene = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"The post's character must be in the target scene",
+		"post author's character is not in the target scene",
+	)
+)
+
+// MovePostResult is a moved post along with the scene it left, so callers can
+// notify both scenes.
+type MovePostResult struct {
+	Post        *PostResponse
+	FromSceneID pgtype.UUID
+	CampaignID  pgtype.UUID
+}
+
+// MovePost moves a submitted post to another scene in the same campaign (GM only).
+// The post's character must already be in the target scene. Witnesses are
+// recomputed from the target scene's characters and witness mode, always keeping
+// the author's character, and post locks are fixed up in both scenes so only each scene's latest post
+// stays editable.
+func (s *PostService) MovePost(
+	ctx context.Context,
+	gmUserID pgtype.UUID,
+	postID, targetSceneID string,
+) (*MovePostResult, error) {
+	postUUID := parseUUIDString(postID)
+	targetUUID := parseUUIDString(targetSceneID)
+
+	post, err := s.queries.GetPost(ctx, postUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if post.IsDraft {
+		return nil, ErrPostNotFound
+	}
+	if post.SceneID == targetUUID {
+		return nil, ErrPostAlreadyInScene
+	}
+
+	source, err := s.queries.GetScene(ctx, post.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: source.CampaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	target, err := s.queries.GetScene(ctx, targetUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSceneNotFound
+		}
+		return nil, err
+	}
+	if target.CampaignID != source.CampaignID {
+		return nil, ErrMoveAcrossCampaigns
+	}
+	if source.IsFrozen || target.IsFrozen {
+		return nil, ErrSceneFrozen
+	}
+
+	if post.CharacterID.Valid && !slices.Contains(target.CharacterIds, post.CharacterID) {
+		return nil, ErrMoveAuthorNotInScene
+	}
+
+	// Same rules as submitting into the target scene, but the author always keeps
+	// sight of their own post
+	witnesses := submittedWitnesses(target.DefaultWitnessMode, target.CharacterIds, post.CharacterID, post.IsHidden)
+	if post.CharacterID.Valid && !slices.Contains(witnesses, post.CharacterID) {
+		witnesses = append(slices.Clone(witnesses), post.CharacterID)
+	}
+
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
+	// Start transaction
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := s.queries.WithTx(tx)
+
+	// Note whether the post was the source scene's latest before it leaves
+	sourceLast, err := qtx.GetLastScenePost(ctx, post.SceneID)
+	if err != nil {
+		return nil, err
+	}
+	wasSourceLast := sourceLast.ID == postUUID
+
+	if _, moveErr := qtx.MovePost(ctx, generated.MovePostParams{
+		ID:               postUUID,
+		SceneID:          targetUUID,
+		Witnesses:        witnesses,
+		WitnessedByScene: witnessedByScene(target.DefaultWitnessMode, post.IsHidden),
+	}); moveErr != nil {
+		return nil, moveErr
+	}
+
+	if rollErr := qtx.MovePostRolls(ctx, generated.MovePostRollsParams{
+		PostID:  postUUID,
+		SceneID: targetUUID,
+	}); rollErr != nil {
+		return nil, rollErr
+	}
+
+	if lockErr := fixMovedPostLocks(ctx, qtx, &post, targetUUID, wasSourceLast); lockErr != nil {
+		return nil, lockErr
+	}
+
+	auditErr := RecordGMAction(ctx, qtx, source.CampaignID, gmUserID, GMActionMovePost,
+		AuditTargetPost, postUUID, map[string]any{
+			"fromSceneId": uuidToString(post.SceneID),
+			"toSceneId":   uuidToString(targetUUID),
+			"authorId":    uuidToString(post.UserID),
+		})
+	if auditErr != nil {
+		return nil, auditErr
+	}
+
+	moved, err := qtx.GetPost(ctx, postUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return nil, commitErr
+	}
+
+	return &MovePostResult{
+		Post:        s.postToResponse(&moved, s.narratorFor(ctx, source.CampaignID)),
+		FromSceneID: post.SceneID,
+		CampaignID:  source.CampaignID,
+	}, nil
+}
+
+// fixMovedPostLocks restores the lock rule in both scenes after a move: every post
+// is locked once a later post exists in its scene.
+func fixMovedPostLocks(
+	ctx context.Context,
+	qtx *generated.Queries,
+	post *generated.Post,
+	targetSceneID pgtype.UUID,
+	wasSourceLast bool,
+) error {
+	// The source scene's new latest post becomes editable again
+	if wasSourceLast {
+		newLast, err := qtx.GetLastScenePost(ctx, post.SceneID)
+		if err == nil {
+			if unlockErr := qtx.UnlockPost(ctx, newLast.ID); unlockErr != nil {
+				return unlockErr
+			}
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+	}
+
+	// The target post just before the moved one now has a later post
+	prev, err := qtx.GetPreviousPost(ctx, generated.GetPreviousPostParams{
+		SceneID:   targetSceneID,
+		CreatedAt: post.CreatedAt,
+	})
+	if err == nil {
+		if !prev.IsLocked {
+			if lockErr := qtx.LockPost(ctx, prev.ID); lockErr != nil {
+				return lockErr
+			}
+		}
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	// The moved post is locked unless it is now the target scene's latest
+	targetLast, err := qtx.GetLastScenePost(ctx, targetSceneID)
+	if err != nil {
+		return err
+	}
+	isTargetLast := targetLast.ID == post.ID
+	switch {
+	case isTargetLast && post.IsLocked:
+		return qtx.UnlockPost(ctx, post.ID)
+	case !isTargetLast && !post.IsLocked:
+		return qtx.LockPost(ctx, post.ID)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+//go:build integration
+
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMovePostRequiresAuthorInTargetScene(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	author := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	post, err := tc.post(author.userID, author.characterID, CreatePostRequest{})
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+
+	target := tc.newScene("Elsewhere")
+	posts := NewPostService(tc.pool)
+	_, err = posts.MovePost(tc.ctx, tc.gm, post.ID, uuidToString(target.ID))
+	if !errors.Is(err, ErrMoveAuthorNotInScene) {
+		t.Fatalf("move to a scene without the author: err = %v, want ErrMoveAuthorNotInScene", err)
+	}
+
+	tc.addToScene(target, author.characterID)
+	moved, err := posts.MovePost(tc.ctx, tc.gm, post.ID, uuidToString(target.ID))
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if !slices.Contains(moved.Post.Witnesses, uuidToString(author.characterID)) {
+		t.Errorf("moved post witnesses %v do not include the author's character", moved.Post.Witnesses)
+	}
+}
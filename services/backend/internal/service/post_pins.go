@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// PinPost pins a submitted post so it lists first in its scene (GM only).
+// Pinning leaves the post's witnesses unchanged.
+func (s *PostService) PinPost(
+	ctx context.Context,
+	userID pgtype.UUID,
+	postID string,
+) (*PostResponse, error) {
+	return s.setPostPinned(ctx, userID, postID, true)
+}
+
+// UnpinPost returns a pinned post to its chronological place (GM only).
+func (s *PostService) UnpinPost(
+	ctx context.Context,
+	userID pgtype.UUID,
+	postID string,
+) (*PostResponse, error) {
+	return s.setPostPinned(ctx, userID, postID, false)
+}
+
+func (s *PostService) setPostPinned(
+	ctx context.Context,
+	userID pgtype.UUID,
+	postID string,
+	pinned bool,
+) (*PostResponse, error) {
+	postUUID := parseUUIDString(postID)
+
+	post, err := s.queries.GetPost(ctx, postUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if post.IsDraft {
+		return nil, ErrPostNotFound
+	}
+	if post.DeletedAt.Valid {
+		return nil, ErrPostRemoved
+	}
+
+	scene, err := s.queries.GetScene(ctx, post.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: scene.CampaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	var updated generated.Post
+	if pinned {
+		updated, err = s.queries.PinPost(ctx, postUUID)
+	} else {
+		updated, err = s.queries.UnpinPost(ctx, postUUID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.postToResponse(&updated, s.narratorFor(ctx, scene.CampaignID)), nil
+}
@@ -0,0 +1,86 @@
+//go:build integration
+
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRemovedPostContentLeavesThePostsTable(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	player := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	created, err := tc.post(player.userID, player.characterID, CreatePostRequest{
+		Blocks: []PostBlock{{Type: "dialog", Content: "The password is swordfish.", Order: 0}},
+	})
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+
+	posts := NewPostService(tc.pool)
+	if _, err = posts.RemovePost(tc.ctx, tc.gm, created.ID); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+
+	stored, err := tc.queries.GetPost(tc.ctx, parseUUIDString(created.ID))
+	if err != nil {
+		t.Fatalf("get post: %v", err)
+	}
+	var blocks []PostBlock
+	if err = json.Unmarshal(stored.Blocks, &blocks); err != nil {
+		t.Fatalf("unmarshal blocks: %v", err)
+	}
+	if len(blocks) != 0 || stored.OocText.Valid {
+		t.Fatalf("removed post still holds content: blocks %s, ooc %v", stored.Blocks, stored.OocText)
+	}
+
+	restored, err := posts.RestorePost(tc.ctx, tc.gm, created.ID)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if len(restored.Blocks) != 1 || restored.Blocks[0].Content != "The password is swordfish." {
+		t.Fatalf("restored blocks = %+v", restored.Blocks)
+	}
+}
+
+func TestRemovedPostContentIsPurgedAfterTheRestoreWindow(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	player := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	created, err := tc.post(player.userID, player.characterID, CreatePostRequest{})
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+
+	posts := NewPostService(tc.pool)
+	if _, err = posts.RemovePost(tc.ctx, tc.gm, created.ID); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+
+	// Age the removal past the restore window
+	expired := time.Now().Add(-postRestoreWindow - time.Hour)
+	if _, err = tc.pool.Exec(tc.ctx,
+		"UPDATE removed_post_contents SET removed_at = $2 WHERE post_id = $1",
+		parseUUIDString(created.ID), expired); err != nil {
+		t.Fatalf("age removal: %v", err)
+	}
+
+	purged, err := posts.PurgeExpiredRemovedPosts(tc.ctx)
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if purged != 1 {
+		t.Fatalf("purged %d rows, want 1", purged)
+	}
+
+	// deleted_at is still recent, so only the missing content stops the restore
+	_, err = posts.RestorePost(tc.ctx, tc.gm, created.ID)
+	if !errors.Is(err, ErrRestoreWindowExpired) {
+		t.Fatalf("restore after purge: got %v, want ErrRestoreWindowExpired", err)
+	}
+}
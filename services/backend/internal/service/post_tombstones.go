@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+const (
+	// removedPostPlaceholder replaces the content of a post removed by the GM.
+	removedPostPlaceholder = "[removed by GM]"
+
+	// postRestoreWindow is how long a removed post can be restored.
+	postRestoreWindow = 7 * 24 * time.Hour
+)
+
+// Post tombstone errors.
+var (
+	ErrPostRemoved = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"This post has been removed by the GM",
+		"post has been removed",
+	)
+	ErrPostNotRemoved = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"This post has not been removed",
+		"post is not removed",
+	)
+	ErrRestoreWindowExpired = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"This post was removed too long ago to be restored",
+		"post restore window has expired",
+	)
+)
+
+// RemovePost replaces a submitted post with a placeholder instead of deleting it
+// (GM only). The post keeps its place in the scene and its lock state, so the
+// posts around it are unaffected, and it can be restored with RestorePost within
+// postRestoreWindow. The content moves to removed_post_contents, which only the
+// GM can read, so witnesses cannot fetch it from the posts table.
+func (s *PostService) RemovePost(
+	ctx context.Context,
+	gmUserID pgtype.UUID,
+	postID string,
+) (*PostResponse, error) {
+	post, campaignID, err := s.gmTombstoneTarget(ctx, gmUserID, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.DeletedAt.Valid {
+		return nil, ErrPostRemoved
+	}
+
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
+	// Start transaction
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := s.queries.WithTx(tx)
+
+	if saveErr := qtx.SaveRemovedPostContent(ctx, post.ID); saveErr != nil {
+		return nil, saveErr
+	}
+
+	removed, err := qtx.TombstonePost(ctx, generated.TombstonePostParams{
+		ID:        post.ID,
+		DeletedBy: gmUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	auditErr := RecordGMAction(ctx, qtx, campaignID, gmUserID, GMActionRemovePost,
+		AuditTargetPost, post.ID, map[string]any{
+			"sceneId":     uuidToString(post.SceneID),
+			"authorId":    uuidToString(post.UserID),
+			"characterId": uuidToString(post.CharacterID),
+			"createdAt":   post.CreatedAt.Time.Format(time.RFC3339),
+		})
+	if auditErr != nil {
+		return nil, auditErr
+	}
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return nil, commitErr
+	}
+
+	return s.postToResponse(&removed, s.narratorFor(ctx, campaignID)), nil
+}
+
+// RestorePost brings back the content of a post removed with RemovePost (GM only).
+// A pin the post had before removal is not restored.
+func (s *PostService) RestorePost(
+	ctx context.Context,
+	gmUserID pgtype.UUID,
+	postID string,
+) (*PostResponse, error) {
+	post, campaignID, err := s.gmTombstoneTarget(ctx, gmUserID, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.DeletedAt.Valid {
+		return nil, ErrPostNotRemoved
+	}
+	if time.Since(post.DeletedAt.Time) > postRestoreWindow {
+		return nil, ErrRestoreWindowExpired
+	}
+
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
+	// Start transaction
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := s.queries.WithTx(tx)
+
+	restored, err := qtx.RestorePost(ctx, post.ID)
+	if err != nil {
+		// The content has already been purged
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRestoreWindowExpired
+		}
+		return nil, err
+	}
+
+	if deleteErr := qtx.DeleteRemovedPostContent(ctx, post.ID); deleteErr != nil {
+		return nil, deleteErr
+	}
+
+	auditErr := RecordGMAction(ctx, qtx, campaignID, gmUserID, GMActionRestorePost,
+		AuditTargetPost, post.ID, map[string]any{
+			"sceneId":   uuidToString(post.SceneID),
+			"authorId":  uuidToString(post.UserID),
+			"removedAt": post.DeletedAt.Time.Format(time.RFC3339),
+		})
+	if auditErr != nil {
+		return nil, auditErr
+	}
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return nil, commitErr
+	}
+
+	return s.postToResponse(&restored, s.narratorFor(ctx, campaignID)), nil
+}
+
+// PurgeExpiredRemovedPosts drops the saved content of posts removed longer ago
+// than postRestoreWindow, which can no longer be restored. It returns how many
+// were purged.
+func (s *PostService) PurgeExpiredRemovedPosts(ctx context.Context) (int64, error) {
+	return s.queries.PurgeRemovedPostContents(ctx, pgtype.Timestamptz{
+		Time:  time.Now().Add(-postRestoreWindow),
+		Valid: true,
+	})
+}
+
+// gmTombstoneTarget loads a submitted post and its campaign, checking the user is
+// the campaign's GM.
+func (s *PostService) gmTombstoneTarget(
+	ctx context.Context,
+	gmUserID pgtype.UUID,
+	postID string,
+) (*generated.Post, pgtype.UUID, error) {
+	post, err := s.queries.GetPost(ctx, parseUUIDString(postID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgtype.UUID{}, ErrPostNotFound
+		}
+		return nil, pgtype.UUID{}, err
+	}
+	if post.IsDraft {
+		return nil, pgtype.UUID{}, ErrPostNotFound
+	}
+
+	scene, err := s.queries.GetScene(ctx, post.SceneID)
+	if err != nil {
+		return nil, pgtype.UUID{}, err
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: scene.CampaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return nil, pgtype.UUID{}, err
+	}
+	if !isGM {
+		return nil, pgtype.UUID{}, ErrNotGM
+	}
+
+	return &post, scene.CampaignID, nil
+}
@@ -0,0 +1,211 @@
+//go:build integration
+
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestHiddenPostsAreVisibleOnlyToAuthorAndGM(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	author := tc.addPlayer()
+	other := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	hidden, err := tc.post(author.userID, author.characterID, CreatePostRequest{IsHidden: true})
+	if err != nil {
+		t.Fatalf("hidden post: %v", err)
+	}
+	public, err := tc.post(other.userID, other.characterID, CreatePostRequest{})
+	if err != nil {
+		t.Fatalf("public post: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		userID     pgtype.UUID
+		seesHidden bool
+	}{
+		{"author", author.userID, true},
+		{"other player", other.userID, false},
+		{"GM", tc.gm, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			visible := tc.visiblePostIDs(tt.userID)
+			if visible[hidden.ID] != tt.seesHidden {
+				t.Errorf("sees hidden post = %v, want %v", visible[hidden.ID], tt.seesHidden)
+			}
+			if !visible[public.ID] {
+				t.Error("public post missing")
+			}
+		})
+	}
+
+	_, err = NewPostService(tc.pool).GetPost(tc.ctx, other.userID, hidden.ID)
+	if !errors.Is(err, ErrPostNotFound) {
+		t.Fatalf("other player GetPost on hidden post: got %v, want ErrPostNotFound", err)
+	}
+}
+
+func TestExplicitWitnessesLimitWhoSeesAPost(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	first := tc.addPlayer()
+	second := tc.addPlayer()
+	outsider := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	whisper, err := tc.post(first.userID, first.characterID, CreatePostRequest{
+		Witnesses: []string{uuidToString(first.characterID), uuidToString(second.characterID)},
+	})
+	if err != nil {
+		t.Fatalf("whisper: %v", err)
+	}
+
+	if !tc.visiblePostIDs(second.userID)[whisper.ID] {
+		t.Error("chosen witness cannot see the post")
+	}
+	if tc.visiblePostIDs(outsider.userID)[whisper.ID] {
+		t.Error("character left out of the witnesses can see the post")
+	}
+
+	// Players must keep their own character among the witnesses
+	_, err = tc.post(first.userID, first.characterID, CreatePostRequest{
+		Witnesses: []string{uuidToString(second.characterID)},
+	})
+	if !errors.Is(err, ErrWitnessesMissingAuthor) {
+		t.Fatalf("witnesses without the author: got %v, want ErrWitnessesMissingAuthor", err)
+	}
+}
+
+func TestLateArrivalsDoNotSeeEarlierPosts(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	early := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	before, err := tc.post(early.userID, early.characterID, CreatePostRequest{})
+	if err != nil {
+		t.Fatalf("post before arrival: %v", err)
+	}
+
+	late := tc.addPlayer()
+	after, err := tc.post(early.userID, early.characterID, CreatePostRequest{})
+	if err != nil {
+		t.Fatalf("post after arrival: %v", err)
+	}
+
+	visible := tc.visiblePostIDs(late.userID)
+	if visible[before.ID] {
+		t.Error("late arrival sees a post made before they joined the scene")
+	}
+	if !visible[after.ID] {
+		t.Error("late arrival misses a post made after they joined the scene")
+	}
+}
+
+func TestGrantWitnessSkipsRestrictedPosts(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	first := tc.addPlayer()
+	second := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	open, err := tc.post(first.userID, first.characterID, CreatePostRequest{})
+	if err != nil {
+		t.Fatalf("open post: %v", err)
+	}
+	whisper, err := tc.post(first.userID, first.characterID, CreatePostRequest{
+		Witnesses: []string{uuidToString(first.characterID)},
+	})
+	if err != nil {
+		t.Fatalf("whisper: %v", err)
+	}
+
+	late := tc.addPlayer()
+	result, err := NewPostService(tc.pool).GrantWitnessToCharacter(tc.ctx, tc.gm, tc.scene.ID, late.characterID, nil)
+	if err != nil {
+		t.Fatalf("grant: %v", err)
+	}
+	if result.Count != 1 {
+		t.Errorf("granted %d posts, want 1", result.Count)
+	}
+
+	visible := tc.visiblePostIDs(late.userID)
+	if !visible[open.ID] {
+		t.Error("newcomer was not granted the post the whole scene saw")
+	}
+	if visible[whisper.ID] {
+		t.Error("newcomer was granted a whisper")
+	}
+	if tc.visiblePostIDs(second.userID)[whisper.ID] {
+		t.Error("whisper leaked to a character left out of it")
+	}
+}
+
+func TestGrantWitnessSkipsAWhisperThatOpensTheScene(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	first := tc.addPlayer()
+	second := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	whisper, err := tc.post(first.userID, first.characterID, CreatePostRequest{
+		Witnesses: []string{uuidToString(first.characterID)},
+	})
+	if err != nil {
+		t.Fatalf("whisper: %v", err)
+	}
+	open, err := tc.post(second.userID, second.characterID, CreatePostRequest{})
+	if err != nil {
+		t.Fatalf("open post: %v", err)
+	}
+
+	late := tc.addPlayer()
+	result, err := NewPostService(tc.pool).GrantWitnessToCharacter(tc.ctx, tc.gm, tc.scene.ID, late.characterID, nil)
+	if err != nil {
+		t.Fatalf("grant: %v", err)
+	}
+	if result.Count != 1 {
+		t.Errorf("granted %d posts, want 1", result.Count)
+	}
+
+	visible := tc.visiblePostIDs(late.userID)
+	if visible[whisper.ID] {
+		t.Error("newcomer was granted the whisper that opened the scene")
+	}
+	if !visible[open.ID] {
+		t.Error("newcomer was not granted the post the whole scene saw")
+	}
+}
+
+func TestGrantWitnessSkipsGMOnlyPosts(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	player := tc.addPlayer()
+	gmOnly := WitnessModeGMOnly
+	if _, err := NewSceneService(tc.pool).UpdateScene(tc.ctx, tc.scene.ID, tc.gm, UpdateSceneRequest{
+		DefaultWitnessMode: &gmOnly,
+	}); err != nil {
+		t.Fatalf("set witness mode: %v", err)
+	}
+	tc.transition(PhasePCPhase)
+
+	if _, err := tc.post(player.userID, player.characterID, CreatePostRequest{}); err != nil {
+		t.Fatalf("player post: %v", err)
+	}
+	if _, err := tc.post(tc.gm, pgtype.UUID{}, CreatePostRequest{}); err != nil {
+		t.Fatalf("narrator post: %v", err)
+	}
+
+	late := tc.addPlayer()
+	result, err := NewPostService(tc.pool).GrantWitnessToCharacter(tc.ctx, tc.gm, tc.scene.ID, late.characterID, nil)
+	if err != nil {
+		t.Fatalf("grant: %v", err)
+	}
+	if result.Count != 0 {
+		t.Errorf("granted %d GM-only posts, want 0", result.Count)
+	}
+	if visible := tc.visiblePostIDs(late.userID); len(visible) != 0 {
+		t.Errorf("newcomer sees %d GM-only posts, want none", len(visible))
+	}
+}
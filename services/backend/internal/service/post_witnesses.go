@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// GrantWitnessResult lists the posts a character was added to as a witness.
+type GrantWitnessResult struct {
+	PostIDs    []string    `json:"postIds"`
+	Count      int         `json:"count"`
+	CampaignID pgtype.UUID `json:"-"`
+}
+
+// GrantWitnessToCharacter adds a character in the scene as a witness to the scene's
+// submitted posts (GM only), so a newcomer can read the story so far. When
+// fromPostID is set, only that post and later ones are granted. Only posts that
+// were submitted to the whole scene are extended: hidden posts are revealed with
+// UnhidePost, and whispers, author-only and GM-only posts stay restricted.
+func (s *PostService) GrantWitnessToCharacter(
+	ctx context.Context,
+	gmUserID pgtype.UUID,
+	sceneID, characterID pgtype.UUID,
+	fromPostID *string,
+) (*GrantWitnessResult, error) {
+	scene, err := s.queries.GetScene(ctx, sceneID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSceneNotFound
+		}
+		return nil, err
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: scene.CampaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	if !slices.Contains(scene.CharacterIds, characterID) {
+		return nil, ErrCharacterNotInScene
+	}
+
+	// Grant from the given post onward, or from the start of the scene
+	var from pgtype.Timestamptz
+	if fromPostID != nil {
+		fromPost, fromErr := s.queries.GetPost(ctx, parseUUIDString(*fromPostID))
+		if fromErr != nil {
+			if errors.Is(fromErr, pgx.ErrNoRows) {
+				return nil, ErrPostNotFound
+			}
+			return nil, fromErr
+		}
+		if fromPost.SceneID != sceneID || fromPost.IsDraft {
+			return nil, ErrPostNotFound
+		}
+		from = fromPost.CreatedAt
+	}
+
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
+	// Start transaction
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := s.queries.WithTx(tx)
+
+	posts, err := qtx.ListScenePosts(ctx, sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GrantWitnessResult{PostIDs: []string{}, CampaignID: scene.CampaignID}
+	for _, post := range posts {
+		// Only posts submitted to the whole scene are extended; an empty witness
+		// list (GM-only scenes) or a whisper never counts as the whole scene
+		if !post.WitnessedByScene || post.IsHidden || slices.Contains(post.Witnesses, characterID) {
+			continue
+		}
+		if from.Valid && post.CreatedAt.Time.Before(from.Time) {
+			continue
+		}
+
+		if _, editErr := qtx.EditPostWitnesses(ctx, generated.EditPostWitnessesParams{
+			ID:               post.ID,
+			Witnesses:        append(slices.Clone(post.Witnesses), characterID),
+			WitnessedByScene: post.WitnessedByScene,
+		}); editErr != nil {
+			return nil, editErr
+		}
+		result.PostIDs = append(result.PostIDs, uuidToString(post.ID))
+	}
+	result.Count = len(result.PostIDs)
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return nil, commitErr
+	}
+
+	return result, nil
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// MaxDisplayNameLen is the maximum length of a profile display name, in characters.
+const MaxDisplayNameLen = 50
+
+// displayNamePunctuation lists the punctuation allowed in display names besides spaces.
+const displayNamePunctuation = "-_.'"
+
+// Profile errors.
+var (
+	ErrInvalidDisplayName = errors.New("invalid display name")
+)
+
+// ProfileService handles user profile business logic.
+type ProfileService struct {
+	queries *generated.Queries
+}
+
+// NewProfileService creates a new ProfileService.
+func NewProfileService(pool *pgxpool.Pool) *ProfileService {
+	return &ProfileService{
+		queries: newQueries(pool),
+	}
+}
+
+// UserProfileResponse represents a user's global profile in API responses.
+type UserProfileResponse struct {
+	UserID            string  `json:"userId"`
+	DisplayName       *string `json:"displayName"`
+	AvatarURL         *string `json:"avatarUrl"`
+	StorageUsedBytes  int64   `json:"storageUsedBytes"`
+	StorageLimitBytes int64   `json:"storageLimitBytes"`
+}
+
+// UpdateProfileRequest represents the request to update a profile.
+// An empty DisplayName clears it; a missing one leaves it unchanged.
+type UpdateProfileRequest struct {
+	DisplayName *string `json:"displayName"`
+}
+
+// GetProfile returns the user's profile. Users who never set one get an empty profile.
+func (s *ProfileService) GetProfile(ctx context.Context, userID pgtype.UUID) (*UserProfileResponse, error) {
+	profile, err := s.queries.GetUserProfile(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			//nolint:exhaustruct // A user without a profile row has nothing else to report
+			return profileToResponse(&generated.UserProfile{UserID: userID}), nil
+		}
+		return nil, err
+	}
+	return profileToResponse(&profile), nil
+}
+
+// UpdateProfile updates the user's profile, creating it on first use.
+func (s *ProfileService) UpdateProfile(
+	ctx context.Context,
+	userID pgtype.UUID,
+	req UpdateProfileRequest,
+) (*UserProfileResponse, error) {
+	if req.DisplayName == nil {
+		return s.GetProfile(ctx, userID)
+	}
+
+	displayName, err := normalizeDisplayName(*req.DisplayName)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.queries.UpsertUserProfileDisplayName(ctx, generated.UpsertUserProfileDisplayNameParams{
+		UserID:      userID,
+		DisplayName: displayName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return profileToResponse(&profile), nil
+}
+
+// DisplayNames returns the profile display names of the given users, keyed by user ID.
+// Users without a display name are left out.
+func (s *ProfileService) DisplayNames(ctx context.Context, userIDs []pgtype.UUID) (map[[16]byte]string, error) {
+	names := make(map[[16]byte]string)
+	if len(userIDs) == 0 {
+		return names, nil
+	}
+
+	profiles, err := s.queries.ListUserProfiles(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, profile := range profiles {
+		if profile.DisplayName.Valid {
+			names[profile.UserID.Bytes] = profile.DisplayName.String
+		}
+	}
+	return names, nil
+}
+
+// normalizeDisplayName trims a display name and checks its length and characters:
+// letters, numbers, spaces, and a little punctuation. An empty name clears it.
+func normalizeDisplayName(name string) (pgtype.Text, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return pgtype.Text{String: "", Valid: false}, nil
+	}
+	if utf8.RuneCountInString(name) > MaxDisplayNameLen {
+		return pgtype.Text{}, ErrInvalidDisplayName
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r) &&
+			r != ' ' && !strings.ContainsRune(displayNamePunctuation, r) {
+			return pgtype.Text{}, ErrInvalidDisplayName
+		}
+	}
+	return pgtype.Text{String: name, Valid: true}, nil
+}
+
+// profileToResponse converts a stored profile to its API response.
+func profileToResponse(profile *generated.UserProfile) *UserProfileResponse {
+	resp := &UserProfileResponse{
+		UserID:            uuidToString(profile.UserID),
+		DisplayName:       nil,
+		AvatarURL:         nil,
+		StorageUsedBytes:  profile.StorageUsedBytes,
+		StorageLimitBytes: UserStorageLimit,
+	}
+	if profile.DisplayName.Valid {
+		resp.DisplayName = &profile.DisplayName.String
+	}
+	if profile.AvatarUrl.Valid {
+		resp.AvatarURL = &profile.AvatarUrl.String
+	}
+	return resp
+}
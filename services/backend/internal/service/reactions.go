@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// MaxReactionEmojiLen is the maximum length of a reaction emoji in bytes.
+// Emoji with skin tones or ZWJ sequences span several code points.
+const MaxReactionEmojiLen = 32
+
+// ErrInvalidReaction is returned when a reaction is empty, too long, or contains text.
+var ErrInvalidReaction = newCodedError(
+	http.StatusBadRequest, "VALIDATION_ERROR",
+	"Reaction must be an emoji",
+	"invalid reaction",
+)
+
+// ReactionCount is the aggregated count for one emoji on a post.
+type ReactionCount struct {
+	Emoji   string `json:"emoji"`
+	Count   int32  `json:"count"`
+	Reacted bool   `json:"reacted"`
+}
+
+// PostReactionsResponse lists the reactions on a post for the requesting user.
+type PostReactionsResponse struct {
+	PostID    string          `json:"postId"`
+	SceneID   string          `json:"sceneId"`
+	Reactions []ReactionCount `json:"reactions"`
+}
+
+// AddReaction adds the user's emoji reaction to a post they can witness.
+func (s *PostService) AddReaction(
+	ctx context.Context,
+	userID pgtype.UUID,
+	postID, emoji string,
+) (*PostReactionsResponse, error) {
+	post, err := s.reactablePost(ctx, userID, postID, emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.queries.AddPostReaction(ctx, generated.AddPostReactionParams{
+		PostID: post.ID,
+		UserID: userID,
+		Emoji:  strings.TrimSpace(emoji),
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.postReactions(ctx, userID, post)
+}
+
+// RemoveReaction removes the user's emoji reaction from a post.
+func (s *PostService) RemoveReaction(
+	ctx context.Context,
+	userID pgtype.UUID,
+	postID, emoji string,
+) (*PostReactionsResponse, error) {
+	post, err := s.reactablePost(ctx, userID, postID, emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.queries.RemovePostReaction(ctx, generated.RemovePostReactionParams{
+		PostID: post.ID,
+		UserID: userID,
+		Emoji:  strings.TrimSpace(emoji),
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.postReactions(ctx, userID, post)
+}
+
+// ListReactions returns the reactions on a post the user can witness.
+func (s *PostService) ListReactions(
+	ctx context.Context,
+	userID pgtype.UUID,
+	postID string,
+) (*PostReactionsResponse, error) {
+	post, err := s.witnessedPost(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.postReactions(ctx, userID, post)
+}
+
+// reactablePost validates the emoji and loads a submitted post the user can witness.
+func (s *PostService) reactablePost(
+	ctx context.Context,
+	userID pgtype.UUID,
+	postID, emoji string,
+) (*generated.Post, error) {
+	if !isValidReactionEmoji(strings.TrimSpace(emoji)) {
+		return nil, ErrInvalidReaction
+	}
+
+	post, err := s.witnessedPost(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.DeletedAt.Valid {
+		return nil, ErrPostRemoved
+	}
+
+	return post, nil
+}
+
+// witnessedPost loads a submitted post the user can witness.
+func (s *PostService) witnessedPost(
+	ctx context.Context,
+	userID pgtype.UUID,
+	postID string,
+) (*generated.Post, error) {
+	post, err := s.queries.GetPost(ctx, parseUUIDString(postID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if post.IsDraft {
+		return nil, ErrPostNotFound
+	}
+
+	if _, err := s.verifyPostAccess(ctx, userID, post.SceneID, post.Witnesses); err != nil {
+		return nil, err
+	}
+
+	return &post, nil
+}
+
+// postReactions returns the aggregated reactions on a post.
+func (s *PostService) postReactions(
+	ctx context.Context,
+	userID pgtype.UUID,
+	post *generated.Post,
+) (*PostReactionsResponse, error) {
+	rows, err := s.queries.ListPostReactionCounts(ctx, generated.ListPostReactionCountsParams{
+		PostID: post.ID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reactions := make([]ReactionCount, 0, len(rows))
+	for _, row := range rows {
+		reactions = append(reactions, ReactionCount{
+			Emoji:   row.Emoji,
+			Count:   row.Count,
+			Reacted: row.Reacted,
+		})
+	}
+
+	return &PostReactionsResponse{
+		PostID:    formatUUID(post.ID.Bytes[:]),
+		SceneID:   formatUUID(post.SceneID.Bytes[:]),
+		Reactions: reactions,
+	}, nil
+}
+
+// isValidReactionEmoji rejects empty, oversized, or plain-text reactions.
+func isValidReactionEmoji(emoji string) bool {
+	if emoji == "" || len(emoji) > MaxReactionEmojiLen || !utf8.ValidString(emoji) {
+		return false
+	}
+	for _, r := range emoji {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
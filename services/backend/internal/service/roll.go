@@ -3,10 +3,17 @@ package service
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -14,6 +21,7 @@ import (
 
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
 	"github.com/tdanbo/vanguard-pbp/services/backend/internal/dice"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/metrics"
 )
 
 // Roll errors.
@@ -24,11 +32,65 @@ var (
 	ErrInvalidDiceCount    = errors.New("dice count must be between 1 and 100")
 	ErrInvalidIntention    = errors.New("intention is required")
 	ErrCannotPassPending   = errors.New("cannot pass with pending rolls")
+	ErrIntentionNotAllowed = errors.New("intention is not in the campaign's intention list")
+	ErrUnknownPreset       = errors.New("unknown dice preset")
+	ErrInvalidRollStatus   = errors.New("invalid roll status")
+	ErrInvalidRollFilter   = errors.New("invalid roll filter character")
+	ErrRollPostMismatch    = errors.New("post does not belong to the roll's scene")
+	ErrInvalidThreshold    = errors.New("success threshold must be within the die's face range")
+	ErrRollNoteTooLong     = errors.New("roll note is too long")
+	ErrEmptyRollBatch      = errors.New("roll batch is empty")
+	ErrRollBatchTooLarge   = errors.New("too many rolls in batch")
+	ErrRollTooLarge        = errors.New("roll exceeds the maximum roll size")
+
+	ErrInvalidRollCursor = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Invalid cursor",
+		"invalid roll cursor",
+	)
 )
 
 // Content preview constants.
 const postContentPreviewLen = 100
 
+// MaxRollNoteLen caps the length of a player's roll note, in characters.
+const MaxRollNoteLen = 200
+
+//nolint:gochecknoglobals // Set once at startup
+var maxRollSize atomic.Int64
+
+// ConfigureMaxRollSize sets the largest roll allowed, measured as dice count times
+// sides. A non-positive size keeps dice.DefaultMaxRollSize.
+func ConfigureMaxRollSize(size int) {
+	maxRollSize.Store(int64(size))
+}
+
+// MaxRollSize returns the configured maximum roll size.
+func MaxRollSize() int {
+	if size := maxRollSize.Load(); size > 0 {
+		return int(size)
+	}
+	return dice.DefaultMaxRollSize
+}
+
+// MaxRollBatch is the maximum number of rolls created in one batch.
+const MaxRollBatch = 10
+
+// RollBatchError reports the batch entry that failed validation.
+// It unwraps to that entry's error.
+type RollBatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *RollBatchError) Error() string {
+	return fmt.Sprintf("roll %d: %v", e.Index, e.Err)
+}
+
+func (e *RollBatchError) Unwrap() error {
+	return e.Err
+}
+
 // RollService handles roll business logic.
 type RollService struct {
 	queries *generated.Queries
@@ -39,21 +101,35 @@ type RollService struct {
 // NewRollService creates a new RollService.
 func NewRollService(pool *pgxpool.Pool) *RollService {
 	return &RollService{
-		queries: generated.New(pool),
+		queries: newLongQueries(pool),
 		pool:    pool,
 		roller:  dice.NewRoller(),
 	}
 }
 
 // CreateRollRequest represents the request to create a roll.
+// When Preset is set, its dice are used for any of DiceType, DiceCount, and
+// Modifier that are not given explicitly. When SuccessThreshold is set the roll
+// counts dice at or above it instead of summing them, and Modifier adds or
+// removes automatic successes. Note is a player annotation shown to the table.
 type CreateRollRequest struct {
-	PostID      *string `json:"postId"`
-	SceneID     string  `json:"sceneId"`
-	CharacterID string  `json:"characterId"`
-	Intention   string  `json:"intention"`
-	Modifier    int     `json:"modifier"`
-	DiceType    string  `json:"diceType"`
-	DiceCount   int     `json:"diceCount"`
+	PostID           *string `json:"postId"`
+	SceneID          string  `json:"sceneId"`
+	CharacterID      string  `json:"characterId"`
+	Intention        string  `json:"intention"`
+	Preset           *string `json:"preset"`
+	Modifier         *int    `json:"modifier"`
+	DiceType         string  `json:"diceType"`
+	DiceCount        int     `json:"diceCount"`
+	SuccessThreshold *int    `json:"successThreshold"`
+	Note             *string `json:"note"`
+}
+
+// CreateRollsRequest represents the request to create a batch of rolls.
+// When Sync is set the dice are rolled before the response is sent.
+type CreateRollsRequest struct {
+	Rolls []CreateRollRequest `json:"rolls"`
+	Sync  bool                `json:"sync"`
 }
 
 // RollResponse represents a roll in API responses.
@@ -71,6 +147,9 @@ type RollResponse struct {
 	DiceCount              int     `json:"diceCount"`
 	Result                 []int32 `json:"result"`
 	Total                  *int    `json:"total"`
+	SuccessThreshold       *int    `json:"successThreshold,omitempty"`
+	Successes              *int    `json:"successes,omitempty"`
+	Note                   *string `json:"note,omitempty"`
 	WasOverridden          bool    `json:"wasOverridden"`
 	OverriddenBy           *string `json:"overriddenBy,omitempty"`
 	OverrideReason         *string `json:"overrideReason,omitempty"`
@@ -81,6 +160,14 @@ type RollResponse struct {
 	Status                 string  `json:"status"`
 	RolledAt               *string `json:"rolledAt,omitempty"`
 	CreatedAt              string  `json:"createdAt"`
+	// SystemPost is the narrator summary posted when the GM asked for one.
+	SystemPost *PostResponse `json:"systemPost,omitempty"`
+}
+
+// RollIntentionsResponse lists a campaign's suggested roll intentions.
+type RollIntentionsResponse struct {
+	Intentions []string `json:"intentions"`
+	Strict     bool     `json:"strict"`
 }
 
 // UnresolvedRollResponse includes additional context for GM dashboard.
@@ -91,14 +178,61 @@ type UnresolvedRollResponse struct {
 	PostContent string `json:"postContent,omitempty"`
 }
 
-// CreateRoll creates a new roll (initially pending).
+// CreateRoll creates a new roll (initially pending) and executes it in the background.
 func (s *RollService) CreateRoll(
 	ctx context.Context,
-	_ pgtype.UUID, // userID reserved for future authorization checks
+	userID pgtype.UUID,
 	req CreateRollRequest,
 ) (*RollResponse, error) {
+	params, err := s.prepareRoll(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	roll, err := s.queries.CreateRoll(ctx, *params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute roll immediately
+	go s.executeRollAsync(context.Background(), roll)
+
+	return s.rollToResponse(&roll, nil), nil
+}
+
+// prepareRoll validates a roll request and builds its insert parameters. The requester must
+// be a member of the scene's campaign, the character must be in the scene, and an attached
+// post must be from it.
+func (s *RollService) prepareRoll(
+	ctx context.Context,
+	userID pgtype.UUID,
+	req CreateRollRequest,
+) (*generated.CreateRollParams, error) {
+	sceneID := parseUUIDStringRoll(req.SceneID)
+
+	campaign, err := s.sceneCampaign(ctx, sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
+		CampaignID: campaign.ID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrNotMember
+	}
+
+	modifier, err := expandRollPreset(&req, campaignDicePresets(campaign.Settings))
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate inputs
-	if err := dice.ValidateModifier(req.Modifier); err != nil {
+	if err := dice.ValidateModifier(modifier); err != nil {
 		return nil, ErrInvalidModifier
 	}
 	if err := dice.ValidateDiceCount(req.DiceCount); err != nil {
@@ -110,73 +244,225 @@ func (s *RollService) CreateRoll(
 	if !dice.IsValidDiceType(req.DiceType) {
 		return nil, errors.New("invalid dice type")
 	}
+	if err := dice.ValidateRollSize(req.DiceType, req.DiceCount, MaxRollSize()); err != nil {
+		return nil, ErrRollTooLarge
+	}
+	var successThreshold pgtype.Int4
+	if req.SuccessThreshold != nil {
+		if err := dice.ValidateSuccessThreshold(req.DiceType, *req.SuccessThreshold); err != nil {
+			return nil, ErrInvalidThreshold
+		}
+		//nolint:gosec // threshold validated above to be within the die's faces
+		successThreshold = pgtype.Int4{Int32: int32(*req.SuccessThreshold), Valid: true}
+	}
+	var note pgtype.Text
+	if req.Note != nil {
+		trimmed := strings.TrimSpace(*req.Note)
+		if utf8.RuneCountInString(trimmed) > MaxRollNoteLen {
+			return nil, ErrRollNoteTooLong
+		}
+		note = pgtype.Text{String: trimmed, Valid: trimmed != ""}
+	}
+
+	intention, err := resolveIntention(campaign.Settings, req.Intention)
+	if err != nil {
+		return nil, err
+	}
+	req.Intention = intention
 
-	sceneID := parseUUIDStringRoll(req.SceneID)
 	characterID := parseUUIDStringRoll(req.CharacterID)
 
+	// The character must be in the scene being rolled in
+	inScene, err := s.queries.IsCharacterInScene(ctx, generated.IsCharacterInSceneParams{
+		ID:      sceneID,
+		Column2: characterID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !inScene {
+		return nil, ErrCharacterNotInScene
+	}
+
+	// An attached post must belong to the same scene
 	var postID pgtype.UUID
 	if req.PostID != nil {
 		postID = parseUUIDStringRoll(*req.PostID)
+
+		post, postErr := s.queries.GetPost(ctx, postID)
+		if postErr != nil {
+			if errors.Is(postErr, pgx.ErrNoRows) {
+				return nil, ErrPostNotFound
+			}
+			return nil, postErr
+		}
+		if post.SceneID != sceneID {
+			return nil, ErrRollPostMismatch
+		}
 	}
 
-	// Create the roll
 	//nolint:gosec,exhaustruct // req values validated above; RequestedBy intentionally empty for player-initiated rolls
-	roll, err := s.queries.CreateRoll(ctx, generated.CreateRollParams{
-		PostID:      postID,
-		SceneID:     sceneID,
-		CharacterID: characterID,
-		RequestedBy: pgtype.UUID{Valid: false}, // NULL for player-initiated
-		Intention:   req.Intention,
-		Modifier:    int32(req.Modifier),
-		DiceType:    req.DiceType,
-		DiceCount:   int32(req.DiceCount),
-	})
+	return &generated.CreateRollParams{
+		PostID:           postID,
+		SceneID:          sceneID,
+		CharacterID:      characterID,
+		RequestedBy:      pgtype.UUID{Valid: false}, // NULL for player-initiated
+		Intention:        req.Intention,
+		Modifier:         int32(modifier),
+		DiceType:         req.DiceType,
+		DiceCount:        int32(req.DiceCount),
+		SuccessThreshold: successThreshold,
+		Note:             note,
+	}, nil
+}
+
+// CreateRolls creates a batch of linked rolls, such as an attack and its damage, in one
+// transaction. Every entry is validated first; if any is invalid the whole batch is rejected
+// with a RollBatchError naming it. When executeNow is set the dice are rolled before
+// returning, otherwise in the background. Rolls are returned in request order.
+func (s *RollService) CreateRolls(
+	ctx context.Context,
+	userID pgtype.UUID,
+	reqs []CreateRollRequest,
+	executeNow bool,
+) ([]RollResponse, error) {
+	if len(reqs) == 0 {
+		return nil, ErrEmptyRollBatch
+	}
+	if len(reqs) > MaxRollBatch {
+		return nil, ErrRollBatchTooLarge
+	}
+
+	params := make([]*generated.CreateRollParams, len(reqs))
+	for i, req := range reqs {
+		p, err := s.prepareRoll(ctx, userID, req)
+		if err != nil {
+			return nil, &RollBatchError{Index: i, Err: err}
+		}
+		params[i] = p
+	}
+
+	ctx, cancel := withLongTxTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer func() { _ = tx.Rollback(ctx) }()
 
-	// Execute roll immediately
-	go s.executeRollAsync(context.Background(), roll.ID, req.DiceType, req.DiceCount, req.Modifier)
+	qtx := s.queries.WithTx(tx)
 
-	return s.rollToResponse(&roll, nil), nil
+	// Immediate rolls are executed in the same transaction so a failure leaves no
+	// pending rolls behind
+	rolls := make([]generated.Roll, len(params))
+	for i, p := range params {
+		rolls[i], err = qtx.CreateRoll(ctx, *p)
+		if err != nil {
+			return nil, err
+		}
+		if executeNow {
+			rolls[i], err = s.executeRoll(ctx, qtx, rolls[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	result := make([]RollResponse, len(rolls))
+	for i, roll := range rolls {
+		if !executeNow {
+			go s.executeRollAsync(context.Background(), roll)
+		}
+		result[i] = *s.rollToResponse(&roll, nil)
+	}
+
+	return result, nil
+}
+
+// expandRollPreset fills DiceType and DiceCount from the requested preset when
+// they are not set, and returns the modifier to use. Campaign presets take
+// precedence over global presets with the same name.
+func expandRollPreset(req *CreateRollRequest, campaignPresets []dice.PresetExpansion) (int, error) {
+	modifier := 0
+	if req.Modifier != nil {
+		modifier = *req.Modifier
+	}
+
+	if req.Preset == nil {
+		return modifier, nil
+	}
+
+	expansion := findDicePreset(campaignPresets, *req.Preset)
+	if expansion == nil {
+		expansion = dice.ExpandPreset(*req.Preset)
+	}
+	if expansion == nil {
+		return 0, ErrUnknownPreset
+	}
+
+	if req.DiceType == "" {
+		req.DiceType = expansion.DiceType
+	}
+	if req.DiceCount == 0 {
+		req.DiceCount = expansion.DiceCount
+	}
+	if req.Modifier == nil {
+		modifier = expansion.Modifier
+	}
+
+	return modifier, nil
 }
 
-// executeRollAsync executes a roll asynchronously.
-func (s *RollService) executeRollAsync(
+// executeRollAsync executes a roll in the background, logging any failure.
+func (s *RollService) executeRollAsync(ctx context.Context, roll generated.Roll) {
+	if _, err := s.executeRoll(ctx, s.queries, roll); err != nil {
+		slog.Default().ErrorContext(ctx, "Failed to execute roll", "rollID", roll.ID, "error", err)
+	}
+}
+
+// executeRoll rolls the dice for a pending roll and saves the results. In success-counting
+// mode the total is the number of successes rather than the sum of the dice.
+func (s *RollService) executeRoll(
 	ctx context.Context,
-	rollID pgtype.UUID,
-	diceType string,
-	diceCount, modifier int,
-) {
-	logger := slog.Default()
+	q *generated.Queries,
+	roll generated.Roll,
+) (_ generated.Roll, err error) {
+	defer func(start time.Time) { metrics.ObserveRollExecution(start, err) }(time.Now())
 
 	// Execute roll
-	results, err := s.roller.Roll(diceType, diceCount)
+	results, err := s.roller.Roll(roll.DiceType, int(roll.DiceCount))
 	if err != nil {
-		logger.ErrorContext(ctx, "Failed to execute roll", "rollID", rollID, "error", err)
-		return
+		return roll, err
 	}
 
 	// Calculate total
-	total := s.roller.CalculateTotal(results, modifier)
+	var successes pgtype.Int4
+	total := s.roller.CalculateTotal(results, int(roll.Modifier))
+	if roll.SuccessThreshold.Valid {
+		total = s.roller.CountSuccesses(results, int(roll.SuccessThreshold.Int32), int(roll.Modifier))
+		//nolint:gosec // successes is at most dice count plus a small modifier
+		successes = pgtype.Int4{Int32: int32(total), Valid: true}
+	}
 
 	// Save results
 	//nolint:gosec // total is guaranteed to be small (sum of dice + small modifier)
-	_, err = s.queries.ExecuteRoll(ctx, generated.ExecuteRollParams{
-		ID:     rollID,
-		Result: results,
-		Total:  pgtype.Int4{Int32: int32(total), Valid: true},
+	return q.ExecuteRoll(ctx, generated.ExecuteRollParams{
+		ID:        roll.ID,
+		Result:    results,
+		Total:     pgtype.Int4{Int32: int32(total), Valid: true},
+		Successes: successes,
 	})
-	if err != nil {
-		logger.ErrorContext(ctx, "Failed to save roll results", "rollID", rollID, "error", err)
-		return
-	}
 }
 
 // GetRoll retrieves a single roll.
 func (s *RollService) GetRoll(
 	ctx context.Context,
-	_ pgtype.UUID, // userID reserved for future authorization checks
+	userID pgtype.UUID,
 	rollID string,
 ) (*RollResponse, error) {
 	rollUUID := parseUUIDStringRoll(rollID)
@@ -189,6 +475,22 @@ func (s *RollService) GetRoll(
 		return nil, err
 	}
 
+	var witnesses []pgtype.UUID
+	if roll.PostID.Valid {
+		post, postErr := s.queries.GetPost(ctx, roll.PostID)
+		if postErr != nil {
+			if errors.Is(postErr, pgx.ErrNoRows) {
+				return nil, ErrRollNotFound
+			}
+			return nil, postErr
+		}
+		witnesses = post.Witnesses
+	}
+
+	if authErr := s.verifyRollAccess(ctx, userID, roll.SceneID, roll.PostID.Valid, witnesses); authErr != nil {
+		return nil, authErr
+	}
+
 	var charName *string
 	if roll.CharacterName.Valid {
 		charName = &roll.CharacterName.String
@@ -200,11 +502,23 @@ func (s *RollService) GetRoll(
 // GetRollsByPost retrieves all rolls for a post.
 func (s *RollService) GetRollsByPost(
 	ctx context.Context,
-	_ pgtype.UUID, // userID reserved for future authorization checks
+	userID pgtype.UUID,
 	postID string,
 ) ([]RollResponse, error) {
 	postUUID := parseUUIDStringRoll(postID)
 
+	post, err := s.queries.GetPost(ctx, postUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRollNotFound
+		}
+		return nil, err
+	}
+
+	if authErr := s.verifyRollAccess(ctx, userID, post.SceneID, true, post.Witnesses); authErr != nil {
+		return nil, authErr
+	}
+
 	rolls, err := s.queries.GetRollsByPostWithCharacter(ctx, postUUID)
 	if err != nil {
 		return nil, err
@@ -222,6 +536,81 @@ func (s *RollService) GetRollsByPost(
 	return result, nil
 }
 
+// verifyRollAccess checks that the user can see rolls in a scene: the GM sees every
+// roll, players only through a character that witnessed the roll's post, or that is
+// in the scene for rolls without a post. Denials return ErrRollNotFound to hide existence.
+func (s *RollService) verifyRollAccess(
+	ctx context.Context,
+	userID, sceneID pgtype.UUID,
+	hasPost bool,
+	witnesses []pgtype.UUID,
+) error {
+	scene, err := s.queries.GetScene(ctx, sceneID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRollNotFound
+		}
+		return err
+	}
+
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
+		CampaignID: scene.CampaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return ErrRollNotFound
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: scene.CampaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return err
+	}
+	if isGM {
+		return nil
+	}
+
+	userChars, err := s.queries.GetUserCharactersInScene(ctx, generated.GetUserCharactersInSceneParams{
+		ID:     sceneID,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rollVisibleTo(hasPost, witnesses, userChars) {
+		return nil
+	}
+
+	return ErrRollNotFound
+}
+
+// rollVisibleTo reports whether a player with the given characters in the scene can
+// see a roll: through a character that witnessed the roll's post, or any character
+// in the scene for rolls without a post. Hidden posts are witnessed only by their
+// author's character, so their rolls stay hidden from everyone else.
+func rollVisibleTo(hasPost bool, witnesses []pgtype.UUID, chars []generated.GetUserCharactersInSceneRow) bool {
+	if !hasPost {
+		return len(chars) > 0
+	}
+	return witnessedBy(witnesses, chars)
+}
+
+// witnessedBy reports whether any of the user's characters is among a post's witnesses.
+func witnessedBy(witnesses []pgtype.UUID, chars []generated.GetUserCharactersInSceneRow) bool {
+	for _, char := range chars {
+		if slices.Contains(witnesses, char.ID) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetPendingRollsForCharacter retrieves pending rolls for a character.
 func (s *RollService) GetPendingRollsForCharacter(
 	ctx context.Context,
@@ -242,16 +631,150 @@ func (s *RollService) GetPendingRollsForCharacter(
 	return result, nil
 }
 
-// GetUnresolvedRollsInCampaign retrieves all unresolved rolls (GM dashboard).
+// Character roll history page sizes.
+const (
+	DefaultCharacterRollLimit = 50
+	MaxCharacterRollLimit     = 100
+)
+
+// CharacterRollsPage is one page of a character's roll history, newest first.
+// NextCursor is empty on the last page.
+type CharacterRollsPage struct {
+	Rolls      []RollResponse `json:"rolls"`
+	NextCursor string         `json:"nextCursor"`
+}
+
+// GetCharacterRolls returns a page of a character's rolls across all scenes, newest
+// first. Only the character's owner and the campaign GM may read it. Pass the previous
+// page's NextCursor as cursor to continue; invalidated rolls are left out unless
+// includeInvalidated is set.
+func (s *RollService) GetCharacterRolls(
+	ctx context.Context,
+	userID pgtype.UUID,
+	characterID string,
+	limit int32,
+	cursor string,
+	includeInvalidated bool,
+) (*CharacterRollsPage, error) {
+	charUUID := parseUUIDStringRoll(characterID)
+
+	character, err := s.queries.GetCharacterWithAssignment(ctx, charUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCharacterNotFound
+		}
+		return nil, err
+	}
+
+	if character.AssignedUserID != userID {
+		isGM, gmErr := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+			CampaignID: character.CampaignID,
+			UserID:     userID,
+		})
+		if gmErr != nil {
+			return nil, gmErr
+		}
+		if !isGM {
+			return nil, ErrCharacterNotOwned
+		}
+	}
+
+	if limit <= 0 || limit > MaxCharacterRollLimit {
+		limit = DefaultCharacterRollLimit
+	}
+
+	params := generated.ListCharacterRollsParams{
+		CharacterID:        charUUID,
+		IncludeInvalidated: includeInvalidated,
+		CursorCreatedAt:    pgtype.Timestamptz{},
+		CursorID:           pgtype.UUID{},
+		RowLimit:           limit + 1, // One extra row tells us whether another page follows
+	}
+	if cursor != "" {
+		params.CursorCreatedAt, params.CursorID, err = decodeRollCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rolls, err := s.queries.ListCharacterRolls(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &CharacterRollsPage{Rolls: make([]RollResponse, 0, len(rolls)), NextCursor: ""}
+	if len(rolls) > int(limit) {
+		rolls = rolls[:limit]
+		last := rolls[len(rolls)-1]
+		page.NextCursor = encodeRollCursor(last.CreatedAt, last.ID)
+	}
+	for _, r := range rolls {
+		page.Rolls = append(page.Rolls, *s.rollToResponse(&r, &character.DisplayName))
+	}
+
+	return page, nil
+}
+
+// encodeRollCursor makes an opaque cursor from the position of the last roll on a page.
+func encodeRollCursor(createdAt pgtype.Timestamptz, id pgtype.UUID) string {
+	raw := createdAt.Time.UTC().Format(time.RFC3339Nano) + "|" + formatUUIDRoll(id.Bytes)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeRollCursor reverses encodeRollCursor.
+func decodeRollCursor(cursor string) (pgtype.Timestamptz, pgtype.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return pgtype.Timestamptz{}, pgtype.UUID{}, ErrInvalidRollCursor
+	}
+	createdAtStr, idStr, found := strings.Cut(string(raw), "|")
+	if !found {
+		return pgtype.Timestamptz{}, pgtype.UUID{}, ErrInvalidRollCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtStr)
+	if err != nil {
+		return pgtype.Timestamptz{}, pgtype.UUID{}, ErrInvalidRollCursor
+	}
+	id := parseUUIDStringRoll(idStr)
+	if !id.Valid {
+		return pgtype.Timestamptz{}, pgtype.UUID{}, ErrInvalidRollCursor
+	}
+	return pgtype.Timestamptz{Time: createdAt, Valid: true, InfinityModifier: pgtype.Finite}, id, nil
+}
+
+// Unresolved roll page sizes.
+const (
+	DefaultUnresolvedRollLimit = 50
+	MaxUnresolvedRollLimit     = 100
+)
+
+// UnresolvedRollsQuery pages through a campaign's unresolved rolls. Since, when set,
+// keeps only rolls created at or after that time.
+type UnresolvedRollsQuery struct {
+	Limit  int32
+	Offset int32
+	Since  *time.Time
+}
+
+// UnresolvedRollsPage is one page of unresolved rolls plus the total matching count.
+type UnresolvedRollsPage struct {
+	Rolls  []UnresolvedRollResponse `json:"rolls"`
+	Total  int64                    `json:"total"`
+	Limit  int32                    `json:"limit"`
+	Offset int32                    `json:"offset"`
+}
+
+// GetUnresolvedRollsInCampaign retrieves a page of unresolved rolls (GM dashboard).
 func (s *RollService) GetUnresolvedRollsInCampaign(
 	ctx context.Context,
 	userID pgtype.UUID,
 	campaignID string,
-) ([]UnresolvedRollResponse, error) {
+	query UnresolvedRollsQuery,
+) (*UnresolvedRollsPage, error) {
 	campaignUUID := parseUUIDStringRoll(campaignID)
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignUUID,
 		UserID:     userID,
 	})
@@ -262,24 +785,54 @@ func (s *RollService) GetUnresolvedRollsInCampaign(
 		return nil, ErrNotGM
 	}
 
-	rolls, err := s.queries.GetUnresolvedRollsInCampaign(ctx, campaignUUID)
+	if query.Limit <= 0 || query.Limit > MaxUnresolvedRollLimit {
+		query.Limit = DefaultUnresolvedRollLimit
+	}
+	query.Offset = max(query.Offset, 0)
+
+	var since pgtype.Timestamptz
+	if query.Since != nil {
+		since = pgtype.Timestamptz{Time: *query.Since, Valid: true, InfinityModifier: pgtype.Finite}
+	}
+
+	total, err := s.queries.CountUnresolvedRollsInCampaign(ctx, generated.CountUnresolvedRollsInCampaignParams{
+		CampaignID: campaignUUID,
+		Column2:    since,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var result []UnresolvedRollResponse
+	rolls, err := s.queries.GetUnresolvedRollsInCampaign(ctx, generated.GetUnresolvedRollsInCampaignParams{
+		CampaignID: campaignUUID,
+		Column2:    since,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]UnresolvedRollResponse, 0, len(rolls))
 	for _, r := range rolls {
 		resp := s.unresolvedRollToResponse(&r)
 		result = append(result, *resp)
 	}
 
-	return result, nil
+	return &UnresolvedRollsPage{
+		Rolls:  result,
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}, nil
 }
 
 // OverrideIntentionRequest represents the request to override a roll's intention.
+// Set PostToScene to also post a narrator summary of the change to the scene.
 type OverrideIntentionRequest struct {
 	NewIntention string `json:"newIntention"`
 	Reason       string `json:"reason"`
+	PostToScene  bool   `json:"postToScene"`
 }
 
 // OverrideIntention overrides a roll's intention (GM only).
@@ -307,7 +860,7 @@ func (s *RollService) OverrideIntention(
 	}
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -347,13 +900,29 @@ func (s *RollService) OverrideIntention(
 		return nil, err
 	}
 
-	return s.rollToResponse(&overriddenRoll, nil), nil
+	logGMAction(ctx, s.queries, scene.CampaignID, userID, GMActionOverrideIntention,
+		AuditTargetRoll, rollUUID, map[string]any{
+			"sceneId":      uuidToString(roll.SceneID),
+			"oldIntention": roll.Intention,
+			"newIntention": req.NewIntention,
+			"reason":       req.Reason,
+		})
+
+	resp := s.rollToResponse(&overriddenRoll, nil)
+	if req.PostToScene {
+		summary := fmt.Sprintf("%s roll changed to %s", roll.Intention, req.NewIntention)
+		resp.SystemPost = s.postRollSummary(ctx, userID, &scene, &roll, summary)
+	}
+
+	return resp, nil
 }
 
 // ManualResolveRequest represents the request to manually resolve a roll.
+// Set PostToScene to also post a narrator summary of the result to the scene.
 type ManualResolveRequest struct {
-	Result int    `json:"result"`
-	Reason string `json:"reason"`
+	Result      int    `json:"result"`
+	Reason      string `json:"reason"`
+	PostToScene bool   `json:"postToScene"`
 }
 
 // ManuallyResolve manually resolves a roll with a GM-assigned result.
@@ -386,7 +955,7 @@ func (s *RollService) ManuallyResolve(
 	}
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -414,7 +983,58 @@ func (s *RollService) ManuallyResolve(
 		return nil, err
 	}
 
-	return s.rollToResponse(&resolvedRoll, nil), nil
+	resp := s.rollToResponse(&resolvedRoll, nil)
+	if req.PostToScene {
+		summary := fmt.Sprintf("%s roll resolved: %d", resolvedRoll.Intention, req.Result)
+		resp.SystemPost = s.postRollSummary(ctx, userID, &scene, &roll, summary)
+	}
+
+	return resp, nil
+}
+
+// postRollSummary submits a narrator post marked as a system post describing a GM
+// roll action. It is seen by whoever could see the roll's post (everyone in the
+// scene for rolls without a post). The roll change has already been saved, so a
+// failure here is logged and nil is returned instead of an error.
+func (s *RollService) postRollSummary(
+	ctx context.Context,
+	userID pgtype.UUID,
+	scene *generated.Scene,
+	roll *generated.Roll,
+	summary string,
+) *PostResponse {
+	req := CreatePostRequest{
+		SceneID:     formatUUIDRoll(scene.ID.Bytes),
+		CharacterID: nil,
+		Blocks:      []PostBlock{{Type: "action", Content: summary, Order: 0}},
+		OOCText:     nil,
+		Intention:   nil,
+		Modifier:    nil,
+		IsHidden:    false,
+		IsSystem:    true,
+	}
+	if roll.PostID.Valid {
+		post, err := s.queries.GetPost(ctx, roll.PostID)
+		if err != nil {
+			slog.Default().ErrorContext(ctx, "Failed to load roll post for summary", "rollID", roll.ID, "error", err)
+			return nil
+		}
+		// Witnesses who have since left the scene are dropped
+		req.IsHidden = post.IsHidden
+		req.Witnesses = make([]string, 0, len(post.Witnesses))
+		for _, id := range post.Witnesses {
+			if slices.Contains(scene.CharacterIds, id) {
+				req.Witnesses = append(req.Witnesses, formatUUIDRoll(id.Bytes))
+			}
+		}
+	}
+
+	post, err := NewPostService(s.pool).CreatePost(ctx, userID, req, true)
+	if err != nil {
+		slog.Default().ErrorContext(ctx, "Failed to post roll summary", "rollID", roll.ID, "error", err)
+		return nil
+	}
+	return post
 }
 
 // InvalidateRoll invalidates a roll (GM only).
@@ -441,7 +1061,7 @@ func (s *RollService) InvalidateRoll(
 	}
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -458,6 +1078,13 @@ func (s *RollService) InvalidateRoll(
 		return nil, err
 	}
 
+	logGMAction(ctx, s.queries, scene.CampaignID, userID, GMActionInvalidateRoll,
+		AuditTargetRoll, rollUUID, map[string]any{
+			"sceneId":        uuidToString(roll.SceneID),
+			"intention":      roll.Intention,
+			"previousStatus": roll.Status,
+		})
+
 	return s.rollToResponse(&invalidatedRoll, nil), nil
 }
 
@@ -476,14 +1103,55 @@ func (s *RollService) CharacterHasPendingRolls(
 	return hasPending, nil
 }
 
-// GetRollsInScene retrieves all rolls in a scene.
+// SceneRollFilter narrows the rolls listed for a scene. Empty fields are not filtered on.
+type SceneRollFilter struct {
+	Status      string
+	CharacterID string
+}
+
+// toParams validates the filter and converts it to query parameters for the scene.
+func (f SceneRollFilter) toParams(sceneID pgtype.UUID) (generated.ListRollsBySceneParams, error) {
+	params := generated.ListRollsBySceneParams{
+		SceneID:     sceneID,
+		Status:      generated.NullRollStatus{RollStatus: "", Valid: false},
+		CharacterID: pgtype.UUID{Valid: false},
+	}
+
+	if f.Status != "" {
+		status := generated.RollStatus(f.Status)
+		switch status {
+		case generated.RollStatusPending, generated.RollStatusCompleted, generated.RollStatusInvalidated:
+			params.Status = generated.NullRollStatus{RollStatus: status, Valid: true}
+		default:
+			return params, ErrInvalidRollStatus
+		}
+	}
+
+	if f.CharacterID != "" {
+		params.CharacterID = parseUUIDStringRoll(f.CharacterID)
+		if !params.CharacterID.Valid {
+			return params, ErrInvalidRollFilter
+		}
+	}
+
+	return params, nil
+}
+
+// GetRollsInScene retrieves the rolls in a scene the user can see, optionally filtered
+// by status and character.
 func (s *RollService) GetRollsInScene(
 	ctx context.Context,
 	userID pgtype.UUID,
 	sceneID string,
+	filter SceneRollFilter,
 ) ([]RollResponse, error) {
 	sceneUUID := parseUUIDStringRoll(sceneID)
 
+	params, err := filter.toParams(sceneUUID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Verify user has access to scene
 	scene, err := s.queries.GetScene(ctx, sceneUUID)
 	if err != nil {
@@ -493,7 +1161,7 @@ func (s *RollService) GetRollsInScene(
 		return nil, err
 	}
 
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -504,13 +1172,33 @@ func (s *RollService) GetRollsInScene(
 		return nil, ErrNotMember
 	}
 
-	rolls, err := s.queries.ListRollsByScene(ctx, sceneUUID)
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: scene.CampaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userChars, err := s.queries.GetUserCharactersInScene(ctx, generated.GetUserCharactersInSceneParams{
+		ID:     sceneUUID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rolls, err := s.queries.ListRollsByScene(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
 	var result []RollResponse
 	for _, r := range rolls {
+		// Players see the same rolls GetRoll would show them
+		if !isGM && !rollVisibleTo(r.PostID.Valid, r.PostWitnesses, userChars) {
+			continue
+		}
 		var charName *string
 		if r.CharacterName.Valid {
 			charName = &r.CharacterName.String
@@ -521,6 +1209,103 @@ func (s *RollService) GetRollsInScene(
 	return result, nil
 }
 
+// GetRollIntentions returns the suggested roll intentions for a campaign.
+func (s *RollService) GetRollIntentions(
+	ctx context.Context,
+	userID pgtype.UUID,
+	campaignID string,
+) (*RollIntentionsResponse, error) {
+	campaignUUID := parseUUIDStringRoll(campaignID)
+
+	campaign, err := s.queries.GetCampaign(ctx, campaignUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, err
+	}
+
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
+		CampaignID: campaignUUID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrNotMember
+	}
+
+	intentions, strict := rollIntentions(campaign.Settings)
+	return &RollIntentionsResponse{
+		Intentions: intentions,
+		Strict:     strict,
+	}, nil
+}
+
+// sceneCampaign returns the campaign a scene belongs to.
+func (s *RollService) sceneCampaign(ctx context.Context, sceneID pgtype.UUID) (*generated.Campaign, error) {
+	scene, err := s.queries.GetScene(ctx, sceneID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSceneNotFound
+		}
+		return nil, err
+	}
+
+	campaign, err := s.queries.GetCampaign(ctx, scene.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaign, nil
+}
+
+// resolveIntention checks an intention against the campaign's list when the
+// campaign enforces strict intentions. Matching is case-insensitive and returns
+// the configured spelling; free text is returned unchanged otherwise.
+func resolveIntention(settingsJSON []byte, intention string) (string, error) {
+	intentions, strict := rollIntentions(settingsJSON)
+	if !strict {
+		return intention, nil
+	}
+
+	trimmed := strings.TrimSpace(intention)
+	for _, allowed := range intentions {
+		if strings.EqualFold(allowed, trimmed) {
+			return allowed, nil
+		}
+	}
+
+	return "", ErrIntentionNotAllowed
+}
+
+// rollIntentions parses campaign settings and returns the suggested intentions
+// and whether rolls are restricted to them.
+func rollIntentions(settingsJSON []byte) ([]string, bool) {
+	intentions := []string{}
+	if len(settingsJSON) == 0 {
+		return intentions, false
+	}
+
+	var settings struct {
+		SystemPreset struct {
+			Intentions []string `json:"intentions"`
+		} `json:"systemPreset"`
+		StrictIntentions bool `json:"strictIntentions"`
+	}
+	if err := json.Unmarshal(settingsJSON, &settings); err != nil {
+		return intentions, false
+	}
+
+	if settings.SystemPreset.Intentions != nil {
+		intentions = settings.SystemPreset.Intentions
+	}
+
+	// Strict mode with an empty list would block every roll
+	return intentions, settings.StrictIntentions && len(intentions) > 0
+}
+
 // Helper functions
 
 //nolint:exhaustruct // Intentionally returning empty UUID with Valid: false
@@ -625,6 +1410,20 @@ func (s *RollService) rollToResponse(r *generated.Roll, charName *string) *RollR
 		resp.RolledAt = &rolledAt
 	}
 
+	if r.SuccessThreshold.Valid {
+		threshold := int(r.SuccessThreshold.Int32)
+		resp.SuccessThreshold = &threshold
+	}
+
+	if r.Successes.Valid {
+		successes := int(r.Successes.Int32)
+		resp.Successes = &successes
+	}
+
+	if r.Note.Valid {
+		resp.Note = &r.Note.String
+	}
+
 	return resp
 }
 
@@ -701,6 +1500,20 @@ func (s *RollService) rollWithCharacterToResponse(
 		resp.RolledAt = &rolledAt
 	}
 
+	if r.SuccessThreshold.Valid {
+		threshold := int(r.SuccessThreshold.Int32)
+		resp.SuccessThreshold = &threshold
+	}
+
+	if r.Successes.Valid {
+		successes := int(r.Successes.Int32)
+		resp.Successes = &successes
+	}
+
+	if r.Note.Valid {
+		resp.Note = &r.Note.String
+	}
+
 	return resp
 }
 
@@ -777,6 +1590,20 @@ func (s *RollService) rollWithCharacterRowToResponse(
 		resp.RolledAt = &rolledAt
 	}
 
+	if r.SuccessThreshold.Valid {
+		threshold := int(r.SuccessThreshold.Int32)
+		resp.SuccessThreshold = &threshold
+	}
+
+	if r.Successes.Valid {
+		successes := int(r.Successes.Int32)
+		resp.Successes = &successes
+	}
+
+	if r.Note.Valid {
+		resp.Note = &r.Note.String
+	}
+
 	return resp
 }
 
@@ -853,6 +1680,20 @@ func (s *RollService) listRollRowToResponse(
 		resp.RolledAt = &rolledAt
 	}
 
+	if r.SuccessThreshold.Valid {
+		threshold := int(r.SuccessThreshold.Int32)
+		resp.SuccessThreshold = &threshold
+	}
+
+	if r.Successes.Valid {
+		successes := int(r.Successes.Int32)
+		resp.Successes = &successes
+	}
+
+	if r.Note.Valid {
+		resp.Note = &r.Note.String
+	}
+
 	return resp
 }
 
@@ -0,0 +1,163 @@
+//go:build integration
+
+package service
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+func TestSceneRollListMatchesPostVisibility(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	roller := tc.addPlayer()
+	outsider := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	whisper, err := tc.post(roller.userID, roller.characterID, CreatePostRequest{
+		Witnesses: []string{uuidToString(roller.characterID)},
+	})
+	if err != nil {
+		t.Fatalf("whisper: %v", err)
+	}
+
+	rolls := NewRollService(tc.pool)
+	roll, err := rolls.CreateRoll(tc.ctx, roller.userID, CreateRollRequest{
+		PostID:      &whisper.ID,
+		SceneID:     uuidToString(tc.scene.ID),
+		CharacterID: uuidToString(roller.characterID),
+		Intention:   "Stealth",
+		DiceType:    "d20",
+		DiceCount:   1,
+	})
+	if err != nil {
+		t.Fatalf("roll: %v", err)
+	}
+
+	listed := func(userID pgtype.UUID) bool {
+		t.Helper()
+		list, listErr := rolls.GetRollsInScene(tc.ctx, userID, uuidToString(tc.scene.ID), SceneRollFilter{})
+		if listErr != nil {
+			t.Fatalf("list rolls: %v", listErr)
+		}
+		for _, r := range list {
+			if r.ID == roll.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !listed(roller.userID) {
+		t.Error("roller cannot see their own roll")
+	}
+	if !listed(tc.gm) {
+		t.Error("GM cannot see the roll")
+	}
+	if listed(outsider.userID) {
+		t.Error("player who did not witness the post sees its roll")
+	}
+}
+
+func TestRollSummaryFollowsRollPostVisibility(t *testing.T) {
+	tests := []struct {
+		name string
+		req  func(roller testPlayer) CreatePostRequest
+	}{
+		{
+			name: "whisper",
+			req: func(roller testPlayer) CreatePostRequest {
+				return CreatePostRequest{Witnesses: []string{uuidToString(roller.characterID)}}
+			},
+		},
+		{
+			name: "hidden",
+			req: func(testPlayer) CreatePostRequest {
+				return CreatePostRequest{IsHidden: true}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTestCampaign(t, nil)
+			roller := tc.addPlayer()
+			outsider := tc.addPlayer()
+			tc.transition(PhasePCPhase)
+
+			rollPost, err := tc.post(roller.userID, roller.characterID, tt.req(roller))
+			if err != nil {
+				t.Fatalf("post: %v", err)
+			}
+
+			rolls := NewRollService(tc.pool)
+			roll, err := rolls.CreateRoll(tc.ctx, roller.userID, CreateRollRequest{
+				PostID:      &rollPost.ID,
+				SceneID:     uuidToString(tc.scene.ID),
+				CharacterID: uuidToString(roller.characterID),
+				Intention:   "Stealth",
+				DiceType:    "d20",
+				DiceCount:   1,
+			})
+			if err != nil {
+				t.Fatalf("roll: %v", err)
+			}
+
+			overridden, err := rolls.OverrideIntention(tc.ctx, tc.gm, roll.ID, OverrideIntentionRequest{
+				NewIntention: "Perception",
+				PostToScene:  true,
+			})
+			if err != nil {
+				t.Fatalf("override: %v", err)
+			}
+			if overridden.SystemPost == nil {
+				t.Fatal("no summary post was created")
+			}
+			summaryID := overridden.SystemPost.ID
+
+			if overridden.SystemPost.IsHidden != rollPost.IsHidden {
+				t.Errorf("summary isHidden = %v, want %v", overridden.SystemPost.IsHidden, rollPost.IsHidden)
+			}
+			if !tc.visiblePostIDs(roller.userID)[summaryID] {
+				t.Error("roller cannot see the summary of their roll")
+			}
+			if !tc.visiblePostIDs(tc.gm)[summaryID] {
+				t.Error("GM cannot see the summary")
+			}
+			if tc.visiblePostIDs(outsider.userID)[summaryID] {
+				t.Error("player who could not see the roll sees its summary")
+			}
+		})
+	}
+}
+
+func TestImmediateRollBatchIsCommittedExecuted(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	roller := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	req := CreateRollRequest{
+		SceneID:     uuidToString(tc.scene.ID),
+		CharacterID: uuidToString(roller.characterID),
+		Intention:   "Athletics",
+		DiceType:    "d6",
+		DiceCount:   2,
+	}
+	rolls := NewRollService(tc.pool)
+	created, err := rolls.CreateRolls(tc.ctx, tc.gm, []CreateRollRequest{req, req}, true)
+	if err != nil {
+		t.Fatalf("create rolls: %v", err)
+	}
+
+	for _, r := range created {
+		stored, getErr := tc.queries.GetRoll(tc.ctx, parseUUIDStringRoll(r.ID))
+		if getErr != nil {
+			t.Fatalf("get roll: %v", getErr)
+		}
+		if stored.Status != generated.RollStatusCompleted || len(stored.Result) != 2 {
+			t.Errorf("roll %s stored as %s with %d dice, want completed with 2", r.ID, stored.Status, len(stored.Result))
+		}
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -13,13 +16,83 @@ import (
 
 // Scene errors.
 var (
-	ErrSceneNotFound     = errors.New("scene not found")
-	ErrSceneLimitReached = errors.New("scene limit reached (25 max)")
-	ErrNoArchivedScenes  = errors.New("no archived scenes available to delete")
-	ErrNotGMPhase        = errors.New("characters can only be moved during GM Phase")
-	ErrCharacterInScene  = errors.New("character is already in a scene")
+	ErrSceneNotFound = newCodedError(
+		http.StatusNotFound, "NOT_FOUND",
+		"Scene not found",
+		"scene not found",
+	)
+	ErrSceneLimitReached = newCodedError(
+		http.StatusForbidden, "SCENE_LIMIT",
+		"Scene limit reached (25 max). Archive and delete a scene first.",
+		"scene limit reached (25 max)",
+	)
+	ErrNoArchivedScenes = newCodedError(
+		http.StatusForbidden, "SCENE_LIMIT_NO_ARCHIVED",
+		"Scene limit reached (25 max). No archived scenes available to delete.",
+		"no archived scenes available to delete",
+	)
+	ErrNotGMPhase = newCodedError(
+		http.StatusForbidden, "NOT_GM_PHASE",
+		"Characters can only be moved during GM Phase.",
+		"characters can only be moved during GM Phase",
+	)
+	ErrCharacterInScene = newCodedError(
+		http.StatusConflict, "CHARACTER_IN_SCENE",
+		"This character is already in this scene.",
+		"character is already in a scene",
+	)
+	ErrSceneOrderInvalid = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Scene order must list every scene in the campaign exactly once",
+		"scene order must list every campaign scene exactly once",
+	)
+	ErrBulkSceneInvalid = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Scenes must belong to the campaign and be listed once each",
+		"bulk scene update must list campaign scenes, once each",
+	)
+	ErrSceneCharacterOrderInvalid = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Character order may only list characters in the scene, once each",
+		"character order may only list characters in the scene, once each",
+	)
+	ErrInvalidWitnessMode = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Default witness mode must be one of: all, author, gm_only",
+		"invalid default witness mode",
+	)
+	ErrInvalidFogOverride = newCodedError(
+		http.StatusBadRequest, "VALIDATION_ERROR",
+		"Fog override must be one of: inherit, always_visible, fog",
+		"invalid fog override",
+	)
+	ErrSceneFrozen = newCodedError(
+		http.StatusForbidden, "SCENE_FROZEN",
+		"This scene is frozen by the GM. Posting and editing are paused.",
+		"scene is frozen",
+	)
 )
 
+// Scene default witness modes decide who witnesses a non-hidden post when it is
+// submitted. Hidden posts are always witnessed by their author only, and UnhidePost
+// only reveals those; posts held back by a scene's mode are revealed by editing
+// their witnesses instead.
+const (
+	WitnessModeAll    = "all"     // every character in the scene
+	WitnessModeAuthor = "author"  // the posting character only
+	WitnessModeGMOnly = "gm_only" // nobody until the GM adds witnesses
+)
+
+// Scene fog overrides decide whether fog of war applies to a scene.
+const (
+	FogOverrideInherit       = "inherit"        // follow the campaign's fogOfWar setting
+	FogOverrideAlwaysVisible = "always_visible" // every member sees the scene
+	FogOverrideFog           = "fog"            // fogged even when the campaign has fog off
+)
+
+// maxSceneTitleLen matches the title limit enforced when scenes are created or renamed.
+const maxSceneTitleLen = 200
+
 // Scene warnings.
 const (
 	SceneWarningThreshold20 = 20
@@ -37,7 +110,7 @@ type SceneService struct {
 // NewSceneService creates a new SceneService.
 func NewSceneService(pool *pgxpool.Pool) *SceneService {
 	return &SceneService{
-		queries: generated.New(pool),
+		queries: newQueries(pool),
 		pool:    pool,
 	}
 }
@@ -53,6 +126,9 @@ type CreateSceneResponse struct {
 	Scene          *generated.Scene `json:"scene"`
 	Warning        string           `json:"warning,omitempty"`
 	DeletedSceneID *string          `json:"deletedSceneId,omitempty"`
+	SceneCount     int64            `json:"sceneCount"`
+	SceneLimit     int64            `json:"sceneLimit"`
+	NearLimit      bool             `json:"nearLimit"`
 }
 
 // CreateScene creates a new scene in a campaign (GM only).
@@ -62,7 +138,7 @@ func (s *SceneService) CreateScene(
 	req CreateSceneRequest,
 ) (*CreateSceneResponse, error) {
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -73,6 +149,9 @@ func (s *SceneService) CreateScene(
 		return nil, ErrNotGM
 	}
 
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
 	// Start transaction
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
@@ -89,16 +168,22 @@ func (s *SceneService) CreateScene(
 	}
 
 	//nolint:exhaustruct // Fields are set conditionally below
-	response := &CreateSceneResponse{}
+	response := &CreateSceneResponse{
+		SceneCount: count,
+		SceneLimit: MaxScenes,
+	}
 
-	// Generate warnings
-	switch count {
+	// Generate warnings from the count once this scene exists
+	switch count + 1 {
 	case SceneWarningThreshold20:
 		response.Warning = "You have 20 of 25 scenes"
+		response.NearLimit = true
 	case SceneWarningThreshold23:
 		response.Warning = "Approaching scene limit (23/25)"
+		response.NearLimit = true
 	case SceneWarningThreshold24:
 		response.Warning = "Nearly at scene limit (24/25)"
+		response.NearLimit = true
 	}
 
 	// Handle auto-deletion at 25+ scenes
@@ -126,6 +211,11 @@ func (s *SceneService) CreateScene(
 		return nil, incrementErr
 	}
 
+	// The limit check above used the count before insert; report the count after it
+	if response.DeletedSceneID == nil {
+		response.SceneCount = count + 1
+	}
+
 	if commitErr := tx.Commit(ctx); commitErr != nil {
 		return nil, commitErr
 	}
@@ -148,7 +238,7 @@ func (s *SceneService) GetScene(
 	}
 
 	// Verify user is a member of the campaign
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -162,7 +252,19 @@ func (s *SceneService) GetScene(
 	return &scene, nil
 }
 
-// ListCampaignScenes returns all scenes in a campaign.
+// SceneListItem is a scene with the requesting user's personal view flags and
+// activity summary. Post counts and the last post time follow fog of war.
+type SceneListItem struct {
+	generated.Scene
+
+	IsFavorite     bool               `json:"isFavorite"`
+	UnreadCount    int64              `json:"unreadCount"`
+	CharacterCount int64              `json:"characterCount"`
+	PostCount      int64              `json:"postCount"`
+	LastPostAt     pgtype.Timestamptz `json:"lastPostAt"`
+}
+
+// ListCampaignScenes returns all scenes in a campaign, flagging the user's favorites.
 // When fog of war is enabled, players only see scenes where their characters have witnessed posts.
 // GMs always see all scenes.
 // If characterID is provided and valid, fog of war filtering uses that specific character instead
@@ -171,9 +273,98 @@ func (s *SceneService) ListCampaignScenes(
 	ctx context.Context,
 	campaignID, userID pgtype.UUID,
 	characterID *pgtype.UUID,
+) ([]SceneListItem, error) {
+	scenes, err := s.listVisibleScenes(ctx, campaignID, userID, characterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Favorites only decorate scenes the user can already see
+	favoriteIDs, err := s.queries.ListFavoriteSceneIDs(ctx, generated.ListFavoriteSceneIDsParams{
+		UserID:     userID,
+		CampaignID: campaignID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	favorites := make(map[[16]byte]bool, len(favoriteIDs))
+	for _, id := range favoriteIDs {
+		favorites[id.Bytes] = true
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.unreadCountsByScene(ctx, campaignID, userID, isGM)
+	if err != nil {
+		return nil, err
+	}
+
+	activityRows, err := s.queries.ListSceneActivity(ctx, generated.ListSceneActivityParams{
+		IsGm:       isGM,
+		UserID:     userID,
+		CampaignID: campaignID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	activity := make(map[[16]byte]generated.ListSceneActivityRow, len(activityRows))
+	for _, row := range activityRows {
+		activity[row.SceneID.Bytes] = row
+	}
+
+	items := make([]SceneListItem, 0, len(scenes))
+	for _, scene := range scenes {
+		stats := activity[scene.ID.Bytes]
+		items = append(items, SceneListItem{
+			Scene:          scene,
+			IsFavorite:     favorites[scene.ID.Bytes],
+			UnreadCount:    unread[scene.ID.Bytes],
+			CharacterCount: stats.CharacterCount,
+			PostCount:      stats.PostCount,
+			LastPostAt:     stats.LastPostAt,
+		})
+	}
+
+	return items, nil
+}
+
+// unreadCountsByScene returns the number of unread posts per scene for the user.
+// GMs count every post; players only count posts their characters witnessed.
+func (s *SceneService) unreadCountsByScene(
+	ctx context.Context,
+	campaignID, userID pgtype.UUID,
+	isGM bool,
+) (map[[16]byte]int64, error) {
+	rows, err := s.queries.CountUnreadPostsByScene(ctx, generated.CountUnreadPostsBySceneParams{
+		UserID:     userID,
+		CampaignID: campaignID,
+		IsGm:       isGM,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[[16]byte]int64, len(rows))
+	for _, row := range rows {
+		counts[row.SceneID.Bytes] = row.UnreadCount
+	}
+	return counts, nil
+}
+
+// listVisibleScenes returns the campaign scenes visible to the user under fog of war.
+func (s *SceneService) listVisibleScenes(
+	ctx context.Context,
+	campaignID, userID pgtype.UUID,
+	characterID *pgtype.UUID,
 ) ([]generated.Scene, error) {
 	// Verify user is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -185,7 +376,7 @@ func (s *SceneService) ListCampaignScenes(
 	}
 
 	// Check if user is GM - GMs always see all scenes
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -205,25 +396,175 @@ func (s *SceneService) ListCampaignScenes(
 	// Parse settings to check fog of war
 	fogOfWarEnabled := s.isFogOfWarEnabled(campaign.Settings)
 
-	// If fog of war is disabled, show all scenes
-	if !fogOfWarEnabled {
-		return s.queries.ListCampaignScenes(ctx, campaignID)
+	scenes, err := s.queries.ListCampaignScenes(ctx, campaignID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Fog of war enabled - check if we should filter by specific character
+	// If no scene is under fog of war, show all scenes
+	if !slices.ContainsFunc(scenes, func(scene generated.Scene) bool {
+		return isSceneFogged(scene, fogOfWarEnabled)
+	}) {
+		return scenes, nil
+	}
+
+	// Fog of war applies - check if we should filter by specific character
+	var witnessed []generated.Scene
 	if characterID != nil && characterID.Valid {
 		// Use character-specific filtering
-		return s.queries.GetVisibleScenesForCharacter(ctx, generated.GetVisibleScenesForCharacterParams{
+		witnessed, err = s.queries.GetVisibleScenesForCharacter(ctx, generated.GetVisibleScenesForCharacterParams{
 			CampaignID: campaignID,
 			Column2:    *characterID,
 		})
+	} else {
+		// Fall back to aggregate visibility across all user's characters
+		witnessed, err = s.queries.GetVisibleScenesForUser(ctx, generated.GetVisibleScenesForUserParams{
+			CampaignID: campaignID,
+			UserID:     userID,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	witnessedIDs := make(map[[16]byte]bool, len(witnessed))
+	for _, scene := range witnessed {
+		witnessedIDs[scene.ID.Bytes] = true
+	}
+
+	visible := make([]generated.Scene, 0, len(scenes))
+	for _, scene := range scenes {
+		if !isSceneFogged(scene, fogOfWarEnabled) || witnessedIDs[scene.ID.Bytes] {
+			visible = append(visible, scene)
+		}
+	}
+	return visible, nil
+}
+
+// isSceneFogged reports whether fog of war hides the scene from members until one of
+// their characters witnesses a post in it. The scene's override wins over the
+// campaign setting.
+func isSceneFogged(scene generated.Scene, campaignFogOfWar bool) bool {
+	switch scene.FogOverride {
+	case FogOverrideAlwaysVisible:
+		return false
+	case FogOverrideFog:
+		return true
+	default:
+		return campaignFogOfWar
+	}
+}
+
+// FavoriteScene pins a scene for the user. Favorites are a personal view preference
+// and do not affect fog-of-war visibility.
+func (s *SceneService) FavoriteScene(
+	ctx context.Context,
+	campaignID, sceneID, userID pgtype.UUID,
+) error {
+	if err := s.verifySceneMembership(ctx, campaignID, sceneID, userID); err != nil {
+		return err
+	}
+
+	return s.queries.AddSceneFavorite(ctx, generated.AddSceneFavoriteParams{
+		UserID:  userID,
+		SceneID: sceneID,
+	})
+}
+
+// UnfavoriteScene unpins a scene for the user.
+func (s *SceneService) UnfavoriteScene(
+	ctx context.Context,
+	campaignID, sceneID, userID pgtype.UUID,
+) error {
+	if err := s.verifySceneMembership(ctx, campaignID, sceneID, userID); err != nil {
+		return err
+	}
+
+	return s.queries.RemoveSceneFavorite(ctx, generated.RemoveSceneFavoriteParams{
+		UserID:  userID,
+		SceneID: sceneID,
+	})
+}
+
+// verifySceneMembership checks the scene belongs to the campaign and the user is a member.
+func (s *SceneService) verifySceneMembership(
+	ctx context.Context,
+	campaignID, sceneID, userID pgtype.UUID,
+) error {
+	scene, err := s.queries.GetScene(ctx, sceneID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSceneNotFound
+		}
+		return err
+	}
+	if scene.CampaignID != campaignID {
+		return ErrSceneNotFound
 	}
 
-	// Fall back to aggregate visibility across all user's characters
-	return s.queries.GetVisibleScenesForUser(ctx, generated.GetVisibleScenesForUserParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return ErrNotMember
+	}
+
+	return nil
+}
+
+// ReorderScenes sets the manual order of a campaign's scenes (GM only).
+// orderedIDs must contain every scene in the campaign, including archived ones, exactly once.
+func (s *SceneService) ReorderScenes(
+	ctx context.Context,
+	campaignID, gmUserID pgtype.UUID,
+	orderedIDs []string,
+) ([]generated.Scene, error) {
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	scenes, err := s.queries.ListCampaignScenes(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if len(orderedIDs) != len(scenes) {
+		return nil, ErrSceneOrderInvalid
+	}
+
+	remaining := make(map[[16]byte]bool, len(scenes))
+	for _, scene := range scenes {
+		remaining[scene.ID.Bytes] = true
+	}
+
+	sceneIDs := make([]pgtype.UUID, 0, len(orderedIDs))
+	for _, idStr := range orderedIDs {
+		sceneID := parseUUIDString(idStr)
+		if !sceneID.Valid || !remaining[sceneID.Bytes] {
+			return nil, ErrSceneOrderInvalid
+		}
+		delete(remaining, sceneID.Bytes)
+		sceneIDs = append(sceneIDs, sceneID)
+	}
+
+	if err := s.queries.UpdateSceneSortOrders(ctx, generated.UpdateSceneSortOrdersParams{
+		CampaignID: campaignID,
+		Column2:    sceneIDs,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.queries.ListCampaignScenes(ctx, campaignID)
 }
 
 // isFogOfWarEnabled parses campaign settings and returns whether fog of war is enabled.
@@ -252,8 +593,12 @@ func (s *SceneService) isFogOfWarEnabled(settingsJSON []byte) bool {
 
 // UpdateSceneRequest represents the request to update a scene.
 type UpdateSceneRequest struct {
-	Title       *string `json:"title,omitempty"`
-	Description *string `json:"description,omitempty"`
+	Title              *string `json:"title,omitempty"`
+	Description        *string `json:"description,omitempty"`
+	DefaultWitnessMode *string `json:"defaultWitnessMode,omitempty"`
+	FogOverride        *string `json:"fogOverride,omitempty"`
+	// Summary is the GM's recap of the scene so far; an empty string clears it.
+	Summary *string `json:"summary,omitempty"`
 }
 
 // UpdateScene updates a scene (GM only).
@@ -272,7 +617,7 @@ func (s *SceneService) UpdateScene(
 	}
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -283,6 +628,40 @@ func (s *SceneService) UpdateScene(
 		return nil, ErrNotGM
 	}
 
+	if req.DefaultWitnessMode != nil {
+		if !isValidWitnessMode(*req.DefaultWitnessMode) {
+			return nil, ErrInvalidWitnessMode
+		}
+		if _, modeErr := s.queries.SetSceneDefaultWitnessMode(ctx, generated.SetSceneDefaultWitnessModeParams{
+			ID:                 sceneID,
+			DefaultWitnessMode: *req.DefaultWitnessMode,
+		}); modeErr != nil {
+			return nil, modeErr
+		}
+	}
+
+	if req.FogOverride != nil {
+		if !isValidFogOverride(*req.FogOverride) {
+			return nil, ErrInvalidFogOverride
+		}
+		if _, fogErr := s.queries.SetSceneFogOverride(ctx, generated.SetSceneFogOverrideParams{
+			ID:          sceneID,
+			FogOverride: *req.FogOverride,
+		}); fogErr != nil {
+			return nil, fogErr
+		}
+	}
+
+	if req.Summary != nil {
+		summary := strings.TrimSpace(*req.Summary)
+		if _, summaryErr := s.queries.SetSceneSummary(ctx, generated.SetSceneSummaryParams{
+			ID:      sceneID,
+			Summary: pgtype.Text{String: summary, Valid: summary != ""},
+		}); summaryErr != nil {
+			return nil, summaryErr
+		}
+	}
+
 	// Build update params
 	//nolint:exhaustruct // Only ID is required, other fields are set conditionally
 	params := generated.UpdateSceneParams{
@@ -305,6 +684,24 @@ func (s *SceneService) UpdateScene(
 	return &updated, nil
 }
 
+func isValidWitnessMode(mode string) bool {
+	switch mode {
+	case WitnessModeAll, WitnessModeAuthor, WitnessModeGMOnly:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidFogOverride(override string) bool {
+	switch override {
+	case FogOverrideInherit, FogOverrideAlwaysVisible, FogOverrideFog:
+		return true
+	default:
+		return false
+	}
+}
+
 // ArchiveScene archives a scene (GM only).
 func (s *SceneService) ArchiveScene(
 	ctx context.Context,
@@ -320,7 +717,7 @@ func (s *SceneService) ArchiveScene(
 	}
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -354,7 +751,7 @@ func (s *SceneService) UnarchiveScene(
 	}
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -373,11 +770,306 @@ func (s *SceneService) UnarchiveScene(
 	return &unarchived, nil
 }
 
+// FreezeScene freezes a scene (GM only). A frozen scene stays readable but
+// accepts no new posts, edits, or compose locks.
+func (s *SceneService) FreezeScene(
+	ctx context.Context,
+	sceneID, userID pgtype.UUID,
+) (*generated.Scene, error) {
+	return s.setSceneFrozen(ctx, sceneID, userID, true)
+}
+
+// UnfreezeScene lifts a scene freeze (GM only).
+func (s *SceneService) UnfreezeScene(
+	ctx context.Context,
+	sceneID, userID pgtype.UUID,
+) (*generated.Scene, error) {
+	return s.setSceneFrozen(ctx, sceneID, userID, false)
+}
+
+func (s *SceneService) setSceneFrozen(
+	ctx context.Context,
+	sceneID, userID pgtype.UUID,
+	frozen bool,
+) (*generated.Scene, error) {
+	// Get scene to verify campaign
+	scene, err := s.queries.GetScene(ctx, sceneID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSceneNotFound
+		}
+		return nil, err
+	}
+
+	// Verify user is GM
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: scene.CampaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	var updated generated.Scene
+	if frozen {
+		updated, err = s.queries.FreezeScene(ctx, sceneID)
+	} else {
+		updated, err = s.queries.UnfreezeScene(ctx, sceneID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// CloneSceneResult is a newly cloned scene and the header image, if any, that still
+// needs to be copied in storage.
+type CloneSceneResult struct {
+	Scene           *generated.Scene
+	HeaderSourceURL string
+}
+
+// CloneScene copies a scene's title and description into a new active scene in the
+// same campaign (GM only). Posts, pass states, locks, and characters are not copied.
+// Unlike CreateScene, cloning never auto-deletes an archived scene to make room.
+// The source header image is returned for the caller to copy in storage.
+func (s *SceneService) CloneScene(
+	ctx context.Context,
+	gmUserID, campaignID, sceneID pgtype.UUID,
+	newTitle string,
+) (*CloneSceneResult, error) {
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	source, err := s.queries.GetScene(ctx, sceneID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSceneNotFound
+		}
+		return nil, err
+	}
+	if source.CampaignID != campaignID {
+		return nil, ErrSceneNotFound
+	}
+
+	title := strings.TrimSpace(newTitle)
+	if title == "" {
+		title = source.Title + " (Copy)"
+	}
+	if runes := []rune(title); len(runes) > maxSceneTitleLen {
+		title = string(runes[:maxSceneTitleLen])
+	}
+
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := s.queries.WithTx(tx)
+
+	count, err := qtx.CountCampaignScenes(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxScenes {
+		return nil, ErrSceneLimitReached
+	}
+
+	scene, err := qtx.CreateScene(ctx, generated.CreateSceneParams{
+		CampaignID:  campaignID,
+		Title:       title,
+		Description: source.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if incrementErr := qtx.IncrementSceneCount(ctx, campaignID); incrementErr != nil {
+		return nil, incrementErr
+	}
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return nil, commitErr
+	}
+
+	result := &CloneSceneResult{Scene: &scene, HeaderSourceURL: ""}
+	if source.HeaderImageUrl.Valid {
+		result.HeaderSourceURL = source.HeaderImageUrl.String
+	}
+	return result, nil
+}
+
+// BulkArchive archives or unarchives several of a campaign's scenes at once (GM only).
+// Every ID must be a scene in the campaign, listed once; the scenes are updated in one
+// transaction and returned in request order.
+func (s *SceneService) BulkArchive(
+	ctx context.Context,
+	gmUserID, campaignID pgtype.UUID,
+	sceneIDs []string,
+	archive bool,
+) ([]generated.Scene, error) {
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	if len(sceneIDs) == 0 {
+		return nil, ErrBulkSceneInvalid
+	}
+
+	scenes, err := s.queries.ListCampaignScenes(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := make(map[[16]byte]bool, len(scenes))
+	for _, scene := range scenes {
+		remaining[scene.ID.Bytes] = true
+	}
+
+	ids := make([]pgtype.UUID, 0, len(sceneIDs))
+	for _, idStr := range sceneIDs {
+		sceneID := parseUUIDString(idStr)
+		if !sceneID.Valid || !remaining[sceneID.Bytes] {
+			return nil, ErrBulkSceneInvalid
+		}
+		delete(remaining, sceneID.Bytes)
+		ids = append(ids, sceneID)
+	}
+
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := s.queries.WithTx(tx)
+
+	updated := make([]generated.Scene, 0, len(ids))
+	for _, sceneID := range ids {
+		var scene generated.Scene
+		if archive {
+			scene, err = qtx.ArchiveScene(ctx, sceneID)
+		} else {
+			scene, err = qtx.UnarchiveScene(ctx, sceneID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		updated = append(updated, scene)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// SceneCharacterMove is the scene a character was added to, with the scene it left, if any.
+type SceneCharacterMove struct {
+	generated.Scene
+
+	MovedFromSceneID    *string `json:"movedFromSceneId"`
+	MovedFromSceneTitle *string `json:"movedFromSceneTitle"`
+}
+
+// GetCharacterCurrentScene returns the active scene a character occupies, or nil if it is in none.
+func (s *SceneService) GetCharacterCurrentScene(
+	ctx context.Context,
+	characterID pgtype.UUID,
+) (*generated.Scene, error) {
+	char, err := s.queries.GetCharacter(ctx, characterID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCharacterNotFound
+		}
+		return nil, err
+	}
+
+	return currentSceneOf(ctx, s.queries, char.CampaignID, characterID)
+}
+
+// GetCharacterScene returns the active scene a character in the campaign occupies (GM only).
+func (s *SceneService) GetCharacterScene(
+	ctx context.Context,
+	campaignID, characterID, gmUserID pgtype.UUID,
+) (*generated.Scene, error) {
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	campaignOfCharacter, err := s.queries.GetCharacterCampaignID(ctx, characterID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCharacterNotFound
+		}
+		return nil, err
+	}
+	if campaignOfCharacter != campaignID {
+		return nil, ErrCharacterNotFound
+	}
+
+	return s.GetCharacterCurrentScene(ctx, characterID)
+}
+
+// currentSceneOf returns the active scene holding the character, or nil if there is none.
+func currentSceneOf(
+	ctx context.Context,
+	q *generated.Queries,
+	campaignID, characterID pgtype.UUID,
+) (*generated.Scene, error) {
+	scene, err := q.GetSceneWithCharacter(ctx, generated.GetSceneWithCharacterParams{
+		CampaignID: campaignID,
+		Column2:    characterID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil //nolint:nilnil // Character is not in any active scene
+		}
+		return nil, err
+	}
+	return &scene, nil
+}
+
 // AddCharacterToScene adds a character to a scene (GM only, GM Phase only).
+// A character in another scene is moved; the result reports the scene it left.
 func (s *SceneService) AddCharacterToScene(
 	ctx context.Context,
 	sceneID, characterID, userID pgtype.UUID,
-) (*generated.Scene, error) {
+) (*SceneCharacterMove, error) {
 	// Get scene with campaign info
 	sceneWithCampaign, err := s.queries.GetSceneWithCampaign(ctx, sceneID)
 	if err != nil {
@@ -388,7 +1080,7 @@ func (s *SceneService) AddCharacterToScene(
 	}
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: sceneWithCampaign.CampaignID,
 		UserID:     userID,
 	})
@@ -417,6 +1109,9 @@ func (s *SceneService) AddCharacterToScene(
 		return nil, ErrCharacterNotFound
 	}
 
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
 	// Start transaction
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
@@ -426,6 +1121,14 @@ func (s *SceneService) AddCharacterToScene(
 
 	qtx := s.queries.WithTx(tx)
 
+	previous, err := currentSceneOf(ctx, qtx, sceneWithCampaign.CampaignID, characterID)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil && previous.ID == sceneID {
+		return nil, ErrCharacterInScene
+	}
+
 	// Remove character from any other scenes first (single-scene constraint)
 	err = qtx.RemoveCharacterFromAllScenes(ctx, generated.RemoveCharacterFromAllScenesParams{
 		CampaignID: sceneWithCampaign.CampaignID,
@@ -448,7 +1151,13 @@ func (s *SceneService) AddCharacterToScene(
 		return nil, commitErr
 	}
 
-	return &scene, nil
+	move := &SceneCharacterMove{Scene: scene}
+	if previous != nil {
+		previousID := uuidToString(previous.ID)
+		move.MovedFromSceneID = &previousID
+		move.MovedFromSceneTitle = &previous.Title
+	}
+	return move, nil
 }
 
 // RemoveCharacterFromScene removes a character from a scene (GM only, GM Phase only).
@@ -466,7 +1175,7 @@ func (s *SceneService) RemoveCharacterFromScene(
 	}
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: sceneWithCampaign.CampaignID,
 		UserID:     userID,
 	})
@@ -494,6 +1203,64 @@ func (s *SceneService) RemoveCharacterFromScene(
 	return &scene, nil
 }
 
+// ReorderSceneCharacters sets the roster order of a scene's characters (GM only, GM Phase only).
+// orderedIDs may list a subset of the scene's characters; the rest sort after them by name.
+func (s *SceneService) ReorderSceneCharacters(
+	ctx context.Context,
+	campaignID, sceneID, gmUserID pgtype.UUID,
+	orderedIDs []string,
+) ([]generated.GetSceneCharactersRow, error) {
+	sceneWithCampaign, err := s.queries.GetSceneWithCampaign(ctx, sceneID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSceneNotFound
+		}
+		return nil, err
+	}
+	if sceneWithCampaign.CampaignID != campaignID {
+		return nil, ErrSceneNotFound
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     gmUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isGM {
+		return nil, ErrNotGM
+	}
+
+	if sceneWithCampaign.CurrentPhase != generated.CampaignPhaseGmPhase {
+		return nil, ErrNotGMPhase
+	}
+
+	remaining := make(map[[16]byte]bool, len(sceneWithCampaign.CharacterIds))
+	for _, id := range sceneWithCampaign.CharacterIds {
+		remaining[id.Bytes] = true
+	}
+
+	characterIDs := make([]pgtype.UUID, 0, len(orderedIDs))
+	for _, idStr := range orderedIDs {
+		characterID := parseUUIDString(idStr)
+		if !characterID.Valid || !remaining[characterID.Bytes] {
+			return nil, ErrSceneCharacterOrderInvalid
+		}
+		delete(remaining, characterID.Bytes)
+		characterIDs = append(characterIDs, characterID)
+	}
+
+	if _, err := s.queries.SetSceneCharacterOrder(ctx, generated.SetSceneCharacterOrderParams{
+		ID:             sceneID,
+		CharacterOrder: characterIDs,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.queries.GetSceneCharacters(ctx, sceneID)
+}
+
 // GetSceneCharacters returns all characters in a scene.
 func (s *SceneService) GetSceneCharacters(
 	ctx context.Context,
@@ -509,7 +1276,7 @@ func (s *SceneService) GetSceneCharacters(
 	}
 
 	// Verify user is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -520,7 +1287,27 @@ func (s *SceneService) GetSceneCharacters(
 		return nil, ErrNotMember
 	}
 
-	return s.queries.GetSceneCharacters(ctx, sceneID)
+	characters, err := s.queries.GetSceneCharacters(ctx, sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: scene.CampaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// GM notes are private to the GM
+	if !isGM {
+		for i := range characters {
+			characters[i].GmNotes = pgtype.Text{}
+		}
+	}
+
+	return characters, nil
 }
 
 // GetSceneCount returns the current scene count and warning level for a campaign.
@@ -529,7 +1316,7 @@ func (s *SceneService) GetSceneCount(
 	campaignID, userID pgtype.UUID,
 ) (int64, string, error) {
 	// Verify user is a member
-	isMember, err := s.queries.IsCampaignMember(ctx, generated.IsCampaignMemberParams{
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
 		CampaignID: campaignID,
 		UserID:     userID,
 	})
@@ -602,7 +1389,7 @@ func (s *SceneService) DeleteScene(
 	}
 
 	// Verify user is GM
-	isGM, err := s.queries.IsUserGM(ctx, generated.IsUserGMParams{
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
 		CampaignID: scene.CampaignID,
 		UserID:     userID,
 	})
@@ -613,6 +1400,9 @@ func (s *SceneService) DeleteScene(
 		return "", pgtype.UUID{}, ErrNotGM
 	}
 
+	ctx, cancel := withTxTimeout(ctx)
+	defer cancel()
+
 	// Start transaction
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
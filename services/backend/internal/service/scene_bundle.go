@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// SceneBundle is everything the scene view needs when it opens. Each part comes
+// from the same service method as its own endpoint, so witness and visibility
+// rules apply unchanged.
+type SceneBundle struct {
+	Scene        *generated.Scene                  `json:"scene"`
+	Characters   []generated.GetSceneCharactersRow `json:"characters"`
+	Posts        []PostResponse                    `json:"posts"`
+	PassStates   map[string]string                 `json:"passStates"`
+	ComposeLocks []SceneLockInfo                   `json:"composeLocks"`
+	Rolls        []RollResponse                    `json:"rolls"`
+	Phase        *PhaseStatus                      `json:"phase"`
+	IsGM         bool                              `json:"isGM"`
+}
+
+// GetSceneBundle loads a scene together with its characters, posts, pass states,
+// compose locks, rolls, and the campaign's phase status in one call.
+func (s *SceneService) GetSceneBundle(
+	ctx context.Context,
+	userID, sceneID pgtype.UUID,
+) (*SceneBundle, error) {
+	// Checks membership before anything else is loaded
+	scene, err := s.GetScene(ctx, sceneID, userID)
+	if err != nil {
+		return nil, err
+	}
+	sceneIDStr := uuidToString(sceneID)
+
+	characters, err := s.GetSceneCharacters(ctx, sceneID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := NewPostService(s.pool).ListScenePosts(ctx, userID, sceneIDStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Phase status auto-passes everyone once the time gate has expired, so it is
+	// checked before pass states are loaded
+	phase, err := NewPhaseService(s.pool).GetPhaseStatus(ctx, scene.CampaignID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	passStates, err := NewPassService(s.pool).GetScenePassStates(ctx, scene.CampaignID, sceneID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	locks, isGM, err := NewComposeService(s.pool).GetSceneLocks(ctx, userID, sceneIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	rolls, err := NewRollService(s.pool).GetRollsInScene(ctx, userID, sceneIDStr, SceneRollFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SceneBundle{
+		Scene:        scene,
+		Characters:   characters,
+		Posts:        posts,
+		PassStates:   passStates,
+		ComposeLocks: locks,
+		Rolls:        rolls,
+		Phase:        phase,
+		IsGM:         isGM,
+	}, nil
+}
@@ -0,0 +1,41 @@
+//go:build integration
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+func TestSceneBundleShowsPassesFromExpiredTimeGate(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	player := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	if err := tc.queries.UpdateCampaignPhase(tc.ctx, generated.UpdateCampaignPhaseParams{
+		ID:           tc.campaign.ID,
+		CurrentPhase: generated.CampaignPhasePcPhase,
+		CurrentPhaseExpiresAt: pgtype.Timestamptz{
+			Time:             time.Now().Add(-time.Minute),
+			Valid:            true,
+			InfinityModifier: pgtype.Finite,
+		},
+	}); err != nil {
+		t.Fatalf("expire time gate: %v", err)
+	}
+
+	bundle, err := NewSceneService(tc.pool).GetSceneBundle(tc.ctx, player.userID, tc.scene.ID)
+	if err != nil {
+		t.Fatalf("get scene bundle: %v", err)
+	}
+	if bundle.Phase.AutoPassedCount != 1 {
+		t.Errorf("auto-passed %d characters, want 1", bundle.Phase.AutoPassedCount)
+	}
+	if state := bundle.PassStates[uuidToString(player.characterID)]; state != PassStatePassed {
+		t.Errorf("pass state = %q, want %q from the expired time gate", state, PassStatePassed)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build integration
+
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDraftsCannotBeSubmittedToAFrozenScene(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	player := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	characterID := uuidToString(player.characterID)
+	posts := NewPostService(tc.pool)
+	draft, err := posts.CreatePost(tc.ctx, player.userID, CreatePostRequest{
+		SceneID:     uuidToString(tc.scene.ID),
+		CharacterID: &characterID,
+		Blocks:      []PostBlock{{Type: "action", Content: "Waits by the door.", Order: 0}},
+	}, false)
+	if err != nil {
+		t.Fatalf("draft: %v", err)
+	}
+
+	if _, err = NewSceneService(tc.pool).FreezeScene(tc.ctx, tc.scene.ID, tc.gm); err != nil {
+		t.Fatalf("freeze: %v", err)
+	}
+
+	_, err = posts.SubmitPost(tc.ctx, player.userID, draft.ID, false)
+	if !errors.Is(err, ErrSceneFrozen) {
+		t.Fatalf("submit to frozen scene: got %v, want ErrSceneFrozen", err)
+	}
+}
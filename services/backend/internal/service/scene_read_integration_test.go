@@ -0,0 +1,88 @@
+//go:build integration
+
+package service
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+func TestMarkSceneReadSkipsPostsTheUserCannotSee(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	author := tc.addPlayer()
+	reader := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	public, err := tc.post(author.userID, author.characterID, CreatePostRequest{})
+	if err != nil {
+		t.Fatalf("public post: %v", err)
+	}
+	hidden, err := tc.post(author.userID, author.characterID, CreatePostRequest{IsHidden: true})
+	if err != nil {
+		t.Fatalf("hidden post: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		userID   pgtype.UUID
+		wantPost string
+	}{
+		{"player marks the latest post they witnessed", reader.userID, public.ID},
+		{"GM marks the latest post", tc.gm, hidden.ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := NewPostService(tc.pool).MarkSceneRead(tc.ctx, tt.userID, uuidToString(tc.scene.ID), nil)
+			if err != nil {
+				t.Fatalf("mark scene read: %v", err)
+			}
+			if resp.LastReadPostID == nil || *resp.LastReadPostID != tt.wantPost {
+				t.Errorf("marked post = %v, want %s", resp.LastReadPostID, tt.wantPost)
+			}
+		})
+	}
+}
+
+func TestDraftSubmittedAfterReadMarkerCountsAsUnread(t *testing.T) {
+	tc := newTestCampaign(t, nil)
+	author := tc.addPlayer()
+	reader := tc.addPlayer()
+	tc.transition(PhasePCPhase)
+
+	posts := NewPostService(tc.pool)
+	characterID := uuidToString(author.characterID)
+	draft, err := posts.CreatePost(tc.ctx, author.userID, CreatePostRequest{
+		SceneID:     uuidToString(tc.scene.ID),
+		CharacterID: &characterID,
+		Blocks:      []PostBlock{{Type: "action", Content: "Something happens.", Order: 0}},
+	}, false)
+	if err != nil {
+		t.Fatalf("draft: %v", err)
+	}
+	if _, err := tc.post(reader.userID, reader.characterID, CreatePostRequest{}); err != nil {
+		t.Fatalf("reader post: %v", err)
+	}
+
+	// The draft was started before the marker but is published after it
+	if _, err := posts.MarkSceneRead(tc.ctx, reader.userID, uuidToString(tc.scene.ID), nil); err != nil {
+		t.Fatalf("mark scene read: %v", err)
+	}
+	if _, err := posts.SubmitPost(tc.ctx, author.userID, draft.ID, false); err != nil {
+		t.Fatalf("submit draft: %v", err)
+	}
+
+	rows, err := tc.queries.CountUnreadPostsByScene(tc.ctx, generated.CountUnreadPostsBySceneParams{
+		UserID:     reader.userID,
+		CampaignID: tc.campaign.ID,
+		IsGm:       false,
+	})
+	if err != nil {
+		t.Fatalf("count unread posts: %v", err)
+	}
+	if len(rows) != 1 || rows[0].UnreadCount != 1 {
+		t.Fatalf("unread counts = %+v, want one unread post", rows)
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// SceneReadResponse describes a user's read marker in a scene.
+type SceneReadResponse struct {
+	SceneID        string  `json:"sceneId"`
+	LastReadPostID *string `json:"lastReadPostId"`
+	LastReadAt     *string `json:"lastReadAt"`
+}
+
+// MarkSceneRead records that the user has read the scene up to lastPostID.
+// When lastPostID is nil the most recent post the user can see is used. Markers never move backwards.
+func (s *PostService) MarkSceneRead(
+	ctx context.Context,
+	userID pgtype.UUID,
+	sceneID string,
+	lastPostID *string,
+) (*SceneReadResponse, error) {
+	sceneUUID := parseUUIDString(sceneID)
+
+	scene, err := s.queries.GetScene(ctx, sceneUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSceneNotFound
+		}
+		return nil, err
+	}
+
+	isMember, err := isCampaignMember(ctx, s.queries, generated.IsCampaignMemberParams{
+		CampaignID: scene.CampaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrNotMember
+	}
+
+	var post generated.Post
+	if lastPostID != nil {
+		post, err = s.queries.GetPost(ctx, parseUUIDString(*lastPostID))
+	} else {
+		post, err = s.lastVisibleScenePost(ctx, userID, scene)
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if lastPostID == nil {
+				// Nothing the user can see yet, so nothing to mark
+				return &SceneReadResponse{SceneID: sceneID, LastReadPostID: nil, LastReadAt: nil}, nil
+			}
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if post.SceneID != sceneUUID || post.IsDraft {
+		return nil, ErrPostNotFound
+	}
+	// Players may only move their marker to posts they can see
+	if _, accessErr := s.verifyPostAccess(ctx, userID, post.SceneID, post.Witnesses); accessErr != nil {
+		return nil, accessErr
+	}
+
+	// The marker is a publish time so drafts submitted after it still count as unread
+	publishedAt := post.SubmittedAt
+	if !publishedAt.Valid {
+		publishedAt = post.CreatedAt
+	}
+
+	if err := s.queries.UpsertSceneRead(ctx, generated.UpsertSceneReadParams{
+		UserID:         userID,
+		SceneID:        sceneUUID,
+		LastReadPostID: post.ID,
+		LastReadAt:     publishedAt,
+	}); err != nil {
+		return nil, err
+	}
+
+	postIDStr := formatUUID(post.ID.Bytes[:])
+	readAt := publishedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	return &SceneReadResponse{
+		SceneID:        sceneID,
+		LastReadPostID: &postIDStr,
+		LastReadAt:     &readAt,
+	}, nil
+}
+
+// lastVisibleScenePost returns the scene's most recently published post that the user can see.
+func (s *PostService) lastVisibleScenePost(
+	ctx context.Context,
+	userID pgtype.UUID,
+	scene generated.Scene,
+) (generated.Post, error) {
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: scene.CampaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return generated.Post{}, err
+	}
+
+	return s.queries.GetLastVisibleScenePost(ctx, generated.GetLastVisibleScenePostParams{
+		SceneID: scene.ID,
+		IsGm:    isGM,
+		UserID:  userID,
+	})
+}
@@ -0,0 +1,384 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+)
+
+// Webhook configuration limits.
+const (
+	maxWebhookURLLen       = 2048
+	minWebhookSecretLen    = 16
+	webhookSecretBytes     = 32
+	webhookMaxAttempts     = 3
+	webhookInitialBackoff  = 1 * time.Second
+	webhookSignatureHeader = "X-Vanguard-Signature"
+	webhookEventHeader     = "X-Vanguard-Event"
+)
+
+// WebhookEvents lists the events that can be delivered to a campaign webhook.
+//
+//nolint:gochecknoglobals // Read-only list of supported events
+var WebhookEvents = []string{EventPostCreated, EventPhaseTransition, EventRollResolved}
+
+// Webhook errors.
+var (
+	ErrInvalidWebhookURL    = errors.New("webhook URL must be a valid https URL")
+	ErrInvalidWebhookEvents = errors.New("invalid webhook events")
+	ErrInvalidWebhookSecret = errors.New("webhook secret is too short")
+	ErrWebhookNotFound      = errors.New("webhook not found")
+	errBlockedWebhookAddr   = errors.New("webhook address is not publicly routable")
+)
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which netip
+// does not count as private.
+//
+//nolint:gochecknoglobals // Constant prefix
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// WebhookService manages campaign webhook configuration and delivers
+// signed event payloads to the configured URLs.
+type WebhookService struct {
+	queries    *generated.Queries
+	httpClient *http.Client
+}
+
+// NewWebhookService creates a new WebhookService.
+func NewWebhookService(pool *pgxpool.Pool) *WebhookService {
+	return &WebhookService{
+		queries: newQueries(pool),
+		//nolint:exhaustruct // Only transport, timeout and redirect policy differ from the defaults
+		httpClient: &http.Client{
+			Transport: newWebhookTransport(),
+			Timeout:   httpClientTimeout,
+			// A redirect could send the signed payload to an unverified host.
+			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// newWebhookTransport returns a transport that refuses to connect to private,
+// loopback and link-local addresses. The check runs on the resolved address at
+// connect time, so a hostname that resolves (or later rebinds) to an internal
+// address cannot be used to reach internal services. Proxies are disabled
+// because the dialer would only see the proxy's address.
+func newWebhookTransport() *http.Transport {
+	//nolint:exhaustruct // Defaults for everything but the connect-time check
+	dialer := &net.Dialer{
+		Timeout: httpClientTimeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			return checkWebhookDialAddr(address)
+		},
+	}
+	transport, _ := http.DefaultTransport.(*http.Transport)
+	transport = transport.Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+// checkWebhookDialAddr rejects a resolved "ip:port" that is not publicly routable.
+func checkWebhookDialAddr(address string) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errBlockedWebhookAddr, address)
+	}
+	if !isPublicWebhookAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", errBlockedWebhookAddr, addrPort.Addr())
+	}
+	return nil
+}
+
+// isPublicWebhookAddr reports whether a webhook may be delivered to addr.
+func isPublicWebhookAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsUnspecified() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// SetWebhookRequest represents the request to configure a campaign webhook.
+// A missing Secret generates a new one; missing Events selects all events.
+type SetWebhookRequest struct {
+	URL     string   `json:"url"`
+	Secret  *string  `json:"secret"`
+	Events  []string `json:"events"`
+	Enabled *bool    `json:"enabled"`
+}
+
+// WebhookResponse represents a campaign webhook in API responses.
+// The secret is only returned to the GM who configures it.
+type WebhookResponse struct {
+	CampaignID string   `json:"campaignId"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	Events     []string `json:"events"`
+	Enabled    bool     `json:"enabled"`
+	CreatedAt  string   `json:"createdAt"`
+	UpdatedAt  string   `json:"updatedAt"`
+}
+
+// WebhookPayload is the JSON body POSTed to a campaign webhook.
+type WebhookPayload struct {
+	Event      string         `json:"event"`
+	CampaignID string         `json:"campaignId"`
+	Timestamp  string         `json:"timestamp"`
+	Data       map[string]any `json:"data"`
+}
+
+// SetWebhook creates or replaces the campaign's webhook (GM only).
+func (s *WebhookService) SetWebhook(
+	ctx context.Context,
+	campaignID, userID pgtype.UUID,
+	req SetWebhookRequest,
+) (*WebhookResponse, error) {
+	if err := s.requireGM(ctx, campaignID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	events, err := normalizeWebhookEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	var secret string
+	if req.Secret != nil {
+		if len(*req.Secret) < minWebhookSecretLen {
+			return nil, ErrInvalidWebhookSecret
+		}
+		secret = *req.Secret
+	} else {
+		secret, err = generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	webhook, err := s.queries.UpsertCampaignWebhook(ctx, generated.UpsertCampaignWebhookParams{
+		CampaignID: campaignID,
+		Url:        req.URL,
+		Secret:     secret,
+		Events:     events,
+		Enabled:    enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookToResponse(&webhook), nil
+}
+
+// DeleteWebhook removes the campaign's webhook (GM only).
+func (s *WebhookService) DeleteWebhook(ctx context.Context, campaignID, userID pgtype.UUID) error {
+	if err := s.requireGM(ctx, campaignID, userID); err != nil {
+		return err
+	}
+
+	deleted, err := s.queries.DeleteCampaignWebhook(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// Deliver POSTs an event to the campaign's webhook if one is configured,
+// enabled, and subscribed to the event. Failed deliveries are retried with
+// exponential backoff; server errors and rate limits are retried, other
+// client errors are not. Intended to run in its own goroutine.
+func (s *WebhookService) Deliver(ctx context.Context, campaignID pgtype.UUID, event string, data map[string]any) {
+	webhook, err := s.queries.GetCampaignWebhook(ctx, campaignID)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			slog.Default().ErrorContext(ctx, "Failed to load campaign webhook",
+				"campaignID", uuidToString(campaignID), "error", err)
+		}
+		return
+	}
+	if !webhook.Enabled || !slices.Contains(webhook.Events, event) {
+		return
+	}
+
+	body, err := json.Marshal(WebhookPayload{
+		Event:      event,
+		CampaignID: uuidToString(campaignID),
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		Data:       data,
+	})
+	if err != nil {
+		slog.Default().ErrorContext(ctx, "Failed to marshal webhook payload", "event", event, "error", err)
+		return
+	}
+	signature := signWebhookBody(webhook.Secret, body)
+
+	backoff := webhookInitialBackoff
+	for attempt := 1; attempt <= webhookMaxAttempts; attempt++ {
+		retry, sendErr := s.send(ctx, webhook.Url, event, signature, body)
+		if sendErr == nil {
+			return
+		}
+		if !retry || attempt == webhookMaxAttempts {
+			slog.Default().WarnContext(ctx, "Webhook delivery failed",
+				"campaignID", uuidToString(campaignID), "event", event, "attempts", attempt, "error", sendErr)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// send makes a single delivery attempt and reports whether a failure is worth retrying.
+func (s *WebhookService) send(
+	ctx context.Context,
+	webhookURL, event, signature string,
+	body []byte,
+) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookEventHeader, event)
+	req.Header.Set(webhookSignatureHeader, signature)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		retry := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+		return retry, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return false, nil
+}
+
+func (s *WebhookService) requireGM(ctx context.Context, campaignID, userID pgtype.UUID) error {
+	isGM, err := isUserGM(ctx, s.queries, generated.IsUserGMParams{
+		CampaignID: campaignID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return err
+	}
+	if !isGM {
+		return ErrNotGM
+	}
+	return nil
+}
+
+// signWebhookBody returns the signature header value: "sha256=" followed by
+// the hex HMAC-SHA256 of the body keyed with the webhook secret.
+func signWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func validateWebhookURL(raw string) error {
+	if raw == "" || len(raw) > maxWebhookURLLen {
+		return ErrInvalidWebhookURL
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme != "https" || parsed.Hostname() == "" || parsed.User != nil {
+		return ErrInvalidWebhookURL
+	}
+
+	// Reject obviously internal hosts up front; the transport also checks the
+	// resolved address on every delivery.
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrInvalidWebhookURL
+	}
+	if addr, addrErr := netip.ParseAddr(host); addrErr == nil && !isPublicWebhookAddr(addr) {
+		return ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+// normalizeWebhookEvents validates the requested events and removes duplicates.
+// An empty list subscribes to every supported event.
+func normalizeWebhookEvents(events []string) ([]string, error) {
+	if len(events) == 0 {
+		return slices.Clone(WebhookEvents), nil
+	}
+
+	normalized := make([]string, 0, len(events))
+	for _, event := range events {
+		if !slices.Contains(WebhookEvents, event) {
+			return nil, ErrInvalidWebhookEvents
+		}
+		if !slices.Contains(normalized, event) {
+			normalized = append(normalized, event)
+		}
+	}
+	return normalized, nil
+}
+
+// generateWebhookSecret generates a random 64-character hex signing secret.
+func generateWebhookSecret() (string, error) {
+	secretBytes := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secretBytes), nil
+}
+
+func webhookToResponse(webhook *generated.CampaignWebhook) *WebhookResponse {
+	return &WebhookResponse{
+		CampaignID: uuidToString(webhook.CampaignID),
+		URL:        webhook.Url,
+		Secret:     webhook.Secret,
+		Events:     webhook.Events,
+		Enabled:    webhook.Enabled,
+		CreatedAt:  webhook.CreatedAt.Time.Format(time.RFC3339),
+		UpdatedAt:  webhook.UpdatedAt.Time.Format(time.RFC3339),
+	}
+}
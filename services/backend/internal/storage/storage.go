@@ -65,9 +65,13 @@ func (c *Client) Upload(ctx context.Context, bucket, path, contentType string, d
 		return "", fmt.Errorf("upload failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	// Return public URL
-	publicURL := fmt.Sprintf("%s/storage/v1/object/public/%s/%s", c.supabaseURL, bucket, path)
-	return publicURL, nil
+	return c.PublicURL(bucket, path), nil
+}
+
+// PublicURL returns the public URL of a file (or, for a folder path ending in a
+// slash, the prefix shared by its files).
+func (c *Client) PublicURL(bucket, path string) string {
+	return fmt.Sprintf("%s/storage/v1/object/public/%s/%s", c.supabaseURL, bucket, path)
 }
 
 // Delete deletes a file from Supabase Storage.
@@ -97,6 +101,41 @@ func (c *Client) Delete(ctx context.Context, bucket, path string) error {
 	return nil
 }
 
+// Copy copies a file within a bucket and returns the public URL of the copy.
+func (c *Client) Copy(ctx context.Context, bucket, sourcePath, destPath string) (string, error) {
+	reqURL := fmt.Sprintf("%s/storage/v1/object/copy", c.supabaseURL)
+
+	payload, err := json.Marshal(map[string]string{
+		"bucketId":       bucket,
+		"sourceKey":      sourcePath,
+		"destinationKey": destPath,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode copy request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.serviceRoleKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to copy: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("copy failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return c.PublicURL(bucket, destPath), nil
+}
+
 // GetFileSize returns the size of a file in bytes, or 0 if not found.
 func (c *Client) GetFileSize(ctx context.Context, bucket, path string) (int64, error) {
 	reqURL := fmt.Sprintf("%s/storage/v1/object/info/%s/%s", c.supabaseURL, bucket, path)
@@ -0,0 +1,290 @@
+//go:build integration
+
+// Package testdb gives integration tests a throwaway Postgres database with the
+// Supabase migrations applied.
+//
+// The server comes from TEST_DATABASE_URL (a postgres:// URL) when set,
+// otherwise from pg_tmp (ephemeralpg) if it is on the PATH; tests are skipped
+// when neither is available. The migrations are applied once per test binary into a template
+// database, and every New call clones the template, so tests never share rows.
+//
+// Run with: go test -tags integration ./...
+package testdb
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	setupTimeout   = 2 * time.Minute
+	pgTmpIdleWait  = "60" // seconds pg_tmp keeps an idle server alive
+	migrationsPath = "supabase/migrations"
+)
+
+var errNoServer = errors.New("no Postgres server: set TEST_DATABASE_URL or install pg_tmp")
+
+//nolint:gochecknoglobals // Shared by every test in the binary
+var (
+	setupOnce    sync.Once
+	setupErr     error
+	serverURL    string
+	templateName string
+)
+
+// supabaseShim stands in for the parts of a Supabase project the migrations use:
+// the API roles, auth.users and auth.uid(), storage tables and the realtime
+// publication. auth.uid() reads the request.jwt.claim.sub setting, as PostgREST sets it.
+const supabaseShim = `
+DO $$
+BEGIN
+    CREATE ROLE anon NOLOGIN;
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+DO $$
+BEGIN
+    CREATE ROLE authenticated NOLOGIN;
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+DO $$
+BEGIN
+    CREATE ROLE service_role NOLOGIN BYPASSRLS;
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+CREATE SCHEMA auth;
+CREATE TABLE auth.users (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE FUNCTION auth.uid() RETURNS UUID
+LANGUAGE sql STABLE
+AS $$ SELECT NULLIF(current_setting('request.jwt.claim.sub', true), '')::UUID $$;
+
+CREATE SCHEMA storage;
+CREATE TABLE storage.buckets (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    public BOOLEAN NOT NULL DEFAULT false
+);
+CREATE TABLE storage.objects (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    bucket_id TEXT REFERENCES storage.buckets(id),
+    name TEXT
+);
+ALTER TABLE storage.objects ENABLE ROW LEVEL SECURITY;
+
+CREATE PUBLICATION supabase_realtime;
+`
+
+// Main runs the tests and then drops the template database. Call it from a
+// package's TestMain: os.Exit(testdb.Main(m)).
+func Main(m *testing.M) int {
+	code := m.Run()
+	if templateName != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), setupTimeout)
+		defer cancel()
+		if err := adminExec(ctx, "DROP DATABASE IF EXISTS "+pgx.Identifier{templateName}.Sanitize()); err != nil {
+			fmt.Fprintf(os.Stderr, "testdb: drop template: %v\n", err)
+		}
+	}
+	return code
+}
+
+// New returns a pool on a fresh, fully migrated database that is dropped when
+// the test ends.
+func New(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+
+	setupOnce.Do(func() { setupErr = setup() })
+	if errors.Is(setupErr, errNoServer) {
+		t.Skip(setupErr)
+	}
+	if setupErr != nil {
+		t.Fatalf("testdb setup: %v", setupErr)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), setupTimeout)
+	defer cancel()
+
+	name := "vanguard_test_" + randomSuffix()
+	create := fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s",
+		pgx.Identifier{name}.Sanitize(), pgx.Identifier{templateName}.Sanitize())
+	if err := adminExec(ctx, create); err != nil {
+		t.Fatalf("testdb: create database: %v", err)
+	}
+
+	pool, err := pgxpool.New(ctx, databaseURL(name))
+	if err != nil {
+		t.Fatalf("testdb: connect: %v", err)
+	}
+
+	t.Cleanup(func() {
+		pool.Close()
+		dropCtx, dropCancel := context.WithTimeout(context.Background(), setupTimeout)
+		defer dropCancel()
+		if dropErr := adminExec(dropCtx, "DROP DATABASE IF EXISTS "+
+			pgx.Identifier{name}.Sanitize()+" WITH (FORCE)"); dropErr != nil {
+			t.Logf("testdb: drop database: %v", dropErr)
+		}
+	})
+
+	return pool
+}
+
+// CreateUser inserts an auth user, standing in for a Supabase sign-up.
+func CreateUser(t testing.TB, pool *pgxpool.Pool, email string) pgtype.UUID {
+	t.Helper()
+
+	var id pgtype.UUID
+	err := pool.QueryRow(context.Background(),
+		"INSERT INTO auth.users (email) VALUES ($1) RETURNING id", email).Scan(&id)
+	if err != nil {
+		t.Fatalf("testdb: create user: %v", err)
+	}
+	return id
+}
+
+// setup finds a server and builds the migrated template database.
+func setup() error {
+	var err error
+	serverURL, err = findServer()
+	if err != nil {
+		return err
+	}
+
+	dir, err := findMigrations()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), setupTimeout)
+	defer cancel()
+
+	templateName = "vanguard_template_" + randomSuffix()
+	if createErr := adminExec(ctx, "CREATE DATABASE "+pgx.Identifier{templateName}.Sanitize()); createErr != nil {
+		templateName = ""
+		return fmt.Errorf("create template: %w", createErr)
+	}
+
+	conn, err := pgx.Connect(ctx, databaseURL(templateName))
+	if err != nil {
+		return fmt.Errorf("connect to template: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	if _, execErr := conn.Exec(ctx, supabaseShim); execErr != nil {
+		return fmt.Errorf("supabase shim: %w", execErr)
+	}
+	return applyMigrations(ctx, conn, dir)
+}
+
+// applyMigrations runs every migration file in name (timestamp) order.
+func applyMigrations(ctx context.Context, conn *pgx.Conn, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".sql") {
+			names = append(names, entry.Name())
+		}
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		sql, readErr := os.ReadFile(filepath.Join(dir, name))
+		if readErr != nil {
+			return readErr
+		}
+		// No arguments, so pgx uses the simple protocol and runs the whole file
+		if _, execErr := conn.Exec(ctx, string(sql)); execErr != nil {
+			return fmt.Errorf("migration %s: %w", name, execErr)
+		}
+	}
+	return nil
+}
+
+// findServer returns TEST_DATABASE_URL, or starts a pg_tmp server.
+func findServer() (string, error) {
+	if dsn := os.Getenv("TEST_DATABASE_URL"); dsn != "" {
+		return dsn, nil
+	}
+
+	pgTmp, err := exec.LookPath("pg_tmp")
+	if err != nil {
+		return "", errNoServer
+	}
+	out, err := exec.Command(pgTmp, "-t", "-w", pgTmpIdleWait).Output()
+	if err != nil {
+		return "", fmt.Errorf("pg_tmp: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// findMigrations walks up from the working directory to the repository's
+// supabase/migrations directory.
+func findMigrations() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		candidate := filepath.Join(dir, migrationsPath)
+		if info, statErr := os.Stat(candidate); statErr == nil && info.IsDir() {
+			return candidate, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("supabase/migrations not found above the test directory")
+		}
+		dir = parent
+	}
+}
+
+// adminExec runs a statement on the server's own database, outside any test database.
+func adminExec(ctx context.Context, sql string) error {
+	conn, err := pgx.Connect(ctx, serverURL)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, sql)
+	return err
+}
+
+// databaseURL points the server URL at another database on the same server.
+func databaseURL(name string) string {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return serverURL
+	}
+	u.Path = "/" + name
+	return u.String()
+}
+
+func randomSuffix() string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+)
+
+// draftPurgeInterval is how often drafts are checked for lost access.
+const draftPurgeInterval = time.Hour
+
+// DraftCleanupWorker removes compose drafts for scenes or characters their authors
+// can no longer access, e.g. after leaving a campaign or losing a character.
+type DraftCleanupWorker struct {
+	draftService *service.DraftService
+	interval     time.Duration
+}
+
+// NewDraftCleanupWorker creates a new draft cleanup worker.
+func NewDraftCleanupWorker(db *database.DB) *DraftCleanupWorker {
+	return &DraftCleanupWorker{
+		draftService: service.NewDraftService(db.Pool),
+		interval:     draftPurgeInterval,
+	}
+}
+
+// Run purges inaccessible drafts on every tick until the context is cancelled.
+func (w *DraftCleanupWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.purgeInaccessible(ctx)
+		}
+	}
+}
+
+// purgeInaccessible runs a single purge pass over all drafts.
+func (w *DraftCleanupWorker) purgeInaccessible(ctx context.Context) {
+	purged, err := w.draftService.PurgeInaccessibleDrafts(ctx)
+	if err != nil {
+		//nolint:sloglint // Error logging doesn't need structured logger injection
+		slog.Error("Failed to purge inaccessible drafts", "error", err)
+		return
+	}
+	if purged > 0 {
+		//nolint:sloglint // Info logging doesn't need structured logger injection
+		slog.Info("Purged inaccessible drafts", "count", purged)
+	}
+}
@@ -0,0 +1,94 @@
+// Package worker runs periodic background jobs alongside the API server.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+)
+
+const (
+	// queueFlushInterval is how often queued notifications are checked for delivery.
+	queueFlushInterval = time.Minute
+	// unreadReconcileInterval is how often unread counters are checked against the notifications table.
+	unreadReconcileInterval = time.Hour
+)
+
+// NotificationWorker delivers notifications that were held back during quiet hours,
+// resurfaces snoozed notifications once their snooze expires, and corrects drift in
+// the unread counters.
+type NotificationWorker struct {
+	notificationService *service.NotificationService
+	interval            time.Duration
+	reconcileInterval   time.Duration
+}
+
+// NewNotificationWorker creates a new notification worker.
+func NewNotificationWorker(db *database.DB) *NotificationWorker {
+	return &NotificationWorker{
+		notificationService: service.NewNotificationService(db, service.NewQueries(db.Pool)),
+		interval:            queueFlushInterval,
+		reconcileInterval:   unreadReconcileInterval,
+	}
+}
+
+// Run flushes due queued notifications on every tick, and reconciles unread counters
+// on a slower tick, until the context is cancelled.
+func (w *NotificationWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	reconcileTicker := time.NewTicker(w.reconcileInterval)
+	defer reconcileTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.flushQueued(ctx)
+			w.clearExpiredSnoozes(ctx)
+		case <-reconcileTicker.C:
+			w.reconcileUnreadCounts(ctx)
+		}
+	}
+}
+
+// flushQueued runs a single delivery pass over the notification queue.
+func (w *NotificationWorker) flushQueued(ctx context.Context) {
+	sent, err := w.notificationService.FlushDueQueued(ctx)
+	if err != nil {
+		//nolint:sloglint // Error logging doesn't need structured logger injection
+		slog.Error("Failed to flush queued notifications", "error", err)
+		return
+	}
+	if sent > 0 {
+		//nolint:sloglint // Info logging doesn't need structured logger injection
+		slog.Info("Delivered queued notifications", "count", sent)
+	}
+}
+
+// clearExpiredSnoozes resets snoozes whose time has passed.
+func (w *NotificationWorker) clearExpiredSnoozes(ctx context.Context) {
+	if _, err := w.notificationService.ClearExpiredSnoozes(ctx); err != nil {
+		//nolint:sloglint // Error logging doesn't need structured logger injection
+		slog.Error("Failed to clear expired notification snoozes", "error", err)
+	}
+}
+
+// reconcileUnreadCounts fixes unread counters that have drifted from the notifications table.
+func (w *NotificationWorker) reconcileUnreadCounts(ctx context.Context) {
+	fixed, err := w.notificationService.ReconcileUnreadCounts(ctx)
+	if err != nil {
+		//nolint:sloglint // Error logging doesn't need structured logger injection
+		slog.Error("Failed to reconcile unread notification counts", "error", err)
+		return
+	}
+	if fixed > 0 {
+		//nolint:sloglint // Warning logging doesn't need structured logger injection
+		slog.Warn("Corrected drifted unread notification counts", "count", fixed)
+	}
+}
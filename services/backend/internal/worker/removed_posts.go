@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+)
+
+// removedPostPurgeInterval is how often removed post content is checked for expiry.
+const removedPostPurgeInterval = time.Hour
+
+// RemovedPostPurgeWorker deletes the saved content of posts the GM removed once
+// they can no longer be restored.
+type RemovedPostPurgeWorker struct {
+	postService *service.PostService
+	interval    time.Duration
+}
+
+// NewRemovedPostPurgeWorker creates a new removed post purge worker.
+func NewRemovedPostPurgeWorker(db *database.DB) *RemovedPostPurgeWorker {
+	return &RemovedPostPurgeWorker{
+		postService: service.NewPostService(db.Pool),
+		interval:    removedPostPurgeInterval,
+	}
+}
+
+// Run purges expired removed post content on every tick until the context is cancelled.
+func (w *RemovedPostPurgeWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.purgeExpired(ctx)
+		}
+	}
+}
+
+// purgeExpired runs a single purge pass.
+func (w *RemovedPostPurgeWorker) purgeExpired(ctx context.Context) {
+	purged, err := w.postService.PurgeExpiredRemovedPosts(ctx)
+	if err != nil {
+		//nolint:sloglint // Error logging doesn't need structured logger injection
+		slog.Error("Failed to purge removed post content", "error", err)
+		return
+	}
+	if purged > 0 {
+		//nolint:sloglint // Info logging doesn't need structured logger injection
+		slog.Info("Purged removed post content", "count", purged)
+	}
+}
@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+)
+
+// storageReconcileInterval is how often campaign storage usage is checked for drift.
+const storageReconcileInterval = time.Hour
+
+// StorageReconcileWorker resets campaign storage usage that went negative after a
+// file was released twice.
+type StorageReconcileWorker struct {
+	imageService *service.ImageService
+	interval     time.Duration
+}
+
+// NewStorageReconcileWorker creates a new storage reconcile worker. It only touches
+// the database, so no storage client is needed.
+func NewStorageReconcileWorker(db *database.DB) *StorageReconcileWorker {
+	return &StorageReconcileWorker{
+		imageService: service.NewImageService(service.NewQueries(db.Pool), nil),
+		interval:     storageReconcileInterval,
+	}
+}
+
+// Run reconciles storage usage on every tick until the context is cancelled.
+func (w *StorageReconcileWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.reconcile(ctx)
+		}
+	}
+}
+
+// reconcile runs a single reconcile pass.
+func (w *StorageReconcileWorker) reconcile(ctx context.Context) {
+	fixed, err := w.imageService.ReconcileStorageUsage(ctx)
+	if err != nil {
+		//nolint:sloglint // Error logging doesn't need structured logger injection
+		slog.Error("Failed to reconcile campaign storage usage", "error", err)
+		return
+	}
+	if fixed > 0 {
+		//nolint:sloglint // Info logging doesn't need structured logger injection
+		slog.Info("Reset negative campaign storage usage", "campaigns", fixed)
+	}
+}
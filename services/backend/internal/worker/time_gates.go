@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/database/generated"
+	"github.com/tdanbo/vanguard-pbp/services/backend/internal/service"
+)
+
+// timeGateCheckInterval is how often expired time gates are checked for.
+const timeGateCheckInterval = time.Minute
+
+// TimeGateWorker auto-passes characters once a campaign's PC Phase time gate expires
+// and broadcasts the new pass counts, so connected clients update without polling
+// and without waiting for someone to trigger the lazy expiry check. Campaigns that
+// move to GM phase automatically as a result are announced like a pass would.
+type TimeGateWorker struct {
+	phaseService        *service.PhaseService
+	broadcastService    *service.BroadcastService
+	webhookService      *service.WebhookService
+	notificationService *service.NotificationService
+	interval            time.Duration
+}
+
+// NewTimeGateWorker creates a new time gate worker.
+func NewTimeGateWorker(db *database.DB) *TimeGateWorker {
+	return &TimeGateWorker{
+		phaseService:        service.NewPhaseService(db.Pool),
+		broadcastService:    service.NewBroadcastServiceFromEnv(),
+		webhookService:      service.NewWebhookService(db.Pool),
+		notificationService: service.NewNotificationService(db, service.NewQueries(db.Pool)),
+		interval:            timeGateCheckInterval,
+	}
+}
+
+// Run processes expired time gates on every tick until the context is cancelled.
+func (w *TimeGateWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.expireTimeGates(ctx)
+		}
+	}
+}
+
+// expireTimeGates runs a single pass over campaigns with expired time gates.
+func (w *TimeGateWorker) expireTimeGates(ctx context.Context) {
+	expired, err := w.phaseService.AutoPassExpiredTimeGates(ctx)
+	if err != nil {
+		//nolint:sloglint // Error logging doesn't need structured logger injection
+		slog.Error("Failed to auto-pass expired time gates", "error", err)
+	}
+
+	for _, gate := range expired {
+		if gate.Status != nil && w.broadcastService != nil {
+			w.broadcastService.BroadcastPhaseStatus(ctx, gate.CampaignID, gate.Status)
+		}
+		if gate.Transitioned != nil {
+			w.announceAutoTransition(ctx, gate.Transitioned)
+		}
+	}
+	if len(expired) > 0 {
+		//nolint:sloglint // Info logging doesn't need structured logger injection
+		slog.Info("Auto-passed characters for expired time gates", "campaigns", len(expired))
+	}
+}
+
+// announceAutoTransition tells clients, webhooks, and members that the campaign
+// moved to GM phase on its own.
+func (w *TimeGateWorker) announceAutoTransition(ctx context.Context, campaign *generated.Campaign) {
+	if w.broadcastService != nil {
+		w.broadcastService.BroadcastPhaseTransition(
+			ctx, campaign.ID, service.PhasePCPhase, service.PhaseGMPhase, "all_passed",
+		)
+	}
+	go w.webhookService.Deliver(ctx, campaign.ID, service.EventPhaseTransition, map[string]any{
+		"fromPhase": service.PhasePCPhase,
+		"toPhase":   service.PhaseGMPhase,
+		"reason":    "all_passed",
+	})
+	if err := w.notificationService.NotifyPhaseAutoTransitioned(ctx, campaign.ID, campaign.Title); err != nil {
+		//nolint:sloglint // Error logging doesn't need structured logger injection
+		slog.Error("Failed to send auto transition notification", "error", err)
+	}
+}
@@ -165,7 +165,7 @@ export function CreateCharacterDialog({
             toast({
               variant: 'destructive',
               title: `${typeLabel} created but avatar upload failed`,
-              description: 'Campaign has reached the 500MB storage limit.',
+              description: 'Campaign has reached its storage limit.',
             })
           } else {
             toast({
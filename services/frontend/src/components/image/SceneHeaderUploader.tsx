@@ -106,7 +106,7 @@ export function SceneHeaderUploader({
           toast({
             variant: 'destructive',
             title: 'Storage full',
-            description: 'Campaign has reached the 500MB storage limit. Delete some images to free space.',
+            description: 'Campaign has reached its storage limit. Delete some images to free space.',
           })
         } else {
           toast({
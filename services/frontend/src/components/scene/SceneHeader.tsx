@@ -5,6 +5,7 @@ interface SceneHeaderProps {
     title: string
     description?: string | null
     header_image_url?: string | null
+    summary?: string | null
   }
   className?: string
 }
@@ -28,6 +29,16 @@ export function SceneHeader({
           )}
         </div>
       </div>
+      {scene.summary && (
+        <div className="max-w-2xl mx-auto px-4 pb-4 text-center">
+          <p className="text-xs uppercase tracking-wide text-muted-foreground mb-1">
+            Previously...
+          </p>
+          <p className="text-sm text-muted-foreground whitespace-pre-line">
+            {scene.summary}
+          </p>
+        </div>
+      )}
     </div>
   )
 }
@@ -5,6 +5,7 @@ import type {
   PhaseTransitionEvent,
   PassStateEvent,
   TimeGateWarningEvent,
+  PhaseStatusEvent,
 } from '@/types'
 import type { RealtimeChannel } from '@supabase/supabase-js'
 
@@ -12,6 +13,7 @@ interface CampaignEventHandlers {
   onPhaseTransition?: (event: PhaseTransitionEvent) => void
   onPassStateChanged?: (event: PassStateEvent) => void
   onTimeGateWarning?: (event: TimeGateWarningEvent) => void
+  onPhaseStatus?: (event: PhaseStatusEvent) => void
   onAnyEvent?: (event: RealtimeEvent) => void
 }
 
@@ -49,6 +51,9 @@ export function useCampaignSubscription({
       case 'timegate_warning':
         currentHandlers.onTimeGateWarning?.(event as TimeGateWarningEvent)
         break
+      case 'phase_status':
+        currentHandlers.onPhaseStatus?.(event as PhaseStatusEvent)
+        break
     }
 
     // Always call the catch-all handler
@@ -74,6 +79,7 @@ export function useCampaignSubscription({
       .on('broadcast', { event: 'phase_transition' }, handleBroadcast)
       .on('broadcast', { event: 'pass_state_changed' }, handleBroadcast)
       .on('broadcast', { event: 'timegate_warning' }, handleBroadcast)
+      .on('broadcast', { event: 'phase_status' }, handleBroadcast)
       .subscribe((status) => {
         if (status === 'SUBSCRIBED') {
           console.log(`Subscribed to campaign channel: ${channelName}`)
@@ -2,9 +2,12 @@ import { useState, useEffect, useCallback, useRef } from 'react'
 import { api } from '@/lib/api'
 import { supabase } from '@/lib/supabase'
 import type {
+  EmailDelivery,
   Notification,
   NotificationPreferences,
+  NotificationType,
   QuietHours,
+  ResolvedLink,
   UpdateNotificationPreferencesRequest,
   UpdateQuietHoursRequest,
 } from '@/types'
@@ -29,6 +32,10 @@ interface UseNotificationsOptions {
   autoFetch?: boolean
   /** Limit per page */
   limit?: number
+  /** Only list notifications of these types */
+  types?: NotificationType[]
+  /** Only list read (true) or unread (false) notifications */
+  read?: boolean
   /** Subscribe to real-time notification updates */
   enableRealtime?: boolean
   /** Callback when new notification arrives */
@@ -39,6 +46,8 @@ export function useNotifications(options: UseNotificationsOptions = {}) {
   const {
     autoFetch = true,
     limit = 50,
+    types,
+    read,
     enableRealtime = true,
     onNewNotification,
   } = options
@@ -49,6 +58,9 @@ export function useNotifications(options: UseNotificationsOptions = {}) {
   const [error, setError] = useState<string | null>(null)
   const [hasMore, setHasMore] = useState(true)
 
+  // A string key keeps callbacks stable when callers pass a new array each render
+  const typesKey = types?.join(',') ?? ''
+
   const offsetRef = useRef(0)
   const channelRef = useRef<RealtimeChannel | null>(null)
   const onNewNotificationRef = useRef(onNewNotification)
@@ -66,9 +78,13 @@ export function useNotifications(options: UseNotificationsOptions = {}) {
 
     try {
       const currentOffset = reset ? 0 : offsetRef.current
-      const response = await api<NotificationsResponse>(
-        `/api/v1/notifications?limit=${limit}&offset=${currentOffset}`
-      )
+      const params = new URLSearchParams({
+        limit: String(limit),
+        offset: String(currentOffset),
+      })
+      if (typesKey) params.set('type', typesKey)
+      if (read !== undefined) params.set('read', String(read))
+      const response = await api<NotificationsResponse>(`/api/v1/notifications?${params}`)
 
       if (reset) {
         setNotifications(response.notifications || [])
@@ -84,7 +100,7 @@ export function useNotifications(options: UseNotificationsOptions = {}) {
     } finally {
       setIsLoading(false)
     }
-  }, [isLoading, limit])
+  }, [isLoading, limit, typesKey, read])
 
   // Fetch unread count
   const fetchUnreadCount = useCallback(async () => {
@@ -111,6 +127,11 @@ export function useNotifications(options: UseNotificationsOptions = {}) {
     return updated
   }, [])
 
+  // Check a notification link before following it
+  const resolveLink = useCallback(async (link: string) => {
+    return api<ResolvedLink>(`/api/v1/links/resolve?link=${encodeURIComponent(link)}`)
+  }, [])
+
   // Mark all notifications as read
   const markAllAsRead = useCallback(async () => {
     const response = await api<MarkAllAsReadResponse>(
@@ -154,12 +175,17 @@ export function useNotifications(options: UseNotificationsOptions = {}) {
 
   // Add new notification to the top of the list
   const addNotification = useCallback((notification: Notification) => {
-    setNotifications(prev => [notification, ...prev])
+    const matchesFilter =
+      (!typesKey || typesKey.split(',').includes(notification.type)) &&
+      (read === undefined || notification.is_read === read)
+    if (matchesFilter) {
+      setNotifications(prev => [notification, ...prev])
+    }
     if (!notification.is_read) {
       setUnreadCount(prev => prev + 1)
     }
     onNewNotificationRef.current?.(notification)
-  }, [])
+  }, [typesKey, read])
 
   // Set up real-time subscription for new notifications
   useEffect(() => {
@@ -216,6 +242,7 @@ export function useNotifications(options: UseNotificationsOptions = {}) {
     hasMore,
     markAsRead,
     markAllAsRead,
+    resolveLink,
     deleteNotification,
     loadMore,
     refresh,
@@ -263,6 +290,14 @@ export function useNotificationPreferences() {
     }
   }, [])
 
+  // Send a test notification to check email delivery
+  const sendTestNotification = useCallback(async (bypassQuietHours = false) => {
+    return api<EmailDelivery>('/api/v1/notifications/test', {
+      method: 'POST',
+      body: { bypassQuietHours },
+    })
+  }, [])
+
   useEffect(() => {
     fetchPreferences()
   }, [fetchPreferences])
@@ -272,6 +307,7 @@ export function useNotificationPreferences() {
     isLoading,
     error,
     updatePreferences,
+    sendTestNotification,
     refresh: fetchPreferences,
   }
 }
@@ -3,10 +3,14 @@ import { supabase } from '@/lib/supabase'
 import type {
   RealtimeEvent,
   PostEvent,
+  PostReactionEvent,
   ComposeLockEvent,
   PassStateEvent,
   CharacterPresenceEvent,
+  CharacterUpdatedEvent,
   RollEvent,
+  OocMessageEvent,
+  TypingPresenceEvent,
 } from '@/types'
 import type { RealtimeChannel } from '@supabase/supabase-js'
 
@@ -14,13 +18,17 @@ interface SceneEventHandlers {
   onPostCreated?: (event: PostEvent) => void
   onPostUpdated?: (event: PostEvent) => void
   onPostDeleted?: (event: PostEvent) => void
+  onPostReaction?: (event: PostReactionEvent) => void
   onComposeLockAcquired?: (event: ComposeLockEvent) => void
   onComposeLockReleased?: (event: ComposeLockEvent) => void
   onPassStateChanged?: (event: PassStateEvent) => void
   onCharacterJoined?: (event: CharacterPresenceEvent) => void
   onCharacterLeft?: (event: CharacterPresenceEvent) => void
+  onCharacterUpdated?: (event: CharacterUpdatedEvent) => void
   onRollCreated?: (event: RollEvent) => void
   onRollResolved?: (event: RollEvent) => void
+  onOocMessageCreated?: (event: OocMessageEvent) => void
+  onTypingPresence?: (event: TypingPresenceEvent) => void
   onAnyEvent?: (event: RealtimeEvent) => void
 }
 
@@ -90,6 +98,9 @@ export function useSceneSubscription({
       case 'post_deleted':
         currentHandlers.onPostDeleted?.(event as PostEvent)
         break
+      case 'post_reaction':
+        currentHandlers.onPostReaction?.(event as PostReactionEvent)
+        break
       case 'compose_lock_acquired':
         currentHandlers.onComposeLockAcquired?.(event as ComposeLockEvent)
         break
@@ -105,12 +116,21 @@ export function useSceneSubscription({
       case 'character_left':
         currentHandlers.onCharacterLeft?.(event as CharacterPresenceEvent)
         break
+      case 'character_updated':
+        currentHandlers.onCharacterUpdated?.(event as CharacterUpdatedEvent)
+        break
       case 'roll_created':
         currentHandlers.onRollCreated?.(event as RollEvent)
         break
       case 'roll_resolved':
         currentHandlers.onRollResolved?.(event as RollEvent)
         break
+      case 'ooc_message_created':
+        currentHandlers.onOocMessageCreated?.(event as OocMessageEvent)
+        break
+      case 'typing_presence':
+        currentHandlers.onTypingPresence?.(event as TypingPresenceEvent)
+        break
     }
 
     // Always call the catch-all handler
@@ -136,13 +156,17 @@ export function useSceneSubscription({
       .on('broadcast', { event: 'post_created' }, handleBroadcast)
       .on('broadcast', { event: 'post_updated' }, handleBroadcast)
       .on('broadcast', { event: 'post_deleted' }, handleBroadcast)
+      .on('broadcast', { event: 'post_reaction' }, handleBroadcast)
       .on('broadcast', { event: 'compose_lock_acquired' }, handleBroadcast)
       .on('broadcast', { event: 'compose_lock_released' }, handleBroadcast)
       .on('broadcast', { event: 'pass_state_changed' }, handleBroadcast)
       .on('broadcast', { event: 'character_joined' }, handleBroadcast)
       .on('broadcast', { event: 'character_left' }, handleBroadcast)
+      .on('broadcast', { event: 'character_updated' }, handleBroadcast)
       .on('broadcast', { event: 'roll_created' }, handleBroadcast)
       .on('broadcast', { event: 'roll_resolved' }, handleBroadcast)
+      .on('broadcast', { event: 'ooc_message_created' }, handleBroadcast)
+      .on('broadcast', { event: 'typing_presence' }, handleBroadcast)
       .subscribe((status) => {
         if (status === 'SUBSCRIBED') {
           console.log(`Subscribed to scene channel: ${channelName}`)
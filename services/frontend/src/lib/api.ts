@@ -8,12 +8,21 @@ interface RequestOptions {
   headers?: Record<string, string>
 }
 
+// One invalid request field, listed on VALIDATION_ERROR responses
+export interface FieldError {
+  field: string
+  message: string
+}
+
 export class APIError extends Error {
   constructor(
     public code: string,
     message: string,
     public status: number,
-    public requestId?: string
+    public requestId?: string,
+    // Set on TOKEN_EXPIRED: when the rejected token expired
+    public expiresAt?: string,
+    public fields?: FieldError[]
   ) {
     super(message)
     this.name = 'APIError'
@@ -62,7 +71,9 @@ export async function api<T>(
       data.error?.code || 'UNKNOWN_ERROR',
       data.error?.message || 'An error occurred',
       response.status,
-      data.error?.requestId
+      data.error?.requestId,
+      data.error?.expiresAt,
+      data.error?.fields
     )
   }
 
@@ -103,7 +114,9 @@ export async function apiUpload<T>(endpoint: string, file: File): Promise<T> {
       data.error?.code || 'UNKNOWN_ERROR',
       data.error?.message || 'An error occurred',
       response.status,
-      data.error?.requestId
+      data.error?.requestId,
+      data.error?.expiresAt,
+      data.error?.fields
     )
   }
 
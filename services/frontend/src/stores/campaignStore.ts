@@ -12,12 +12,16 @@ import type {
   Scene,
   CreateSceneRequest,
   UpdateSceneRequest,
+  BulkArchiveScenesRequest,
+  CloneSceneRequest,
+  CloneSceneResponse,
   CreateSceneResponse,
   ListScenesResponse,
   Post,
   CreatePostRequest,
   UpdatePostRequest,
   ListPostsResponse,
+  GrantWitnessResult,
   PhaseStatus,
   CampaignPassSummary,
   CampaignPhase,
@@ -88,6 +92,10 @@ interface CampaignState {
   updateScene: (campaignId: string, sceneId: string, data: UpdateSceneRequest) => Promise<void>
   archiveScene: (campaignId: string, sceneId: string) => Promise<void>
   unarchiveScene: (campaignId: string, sceneId: string) => Promise<void>
+  freezeScene: (campaignId: string, sceneId: string) => Promise<void>
+  unfreezeScene: (campaignId: string, sceneId: string) => Promise<void>
+  bulkArchiveScenes: (campaignId: string, sceneIds: string[], archive: boolean) => Promise<void>
+  cloneScene: (campaignId: string, sceneId: string, data?: CloneSceneRequest) => Promise<CloneSceneResponse>
   deleteScene: (campaignId: string, sceneId: string) => Promise<void>
   addCharacterToScene: (campaignId: string, sceneId: string, characterId: string) => Promise<void>
   removeCharacterFromScene: (campaignId: string, sceneId: string, characterId: string) => Promise<void>
@@ -97,9 +105,21 @@ interface CampaignState {
   createPost: (campaignId: string, data: CreatePostRequest, submitImmediately?: boolean) => Promise<Post>
   updatePost: (postId: string, data: UpdatePostRequest) => Promise<Post>
   deletePost: (postId: string) => Promise<void>
+  removePost: (postId: string) => Promise<Post>
+  restorePost: (postId: string) => Promise<Post>
   submitPost: (postId: string, isHidden?: boolean) => Promise<Post>
   unhidePost: (postId: string) => Promise<Post>
+  pinPost: (postId: string) => Promise<Post>
+  unpinPost: (postId: string) => Promise<Post>
+  movePost: (postId: string, targetSceneId: string) => Promise<Post>
+  attributePost: (postId: string, characterId: string) => Promise<Post>
   updatePostWitnesses: (postId: string, witnesses: string[]) => Promise<Post>
+  grantWitness: (
+    campaignId: string,
+    sceneId: string,
+    characterId: string,
+    fromPostId?: string
+  ) => Promise<GrantWitnessResult>
   clearPosts: () => void
 
   // Phase management
@@ -118,6 +138,13 @@ interface CampaignState {
   setCurrentCampaign: (campaign: Campaign | null) => void
 }
 
+// Matches the server's scene post order: pinned posts first, then oldest first.
+function sortPinnedFirst(posts: Post[]): Post[] {
+  return [...posts].sort(
+    (a, b) => Number(b.isPinned) - Number(a.isPinned) || a.createdAt.localeCompare(b.createdAt)
+  )
+}
+
 export const useCampaignStore = create<CampaignState>((set, get) => ({
   campaigns: [],
   currentCampaign: null,
@@ -592,6 +619,76 @@ export const useCampaignStore = create<CampaignState>((set, get) => ({
     }
   },
 
+  freezeScene: async (campaignId: string, sceneId: string) => {
+    set({ loadingScenes: true, error: null })
+    try {
+      const scene = await api<Scene>(`/api/v1/campaigns/${campaignId}/scenes/${sceneId}/freeze`, {
+        method: 'POST',
+      })
+      set((state) => ({
+        scenes: state.scenes.map((s) => (s.id === sceneId ? scene : s)),
+        loadingScenes: false,
+      }))
+    } catch (error) {
+      set({ error: (error as Error).message, loadingScenes: false })
+      throw error
+    }
+  },
+
+  unfreezeScene: async (campaignId: string, sceneId: string) => {
+    set({ loadingScenes: true, error: null })
+    try {
+      const scene = await api<Scene>(`/api/v1/campaigns/${campaignId}/scenes/${sceneId}/unfreeze`, {
+        method: 'POST',
+      })
+      set((state) => ({
+        scenes: state.scenes.map((s) => (s.id === sceneId ? scene : s)),
+        loadingScenes: false,
+      }))
+    } catch (error) {
+      set({ error: (error as Error).message, loadingScenes: false })
+      throw error
+    }
+  },
+
+  bulkArchiveScenes: async (campaignId: string, sceneIds: string[], archive: boolean) => {
+    set({ loadingScenes: true, error: null })
+    try {
+      const body: BulkArchiveScenesRequest = { sceneIds, archive }
+      const response = await api<{ scenes: Scene[] }>(`/api/v1/campaigns/${campaignId}/scenes/bulk-archive`, {
+        method: 'POST',
+        body,
+      })
+      const updated = new Map(response.scenes.map((scene) => [scene.id, scene]))
+      set((state) => ({
+        scenes: state.scenes.map((s) => updated.get(s.id) ?? s),
+        loadingScenes: false,
+      }))
+    } catch (error) {
+      set({ error: (error as Error).message, loadingScenes: false })
+      throw error
+    }
+  },
+
+  cloneScene: async (campaignId: string, sceneId: string, data: CloneSceneRequest = {}) => {
+    set({ loadingScenes: true, error: null })
+    try {
+      const response = await api<CloneSceneResponse>(`/api/v1/campaigns/${campaignId}/scenes/${sceneId}/clone`, {
+        method: 'POST',
+        body: data,
+      })
+      set((state) => ({
+        scenes: [...state.scenes, response.scene],
+        sceneWarning: response.warning || null,
+        loadingScenes: false,
+      }))
+      return response
+    } catch (error) {
+      set({ error: (error as Error).message, loadingScenes: false })
+      throw error
+    }
+  },
+
   deleteScene: async (campaignId: string, sceneId: string) => {
     set({ loadingScenes: true, error: null })
     try {
@@ -715,6 +812,40 @@ export const useCampaignStore = create<CampaignState>((set, get) => ({
     }
   },
 
+  removePost: async (postId: string) => {
+    set({ loadingPosts: true, error: null })
+    try {
+      const post = await api<Post>(`/api/v1/posts/${postId}?mode=tombstone`, {
+        method: 'DELETE',
+      })
+      set((state) => ({
+        posts: sortPinnedFirst(state.posts.map((p) => (p.id === postId ? post : p))),
+        loadingPosts: false,
+      }))
+      return post
+    } catch (error) {
+      set({ error: (error as Error).message, loadingPosts: false })
+      throw error
+    }
+  },
+
+  restorePost: async (postId: string) => {
+    set({ loadingPosts: true, error: null })
+    try {
+      const post = await api<Post>(`/api/v1/posts/${postId}/restore`, {
+        method: 'POST',
+      })
+      set((state) => ({
+        posts: state.posts.map((p) => (p.id === postId ? post : p)),
+        loadingPosts: false,
+      }))
+      return post
+    } catch (error) {
+      set({ error: (error as Error).message, loadingPosts: false })
+      throw error
+    }
+  },
+
   submitPost: async (postId: string, isHidden = false) => {
     set({ loadingPosts: true, error: null })
     try {
@@ -750,6 +881,77 @@ export const useCampaignStore = create<CampaignState>((set, get) => ({
     }
   },
 
+  pinPost: async (postId: string) => {
+    set({ loadingPosts: true, error: null })
+    try {
+      const post = await api<Post>(`/api/v1/posts/${postId}/pin`, {
+        method: 'POST',
+      })
+      set((state) => ({
+        posts: sortPinnedFirst(state.posts.map((p) => (p.id === postId ? post : p))),
+        loadingPosts: false,
+      }))
+      return post
+    } catch (error) {
+      set({ error: (error as Error).message, loadingPosts: false })
+      throw error
+    }
+  },
+
+  unpinPost: async (postId: string) => {
+    set({ loadingPosts: true, error: null })
+    try {
+      const post = await api<Post>(`/api/v1/posts/${postId}/unpin`, {
+        method: 'POST',
+      })
+      set((state) => ({
+        posts: sortPinnedFirst(state.posts.map((p) => (p.id === postId ? post : p))),
+        loadingPosts: false,
+      }))
+      return post
+    } catch (error) {
+      set({ error: (error as Error).message, loadingPosts: false })
+      throw error
+    }
+  },
+
+  movePost: async (postId: string, targetSceneId: string) => {
+    set({ loadingPosts: true, error: null })
+    try {
+      const post = await api<Post>(`/api/v1/posts/${postId}/move`, {
+        method: 'POST',
+        body: { targetSceneId },
+      })
+      // The loaded posts belong to the scene the post left
+      set((state) => ({
+        posts: state.posts.filter((p) => p.id !== postId),
+        loadingPosts: false,
+      }))
+      return post
+    } catch (error) {
+      set({ error: (error as Error).message, loadingPosts: false })
+      throw error
+    }
+  },
+
+  attributePost: async (postId: string, characterId: string) => {
+    set({ loadingPosts: true, error: null })
+    try {
+      const post = await api<Post>(`/api/v1/posts/${postId}/attribute`, {
+        method: 'POST',
+        body: { characterId },
+      })
+      set((state) => ({
+        posts: state.posts.map((p) => (p.id === postId ? post : p)),
+        loadingPosts: false,
+      }))
+      return post
+    } catch (error) {
+      set({ error: (error as Error).message, loadingPosts: false })
+      throw error
+    }
+  },
+
   updatePostWitnesses: async (postId: string, witnesses: string[]) => {
     set({ loadingPosts: true, error: null })
     try {
@@ -768,6 +970,32 @@ export const useCampaignStore = create<CampaignState>((set, get) => ({
     }
   },
 
+  grantWitness: async (
+    campaignId: string,
+    sceneId: string,
+    characterId: string,
+    fromPostId?: string
+  ) => {
+    set({ error: null })
+    try {
+      const result = await api<GrantWitnessResult>(
+        `/api/v1/campaigns/${campaignId}/scenes/${sceneId}/grant-witness`,
+        {
+          method: 'POST',
+          body: { characterId, fromPostId },
+        }
+      )
+      // Pick up the new witness lists
+      if (result.count > 0) {
+        await get().fetchPosts(campaignId, sceneId)
+      }
+      return result
+    } catch (error) {
+      set({ error: (error as Error).message })
+      throw error
+    }
+  },
+
   clearPosts: () => set({ posts: [] }),
 
   // Phase management
@@ -3,10 +3,16 @@ import { api } from '@/lib/api'
 import type {
   Roll,
   CreateRollRequest,
+  CreateRollsRequest,
   OverrideIntentionRequest,
   ManualResolveRequest,
   UnresolvedRoll,
+  UnresolvedRollsPage,
+  UnresolvedRollsQuery,
+  CharacterRollsPage,
+  CharacterRollsQuery,
   DicePreset,
+  SceneRollFilter,
 } from '@/types'
 
 interface RollState {
@@ -14,6 +20,7 @@ interface RollState {
   rolls: Roll[]
   pendingRolls: Roll[]
   unresolvedRolls: UnresolvedRoll[]
+  unresolvedRollsTotal: number
   dicePresets: DicePreset[]
   validDiceTypes: string[]
 
@@ -27,11 +34,13 @@ interface RollState {
 
   // Roll operations
   createRoll: (data: CreateRollRequest) => Promise<Roll>
+  createRolls: (data: CreateRollsRequest) => Promise<Roll[]>
   getRoll: (rollId: string) => Promise<Roll>
   getRollsByPost: (postId: string) => Promise<Roll[]>
   getPendingRollsForCharacter: (characterId: string) => Promise<void>
-  getUnresolvedRollsInCampaign: (campaignId: string) => Promise<void>
-  getRollsInScene: (sceneId: string) => Promise<void>
+  getCharacterRolls: (characterId: string, query?: CharacterRollsQuery) => Promise<CharacterRollsPage>
+  getUnresolvedRollsInCampaign: (campaignId: string, query?: UnresolvedRollsQuery) => Promise<void>
+  getRollsInScene: (sceneId: string, filter?: SceneRollFilter) => Promise<void>
 
   // GM operations
   overrideIntention: (rollId: string, data: OverrideIntentionRequest) => Promise<Roll>
@@ -51,6 +60,7 @@ export const useRollStore = create<RollState>((set) => ({
   rolls: [],
   pendingRolls: [],
   unresolvedRolls: [],
+  unresolvedRollsTotal: 0,
   dicePresets: [],
   validDiceTypes: [],
   loadingRolls: false,
@@ -80,6 +90,26 @@ export const useRollStore = create<RollState>((set) => ({
     }
   },
 
+  createRolls: async (data: CreateRollsRequest) => {
+    set({ loadingRolls: true, error: null })
+    try {
+      const response = await api<{ rolls: Roll[] }>('/api/v1/rolls/batch', {
+        method: 'POST',
+        body: data,
+      })
+      const pending = response.rolls.filter((roll) => roll.status === 'pending')
+      set((state) => ({
+        rolls: [...state.rolls, ...response.rolls],
+        pendingRolls: [...state.pendingRolls, ...pending],
+        loadingRolls: false,
+      }))
+      return response.rolls
+    } catch (error) {
+      set({ error: (error as Error).message, loadingRolls: false })
+      throw error
+    }
+  },
+
   getRoll: async (rollId: string) => {
     set({ loadingRolls: true, error: null })
     try {
@@ -119,21 +149,49 @@ export const useRollStore = create<RollState>((set) => ({
     }
   },
 
-  getUnresolvedRollsInCampaign: async (campaignId: string) => {
+  getCharacterRolls: async (characterId: string, query?: CharacterRollsQuery) => {
+    const params = new URLSearchParams()
+    if (query?.limit !== undefined) params.set('limit', String(query.limit))
+    if (query?.cursor) params.set('cursor', query.cursor)
+    if (query?.includeInvalidated) params.set('includeInvalidated', 'true')
+    const qs = params.toString() ? `?${params.toString()}` : ''
+    try {
+      const page = await api<CharacterRollsPage>(`/api/v1/characters/${characterId}/rolls${qs}`)
+      return { rolls: page.rolls ?? [], nextCursor: page.nextCursor ?? '' }
+    } catch (error) {
+      set({ error: (error as Error).message })
+      throw error
+    }
+  },
+
+  getUnresolvedRollsInCampaign: async (campaignId: string, query?: UnresolvedRollsQuery) => {
     set({ loadingUnresolvedRolls: true, error: null })
     try {
-      const response = await api<{ rolls: UnresolvedRoll[] }>(`/api/v1/campaigns/${campaignId}/rolls/unresolved`)
-      set({ unresolvedRolls: response.rolls ?? [], loadingUnresolvedRolls: false })
+      const params = new URLSearchParams()
+      if (query?.limit !== undefined) params.set('limit', String(query.limit))
+      if (query?.offset !== undefined) params.set('offset', String(query.offset))
+      if (query?.since) params.set('since', query.since)
+      const qs = params.toString() ? `?${params.toString()}` : ''
+      const response = await api<UnresolvedRollsPage>(`/api/v1/campaigns/${campaignId}/rolls/unresolved${qs}`)
+      set({
+        unresolvedRolls: response.rolls ?? [],
+        unresolvedRollsTotal: response.total ?? 0,
+        loadingUnresolvedRolls: false,
+      })
     } catch (error) {
       set({ error: (error as Error).message, loadingUnresolvedRolls: false })
       throw error
     }
   },
 
-  getRollsInScene: async (sceneId: string) => {
+  getRollsInScene: async (sceneId: string, filter?: SceneRollFilter) => {
     set({ loadingRolls: true, error: null })
     try {
-      const response = await api<{ rolls: Roll[] }>(`/api/v1/scenes/${sceneId}/rolls`)
+      const params = new URLSearchParams()
+      if (filter?.status) params.set('status', filter.status)
+      if (filter?.characterId) params.set('characterId', filter.characterId)
+      const query = params.toString() ? `?${params.toString()}` : ''
+      const response = await api<{ rolls: Roll[] }>(`/api/v1/scenes/${sceneId}/rolls${query}`)
       set({ rolls: response.rolls ?? [], loadingRolls: false })
     } catch (error) {
       set({ error: (error as Error).message, loadingRolls: false })
@@ -172,6 +230,9 @@ export const useRollStore = create<RollState>((set) => ({
       set((state) => ({
         rolls: state.rolls.map(r => r.id === rollId ? roll : r),
         unresolvedRolls: state.unresolvedRolls.filter(r => r.id !== rollId),
+        unresolvedRollsTotal: state.unresolvedRolls.some(r => r.id === rollId)
+          ? state.unresolvedRollsTotal - 1
+          : state.unresolvedRollsTotal,
         pendingRolls: state.pendingRolls.filter(r => r.id !== rollId),
         loadingRolls: false,
       }))
@@ -191,6 +252,9 @@ export const useRollStore = create<RollState>((set) => ({
       set((state) => ({
         rolls: state.rolls.map(r => r.id === rollId ? roll : r),
         unresolvedRolls: state.unresolvedRolls.filter(r => r.id !== rollId),
+        unresolvedRollsTotal: state.unresolvedRolls.some(r => r.id === rollId)
+          ? state.unresolvedRollsTotal - 1
+          : state.unresolvedRollsTotal,
         pendingRolls: state.pendingRolls.filter(r => r.id !== rollId),
         loadingRolls: false,
       }))
@@ -224,5 +288,5 @@ export const useRollStore = create<RollState>((set) => ({
   },
 
   clearError: () => set({ error: null }),
-  clearRolls: () => set({ rolls: [], pendingRolls: [], unresolvedRolls: [] }),
+  clearRolls: () => set({ rolls: [], pendingRolls: [], unresolvedRolls: [], unresolvedRollsTotal: 0 }),
 }))
@@ -44,6 +44,50 @@ export type Database = {
           },
         ]
       }
+      campaign_bot_tokens: {
+        Row: {
+          campaign_id: string
+          created_at: string
+          created_by: string | null
+          id: string
+          last_used_at: string | null
+          name: string
+          revoked_at: string | null
+          token_hash: string
+          token_prefix: string
+        }
+        Insert: {
+          campaign_id: string
+          created_at?: string
+          created_by?: string | null
+          id?: string
+          last_used_at?: string | null
+          name: string
+          revoked_at?: string | null
+          token_hash: string
+          token_prefix: string
+        }
+        Update: {
+          campaign_id?: string
+          created_at?: string
+          created_by?: string | null
+          id?: string
+          last_used_at?: string | null
+          name?: string
+          revoked_at?: string | null
+          token_hash?: string
+          token_prefix?: string
+        }
+        Relationships: [
+          {
+            foreignKeyName: "campaign_bot_tokens_campaign_id_fkey"
+            columns: ["campaign_id"]
+            isOneToOne: false
+            referencedRelation: "campaigns"
+            referencedColumns: ["id"]
+          },
+        ]
+      }
       campaign_members: {
         Row: {
           alias: string | null
@@ -79,6 +123,44 @@ export type Database = {
           },
         ]
       }
+      campaign_webhooks: {
+        Row: {
+          campaign_id: string
+          created_at: string
+          enabled: boolean
+          events: string[]
+          secret: string
+          updated_at: string
+          url: string
+        }
+        Insert: {
+          campaign_id: string
+          created_at?: string
+          enabled?: boolean
+          events?: string[]
+          secret: string
+          updated_at?: string
+          url: string
+        }
+        Update: {
+          campaign_id?: string
+          created_at?: string
+          enabled?: boolean
+          events?: string[]
+          secret?: string
+          updated_at?: string
+          url?: string
+        }
+        Relationships: [
+          {
+            foreignKeyName: "campaign_webhooks_campaign_id_fkey"
+            columns: ["campaign_id"]
+            isOneToOne: true
+            referencedRelation: "campaigns"
+            referencedColumns: ["id"]
+          },
+        ]
+      }
       campaigns: {
         Row: {
           created_at: string
@@ -167,8 +249,11 @@ export type Database = {
           created_at: string
           description: string | null
           display_name: string
+          gm_notes: string | null
           id: string
           is_archived: boolean
+          pronouns: string | null
+          tags: string[]
           updated_at: string
         }
         Insert: {
@@ -178,8 +263,11 @@ export type Database = {
           created_at?: string
           description?: string | null
           display_name: string
+          gm_notes?: string | null
           id?: string
           is_archived?: boolean
+          pronouns?: string | null
+          tags?: string[]
           updated_at?: string
         }
         Update: {
@@ -189,8 +277,11 @@ export type Database = {
           created_at?: string
           description?: string | null
           display_name?: string
+          gm_notes?: string | null
           id?: string
           is_archived?: boolean
+          pronouns?: string | null
+          tags?: string[]
           updated_at?: string
         }
         Relationships: [
@@ -332,6 +423,47 @@ export type Database = {
         }
         Relationships: []
       }
+      gm_audit_log: {
+        Row: {
+          action: string
+          campaign_id: string
+          created_at: string
+          detail: Json
+          gm_user_id: string | null
+          id: string
+          target_id: string | null
+          target_type: string
+        }
+        Insert: {
+          action: string
+          campaign_id: string
+          created_at?: string
+          detail?: Json
+          gm_user_id?: string | null
+          id?: string
+          target_id?: string | null
+          target_type: string
+        }
+        Update: {
+          action?: string
+          campaign_id?: string
+          created_at?: string
+          detail?: Json
+          gm_user_id?: string | null
+          id?: string
+          target_id?: string | null
+          target_type?: string
+        }
+        Relationships: [
+          {
+            foreignKeyName: "gm_audit_log_campaign_id_fkey"
+            columns: ["campaign_id"]
+            isOneToOne: false
+            referencedRelation: "campaigns"
+            referencedColumns: ["id"]
+          },
+        ]
+      }
       invite_links: {
         Row: {
           campaign_id: string
@@ -383,6 +515,7 @@ export type Database = {
           email_frequency: Database["public"]["Enums"]["notification_frequency"]
           id: string
           in_app_enabled: boolean
+          type_preferences: Json
           updated_at: string
           user_id: string
         }
@@ -392,6 +525,7 @@ export type Database = {
           email_frequency?: Database["public"]["Enums"]["notification_frequency"]
           id?: string
           in_app_enabled?: boolean
+          type_preferences?: Json
           updated_at?: string
           user_id: string
         }
@@ -401,6 +535,7 @@ export type Database = {
           email_frequency?: Database["public"]["Enums"]["notification_frequency"]
           id?: string
           in_app_enabled?: boolean
+          type_preferences?: Json
           updated_at?: string
           user_id?: string
         }
@@ -457,6 +592,7 @@ export type Database = {
           post_id: string | null
           read_at: string | null
           scene_id: string | null
+          snooze_until: string | null
           title: string
           type: string
           user_id: string
@@ -476,6 +612,7 @@ export type Database = {
           post_id?: string | null
           read_at?: string | null
           scene_id?: string | null
+          snooze_until?: string | null
           title: string
           type: string
           user_id: string
@@ -495,6 +632,7 @@ export type Database = {
           post_id?: string | null
           read_at?: string | null
           scene_id?: string | null
+          snooze_until?: string | null
           title?: string
           type?: string
           user_id?: string
@@ -530,8 +668,80 @@ export type Database = {
           },
         ]
       }
+      ooc_messages: {
+        Row: {
+          body: string
+          created_at: string
+          id: string
+          parent_id: string | null
+          scene_id: string
+          user_id: string
+        }
+        Insert: {
+          body: string
+          created_at?: string
+          id?: string
+          parent_id?: string | null
+          scene_id: string
+          user_id: string
+        }
+        Update: {
+          body?: string
+          created_at?: string
+          id?: string
+          parent_id?: string | null
+          scene_id?: string
+          user_id?: string
+        }
+        Relationships: [
+          {
+            foreignKeyName: "ooc_messages_parent_id_fkey"
+            columns: ["parent_id"]
+            isOneToOne: false
+            referencedRelation: "ooc_messages"
+            referencedColumns: ["id"]
+          },
+          {
+            foreignKeyName: "ooc_messages_scene_id_fkey"
+            columns: ["scene_id"]
+            isOneToOne: false
+            referencedRelation: "scenes"
+            referencedColumns: ["id"]
+          },
+        ]
+      }
+      post_reactions: {
+        Row: {
+          created_at: string
+          emoji: string
+          post_id: string
+          user_id: string
+        }
+        Insert: {
+          created_at?: string
+          emoji: string
+          post_id: string
+          user_id: string
+        }
+        Update: {
+          created_at?: string
+          emoji?: string
+          post_id?: string
+          user_id?: string
+        }
+        Relationships: [
+          {
+            foreignKeyName: "post_reactions_post_id_fkey"
+            columns: ["post_id"]
+            isOneToOne: false
+            referencedRelation: "posts"
+            referencedColumns: ["id"]
+          },
+        ]
+      }
       posts: {
         Row: {
+          authored_by_gm: boolean
           blocks: Json
           character_id: string | null
           created_at: string
@@ -541,7 +751,9 @@ export type Database = {
           is_draft: boolean
           is_hidden: boolean
           is_locked: boolean
+          is_system: boolean
           locked_at: string | null
+          mentions: string[]
           modifier: number | null
           ooc_text: string | null
           scene_id: string
@@ -550,6 +762,7 @@ export type Database = {
           witnesses: string[]
         }
         Insert: {
+          authored_by_gm?: boolean
           blocks?: Json
           character_id?: string | null
           created_at?: string
@@ -559,7 +772,9 @@ export type Database = {
           is_draft?: boolean
           is_hidden?: boolean
           is_locked?: boolean
+          is_system?: boolean
           locked_at?: string | null
+          mentions?: string[]
           modifier?: number | null
           ooc_text?: string | null
           scene_id: string
@@ -568,6 +783,7 @@ export type Database = {
           witnesses?: string[]
         }
         Update: {
+          authored_by_gm?: boolean
           blocks?: Json
           character_id?: string | null
           created_at?: string
@@ -577,7 +793,9 @@ export type Database = {
           is_draft?: boolean
           is_hidden?: boolean
           is_locked?: boolean
+          is_system?: boolean
           locked_at?: string | null
+          mentions?: string[]
           modifier?: number | null
           ooc_text?: string | null
           scene_id?: string
@@ -650,6 +868,7 @@ export type Database = {
           manual_result: number | null
           manually_resolved_by: string | null
           modifier: number
+          note: string | null
           original_intention: string | null
           overridden_by: string | null
           override_reason: string | null
@@ -660,6 +879,8 @@ export type Database = {
           rolled_at: string | null
           scene_id: string
           status: Database["public"]["Enums"]["roll_status"]
+          success_threshold: number | null
+          successes: number | null
           total: number | null
           was_overridden: boolean
         }
@@ -674,6 +895,7 @@ export type Database = {
           manual_result?: number | null
           manually_resolved_by?: string | null
           modifier?: number
+          note?: string | null
           original_intention?: string | null
           overridden_by?: string | null
           override_reason?: string | null
@@ -684,6 +906,8 @@ export type Database = {
           rolled_at?: string | null
           scene_id: string
           status?: Database["public"]["Enums"]["roll_status"]
+          success_threshold?: number | null
+          successes?: number | null
           total?: number | null
           was_overridden?: boolean
         }
@@ -698,6 +922,7 @@ export type Database = {
           manual_result?: number | null
           manually_resolved_by?: string | null
           modifier?: number
+          note?: string | null
           original_intention?: string | null
           overridden_by?: string | null
           override_reason?: string | null
@@ -708,6 +933,8 @@ export type Database = {
           rolled_at?: string | null
           scene_id?: string
           status?: Database["public"]["Enums"]["roll_status"]
+          success_threshold?: number | null
+          successes?: number | null
           total?: number | null
           was_overridden?: boolean
         }
@@ -735,40 +962,111 @@ export type Database = {
           },
         ]
       }
+      scene_favorites: {
+        Row: {
+          created_at: string
+          scene_id: string
+          user_id: string
+        }
+        Insert: {
+          created_at?: string
+          scene_id: string
+          user_id: string
+        }
+        Update: {
+          created_at?: string
+          scene_id?: string
+          user_id?: string
+        }
+        Relationships: [
+          {
+            foreignKeyName: "scene_favorites_scene_id_fkey"
+            columns: ["scene_id"]
+            isOneToOne: false
+            referencedRelation: "scenes"
+            referencedColumns: ["id"]
+          },
+        ]
+      }
+      scene_reads: {
+        Row: {
+          last_read_at: string
+          last_read_post_id: string | null
+          scene_id: string
+          updated_at: string
+          user_id: string
+        }
+        Insert: {
+          last_read_at: string
+          last_read_post_id?: string | null
+          scene_id: string
+          updated_at?: string
+          user_id: string
+        }
+        Update: {
+          last_read_at?: string
+          last_read_post_id?: string | null
+          scene_id?: string
+          updated_at?: string
+          user_id?: string
+        }
+        Relationships: [
+          {
+            foreignKeyName: "scene_reads_last_read_post_id_fkey"
+            columns: ["last_read_post_id"]
+            isOneToOne: false
+            referencedRelation: "posts"
+            referencedColumns: ["id"]
+          },
+          {
+            foreignKeyName: "scene_reads_scene_id_fkey"
+            columns: ["scene_id"]
+            isOneToOne: false
+            referencedRelation: "scenes"
+            referencedColumns: ["id"]
+          },
+        ]
+      }
       scenes: {
         Row: {
           campaign_id: string
           character_ids: string[]
+          character_order: string[]
           created_at: string
           description: string | null
           header_image_url: string | null
           id: string
           is_archived: boolean
           pass_states: Json
+          sort_order: number
           title: string
           updated_at: string
         }
         Insert: {
           campaign_id: string
           character_ids?: string[]
+          character_order?: string[]
           created_at?: string
           description?: string | null
           header_image_url?: string | null
           id?: string
           is_archived?: boolean
           pass_states?: Json
+          sort_order?: number
           title: string
           updated_at?: string
         }
         Update: {
           campaign_id?: string
           character_ids?: string[]
+          character_order?: string[]
           created_at?: string
           description?: string | null
           header_image_url?: string | null
           id?: string
           is_archived?: boolean
           pass_states?: Json
+          sort_order?: number
           title?: string
           updated_at?: string
         }
@@ -782,6 +1080,33 @@ export type Database = {
           },
         ]
       }
+      user_profiles: {
+        Row: {
+          avatar_url: string | null
+          created_at: string
+          display_name: string | null
+          storage_used_bytes: number
+          updated_at: string
+          user_id: string
+        }
+        Insert: {
+          avatar_url?: string | null
+          created_at?: string
+          display_name?: string | null
+          storage_used_bytes?: number
+          updated_at?: string
+          user_id: string
+        }
+        Update: {
+          avatar_url?: string | null
+          created_at?: string
+          display_name?: string | null
+          storage_used_bytes?: number
+          updated_at?: string
+          user_id?: string
+        }
+        Relationships: []
+      }
     }
     Views: {
       [_ in never]: never
@@ -9,9 +9,23 @@ export interface CampaignSettings {
   oocVisibility: 'all' | 'gm_only'
   characterLimit: 1000 | 3000 | 6000 | 10000
   rollRequestTimeoutHours?: number
+  // Player cap for the campaign, 1-50; the GM does not count toward it
+  maxPlayers?: number
+  // Seconds after posting that players may still edit or delete a post; 0 = no limit
+  playerEditWindowSeconds?: number
+  strictIntentions?: boolean
+  autoTransitionOnAllPassed?: boolean
+  archiveOrphanedCharacters?: boolean
+  dicePresets?: DicePresetExpansion[]
+  narrator?: NarratorPersona
   systemPreset: SystemPreset
 }
 
+export interface NarratorPersona {
+  name: string
+  avatarUrl?: string
+}
+
 export interface SystemPreset {
   name: string
   diceType: string
@@ -80,6 +94,70 @@ export interface UpdateCampaignRequest {
   settings?: Partial<CampaignSettings>
 }
 
+// GM audit log (GET /campaigns/:id/audit)
+export type GMAction =
+  | 'force_transition'
+  | 'force_release_lock'
+  | 'force_release_scene_locks'
+  | 'override_intention'
+  | 'invalidate_roll'
+  | 'remove_member'
+  | 'delete_post'
+  | 'remove_post'
+  | 'restore_post'
+  | 'unhide_post'
+  | 'move_post'
+  | 'attribute_post'
+  | 'create_bot_token'
+  | 'revoke_bot_token'
+
+export interface AuditEntry {
+  id: string
+  gmUserId: string | null
+  action: GMAction
+  targetType: 'campaign' | 'scene' | 'post' | 'roll' | 'compose_lock' | 'user' | 'bot_token'
+  targetId: string | null
+  detail: Record<string, unknown>
+  createdAt: string
+}
+
+export interface AuditLogPage {
+  entries: AuditEntry[]
+  total: number
+  limit: number
+  offset: number
+}
+
+// Campaign bot tokens. `token` is only present in the create response.
+export interface BotToken {
+  id: string
+  name: string
+  tokenPrefix: string
+  token?: string
+  createdBy: string | null
+  lastUsedAt: string | null
+  createdAt: string
+}
+
+export type WebhookEvent = 'post_created' | 'phase_transition' | 'roll_resolved'
+
+export interface CampaignWebhook {
+  campaignId: string
+  url: string
+  secret: string
+  events: WebhookEvent[]
+  enabled: boolean
+  createdAt: string
+  updatedAt: string
+}
+
+export interface SetWebhookRequest {
+  url: string
+  secret?: string
+  events?: WebhookEvent[]
+  enabled?: boolean
+}
+
 // Character types
 export type CharacterType = 'pc' | 'npc'
 
@@ -93,6 +171,9 @@ export interface Character {
   is_archived: boolean
   created_at: string
   updated_at: string
+  pronouns: string | null
+  gm_notes: string | null
+  tags: string[]
   assigned_user_id?: string | null
   assigned_at?: string | null
 }
@@ -108,6 +189,20 @@ export interface UpdateCharacterRequest {
   displayName?: string
   description?: string
   characterType?: CharacterType
+  pronouns?: string
+  gmNotes?: string
+}
+
+// A character's previous avatar (GET .../avatar/history, newest first). At most
+// five are kept; restore one with POST .../avatar/restore.
+export interface AvatarHistoryEntry {
+  id: string
+  url: string
+  replacedAt: string
+}
+
+export interface RestoreAvatarRequest {
+  historyId: string
 }
 
 // Scene types
@@ -120,10 +215,68 @@ export interface Scene {
   description: string | null
   header_image_url: string | null
   character_ids: string[]
+  character_order: string[]
   pass_states: Record<string, PassState>
   is_archived: boolean
+  is_frozen: boolean
+  default_witness_mode: WitnessMode
+  fog_override: FogOverride
+  summary: string | null
+  sort_order: number
   created_at: string
   updated_at: string
+  isFavorite?: boolean
+  unreadCount?: number
+  // Scene list only; post counts and lastPostAt respect fog of war
+  characterCount?: number
+  postCount?: number
+  lastPostAt?: string | null
+}
+
+export interface SceneCharacterMove extends Scene {
+  movedFromSceneId: string | null
+  movedFromSceneTitle: string | null
+}
+
+export interface SceneReadMarker {
+  sceneId: string
+  lastReadPostId: string | null
+  lastReadAt: string | null
+}
+
+export interface ReorderScenesRequest {
+  sceneIds: string[]
+}
+
+// Omit title to use the source title with " (Copy)" appended
+export interface CloneSceneRequest {
+  title?: string
+}
+
+export interface CloneSceneResponse {
+  scene: Scene
+  warning?: string
+}
+
+// GET /campaigns/:id/scenes/:sceneId/bundle: everything needed to open a scene
+export interface SceneBundle {
+  scene: Scene
+  characters: Character[]
+  posts: Post[]
+  passStates: Record<string, PassState>
+  composeLocks: SceneLocksResponse['locks']
+  rolls: Roll[]
+  phase: PhaseStatus
+  isGM: boolean
+}
+
+export interface BulkArchiveScenesRequest {
+  sceneIds: string[]
+  archive: boolean
+}
+
+export interface ReorderSceneCharactersRequest {
+  characterIds: string[]
 }
 
 export interface CreateSceneRequest {
@@ -131,15 +284,30 @@ export interface CreateSceneRequest {
   description?: string
 }
 
+// Who witnesses non-hidden posts when they are submitted. Posts held back by
+// 'author' or 'gm_only' are revealed by editing witnesses, not by unhiding.
+export type WitnessMode = 'all' | 'author' | 'gm_only'
+
+// Whether fog of war applies to a scene. 'inherit' follows the campaign's
+// fogOfWar setting; 'always_visible' shows the scene to every member.
+export type FogOverride = 'inherit' | 'always_visible' | 'fog'
+
 export interface UpdateSceneRequest {
   title?: string
   description?: string
+  defaultWitnessMode?: WitnessMode
+  fogOverride?: FogOverride
+  // GM recap shown to players entering the scene; an empty string clears it
+  summary?: string
 }
 
 export interface CreateSceneResponse {
   scene: Scene
   warning?: string
   deletedSceneId?: string
+  sceneCount: number
+  sceneLimit: number
+  nearLimit: boolean
 }
 
 export interface ListScenesResponse {
@@ -162,6 +330,12 @@ export interface Post {
   isHidden: boolean
   isDraft: boolean
   isLocked: boolean
+  authoredByGm: boolean
+  isSystem: boolean
+  isPinned: boolean
+  isRemoved: boolean
+  removedAt: string | null
+  mentions: string[]
   createdAt: string
   updatedAt: string
   characterName: string
@@ -169,6 +343,32 @@ export interface Post {
   characterType: CharacterType
 }
 
+export interface ReactionCount {
+  emoji: string
+  count: number
+  reacted: boolean
+}
+
+export interface PostReactions {
+  postId: string
+  sceneId: string
+  reactions: ReactionCount[]
+}
+
+export interface OocMessage {
+  id: string
+  sceneId: string
+  userId: string
+  parentId: string | null
+  body: string
+  createdAt: string
+}
+
+export interface CreateOocMessageRequest {
+  body: string
+  parentId?: string
+}
+
 export interface CreatePostRequest {
   sceneId: string
   characterId: string | null  // null for Narrator posts (GM only)
@@ -177,6 +377,7 @@ export interface CreatePostRequest {
   intention?: string
   modifier?: number
   isHidden?: boolean
+  witnesses?: string[]  // subset of scene characters; players must include their own
 }
 
 export interface UpdatePostRequest {
@@ -205,12 +406,30 @@ export interface AcquireLockRequest {
 
 export interface HeartbeatRequest {
   lockId: string
+  blocks?: PostBlock[]
 }
 
 export interface HeartbeatResponse {
   acknowledged: boolean
   expiresAt: string
   remainingSeconds: number
+  draftSaved: boolean
+  warning?: string
+}
+
+export interface HeartbeatManyRequest {
+  lockIds: string[]
+}
+
+export interface LockHeartbeat {
+  lockId: string
+  expiresAt: string
+  remainingSeconds: number
+}
+
+export interface HeartbeatManyResponse {
+  locks: LockHeartbeat[]
+  skipped: string[]
 }
 
 export interface SceneLocksResponse {
@@ -227,6 +446,24 @@ export interface ComposeLockInfo {
   characterName?: string
 }
 
+export interface CampaignLockInfo {
+  id: string
+  sceneId: string
+  sceneTitle: string
+  characterId: string
+  characterName: string
+  characterAvatar?: string
+  userId: string
+  userAlias?: string
+  acquiredAt: string
+  expiresAt: string
+  isHidden: boolean
+}
+
+export interface CampaignLocksResponse {
+  locks: CampaignLockInfo[]
+}
+
 // Draft types
 export interface Draft {
   id: string
@@ -241,6 +478,9 @@ export interface Draft {
   sceneTitle?: string
   characterName?: string
   updatedAt: string
+  warning?: string
+  isStale: boolean
+  staleSincePostId?: string
 }
 
 export interface SaveDraftRequest {
@@ -272,6 +512,26 @@ export interface PhaseStatus {
   transitionBlock: string | null
 }
 
+export type TransitionBlockerCode =
+  | 'ALREADY_IN_PHASE'
+  | 'CAMPAIGN_PAUSED'
+  | 'ACTIVE_COMPOSE_LOCKS'
+  | 'PENDING_ROLLS'
+  | 'NOT_ALL_PASSED'
+
+export interface TransitionBlocker {
+  code: TransitionBlockerCode
+  message: string
+  count: number
+  characters?: CharacterPassInfo[]
+}
+
+export interface TransitionCheck {
+  toPhase: CampaignPhase
+  canTransition: boolean
+  blockers: TransitionBlocker[]
+}
+
 export interface TransitionPhaseRequest {
   toPhase: CampaignPhase
 }
@@ -292,13 +552,85 @@ export interface CampaignPassSummary {
   characters: CharacterPassInfo[]
 }
 
+export interface GMPassAllResponse {
+  passed: { sceneId: string; characterId: string }[]
+  count: number
+}
+
 export interface SetPassRequest {
   passState: PassState
 }
 
+// Current user with an account summary (GET /me)
+export interface CurrentUser {
+  id: string
+  email: string
+  campaignCount: number
+  actionItemCount: number
+  unreadNotificationCount: number
+  notificationPreferences: NotificationPreferences
+  quietHours: QuietHours
+}
+
+// Global user profile (GET/PATCH /me/profile)
+export interface UserProfile {
+  userId: string
+  displayName: string | null
+  avatarUrl: string | null
+  storageUsedBytes: number
+  storageLimitBytes: number
+}
+
+// An empty displayName clears it; omit it to leave it unchanged
+export interface UpdateProfileRequest {
+  displayName?: string
+}
+
+// Cross-campaign action items (GET /me/action-items)
+export interface ActionItems {
+  campaigns: CampaignActionItems[]
+  mentions: MentionActionItem[]
+}
+
+export interface CampaignActionItems {
+  campaignId: string
+  campaignTitle: string
+  currentPhase: CampaignPhase
+  isPaused: boolean
+  phaseExpiresAt: string | null
+  timeRemainingSeconds: number | null
+  unpassedScenes: {
+    sceneId: string
+    sceneTitle: string
+    characterId: string
+    characterName: string
+  }[]
+  pendingRolls: {
+    rollId: string
+    sceneId: string
+    characterId: string
+    characterName: string
+    intention: string
+  }[]
+}
+
+export interface MentionActionItem {
+  notificationId: string
+  campaignId: string | null
+  sceneId: string | null
+  postId: string | null
+  body: string
+  createdAt: string
+}
+
 // Roll types
 export type RollStatus = 'pending' | 'completed' | 'invalidated'
 
+export interface SceneRollFilter {
+  status?: RollStatus
+  characterId?: string
+}
+
 export interface Roll {
   id: string
   postId: string | null
@@ -314,6 +646,11 @@ export interface Roll {
   total: number | null
   rolledAt: string | null
   createdAt: string
+  // Success-counting mode: total is the success count, result stays raw
+  successThreshold?: number
+  successes?: number
+  // Player annotation, hidden from players who did not witness the post
+  note?: string
   // Override fields
   wasOverridden: boolean
   originalIntention: string | null
@@ -327,6 +664,8 @@ export interface Roll {
   // Additional display fields
   characterName?: string
   sceneTitle?: string
+  // Narrator summary posted by a GM action with postToScene
+  systemPost?: Post
 }
 
 export interface CreateRollRequest {
@@ -334,19 +673,36 @@ export interface CreateRollRequest {
   sceneId: string
   characterId: string
   intention: string
+  preset?: string
   modifier?: number
   diceType?: string
   diceCount?: number
+  successThreshold?: number
+  note?: string
+}
+
+// Linked rolls created together; a rejected batch names the failing entry
+// in the X-Roll-Batch-Index response header
+export interface CreateRollsRequest {
+  rolls: CreateRollRequest[]
+  sync?: boolean
+}
+
+export interface RollIntentions {
+  intentions: string[]
+  strict: boolean
 }
 
 export interface OverrideIntentionRequest {
   newIntention: string
   reason: string
+  postToScene?: boolean
 }
 
 export interface ManualResolveRequest {
   result: number
   reason: string
+  postToScene?: boolean
 }
 
 export interface UnresolvedRoll extends Roll {
@@ -355,10 +711,49 @@ export interface UnresolvedRoll extends Roll {
   postContent?: string
 }
 
+export interface UnresolvedRollsPage {
+  rolls: UnresolvedRoll[]
+  total: number
+  limit: number
+  offset: number
+}
+
+export interface UnresolvedRollsQuery {
+  limit?: number
+  offset?: number
+  since?: string
+}
+
+export interface CharacterRollsPage {
+  rolls: Roll[]
+  // Empty on the last page
+  nextCursor: string
+}
+
+export interface CharacterRollsQuery {
+  limit?: number
+  cursor?: string
+  includeInvalidated?: boolean
+}
+
 export interface DicePreset {
   name: string
   intentions: string[]
   diceType: string
+  diceCount: number
+  modifier: number
+}
+
+export interface DicePresetExpansion {
+  name: string
+  diceType: string
+  diceCount: number
+  modifier: number
+}
+
+export interface CampaignDicePresets {
+  campaign: DicePresetExpansion[]
+  global: DicePresetExpansion[]
 }
 
 // Notification types
@@ -382,6 +777,10 @@ export type NotificationType =
   | 'unresolved_rolls_exist'
   | 'campaign_at_player_limit'
   | 'scene_limit_warning'
+  | 'mentioned'
+  | 'phase_auto_transitioned'
+  | 'characters_orphaned'
+  | 'test_notification'
 
 export interface Notification {
   id: string
@@ -400,18 +799,40 @@ export interface Notification {
   expires_at: string | null
   created_at: string
   metadata: Record<string, unknown> | null
+  snooze_until: string | null
+}
+
+// POST /campaigns/:id/scenes/:sceneId/grant-witness
+export interface GrantWitnessResult {
+  postIds: string[]
+  count: number
+}
+
+// GET /links/resolve: whether a notification link still leads somewhere
+export type ResolvedLinkStatus = 'ok' | 'deleted' | 'forbidden'
+
+export interface ResolvedLink {
+  status: ResolvedLinkStatus
+  link?: string
+  campaign_id?: string
+  scene_id?: string
+  post_id?: string
+  fallback?: string
 }
 
 export type EmailFrequency = 'realtime' | 'digest_daily' | 'digest_weekly' | 'off'
 
+export type NotificationChannel = 'in_app' | 'email'
+
+export type NotificationTypePreferences = Partial<
+  Record<NotificationType, Partial<Record<NotificationChannel, boolean>>>
+>
+
 export interface NotificationPreferences {
-  id: string
-  user_id: string
   email_enabled: boolean
   email_frequency: EmailFrequency
   in_app_enabled: boolean
-  created_at: string
-  updated_at: string
+  type_preferences: NotificationTypePreferences
 }
 
 export interface QuietHours {
@@ -430,6 +851,16 @@ export interface UpdateNotificationPreferencesRequest {
   email_enabled: boolean
   email_frequency: EmailFrequency
   in_app_enabled: boolean
+  type_preferences?: NotificationTypePreferences
+}
+
+export type EmailDeliveryDecision = 'sent' | 'queued' | 'suppressed'
+
+export type EmailSuppressedReason = 'email_disabled' | 'type_disabled' | 'digest' | 'quiet_hours'
+
+export interface EmailDelivery {
+  decision: EmailDeliveryDecision
+  suppressedReason?: EmailSuppressedReason
 }
 
 export interface UpdateQuietHoursRequest {
@@ -446,14 +877,19 @@ export type RealtimeEventType =
   | 'post_created'
   | 'post_updated'
   | 'post_deleted'
+  | 'post_reaction'
   | 'compose_lock_acquired'
   | 'compose_lock_released'
   | 'pass_state_changed'
   | 'character_joined'
   | 'character_left'
+  | 'character_updated'
   | 'roll_created'
   | 'roll_resolved'
   | 'timegate_warning'
+  | 'phase_status'
+  | 'ooc_message_created'
+  | 'typing_presence'
 
 export interface PhaseTransitionEvent {
   type: 'phase_transition'
@@ -475,6 +911,16 @@ export interface PostEvent {
   timestamp: string
 }
 
+export interface PostReactionEvent {
+  type: 'post_reaction'
+  post_id: string
+  scene_id: string
+  campaign_id: string
+  emoji: string
+  count: number
+  timestamp: string
+}
+
 export interface ComposeLockEvent {
   type: 'compose_lock_acquired' | 'compose_lock_released'
   scene_id: string
@@ -483,6 +929,24 @@ export interface ComposeLockEvent {
   timestamp: string
 }
 
+// Sent from POST /campaigns/:id/scenes/:sceneId/presence; drop it at expires_at
+// unless refreshed. Hidden typists carry no character.
+export interface TypingPresenceEvent {
+  type: 'typing_presence'
+  scene_id: string
+  campaign_id: string
+  character_id?: string
+  character_name?: string
+  is_hidden: boolean
+  expires_at: string
+  timestamp: string
+}
+
+export interface TypingPresenceRequest {
+  characterId: string
+  isHidden?: boolean
+}
+
 export interface PassStateEvent {
   type: 'pass_state_changed'
   campaign_id: string
@@ -500,6 +964,15 @@ export interface CharacterPresenceEvent {
   timestamp: string
 }
 
+export interface CharacterUpdatedEvent {
+  type: 'character_updated'
+  campaign_id: string
+  scene_id?: string
+  character_id: string
+  tags: string[]
+  timestamp: string
+}
+
 export interface RollEvent {
   type: 'roll_created' | 'roll_resolved'
   roll_id: string
@@ -507,7 +980,8 @@ export interface RollEvent {
   scene_id: string
   campaign_id: string
   character_id: string
-  intention: string
+  intention?: string
+  note?: string
   status: string
   timestamp: string
 }
@@ -519,11 +993,39 @@ export interface TimeGateWarningEvent {
   timestamp: string
 }
 
+export interface PhaseStatusEvent {
+  type: 'phase_status'
+  campaign_id: string
+  phase: CampaignPhase
+  is_expired: boolean
+  expires_at?: string
+  passed_count: number
+  total_count: number
+  all_passed: boolean
+  timestamp: string
+}
+
+export interface OocMessageEvent {
+  type: 'ooc_message_created'
+  message_id: string
+  scene_id: string
+  campaign_id: string
+  user_id: string
+  parent_id?: string
+  body: string
+  timestamp: string
+}
+
 export type RealtimeEvent =
   | PhaseTransitionEvent
   | PostEvent
+  | PostReactionEvent
   | ComposeLockEvent
   | PassStateEvent
   | CharacterPresenceEvent
+  | CharacterUpdatedEvent
   | RollEvent
   | TimeGateWarningEvent
+  | PhaseStatusEvent
+  | OocMessageEvent
+  | TypingPresenceEvent
@@ -0,0 +1,13 @@
+-- ============================================
+-- PER-TYPE NOTIFICATION PREFERENCES
+-- ============================================
+--
+-- Lets users mute individual notification types per delivery channel.
+-- Stored as a JSONB map keyed by notification type, e.g.
+--   {"roll_requested": {"in_app": true, "email": false}}
+-- Types or channels missing from the map are enabled.
+
+ALTER TABLE notification_preferences
+ADD COLUMN type_preferences JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+COMMENT ON COLUMN notification_preferences.type_preferences IS 'Per-type channel toggles keyed by notification type; missing entries default to enabled';
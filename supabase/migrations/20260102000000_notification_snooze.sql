@@ -0,0 +1,13 @@
+-- ============================================
+-- NOTIFICATION SNOOZE
+-- ============================================
+--
+-- Snoozed notifications are hidden from unread lists and counts until
+-- snooze_until passes, after which they resurface automatically.
+
+ALTER TABLE notifications
+ADD COLUMN snooze_until TIMESTAMPTZ;
+
+COMMENT ON COLUMN notifications.snooze_until IS 'Hidden from unread lists and counts until this time';
+
+CREATE INDEX IF NOT EXISTS idx_notifications_snooze_until ON notifications(user_id, snooze_until) WHERE snooze_until IS NOT NULL;
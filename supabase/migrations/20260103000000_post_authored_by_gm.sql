@@ -0,0 +1,12 @@
+-- ============================================
+-- GM IMPERSONATION FLAG
+-- ============================================
+--
+-- Marks posts the GM wrote as a player character owned by someone else,
+-- so players can tell the GM spoke for that character. Not set for NPC or
+-- narrator posts.
+
+ALTER TABLE posts
+ADD COLUMN authored_by_gm BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN posts.authored_by_gm IS 'True when the GM posted as a player character owned by someone else';
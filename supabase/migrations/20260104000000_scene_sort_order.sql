@@ -0,0 +1,21 @@
+-- ============================================
+-- SCENE ORDERING
+-- ============================================
+--
+-- GMs can manually order scenes within a campaign. Existing scenes are
+-- numbered by creation time; new scenes are appended at the end.
+
+ALTER TABLE scenes
+ADD COLUMN sort_order INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN scenes.sort_order IS 'Manual position of the scene within its campaign';
+
+UPDATE scenes
+SET sort_order = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY campaign_id ORDER BY created_at) - 1 AS position
+    FROM scenes
+) ordered
+WHERE scenes.id = ordered.id;
+
+CREATE INDEX IF NOT EXISTS idx_scenes_campaign_sort_order ON scenes(campaign_id, sort_order);
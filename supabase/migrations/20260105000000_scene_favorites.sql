@@ -0,0 +1,24 @@
+-- ============================================
+-- SCENE FAVORITES
+-- ============================================
+--
+-- Per-user pinned scenes. This is a personal view preference only and has
+-- no effect on fog-of-war visibility. Rows are removed with the scene.
+
+CREATE TABLE scene_favorites (
+    user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    scene_id UUID NOT NULL REFERENCES scenes(id) ON DELETE CASCADE,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, scene_id)
+);
+
+CREATE INDEX idx_scene_favorites_scene_id ON scene_favorites(scene_id);
+
+ALTER TABLE scene_favorites ENABLE ROW LEVEL SECURITY;
+
+-- Users can manage their own favorites
+CREATE POLICY "Users can manage their scene favorites"
+ON scene_favorites FOR ALL
+USING (user_id = auth.uid());
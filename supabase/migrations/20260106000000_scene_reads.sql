@@ -0,0 +1,26 @@
+-- ============================================
+-- SCENE READ MARKERS
+-- ============================================
+--
+-- Tracks the newest post each user has read in each scene so clients can
+-- show unread badges. Markers only move forward.
+
+CREATE TABLE scene_reads (
+    user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    scene_id UUID NOT NULL REFERENCES scenes(id) ON DELETE CASCADE,
+    last_read_post_id UUID REFERENCES posts(id) ON DELETE SET NULL,
+    last_read_at TIMESTAMPTZ NOT NULL,
+
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, scene_id)
+);
+
+CREATE INDEX idx_scene_reads_scene_id ON scene_reads(scene_id);
+
+ALTER TABLE scene_reads ENABLE ROW LEVEL SECURITY;
+
+-- Users can manage their own read markers
+CREATE POLICY "Users can manage their scene reads"
+ON scene_reads FOR ALL
+USING (user_id = auth.uid());
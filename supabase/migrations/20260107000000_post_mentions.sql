@@ -0,0 +1,11 @@
+-- ============================================
+-- POST MENTIONS
+-- ============================================
+--
+-- User IDs resolved from @mentions in a post's OOC text. Only users who
+-- can witness the post are stored.
+
+ALTER TABLE posts
+ADD COLUMN mentions UUID[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN posts.mentions IS 'Users mentioned in the OOC text who can witness the post';
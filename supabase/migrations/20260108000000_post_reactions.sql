@@ -0,0 +1,28 @@
+-- ============================================
+-- POST REACTIONS
+-- ============================================
+--
+-- Lightweight emoji reactions on posts. Each user can add a given emoji to a
+-- post once; counts are aggregated per emoji when read. Rows are removed with
+-- the post.
+
+CREATE TABLE post_reactions (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    emoji TEXT NOT NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (post_id, user_id, emoji)
+);
+
+CREATE INDEX idx_post_reactions_user_id ON post_reactions(user_id);
+
+ALTER TABLE post_reactions ENABLE ROW LEVEL SECURITY;
+
+-- Users can manage their own reactions
+CREATE POLICY "Users can manage their post reactions"
+ON post_reactions FOR ALL
+USING (user_id = auth.uid());
+
+COMMENT ON COLUMN post_reactions.emoji IS 'Emoji used as the reaction, stored as entered';
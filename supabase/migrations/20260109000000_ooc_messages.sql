@@ -0,0 +1,43 @@
+-- ============================================
+-- OOC MESSAGES
+-- ============================================
+--
+-- Out-of-character discussion per scene, kept apart from the in-character
+-- transcript. OOC messages are meta: every campaign member can read them
+-- regardless of witness rules. They never lock and never affect pass state.
+-- Replies reference a top-level message through parent_id.
+
+CREATE TABLE ooc_messages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scene_id UUID NOT NULL REFERENCES scenes(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES ooc_messages(id) ON DELETE CASCADE,
+
+    body TEXT NOT NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_ooc_messages_scene_created ON ooc_messages(scene_id, created_at);
+CREATE INDEX idx_ooc_messages_parent_id ON ooc_messages(parent_id);
+
+ALTER TABLE ooc_messages ENABLE ROW LEVEL SECURITY;
+
+-- Members can read OOC messages in their campaigns' scenes
+CREATE POLICY "Members can view OOC messages"
+ON ooc_messages FOR SELECT
+USING (
+    EXISTS (
+        SELECT 1 FROM scenes s
+        JOIN campaign_members cm ON cm.campaign_id = s.campaign_id
+        WHERE s.id = ooc_messages.scene_id
+        AND cm.user_id = auth.uid()
+    )
+);
+
+-- Users can manage their own OOC messages
+CREATE POLICY "Users can manage their OOC messages"
+ON ooc_messages FOR ALL
+USING (user_id = auth.uid());
+
+COMMENT ON COLUMN ooc_messages.parent_id IS 'Top-level message this is a reply to, NULL for a new thread';
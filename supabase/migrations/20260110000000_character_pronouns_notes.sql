@@ -0,0 +1,13 @@
+-- ============================================
+-- CHARACTER PRONOUNS AND GM NOTES
+-- ============================================
+--
+-- Optional pronouns visible to every campaign member, and private notes
+-- that only the GM can read or write.
+
+ALTER TABLE characters
+ADD COLUMN pronouns TEXT,
+ADD COLUMN gm_notes TEXT;
+
+COMMENT ON COLUMN characters.pronouns IS 'Optional pronouns shown to all campaign members';
+COMMENT ON COLUMN characters.gm_notes IS 'Private GM notes, never exposed to players';
@@ -0,0 +1,11 @@
+-- ============================================
+-- CHARACTER TAGS
+-- ============================================
+--
+-- Lightweight condition tracking ("poisoned", "prone") set by the GM and
+-- shown in scene rosters.
+
+ALTER TABLE characters
+ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN characters.tags IS 'Condition tags set by the GM, visible to all campaign members';
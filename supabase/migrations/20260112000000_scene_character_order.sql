@@ -0,0 +1,11 @@
+-- ============================================
+-- SCENE CHARACTER ORDER
+-- ============================================
+--
+-- GMs can arrange a scene's roster for turn order or grouping. Characters
+-- not listed sort after the ordered ones by name.
+
+ALTER TABLE scenes
+ADD COLUMN character_order UUID[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN scenes.character_order IS 'Manual roster order of the scene''s characters; unlisted characters sort after by name';
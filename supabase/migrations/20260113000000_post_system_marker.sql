@@ -0,0 +1,12 @@
+-- ============================================
+-- SYSTEM POSTS
+-- ============================================
+--
+-- Marks narrator posts the server writes on the GM's behalf, such as the
+-- summary posted when a GM resolves or overrides a roll, so clients can
+-- style them apart from hand-written narration.
+
+ALTER TABLE posts
+ADD COLUMN is_system BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN posts.is_system IS 'True for narrator posts generated by the server, e.g. roll resolution summaries';
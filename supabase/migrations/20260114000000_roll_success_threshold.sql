@@ -0,0 +1,15 @@
+-- ============================================
+-- DICE ROLLING: SUCCESS-COUNTING MODE
+-- ============================================
+--
+-- Dice pool systems count the dice that meet a target number instead of
+-- summing them. When success_threshold is set the roll's total is the
+-- number of successes, with the modifier adding or removing automatic
+-- successes.
+
+ALTER TABLE rolls
+ADD COLUMN success_threshold INTEGER CHECK (success_threshold IS NULL OR success_threshold >= 1),
+ADD COLUMN successes INTEGER;
+
+COMMENT ON COLUMN rolls.success_threshold IS 'Success-counting mode: dice at or above this value count as successes';
+COMMENT ON COLUMN rolls.successes IS 'Computed successes in success-counting mode, including modifier';
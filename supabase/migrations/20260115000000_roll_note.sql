@@ -0,0 +1,11 @@
+-- ============================================
+-- DICE ROLLING: PLAYER NOTES
+-- ============================================
+--
+-- Lets a player annotate a roll when making it ("spending a hero point").
+-- Separate from the intention and from the GM's override reason.
+
+ALTER TABLE rolls
+ADD COLUMN note TEXT;
+
+COMMENT ON COLUMN rolls.note IS 'Player annotation shown to the table alongside the roll';
@@ -0,0 +1,46 @@
+-- ============================================
+-- USER PROFILES
+-- ============================================
+--
+-- A global display name and avatar per user, independent of characters and
+-- per-campaign aliases. Avatars live in the campaign-assets bucket under
+-- users/<user_id>/ and are charged to storage_used_bytes, which has its own
+-- small quota instead of counting against any campaign.
+
+CREATE TABLE user_profiles (
+    user_id UUID PRIMARY KEY REFERENCES auth.users(id) ON DELETE CASCADE,
+
+    display_name VARCHAR(50),
+    avatar_url TEXT,
+    storage_used_bytes BIGINT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_user_profiles_updated_at
+    BEFORE UPDATE ON user_profiles
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE user_profiles ENABLE ROW LEVEL SECURITY;
+
+-- Profiles are visible to anyone sharing a campaign with the user
+CREATE POLICY "Campaign co-members can view profiles"
+ON user_profiles FOR SELECT
+USING (
+    user_id = auth.uid()
+    OR EXISTS (
+        SELECT 1 FROM campaign_members mine
+        JOIN campaign_members theirs ON theirs.campaign_id = mine.campaign_id
+        WHERE mine.user_id = auth.uid()
+        AND theirs.user_id = user_profiles.user_id
+    )
+);
+
+-- Users can manage their own profile
+CREATE POLICY "Users can manage their profile"
+ON user_profiles FOR ALL
+USING (user_id = auth.uid());
+
+COMMENT ON COLUMN user_profiles.display_name IS 'Global display name, used where a campaign alias is not set';
+COMMENT ON COLUMN user_profiles.storage_used_bytes IS 'Bytes used by the user''s own uploads (avatar), separate from campaign storage';
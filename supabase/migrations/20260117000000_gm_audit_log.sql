@@ -0,0 +1,42 @@
+-- ============================================
+-- GM AUDIT LOG
+-- ============================================
+--
+-- An append-only record of GM administrative actions (forced phase
+-- transitions, lock releases, roll overrides, member removals, post
+-- deletions, and so on) so disputes can be settled by who did what when.
+-- Entries outlive the GM's account and the targets they describe, so
+-- target_id is not a foreign key and detail keeps what was changed.
+
+CREATE TABLE gm_audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    campaign_id UUID NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    gm_user_id UUID REFERENCES auth.users(id) ON DELETE SET NULL,
+
+    action TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target_id UUID,
+    detail JSONB NOT NULL DEFAULT '{}',
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_gm_audit_log_campaign_created ON gm_audit_log(campaign_id, created_at DESC);
+
+ALTER TABLE gm_audit_log ENABLE ROW LEVEL SECURITY;
+
+-- GMs can read their campaigns' audit log; entries are written by the backend only
+CREATE POLICY "GMs can view audit log"
+ON gm_audit_log FOR SELECT
+USING (
+    EXISTS (
+        SELECT 1 FROM campaign_members
+        WHERE campaign_members.campaign_id = gm_audit_log.campaign_id
+        AND campaign_members.user_id = auth.uid()
+        AND campaign_members.role = 'gm'
+    )
+);
+
+COMMENT ON COLUMN gm_audit_log.action IS 'What the GM did, e.g. force_transition or delete_post';
+COMMENT ON COLUMN gm_audit_log.target_type IS 'Kind of thing acted on: campaign, scene, post, roll, compose_lock, or user';
+COMMENT ON COLUMN gm_audit_log.detail IS 'Action-specific context, such as the previous and new values';
@@ -0,0 +1,29 @@
+-- ============================================
+-- CAMPAIGN WEBHOOKS
+-- ============================================
+--
+-- One outgoing webhook per campaign. Selected server events (new posts,
+-- phase transitions, roll resolutions) are POSTed as JSON to the URL, signed
+-- with an HMAC-SHA256 of the body using the secret. Only the backend reads
+-- this table, so RLS is enabled with no policies to keep secrets private.
+
+CREATE TABLE campaign_webhooks (
+    campaign_id UUID PRIMARY KEY REFERENCES campaigns(id) ON DELETE CASCADE,
+
+    url TEXT NOT NULL CHECK (url LIKE 'https://%'),
+    secret TEXT NOT NULL,
+    events TEXT[] NOT NULL DEFAULT '{post_created,phase_transition,roll_resolved}',
+    enabled BOOLEAN NOT NULL DEFAULT true,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_campaign_webhooks_updated_at
+    BEFORE UPDATE ON campaign_webhooks
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE campaign_webhooks ENABLE ROW LEVEL SECURITY;
+
+COMMENT ON COLUMN campaign_webhooks.secret IS 'HMAC-SHA256 signing key; deliveries carry the body signature in X-Vanguard-Signature';
+COMMENT ON COLUMN campaign_webhooks.events IS 'Event types delivered to the webhook';
@@ -0,0 +1,30 @@
+-- ============================================
+-- CAMPAIGN BOT TOKENS
+-- ============================================
+--
+-- Scoped tokens that let an external bot (e.g. a Discord relay) post narrator
+-- posts and OOC messages in a single campaign. Only a SHA-256 hash of each
+-- token is stored; the plaintext is shown to the GM once at creation. Only the
+-- backend reads this table, so RLS is enabled with no policies.
+
+CREATE TABLE campaign_bot_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    campaign_id UUID NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES auth.users(id) ON DELETE SET NULL,
+
+    name TEXT NOT NULL CHECK (char_length(name) BETWEEN 1 AND 50),
+    token_hash TEXT NOT NULL UNIQUE,
+    token_prefix TEXT NOT NULL,
+
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_campaign_bot_tokens_campaign ON campaign_bot_tokens(campaign_id)
+    WHERE revoked_at IS NULL;
+
+ALTER TABLE campaign_bot_tokens ENABLE ROW LEVEL SECURITY;
+
+COMMENT ON COLUMN campaign_bot_tokens.token_hash IS 'Hex SHA-256 of the token; the plaintext is never stored';
+COMMENT ON COLUMN campaign_bot_tokens.token_prefix IS 'First characters of the token, shown so GMs can tell tokens apart';
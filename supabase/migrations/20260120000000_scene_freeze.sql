@@ -0,0 +1,11 @@
+-- ============================================
+-- SCENE FREEZE
+-- ============================================
+--
+-- GMs can freeze a scene to pause it while they sort something out. A frozen
+-- scene stays readable but accepts no new posts, edits, or compose locks.
+
+ALTER TABLE scenes
+ADD COLUMN is_frozen BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN scenes.is_frozen IS 'GM freeze: no new posts, edits, or compose locks while set';
@@ -0,0 +1,11 @@
+-- ============================================
+-- POST PINS
+-- ============================================
+--
+-- GMs can pin key posts (scene setup, rules reminders) so they list first in
+-- their scene. Pinning does not change who can see a post.
+
+ALTER TABLE posts
+ADD COLUMN is_pinned BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN posts.is_pinned IS 'True when the GM has pinned the post to the top of its scene';
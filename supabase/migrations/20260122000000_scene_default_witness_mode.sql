@@ -0,0 +1,15 @@
+-- ============================================
+-- SCENE DEFAULT WITNESS MODE
+-- ============================================
+--
+-- Decides who witnesses a non-hidden post when it is submitted, so GMs can set
+-- up a scene for staged reveals. 'all' keeps the usual behavior (every character
+-- in the scene), 'author' limits it to the posting character, and 'gm_only'
+-- leaves it unwitnessed until the GM edits the witnesses. Hidden posts are
+-- always witnessed by their author only.
+
+ALTER TABLE scenes
+ADD COLUMN default_witness_mode TEXT NOT NULL DEFAULT 'all'
+    CHECK (default_witness_mode IN ('all', 'author', 'gm_only'));
+
+COMMENT ON COLUMN scenes.default_witness_mode IS 'Who witnesses non-hidden posts by default: all, author, or gm_only';
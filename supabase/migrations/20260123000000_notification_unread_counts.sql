@@ -0,0 +1,88 @@
+-- ============================================
+-- NOTIFICATION UNREAD COUNTERS
+-- ============================================
+--
+-- Unread badge polling used to COUNT the notifications table on every request.
+-- notification_unread_counts keeps a running count per user and campaign
+-- (campaign_id NULL for notifications outside any campaign), maintained by a
+-- trigger on notifications so every write path keeps it current.
+--
+-- A notification counts while it is unread and not snoozed. Snoozes that run
+-- out are picked up when the notification worker clears them. The COUNT over
+-- notifications stays the source of truth: the worker periodically rewrites
+-- any counter that has drifted from it.
+
+CREATE TABLE notification_unread_counts (
+    user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    campaign_id UUID,
+    unread_count INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp(),
+
+    UNIQUE NULLS NOT DISTINCT (user_id, campaign_id)
+);
+
+ALTER TABLE notification_unread_counts ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Users can view own unread counts"
+ON notification_unread_counts FOR SELECT
+USING (user_id = auth.uid());
+
+-- Moves a notification's contribution between counters as it is created, read,
+-- snoozed, or deleted. Updates that don't change whether or where it counts
+-- leave the counters (and their updated_at) alone.
+CREATE OR REPLACE FUNCTION track_notification_unread_count()
+RETURNS TRIGGER AS $$
+DECLARE
+    old_counted BOOLEAN := false;
+    new_counted BOOLEAN := false;
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        old_counted := NOT OLD.is_read AND (OLD.snooze_until IS NULL OR OLD.snooze_until <= NOW());
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        new_counted := NOT NEW.is_read AND (NEW.snooze_until IS NULL OR NEW.snooze_until <= NOW());
+    END IF;
+
+    IF TG_OP = 'UPDATE'
+        AND old_counted = new_counted
+        AND OLD.user_id = NEW.user_id
+        AND OLD.campaign_id IS NOT DISTINCT FROM NEW.campaign_id THEN
+        RETURN NULL;
+    END IF;
+
+    IF old_counted THEN
+        UPDATE notification_unread_counts
+        SET
+            unread_count = GREATEST(unread_count - 1, 0),
+            updated_at = clock_timestamp()
+        WHERE user_id = OLD.user_id
+          AND campaign_id IS NOT DISTINCT FROM OLD.campaign_id;
+    END IF;
+
+    IF new_counted THEN
+        INSERT INTO notification_unread_counts (user_id, campaign_id, unread_count)
+        VALUES (NEW.user_id, NEW.campaign_id, 1)
+        ON CONFLICT (user_id, campaign_id) DO UPDATE SET
+            unread_count = notification_unread_counts.unread_count + 1,
+            updated_at = clock_timestamp();
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER track_notification_unread_count
+    AFTER INSERT OR UPDATE OR DELETE ON notifications
+    FOR EACH ROW EXECUTE FUNCTION track_notification_unread_count();
+
+-- Backfill from existing notifications
+INSERT INTO notification_unread_counts (user_id, campaign_id, unread_count)
+SELECT user_id, campaign_id, COUNT(*)
+FROM notifications
+WHERE is_read = false
+  AND (snooze_until IS NULL OR snooze_until <= NOW())
+GROUP BY user_id, campaign_id;
+
+COMMENT ON COLUMN notification_unread_counts.campaign_id IS 'Campaign the notifications belong to, NULL for notifications outside any campaign';
+COMMENT ON COLUMN notification_unread_counts.unread_count IS 'Unread, unsnoozed notifications; reconciled against the notifications table by the notification worker';
+COMMENT ON COLUMN notification_unread_counts.updated_at IS 'Last change to the count, used in unread count ETags';
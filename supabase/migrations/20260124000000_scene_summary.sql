@@ -0,0 +1,13 @@
+-- ============================================
+-- SCENE SUMMARY
+-- ============================================
+--
+-- A GM-written "previously..." recap shown to players entering a long scene,
+-- so they can catch up without reading every post. Purely descriptive: it
+-- does not affect posts or visibility.
+
+ALTER TABLE scenes
+ADD COLUMN summary TEXT
+    CHECK (char_length(summary) <= 5000);
+
+COMMENT ON COLUMN scenes.summary IS 'GM-written recap of the scene so far, NULL when not set';
@@ -0,0 +1,15 @@
+-- ============================================
+-- POST TOMBSTONES
+-- ============================================
+--
+-- Lets a GM remove a post without leaving a gap in the transcript. A removed
+-- post keeps its place, witnesses, and lock state, but the API shows a
+-- "[removed by GM]" placeholder instead of its content. The content is kept
+-- so the GM can restore the post within a limited window.
+
+ALTER TABLE posts
+ADD COLUMN deleted_at TIMESTAMPTZ,
+ADD COLUMN deleted_by UUID REFERENCES auth.users(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN posts.deleted_at IS 'When a GM removed the post, leaving a placeholder in the transcript; NULL if not removed';
+COMMENT ON COLUMN posts.deleted_by IS 'GM who removed the post';
@@ -0,0 +1,15 @@
+-- ============================================
+-- SCENE FOG OVERRIDE
+-- ============================================
+--
+-- Lets the GM opt a single scene in or out of fog of war, e.g. a "public
+-- square" every member can see while the rest of the campaign stays fogged.
+-- 'inherit' follows the campaign's fogOfWar setting, 'always_visible' shows
+-- the scene to every member, and 'fog' hides it until one of the member's
+-- characters witnesses a post even when the campaign has fog turned off.
+
+ALTER TABLE scenes
+ADD COLUMN fog_override TEXT NOT NULL DEFAULT 'inherit'
+    CHECK (fog_override IN ('inherit', 'always_visible', 'fog'));
+
+COMMENT ON COLUMN scenes.fog_override IS 'Per-scene fog of war: inherit the campaign setting, always_visible, or fog';
@@ -0,0 +1,15 @@
+-- ============================================
+-- NON-NEGATIVE CAMPAIGN STORAGE
+-- ============================================
+--
+-- Storage is released with best-effort file sizes, so a delete that runs twice
+-- or a stale size could have pushed a campaign's usage below zero before
+-- DecrementCampaignStorage clamped at zero. Reset any such rows and make the
+-- database reject negative usage from now on.
+
+UPDATE campaigns
+SET storage_used_bytes = 0
+WHERE storage_used_bytes < 0;
+
+ALTER TABLE campaigns
+ADD CONSTRAINT campaigns_storage_used_bytes_nonnegative CHECK (storage_used_bytes >= 0);
@@ -0,0 +1,27 @@
+-- ============================================
+-- CHARACTER AVATAR HISTORY
+-- ============================================
+--
+-- Keeps a character's previous avatars when the GM uploads a new one, so an
+-- earlier image can be reselected without uploading it again. The backend keeps
+-- the newest few per character and deletes the evicted files, releasing their
+-- size from campaign storage. Only the backend reads this table, so RLS is
+-- enabled with no policies.
+
+CREATE TABLE character_avatar_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+
+    avatar_url TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_character_avatar_history_character
+    ON character_avatar_history(character_id, created_at DESC);
+
+ALTER TABLE character_avatar_history ENABLE ROW LEVEL SECURITY;
+
+COMMENT ON COLUMN character_avatar_history.size_bytes IS 'Stored file size, released from campaign storage when the entry is evicted; 0 if unknown';
+COMMENT ON COLUMN character_avatar_history.created_at IS 'When the avatar was replaced and moved into history';
@@ -0,0 +1,14 @@
+-- ============================================
+-- READ-ONLY POST REACTIONS
+-- ============================================
+--
+-- Reactions are added and removed through the backend, which checks that the
+-- user witnessed the post. The old FOR ALL policy let clients write reactions
+-- straight through PostgREST on posts they cannot see, so clients may now only
+-- read their own reactions.
+
+DROP POLICY "Users can manage their post reactions" ON post_reactions;
+
+CREATE POLICY "Users can view their post reactions"
+ON post_reactions FOR SELECT
+USING (user_id = auth.uid());
@@ -0,0 +1,26 @@
+-- ============================================
+-- PRIVATE CHARACTER GM NOTES
+-- ============================================
+--
+-- The "Members can view characters" policy lets every campaign member read
+-- character rows through PostgREST, which included gm_notes. Row policies
+-- cannot hide a single column, so clients lose table-wide SELECT and are
+-- granted every column except gm_notes. The backend connects as a privileged
+-- role and still reads the notes for the GM. Columns added to characters
+-- later must be granted here too before clients can read them.
+
+REVOKE SELECT ON characters FROM anon, authenticated;
+
+GRANT SELECT (
+    id,
+    campaign_id,
+    display_name,
+    description,
+    avatar_url,
+    character_type,
+    is_archived,
+    created_at,
+    updated_at,
+    pronouns,
+    tags
+) ON characters TO anon, authenticated;
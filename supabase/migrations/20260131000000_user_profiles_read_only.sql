@@ -0,0 +1,14 @@
+-- ============================================
+-- READ-ONLY USER PROFILES
+-- ============================================
+--
+-- Profiles are written through the backend, which validates display names and
+-- keeps storage_used_bytes in step with avatar uploads. The old FOR ALL policy
+-- let clients rewrite their own row through PostgREST, including resetting
+-- storage_used_bytes to dodge the quota, so clients may now only read it.
+
+DROP POLICY "Users can manage their profile" ON user_profiles;
+
+CREATE POLICY "Users can view their profile"
+ON user_profiles FOR SELECT
+USING (user_id = auth.uid());
@@ -0,0 +1,28 @@
+-- ============================================
+-- ADMIN-ONLY CAMPAIGN STORAGE LIMITS
+-- ============================================
+--
+-- The per-campaign storage limit used to live in campaigns.settings, which the
+-- GM edits, so a GM could raise their own quota. Limits now live in a table that
+-- only the service role can write, through the admin API. Campaigns without a
+-- row use the default limit. RLS is enabled with no policies, so clients can
+-- neither read nor write it directly.
+
+CREATE TABLE campaign_storage_limits (
+    campaign_id UUID PRIMARY KEY REFERENCES campaigns(id) ON DELETE CASCADE,
+    storage_limit_bytes BIGINT NOT NULL CHECK (storage_limit_bytes > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_campaign_storage_limits_updated_at
+    BEFORE UPDATE ON campaign_storage_limits
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE campaign_storage_limits ENABLE ROW LEVEL SECURITY;
+
+COMMENT ON COLUMN campaign_storage_limits.storage_limit_bytes IS 'Image storage quota set by a platform admin; overrides the default';
+
+-- Limits set by GMs were never trusted, so they are dropped rather than carried over
+UPDATE campaigns
+SET settings = settings - 'storageLimitMB'
+WHERE settings ? 'storageLimitMB';
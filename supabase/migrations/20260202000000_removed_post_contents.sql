@@ -0,0 +1,54 @@
+-- ============================================
+-- REMOVED POST CONTENTS
+-- ============================================
+--
+-- Removing a post used to leave its content in posts, where every witness could
+-- still read it through PostgREST. The content now moves to a table only the
+-- GM can read, and the post row keeps nothing but its placeholder. Restoring a
+-- post moves the content back; the backend purges content once the restore
+-- window has passed.
+
+CREATE TABLE removed_post_contents (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+
+    blocks JSONB NOT NULL,
+    ooc_text TEXT,
+    intention VARCHAR(100),
+    modifier INTEGER,
+
+    removed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_removed_post_contents_removed_at ON removed_post_contents(removed_at);
+
+ALTER TABLE removed_post_contents ENABLE ROW LEVEL SECURITY;
+
+-- GMs can read removed content in their campaigns; rows are written by the backend only
+CREATE POLICY "GMs can view removed post contents"
+ON removed_post_contents FOR SELECT
+USING (
+    EXISTS (
+        SELECT 1 FROM posts
+        JOIN scenes ON scenes.id = posts.scene_id
+        JOIN campaign_members ON campaign_members.campaign_id = scenes.campaign_id
+        WHERE posts.id = removed_post_contents.post_id
+        AND campaign_members.user_id = auth.uid()
+        AND campaign_members.role = 'gm'
+    )
+);
+
+COMMENT ON COLUMN removed_post_contents.removed_at IS 'When the post was removed; content is purged once the restore window passes';
+
+-- Move the content of posts that are already removed
+INSERT INTO removed_post_contents (post_id, blocks, ooc_text, intention, modifier, removed_at)
+SELECT id, blocks, ooc_text, intention, modifier, deleted_at
+FROM posts
+WHERE deleted_at IS NOT NULL;
+
+UPDATE posts
+SET
+    blocks = '[]'::jsonb,
+    ooc_text = NULL,
+    intention = NULL,
+    modifier = NULL
+WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,59 @@
+-- ============================================
+-- UNREAD COUNTERS AND EXPIRED SNOOZES
+-- ============================================
+--
+-- The unread counter trigger judged snoozes by the clock, so a snooze that ran
+-- out looked the same before and after the worker cleared snooze_until and the
+-- notification was never counted back. Marking such a notification read also
+-- decremented a counter it was no longer in. Counters now count unread
+-- notifications with no snooze set: a snooze leaves the count when it is set
+-- and comes back when the worker clears it, which happens every minute. The
+-- reconcile query uses the same rule.
+
+CREATE OR REPLACE FUNCTION track_notification_unread_count()
+RETURNS TRIGGER AS $$
+DECLARE
+    old_counted BOOLEAN := false;
+    new_counted BOOLEAN := false;
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        old_counted := NOT OLD.is_read AND OLD.snooze_until IS NULL;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        new_counted := NOT NEW.is_read AND NEW.snooze_until IS NULL;
+    END IF;
+
+    IF TG_OP = 'UPDATE'
+        AND old_counted = new_counted
+        AND OLD.user_id = NEW.user_id
+        AND OLD.campaign_id IS NOT DISTINCT FROM NEW.campaign_id THEN
+        RETURN NULL;
+    END IF;
+
+    IF old_counted THEN
+        UPDATE notification_unread_counts
+        SET
+            unread_count = GREATEST(unread_count - 1, 0),
+            updated_at = clock_timestamp()
+        WHERE user_id = OLD.user_id
+          AND campaign_id IS NOT DISTINCT FROM OLD.campaign_id;
+    END IF;
+
+    IF new_counted THEN
+        INSERT INTO notification_unread_counts (user_id, campaign_id, unread_count)
+        VALUES (NEW.user_id, NEW.campaign_id, 1)
+        ON CONFLICT (user_id, campaign_id) DO UPDATE SET
+            unread_count = notification_unread_counts.unread_count + 1,
+            updated_at = clock_timestamp();
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Resurface snoozes that have already run out so they count under the new rule
+UPDATE notifications
+SET snooze_until = NULL
+WHERE snooze_until <= NOW();
+
+COMMENT ON COLUMN notification_unread_counts.unread_count IS 'Unread notifications with no snooze set; reconciled against the notifications table by the notification worker';
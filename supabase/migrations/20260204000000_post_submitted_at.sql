@@ -0,0 +1,18 @@
+-- ============================================
+-- POST SUBMISSION TIME
+-- ============================================
+--
+-- The player edit window was counted from created_at, which for a post that
+-- sat as a draft is when the draft was started, so the window could close
+-- before the post was ever published. submitted_at records when the post was
+-- published and the edit window counts from it.
+
+ALTER TABLE posts
+ADD COLUMN submitted_at TIMESTAMPTZ;
+
+-- The best record of earlier submissions is their creation time
+UPDATE posts
+SET submitted_at = created_at
+WHERE is_draft = false;
+
+COMMENT ON COLUMN posts.submitted_at IS 'When the post was published (created directly or submitted from a draft); NULL for drafts';
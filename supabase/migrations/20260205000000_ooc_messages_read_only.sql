@@ -0,0 +1,10 @@
+-- ============================================
+-- READ-ONLY OOC MESSAGES
+-- ============================================
+--
+-- OOC messages are posted through the backend, which checks that the user
+-- belongs to the scene's campaign. The old FOR ALL policy let any user write
+-- OOC rows into any scene straight through PostgREST, so clients may now only
+-- read the messages of their campaigns' scenes.
+
+DROP POLICY "Users can manage their OOC messages" ON ooc_messages;
@@ -0,0 +1,24 @@
+-- ============================================
+-- POSTS WITNESSED BY THE WHOLE SCENE
+-- ============================================
+--
+-- Granting a newcomer the story so far must only extend posts the whole scene
+-- saw. Inferring that from earlier posts' witnesses let whispers, author-only
+-- posts and GM-only scenes leak to newcomers, so the audience is now recorded
+-- when the post is submitted: true when it went to every character in the
+-- scene under the default 'all' witness mode, without chosen witnesses.
+
+ALTER TABLE posts
+ADD COLUMN witnessed_by_scene BOOLEAN NOT NULL DEFAULT false;
+
+-- Earlier posts count only if every character now in the scene witnessed them
+UPDATE posts p
+SET witnessed_by_scene = true
+FROM scenes s
+WHERE s.id = p.scene_id
+  AND p.is_draft = false
+  AND p.is_hidden = false
+  AND cardinality(s.character_ids) > 0
+  AND s.character_ids <@ p.witnesses;
+
+COMMENT ON COLUMN posts.witnessed_by_scene IS 'Whether the post was submitted to every character in its scene';
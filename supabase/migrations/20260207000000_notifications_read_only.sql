@@ -0,0 +1,15 @@
+-- ============================================
+-- READ-ONLY NOTIFICATIONS
+-- ============================================
+--
+-- Notifications are created, read, snoozed, and deleted through the backend.
+-- The old FOR ALL policy also let clients write their own rows through
+-- PostgREST, where the unread counter trigger runs as the client and has no
+-- policy allowing it to write notification_unread_counts, so the write failed
+-- or the counters drifted. Clients may now only read their notifications.
+
+DROP POLICY "Users can view own notifications" ON notifications;
+
+CREATE POLICY "Users can view own notifications"
+ON notifications FOR SELECT
+USING (user_id = auth.uid());
@@ -0,0 +1,13 @@
+-- ============================================
+-- READ-ONLY SCENE FAVORITES
+-- ============================================
+--
+-- Favorites are set through the backend, which checks that the user belongs to
+-- the scene's campaign. The old FOR ALL policy let clients favorite any scene
+-- straight through PostgREST, so clients may now only read their favorites.
+
+DROP POLICY "Users can manage their scene favorites" ON scene_favorites;
+
+CREATE POLICY "Users can view their scene favorites"
+ON scene_favorites FOR SELECT
+USING (user_id = auth.uid());
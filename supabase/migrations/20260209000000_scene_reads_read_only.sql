@@ -0,0 +1,13 @@
+-- ============================================
+-- READ-ONLY SCENE READ MARKERS
+-- ============================================
+--
+-- Read markers are set through the backend, which only moves them to posts the
+-- user can see. The old FOR ALL policy let clients point their marker at any
+-- post straight through PostgREST, so clients may now only read their markers.
+
+DROP POLICY "Users can manage their scene reads" ON scene_reads;
+
+CREATE POLICY "Users can view their scene reads"
+ON scene_reads FOR SELECT
+USING (user_id = auth.uid());